@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm/printer"
+)
+
+var (
+	diagDriverAllowList string // 逗号分隔的驱动版本允许列表，支持通配符
+	diagJSON            bool   // 以 JSON 输出
+)
+
+var diagCmd = &cobra.Command{
+	Use:   "diag",
+	Short: "Run the software diagnostic",
+	Long: `Run a quick software diagnostic like dcgmi diag -r 1: check that librocm_smi64 and libhydmi are loaded with
+compatible versions, /dev/kfd and /dev/dri/renderD* are accessible, the driver version is on the allow-list, the
+number of devices on the PCI bus matches rsmi, the firmware is consistent across devices of the same model and
+/etc/vdev is readable. Exits with status 2 when any check fails.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// 初始化失败时仍然运行诊断，由各诊断项报告具体原因
+		if err := rootCmd.PersistentPreRunE(cmd, args); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		allowList := diagDriverAllowList
+		if allowList == "" {
+			allowList = os.Getenv("DCU_DCGM_DRIVER_ALLOWLIST")
+		}
+		report := dcgm.RunSoftwareDiag(dcgm.DiagOptions{DriverAllowList: dcgm.ParseDriverAllowList(allowList)})
+		if diagJSON {
+			fmt.Println(dataToJson(report))
+		} else {
+			printer.Diag(os.Stdout, report)
+		}
+		if report.Result == dcgm.DiagFail {
+			os.Exit(2)
+		}
+	},
+}
+
+func init() {
+	diagCmd.Flags().StringVar(&diagDriverAllowList, "driver-allowlist", "", "Comma separated allowed driver versions, wildcards supported, e.g. 6.3.8-*,6.4.* (env DCU_DCGM_DRIVER_ALLOWLIST)")
+	diagCmd.Flags().BoolVar(&diagJSON, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(diagCmd)
+}
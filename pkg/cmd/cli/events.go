@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm/printer"
+)
+
+var (
+	eventsDevices string // 设备列表，支持区间，为空时为全部设备
+	eventsTypes   string // 逗号分隔的事件类型，为空时为全部类型
+	eventsHost    string // 读取 dcu-dcgm 服务事件流的地址，为空时在本地订阅
+	eventsJSON    bool   // 每个事件输出一行 JSON
+)
+
+// eventsRetry 服务事件流断开后重连的等待时间
+const eventsRetry = 3 * time.Second
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Watch device event notifications",
+	Long: `Subscribe to device event notifications (VM_FAULT, THERMAL_THROTTLE, GPU_PRE_RESET, GPU_POST_RESET)
+and print them as they arrive until interrupted with Ctrl-C. With --host the event stream of a running dcu-dcgm
+service is read instead, which also carries policy violations (POLICY_VIOLATION) and is resumed without losing
+events after a disconnect.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if eventsHost != "" {
+			return nil
+		}
+		return rootCmd.PersistentPreRunE(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		var events <-chan dcgm.StreamEvent
+		var err error
+		if eventsHost != "" {
+			events, err = serviceEvents(ctx, eventsHost, eventsDevices, eventsTypes)
+		} else {
+			events, err = localEvents(ctx, eventsDevices, eventsTypes)
+		}
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		if !eventsJSON {
+			fmt.Printf("%-8s%-21s%-18s%s\n", "DEVICE", "TIME", "TYPE", "DESCRIPTION")
+		}
+		for event := range events {
+			if eventsJSON {
+				fmt.Println(dataToJson(event))
+				continue
+			}
+			printer.Event(os.Stdout, dcgm.Event{DeviceID: event.DeviceID, Time: event.Time, Type: event.Type, Message: event.Message})
+		}
+	},
+}
+
+// localEvents 在本地订阅事件通知
+func localEvents(ctx context.Context, deviceSpec, typeSpec string) (<-chan dcgm.StreamEvent, error) {
+	devices, err := parseDevices(deviceSpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid devices: %v", err)
+	}
+	types, err := dcgm.ParseNotificationTypes(typeSpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event types: %v", err)
+	}
+	events, err := dcgm.Subscribe(ctx, devices, types)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan dcgm.StreamEvent)
+	go func() {
+		defer close(out)
+		for event := range events {
+			out <- dcgm.StreamEvent{DeviceID: event.DeviceID, Time: event.Time, Type: event.Type, Message: event.Message}
+		}
+	}()
+	return out, nil
+}
+
+// serviceEvents 读取服务的 SSE 事件流直到 ctx 取消，断开后携带收到的最后一个序号重连，由服务补发期间的事件
+func serviceEvents(ctx context.Context, host, deviceSpec, typeSpec string) (<-chan dcgm.StreamEvent, error) {
+	query := url.Values{}
+	if deviceSpec != "" {
+		query.Set("devices", deviceSpec)
+	}
+	query.Set("types", typeSpec)
+	streamURL := serviceURL(host, "/events/stream?"+query.Encode())
+	// 首次连接同步进行，参数错误或事件流未启动时直接返回错误
+	resp, err := openEventStream(ctx, streamURL, "")
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan dcgm.StreamEvent)
+	go func() {
+		defer close(out)
+		var lastID string
+		for {
+			err := readEventStream(resp, &lastID, out)
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Event stream disconnected (%v), reconnecting after event %s\n", err, lastID)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(eventsRetry):
+				}
+				if resp, err = openEventStream(ctx, streamURL, lastID); err == nil {
+					break
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				fmt.Fprintln(os.Stderr, "Reconnect failed:", err)
+			}
+		}
+	}()
+	return out, nil
+}
+
+// openEventStream 连接事件流，lastID 不为空时通过 Last-Event-ID 请求补发
+func openEventStream(ctx context.Context, streamURL, lastID string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, decodeServiceResponse(resp, nil)
+	}
+	return resp, nil
+}
+
+// readEventStream 逐条解析 SSE 记录直到连接断开，记录收到的序号
+func readEventStream(resp *http.Response, lastID *string, out chan<- dcgm.StreamEvent) error {
+	defer resp.Body.Close()
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id:"):
+			*lastID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "data:"):
+			var event dcgm.StreamEvent
+			if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &event); err != nil {
+				return fmt.Errorf("decode event: %v", err)
+			}
+			out <- event
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("stream closed by service")
+}
+
+func init() {
+	eventsCmd.Flags().StringVarP(&eventsDevices, "devices", "d", "", "Device indices, e.g. 0-3 (default all devices)")
+	eventsCmd.Flags().StringVar(&eventsTypes, "types", "all", "Event types: all or a comma separated list of vm_fault, thermal_throttle, gpu_pre_reset, gpu_post_reset, gpu_reset and with --host policy_violation")
+	eventsCmd.Flags().StringVar(&eventsHost, "host", "", "Address of a dcu-dcgm service whose event stream is read, e.g. localhost:16081")
+	eventsCmd.Flags().BoolVar(&eventsJSON, "json", false, "Output one JSON object per event")
+	rootCmd.AddCommand(eventsCmd)
+}
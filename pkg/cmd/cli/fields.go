@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
+
+var (
+	fieldIDs      string // 字段编号或名称，逗号分隔
+	fieldEntities string // 实体 ID 列表，支持区间
+	fieldJSON     bool   // 以 JSON 输出
+)
+
+var fieldsCmd = &cobra.Command{
+	Use:   "fields",
+	Short: "List all fields in the field registry",
+	Long:  `List the numeric ID, name, unit, value type and scope of every field that can be queried or watched.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		metas := dcgm.Fields()
+		if fieldJSON {
+			fmt.Println(dataToJson(metas))
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tUNIT\tTYPE\tSCOPE\tSTATIC\tDESCRIPTION")
+		for _, meta := range metas {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%v\t%s\n", meta.ID, meta.Name, meta.Unit, meta.Type, meta.Scope, meta.Static, meta.Description)
+		}
+		w.Flush()
+	},
+}
+
+var fieldValuesCmd = &cobra.Command{
+	Use:   "field-values",
+	Short: "Query field values",
+	Long:  `Query fields by ID or name for a list of entities, e.g. --fields 150,155,252 --entities 0-3. Entity IDs are device indices, virtual device indices or PIDs depending on the field scope.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fields, err := dcgm.ParseFieldIDs(fieldIDs)
+		if err != nil {
+			fmt.Println("Invalid fields:", err)
+			os.Exit(1)
+		}
+		entities, err := dcgm.ParseEntityList(fieldEntities)
+		if err != nil {
+			fmt.Println("Invalid entities:", err)
+			os.Exit(1)
+		}
+		values, err := dcgm.GetFieldValues(entities, fields)
+		if err != nil {
+			fmt.Println("Error fetching field values:", err)
+			os.Exit(1)
+		}
+		if fieldJSON {
+			fmt.Println(dataToJson(values))
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ENTITY\tFIELD\tNAME\tVALUE\tUNIT")
+		for _, value := range values {
+			meta, _ := dcgm.FieldByID(value.FieldID)
+			fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", value.EntityID, value.FieldID, meta.Name, formatFieldValue(meta, value), meta.Unit)
+		}
+		w.Flush()
+	},
+}
+
+// formatFieldValue 按字段类型格式化字段值，读取失败时显示错误信息
+func formatFieldValue(meta dcgm.FieldMeta, value dcgm.FieldValue) string {
+	if value.Err != "" {
+		return "N/A (" + value.Err + ")"
+	}
+	switch meta.Type {
+	case dcgm.FieldTypeString:
+		return value.Text
+	case dcgm.FieldTypeInt64:
+		return strconv.FormatInt(int64(value.Value), 10)
+	default:
+		return strconv.FormatFloat(value.Value, 'f', 2, 64)
+	}
+}
+
+func init() {
+	fieldsCmd.Flags().BoolVar(&fieldJSON, "json", false, "Output in JSON format")
+	fieldValuesCmd.Flags().StringVarP(&fieldIDs, "fields", "f", "", "Comma separated field IDs or names, e.g. 150,155,252")
+	fieldValuesCmd.Flags().StringVarP(&fieldEntities, "entities", "e", "", "Entity IDs, e.g. 0-3 (default all entities of each field's scope)")
+	fieldValuesCmd.Flags().BoolVar(&fieldJSON, "json", false, "Output in JSON format")
+	fieldValuesCmd.MarkFlagRequired("fields")
+	rootCmd.AddCommand(fieldsCmd)
+	rootCmd.AddCommand(fieldValuesCmd)
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm/printer"
+)
+
+var (
+	healthDevices string // 设备列表，支持区间，为空时为全部设备
+	healthHost    string // 查询 dcu-dcgm 服务健康监控结果的地址，为空时在本地立即检查
+	healthJSON    bool   // 以 JSON 输出
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check device health",
+	Long: `Check ECC errors, retired and pending pages, temperatures against the critical/emergency thresholds,
+PCIe replays, XGMI errors and thermal throttling, and print a PASS/WARN/FAIL verdict per subsystem with incidents.
+Without --host the check runs once locally and counters are evaluated since driver load; with --host the results
+of the health watch of a running dcu-dcgm service are shown.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if healthHost != "" {
+			return nil
+		}
+		return rootCmd.PersistentPreRunE(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		var devices []int
+		var err error
+		if healthHost != "" {
+			devices, err = serviceDevices(healthHost, healthDevices)
+		} else {
+			devices, err = parseDevices(healthDevices)
+		}
+		if err != nil {
+			fmt.Println("Invalid devices:", err)
+			os.Exit(1)
+		}
+		var healths []dcgm.DeviceHealth
+		for _, dvInd := range devices {
+			var health dcgm.DeviceHealth
+			if healthHost != "" {
+				var data struct{ Health dcgm.DeviceHealth }
+				err = serviceRequest(healthHost, http.MethodGet, "/health/"+strconv.Itoa(dvInd), &data)
+				health = data.Health
+			} else {
+				health, err = dcgm.HealthCheck(dvInd)
+			}
+			if err != nil {
+				fmt.Printf("Error checking health of device %d: %v\n", dvInd, err)
+				os.Exit(1)
+			}
+			healths = append(healths, health)
+		}
+		if healthJSON {
+			fmt.Println(dataToJson(healths))
+		} else {
+			printer.Health(os.Stdout, healths)
+		}
+		for _, health := range healths {
+			if health.Result == dcgm.HealthFail {
+				os.Exit(2)
+			}
+		}
+	},
+}
+
+// serviceDevices 解析设备列表，为空时返回服务监视的全部设备
+func serviceDevices(host, spec string) ([]int, error) {
+	if spec != "" {
+		return dcgm.ParseEntityList(spec)
+	}
+	var data struct{ GpuCount int }
+	if err := serviceRequest(host, http.MethodGet, "/NumMonitorDevices", &data); err != nil {
+		return nil, err
+	}
+	devices := make([]int, data.GpuCount)
+	for i := range devices {
+		devices[i] = i
+	}
+	return devices, nil
+}
+
+func init() {
+	healthCmd.Flags().StringVarP(&healthDevices, "devices", "d", "", "Device indices, e.g. 0-3 (default all devices)")
+	healthCmd.Flags().StringVar(&healthHost, "host", "", "Address of a dcu-dcgm service whose health watch results are shown, e.g. localhost:16081")
+	healthCmd.Flags().BoolVar(&healthJSON, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(healthCmd)
+}
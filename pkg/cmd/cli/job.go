@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
+
+var (
+	jobHost    string // 统计任务的 dcu-dcgm 服务地址
+	jobDevices string // 任务使用的设备列表，支持区间
+	jobJSON    bool   // 以 JSON 输出
+)
+
+// jobCmd 任务统计需要长期采样，由 dcu-dcgm 服务完成，命令行通过 REST 接口访问服务，不初始化本地后端
+var jobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "Record per-job statistics on a group of devices",
+	Long: `Start and stop job statistics recording through a running dcu-dcgm service, similar to dcgmi stats.
+A job report contains duration, energy, average/max utilization and memory, max temperature, power min/avg/max,
+ECC and retired page deltas and the KFD processes seen on the job devices.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+}
+
+var jobStartCmd = &cobra.Command{
+	Use:   "start <job-id>",
+	Short: "Start recording a job",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		query := url.Values{"id": {args[0]}}
+		if jobDevices != "" {
+			query.Set("devices", jobDevices)
+		}
+		var data struct{ Job dcgm.Job }
+		if err := serviceRequest(jobHost, http.MethodPost, "/jobs?"+query.Encode(), &data); err != nil {
+			fmt.Println("Error starting job:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Job %s started on devices %v\n", data.Job.ID, data.Job.Devices)
+	},
+}
+
+var jobStopCmd = &cobra.Command{
+	Use:   "stop <job-id>",
+	Short: "Stop recording a job and print its report",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var data struct{ Job dcgm.Job }
+		if err := serviceRequest(jobHost, http.MethodPost, "/jobs/"+url.PathEscape(args[0])+"/stop", &data); err != nil {
+			fmt.Println("Error stopping job:", err)
+			os.Exit(1)
+		}
+		printJob(data.Job)
+	},
+}
+
+var jobShowCmd = &cobra.Command{
+	Use:   "show <job-id>",
+	Short: "Print the report of a job",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var data struct{ Job dcgm.Job }
+		if err := serviceRequest(jobHost, http.MethodGet, "/jobs/"+url.PathEscape(args[0]), &data); err != nil {
+			fmt.Println("Error fetching job:", err)
+			os.Exit(1)
+		}
+		printJob(data.Job)
+	},
+}
+
+var jobListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all jobs",
+	Run: func(cmd *cobra.Command, args []string) {
+		var data struct{ Jobs []dcgm.Job }
+		if err := serviceRequest(jobHost, http.MethodGet, "/jobs", &data); err != nil {
+			fmt.Println("Error listing jobs:", err)
+			os.Exit(1)
+		}
+		if jobJSON {
+			fmt.Println(dataToJson(data.Jobs))
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTATE\tDEVICES\tSTART\tDURATION")
+		for _, job := range data.Jobs {
+			fmt.Fprintf(w, "%s\t%s\t%v\t%s\t%s\n", job.ID, job.State, job.Devices,
+				job.StartTime.Local().Format(time.DateTime), job.Duration.Round(time.Second))
+		}
+		w.Flush()
+	},
+}
+
+var jobRemoveCmd = &cobra.Command{
+	Use:   "remove <job-id>",
+	Short: "Remove a job record",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := serviceRequest(jobHost, http.MethodDelete, "/jobs/"+url.PathEscape(args[0]), nil); err != nil {
+			fmt.Println("Error removing job:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Job %s removed\n", args[0])
+	},
+}
+
+// printJob 按设备输出任务报告
+func printJob(job dcgm.Job) {
+	if jobJSON {
+		fmt.Println(dataToJson(job))
+		return
+	}
+	fmt.Printf("Job %s (%s)\n", job.ID, job.State)
+	fmt.Printf("  Start time: %s\n", job.StartTime.Local().Format(time.DateTime))
+	if !job.EndTime.IsZero() {
+		fmt.Printf("  End time:   %s\n", job.EndTime.Local().Format(time.DateTime))
+	}
+	fmt.Printf("  Duration:   %s\n", job.Duration.Round(time.Second))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DEVICE\tENERGY (J)\tUTIL AVG/MAX (%)\tMEM AVG/MAX (MiB)\tTEMP MAX (C)\tPOWER MIN/AVG/MAX (W)\tECC CE/UE\tRETIRED PAGES\tSAMPLES")
+	for _, s := range job.Stats {
+		fmt.Fprintf(w, "%d\t%.1f\t%.1f / %.1f\t%.0f / %.0f\t%.1f\t%.1f / %.1f / %.1f\t%d / %d\t%d\t%d\n",
+			s.Device, s.EnergyJoules, s.UtilizationAvg, s.UtilizationMax, s.MemoryUsedAvg, s.MemoryUsedMax,
+			s.TemperatureMax, s.PowerMin, s.PowerAvg, s.PowerMax, s.EccCorrectable, s.EccUncorrectable, s.RetiredPages, s.Samples)
+	}
+	w.Flush()
+	if len(job.Processes) == 0 {
+		return
+	}
+	fmt.Println("Processes:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PID\tNAME\tDEVICES\tMAX VRAM (MiB)\tFIRST SEEN\tLAST SEEN")
+	for _, p := range job.Processes {
+		fmt.Fprintf(w, "%d\t%s\t%v\t%.0f\t%s\t%s\n", p.PID, p.Name, p.Devices, float64(p.MaxVramBytes)/1024/1024,
+			p.FirstSeen.Local().Format(time.TimeOnly), p.LastSeen.Local().Format(time.TimeOnly))
+	}
+	w.Flush()
+}
+
+func init() {
+	jobCmd.PersistentFlags().StringVar(&jobHost, "host", "localhost:16081", "Address of the dcu-dcgm service")
+	jobCmd.PersistentFlags().BoolVar(&jobJSON, "json", false, "Output in JSON format")
+	jobStartCmd.Flags().StringVarP(&jobDevices, "devices", "d", "", "Device indices, e.g. 0-3 (default all devices)")
+	jobCmd.AddCommand(jobStartCmd, jobStopCmd, jobShowCmd, jobListCmd, jobRemoveCmd)
+	rootCmd.AddCommand(jobCmd)
+}
@@ -2,11 +2,16 @@ package cli
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
-	"g.sugon.com/das/dcgm-dcu/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
 )
 
 var pidListCmd = &cobra.Command{
@@ -41,7 +46,60 @@ var showPidsCmd = &cobra.Command{
 	},
 }
 
+var (
+	processHost   string // 进程统计所在的 dcu-dcgm 服务地址
+	processDevice int    // 只显示使用该设备的进程，-1 表示全部
+	processState  string // running 或 exited，为空时全部显示
+	processJSON   bool   // 以 JSON 输出
+)
+
+// processHistoryCmd 进程统计由 dcu-dcgm 服务在后台采样，命令行通过 REST 接口查询，不初始化本地后端
+var processHistoryCmd = &cobra.Command{
+	Use:   "process-history",
+	Short: "Show KFD process accounting history",
+	Long: `Show the KFD processes sampled by a running dcu-dcgm service, including exited ones: start/end time,
+devices used and peak/average VRAM, SDMA usage and CU occupancy. Useful to find which process used a card after it exited.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		query := url.Values{}
+		if processDevice >= 0 {
+			query.Set("dvInd", strconv.Itoa(processDevice))
+		}
+		if processState != "" {
+			query.Set("state", processState)
+		}
+		var data struct{ Processes []dcgm.ProcessRecord }
+		if err := serviceRequest(processHost, http.MethodGet, "/process/history?"+query.Encode(), &data); err != nil {
+			fmt.Println("Error fetching process history:", err)
+			os.Exit(1)
+		}
+		if processJSON {
+			fmt.Println(dataToJson(data.Processes))
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PID\tNAME\tDEVICES\tSTATE\tSTART\tEND\tVRAM PEAK/AVG (MiB)\tSDMA PEAK/AVG (us)\tCU MAX/AVG")
+		for _, p := range data.Processes {
+			state, end := "running", "-"
+			if !p.Running {
+				state, end = "exited", p.EndTime.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%v\t%s\t%s\t%s\t%.0f / %.0f\t%d / %.0f\t%d / %.1f\n", p.PID, p.Name, p.Devices, state,
+				p.StartTime.Local().Format(time.DateTime), end, float64(p.VramPeak)/1024/1024, p.VramAvg/1024/1024,
+				p.SdmaPeak, p.SdmaAvg, p.CuOccupancyMax, p.CuOccupancyAvg)
+		}
+		w.Flush()
+	},
+}
+
 func init() {
+	processHistoryCmd.Flags().StringVar(&processHost, "host", "localhost:16081", "Address of the dcu-dcgm service")
+	processHistoryCmd.Flags().IntVarP(&processDevice, "device", "d", -1, "Only show processes that used this device")
+	processHistoryCmd.Flags().StringVar(&processState, "state", "", "Only show running or exited processes")
+	processHistoryCmd.Flags().BoolVar(&processJSON, "json", false, "Output in JSON format")
 	rootCmd.AddCommand(pidListCmd)
 	rootCmd.AddCommand(showPidsCmd)
+	rootCmd.AddCommand(processHistoryCmd)
 }
@@ -6,18 +6,30 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"g.sugon.com/das/dcgm-dcu/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
 )
 
 var dcgmInitialized bool // 追踪 DCGM 是否成功初始化
 
+var backendName string // 使用的 DCGM 后端名称
+
+var recordFile string // 录制原语调用的文件，为空时不录制
+
+var recorder *dcgm.RecordingBackend
+
 var rootCmd = &cobra.Command{
 	Use:   "dcgm",
 	Short: "DCGM CLI tool",
 	Long:  "Command-line interface for managing and interacting with DCGM. Use dcgm-cli [command] --help for more information on a command.",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// 在执行任何命令之前运行初始化
-		if err := dcgm.Init(); err != nil {
+		var err error
+		if recordFile != "" {
+			recorder, err = dcgm.InitWithRecording(backendName, recordFile)
+		} else {
+			err = dcgm.InitWithBackendName(backendName)
+		}
+		if err != nil {
 			return fmt.Errorf("initialization failed: %v", err)
 		}
 		dcgmInitialized = true // 表示初始化成功
@@ -25,6 +37,11 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	rootCmd.PersistentFlags().StringVar(&backendName, "backend", dcgm.BackendCgo, "DCGM backend spec name[:config], e.g. cgo, auto, sysfs:/sys, replay:calls.jsonl")
+	rootCmd.PersistentFlags().StringVar(&recordFile, "record", "", "Record every rsmi/dmi call to this file for replay")
+}
+
 // Execute 执行 root 命令
 func Execute() {
 	defer func() {
@@ -34,6 +51,9 @@ func Execute() {
 				fmt.Println("Failed to shut down properly:", err)
 			}
 		}
+		if recorder != nil {
+			recorder.Close()
+		}
 	}()
 
 	if err := rootCmd.Execute(); err != nil {
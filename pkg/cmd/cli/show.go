@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm/printer"
+)
+
+var (
+	showDevices     string // 设备列表，支持区间，为空时为全部设备
+	showJSON        bool   // 以 JSON 输出
+	showMemTypes    string // 内存类型，逗号分隔
+	showRangeType   string // 范围类型 sclk、mclk、voltage
+	showRetiredType string // 退役页类型
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show device information",
+	Long:  `Show clocks, fans, energy, memory, ranges, retired pages, voltage curve, XGMI errors and topology of a list of devices.`,
+}
+
+// newShowCmd 创建 show 子命令：调用 fetch 读取设备列表的结构化结果，再按 --json 输出 JSON 或由 printer 输出表格
+func newShowCmd[T any](use, short string, fetch func(devices []int) (T, error), print func(w io.Writer, result T)) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Run: func(cmd *cobra.Command, args []string) {
+			devices, err := parseDevices(showDevices)
+			if err != nil {
+				fmt.Println("Invalid devices:", err)
+				os.Exit(1)
+			}
+			result, err := fetch(devices)
+			if err != nil {
+				fmt.Println("Error:", err)
+				os.Exit(1)
+			}
+			if showJSON {
+				fmt.Println(dataToJson(result))
+				return
+			}
+			print(os.Stdout, result)
+		},
+	}
+}
+
+// parseDevices 解析设备列表，为空时返回全部设备
+func parseDevices(spec string) ([]int, error) {
+	if spec != "" {
+		return dcgm.ParseEntityList(spec)
+	}
+	count, err := dcgm.NumMonitorDevices()
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]int, count)
+	for i := range devices {
+		devices[i] = i
+	}
+	return devices, nil
+}
+
+func init() {
+	showCmd.PersistentFlags().StringVarP(&showDevices, "devices", "d", "", "Device indices, e.g. 0-3 (default all devices)")
+	showCmd.PersistentFlags().BoolVar(&showJSON, "json", false, "Output in JSON format")
+
+	memInfoCmd := newShowCmd("meminfo", "Show memory usage by memory type", func(devices []int) ([]dcgm.DeviceMemInfo, error) {
+		return dcgm.ShowMemInfo(devices, strings.Split(showMemTypes, ","))
+	}, printer.MemInfo)
+	memInfoCmd.Flags().StringVar(&showMemTypes, "types", "all", "Memory types: all or a comma separated list of vram, vis_vram, gtt")
+
+	rangeCmd := newShowCmd("range", "Show the valid sclk, mclk or voltage range", func(devices []int) ([]dcgm.DeviceRangeInfo, error) {
+		return dcgm.ShowRange(devices, showRangeType)
+	}, printer.Range)
+	rangeCmd.Flags().StringVar(&showRangeType, "type", "sclk", "Range type: sclk, mclk or voltage")
+
+	retiredPagesCmd := newShowCmd("retired-pages", "Show retired memory pages", func(devices []int) ([]dcgm.DeviceRetiredPagesInfo, error) {
+		return dcgm.ShowRetiredPages(devices, showRetiredType)
+	}, printer.RetiredPages)
+	retiredPagesCmd.Flags().StringVar(&showRetiredType, "type", "all", "Page status: all, reserved, pending or unreservable")
+
+	showCmd.AddCommand(
+		newShowCmd("hw", "Show concise hardware information", dcgm.ShowAllConciseHw, printer.ConciseHw),
+		newShowCmd("clocks", "Show supported clock frequencies", dcgm.ShowClocks, printer.Clocks),
+		newShowCmd("fans", "Show fan level, speed and RPM", dcgm.ShowCurrentFans, printer.Fans),
+		newShowCmd("energy", "Show accumulated energy", dcgm.ShowEnergy, printer.Energy),
+		memInfoCmd,
+		newShowCmd("memuse", "Show memory busy percent and activity", dcgm.ShowMemUse, printer.MemUse),
+		rangeCmd,
+		retiredPagesCmd,
+		newShowCmd("voltage-curve", "Show voltage curve points", dcgm.ShowVoltageCurve, printer.VoltageCurve),
+		newShowCmd("gpu-metrics", "Show the gpu metrics table decoded by its revision", dcgm.ShowGpuMetrics, printer.GpuMetrics),
+		newShowCmd("xgmi-err", "Show XGMI error status", dcgm.ShowXgmiErr, printer.XgmiErr),
+		newShowCmd("topology", "Show link weight, hops, link type and NUMA nodes", dcgm.ShowHwTopology, printer.HwTopology),
+	)
+	rootCmd.AddCommand(showCmd)
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm/printer"
+)
+
+var (
+	topoMatrix  bool   // 输出连接矩阵
+	topoDevices string // 设备列表，支持区间，为空时为全部设备
+	topoJSON    bool   // 以 JSON 输出完整拓扑
+)
+
+var topoCmd = &cobra.Command{
+	Use:   "topo",
+	Short: "Show the device topology matrix",
+	Long: `Show the connection between every pair of devices like nvidia-smi topo -m: XGMI links with their hops,
+PCIe links within or across NUMA nodes, the NUMA affinity of each device and the min/max XGMI bandwidth.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !topoMatrix && !topoJSON {
+			cmd.Help()
+			return
+		}
+		devices, err := parseDevices(topoDevices)
+		if err != nil {
+			fmt.Println("Invalid devices:", err)
+			os.Exit(1)
+		}
+		topology, err := dcgm.ShowNodeTopology(devices)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		if topoJSON {
+			fmt.Println(dataToJson(topology))
+			return
+		}
+		printer.NodeTopology(os.Stdout, topology)
+	},
+}
+
+func init() {
+	topoCmd.Flags().BoolVarP(&topoMatrix, "matrix", "m", false, "Display the topology matrix")
+	topoCmd.Flags().StringVarP(&topoDevices, "devices", "d", "", "Device indices, e.g. 0-3 (default all devices)")
+	topoCmd.Flags().BoolVar(&topoJSON, "json", false, "Output the full topology in JSON format")
+	rootCmd.AddCommand(topoCmd)
+}
@@ -3,6 +3,9 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 )
 
 func dataToJson(data any) string {
@@ -12,3 +15,50 @@ func dataToJson(data any) string {
 	}
 	return string(jsonData)
 }
+
+// serviceRequest 调用 dcu-dcgm 服务的 REST 接口，成功时将响应中的 data 解析到 out；
+// 需要长期采样的功能（任务统计、进程历史）由服务完成，命令行通过该函数访问
+func serviceRequest(host, method, path string, out any) error {
+	req, err := http.NewRequest(method, serviceURL(host, path), nil)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeServiceResponse(resp, out)
+}
+
+// serviceURL 拼接服务地址与接口路径，地址未指定协议时使用 http
+func serviceURL(host, path string) string {
+	host = strings.TrimSuffix(host, "/")
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	return host + path
+}
+
+// decodeServiceResponse 解析服务的响应，状态码不为 200 时返回服务给出的错误
+func decodeServiceResponse(resp *http.Response, out any) error {
+	var body struct {
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("decode response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		if json.Unmarshal(body.Data, &msg) != nil {
+			msg = string(body.Data)
+		}
+		return fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(body.Data, out)
+}
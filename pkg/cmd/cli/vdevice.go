@@ -7,7 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"g.sugon.com/das/dcgm-dcu/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
 )
 
 var vDeviceInfoCmd = &cobra.Command{
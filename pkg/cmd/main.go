@@ -1,6 +1,6 @@
 package main
 
-import "g.sugon.com/das/dcgm-dcu/pkg/cmd/cli"
+import "github.com/Project-HAMi/dcu-dcgm/pkg/cmd/cli"
 
 func main() {
 	cli.Execute() // 执行 rootCmd
@@ -2,7 +2,7 @@ package dcgm
 
 /*
 #cgo CFLAGS: -Wall -I./include
-#cgo LDFLAGS: -L./lib -lrocm_smi64 -Wl,--unresolved-symbols=ignore-in-object-files
+#cgo LDFLAGS: -ldl
 #include <stdint.h>
 #include <kfd_ioctl.h>
 #include <rocm_smi64Config.h>
@@ -15,22 +15,26 @@ import (
 	"github.com/golang/glog"
 )
 
-// rsmiInit 初始化rocm_smi
-func rsmiInit() (err error) {
+// RsmiInit 初始化rocm_smi
+func (b *cgoBackend) RsmiInit() (err error) {
+	// 首次初始化时加载动态库，librocm_smi64 无法加载时直接返回，不再调用缺失的符号
+	if err = LoadLibraries(); err != nil {
+		return err
+	}
 	ret := C.rsmi_init(0)
 	glog.Info("go_rsmi_init_ret:", ret)
 	if err = errorString(ret); err != nil {
-		return fmt.Errorf("Error go_rsmi_init: %s", err)
+		return fmt.Errorf("Error go_rsmi_init: %w", err)
 	}
 	return
 }
 
-// rsmiShutdown 关闭rocm_smi
-func rsmiShutdown() (err error) {
+// RsmiShutdown 关闭rocm_smi
+func (b *cgoBackend) RsmiShutdown() (err error) {
 	ret := C.rsmi_shut_down()
 	glog.Info("go_rsmi_shutdown_ret:", ret)
 	if err = errorString(ret); err != nil {
-		return fmt.Errorf("Error rsmi_shutdown: %s", err)
+		return fmt.Errorf("Error rsmi_shutdown: %w", err)
 	}
 	return
 }
@@ -1,8 +1,8 @@
 package dcgm
 
-import "C"
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -22,7 +22,7 @@ import (
 // @Failure 500 {object} error "初始化失败"
 // @Router /Init [post]
 func Init() (err error) {
-	devCount := listFilesInDevDri()
+	devCount := getBackend().ProbeDeviceCount()
 	glog.Infof("devCount:%v", devCount)
 	maxRetries := 12                   // 最大重试次数
 	retryCount := 0                    // 记录连续返回相同设备数量的次数
@@ -57,6 +57,9 @@ func Init() (err error) {
 				}
 				time.Sleep(restartTimeout) // 等待10秒
 			}
+		} else if errors.Is(err, ErrLibraryNotLoaded) || errors.Is(err, ErrNoBackend) {
+			glog.Errorf("动态库加载失败，终止初始化: %v", err)
+			return err // 动态库缺失或未启用 cgo 时重试没有意义
 		} else {
 			initFailCount++ // 初始化失败，计数加一
 			glog.Infof("初始化失败: %v. 10秒后重试...\n", err)
@@ -77,6 +80,18 @@ func Init() (err error) {
 // @Failure 500 {object} error "关闭失败"
 // @Router /ShutDown [post]
 func ShutDown() error {
+	// 先停止后台采样，避免关闭后继续访问硬件
+	unwatchAll()
+	StopHistory()
+	StopEnergyAccounting()
+	closeJobs()
+	closeCounterSessions()
+	StopProcessAccounting()
+	StopThrottleAccounting()
+	StopHealthWatch()
+	StopEventStream()
+	closePolicies()
+	closeEvents()
 	return rsmiShutdown()
 }
 
@@ -230,6 +245,11 @@ func DevPowerCapRange(dvInd int, senserId int) (max, min int64, err error) {
 // @Failure 404 {object} error "设备未找到"
 // @Router /CollectDeviceMetrics [get]
 func CollectDeviceMetrics() (monitorInfos []MonitorInfo, err error) {
+	return collectDeviceMetrics(defaultActivity)
+}
+
+// collectDeviceMetrics 收集所有设备的监控指标，平均活动百分比由 cursor 计算
+func collectDeviceMetrics(cursor *ActivityCursor) (monitorInfos []MonitorInfo, err error) {
 	numMonitorDevices, err := rsmiNumMonitorDevices()
 	if err != nil {
 		return nil, err
@@ -414,6 +434,39 @@ func CollectDeviceMetrics() (monitorInfos []MonitorInfo, err error) {
 				muDevice.Unlock()
 			}()
 
+			// Collect Average Activity
+			wgDevice.Add(1)
+			go func() {
+				defer wgDevice.Done()
+				util, err := cursor.Utilization(deviceIndex)
+				if err != nil {
+					glog.V(2).Infof("Failed to get average activity for device %d: %v", deviceIndex, err)
+					return
+				}
+				muDevice.Lock()
+				monitorInfo.GfxActivity = util.GfxActivity
+				monitorInfo.MemActivity = util.MemActivity
+				muDevice.Unlock()
+			}()
+
+			// Collect Throttle Reasons
+			wgDevice.Add(1)
+			go func() {
+				defer wgDevice.Done()
+				throttleInfo, err := DeviceThrottleInfo(deviceIndex)
+				if err != nil {
+					glog.V(2).Infof("Failed to get throttle reasons for device %d: %v", deviceIndex, err)
+					return
+				}
+				var reasons []string
+				for _, reason := range throttleInfo.Reasons {
+					reasons = append(reasons, string(reason))
+				}
+				muDevice.Lock()
+				monitorInfo.ThrottleReasons = reasons
+				muDevice.Unlock()
+			}()
+
 			wgDevice.Wait()
 
 			deviceResults <- monitorInfo // Send result to channel
@@ -566,12 +619,12 @@ func AllDeviceInfos() ([]PhysicalDeviceInfo, error) {
 		deviceMap[device.MinorNumber] = &pdi
 	}
 
-	// 获取虚拟设备数量
-	//vDeviceCount, err := dmiGetVDeviceCount()
-	vDeviceCount := deviceCount * 4
-	if err != nil {
-		return nil, err
+	// 虚拟设备索引按物理设备分段，每个物理设备最多 maxVDeviceCount 个
+	maxVDeviceCount, err := dmiGetMaxVDeviceCount()
+	if err != nil || maxVDeviceCount <= 0 {
+		maxVDeviceCount = 4
 	}
+	vDeviceCount := deviceCount * maxVDeviceCount
 	// 获取所有虚拟设备信息并关联到对应的物理设备
 	for j := 0; j < vDeviceCount; j++ {
 		vDeviceInfo, err := dmiGetVDeviceInfo(j)
@@ -764,20 +817,18 @@ func MaxPower(dvInd int) (power int64, err error) {
 func MemInfo(dvInd int, memType string) (memUsed int64, memTotal int64, err error) {
 	memType = strings.ToUpper(memType)
 	if !contains(memoryTypeL, memType) {
-		fmt.Println(dvInd, fmt.Sprintf("Invalid memory type %s", memType))
-		return 0, 0, fmt.Errorf("invalid memory type")
+		return 0, 0, fmt.Errorf("invalid memory type %s", memType)
 	}
 	memTypeIndex := RSMIMemoryType(indexOf(memoryTypeL, memType))
 	memUsed, err = rsmiDevMemoryUsageGet(dvInd, memTypeIndex)
 	if err != nil {
 		return memUsed, memTotal, err
 	}
-	fmt.Println(dvInd, fmt.Sprintf("memUsed: %d", memUsed))
 	memTotal, err = rsmiDevMemoryTotalGet(dvInd, memTypeIndex)
 	if err != nil {
 		return memUsed, memTotal, err
 	}
-	fmt.Println(dvInd, fmt.Sprintf("memTotal: %d", memTotal))
+	glog.Infof("device:%v %s memUsed:%d memTotal:%d", dvInd, memType, memUsed, memTotal)
 	return
 }
 
@@ -1635,17 +1686,10 @@ func GetBus(device int) (picId string, err error) {
 // @Accept  json
 // @Produce  json
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "成功信息"
+// @Success 200 {object} []ConciseHwInfo "设备简要硬件信息列表"
 // @Failure 400 {string} string "失败信息"
 // @Router /ShowAllConciseHw [post]
-func ShowAllConciseHw(dvIdList []int) {
-	header := []string{"GPU", "DID", "GFX RAS", "SDMA RAS", "UMC RAS", "VBIOS", "BUS"}
-	headWidths := make([]int, len(header))
-	for i, head := range header {
-		headWidths[i] = len(head) + 2
-	}
-
-	values := make(map[string][]string)
+func ShowAllConciseHw(dvIdList []int) (conciseHwInfos []ConciseHwInfo, err error) {
 	for _, device := range dvIdList {
 		gpuid, _ := rsmiDevIdGet(device)
 		gfxRas, _ := EccStatus(device, RSMIGpuBlockGFX)
@@ -1653,39 +1697,18 @@ func ShowAllConciseHw(dvIdList []int) {
 		umcRas, _ := EccStatus(device, RSMIGpuBlockUMC)
 		vbios, _ := VbiosVersion(device)
 		bus, _ := GetBus(device)
-		values[fmt.Sprintf("card%d", device)] = []string{
-			fmt.Sprintf("GPU%d", device), strconv.Itoa(gpuid), gfxRas, sdmaRas, umcRas, vbios, bus,
-		}
-	}
-
-	valWidths := make(map[int][]int)
-	for _, device := range dvIdList {
-		valWidths[device] = make([]int, len(values[fmt.Sprintf("card%d", device)]))
-		for i, val := range values[fmt.Sprintf("card%d", device)] {
-			valWidths[device][i] = len(val) + 2
-		}
-	}
-	maxWidths := headWidths
-	for _, device := range dvIdList {
-		for col := range valWidths[device] {
-			if valWidths[device][col] > maxWidths[col] {
-				maxWidths[col] = valWidths[device][col]
-			}
-		}
-	}
-
-	for i, head := range header {
-		fmt.Printf("%-*s", maxWidths[i], head)
-	}
-	fmt.Println()
-
-	for _, device := range dvIdList {
-		for i, val := range values[fmt.Sprintf("card%d", device)] {
-			fmt.Printf("%-*s", maxWidths[i], val)
-		}
-		fmt.Println()
+		conciseHwInfos = append(conciseHwInfos, ConciseHwInfo{
+			DeviceID: device,
+			DID:      gpuid,
+			GfxRas:   gfxRas,
+			SdmaRas:  sdmaRas,
+			UmcRas:   umcRas,
+			VBIOS:    vbios,
+			Bus:      bus,
+		})
 	}
-
+	glog.Infof("conciseHwInfos:%v", dataToJson(conciseHwInfos))
+	return
 }
 
 // ShowClocks 显示时钟信息
@@ -1695,43 +1718,42 @@ func ShowAllConciseHw(dvIdList []int) {
 // @Accept  json
 // @Produce  json
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "成功信息"
+// @Success 200 {object} []DeviceClockInfo "设备时钟频率信息列表"
 // @Failure 400 {string} string "失败信息"
 // @Router /ShowClocks [post]
-func ShowClocks(dvIdList []int) {
-
+func ShowClocks(dvIdList []int) (deviceClockInfos []DeviceClockInfo, err error) {
+	clkTypes := []string{"sclk", "mclk", "fclk", "socclk", "dcefclk"}
 	for _, device := range dvIdList {
-		for clkType, clkID := range rsmiClkNamesDict {
-
-			freq, err := rsmiDevGpuClkFreqGet(device, clkID)
-			if err == nil {
-				glog.Infof("device:%v Supported %v frequencies on GPU%v", device, clkType, device)
-				for x := 0; x < int(freq.NumSupported); x++ {
-					fr := fmt.Sprintf("%dMhz", freq.Frequency[x]/1000000)
-					if uint32(x) == freq.Current {
-						glog.Infof("Device %d: %d %s *", device, x, fr)
-					} else {
-						glog.Infof("Device %d: %d %s", device, x, fr)
-					}
-				}
-			} else {
+		deviceClockInfo := DeviceClockInfo{DeviceID: device}
+		for _, clkType := range clkTypes {
+			freq, err := rsmiDevGpuClkFreqGet(device, rsmiClkNamesDict[clkType])
+			if err != nil {
 				glog.Errorf("device:%v clkType:%v frequency is unsupported", device, clkType)
-
+				continue
 			}
+			n := min(int(freq.NumSupported), len(freq.Frequency))
+			deviceClockInfo.Clocks = append(deviceClockInfo.Clocks, ClockFrequencies{
+				Type:        clkType,
+				Current:     int(freq.Current),
+				Frequencies: append([]uint64{}, freq.Frequency[:n]...),
+			})
 		}
 		bw, err := rsmiDevPciBandwidthGet(device)
 		if err == nil {
-			glog.Infof("Supported PCIe frequencies on GPU%d", device)
-			for x := 0; x < int(bw.TransferRate.NumSupported); x++ {
-				fr := fmt.Sprintf("%.1fGT/s x%d", float64(bw.TransferRate.Frequency[x])/1000000000, bw.Lanes[x])
-				if uint32(x) == bw.TransferRate.Current {
-					glog.Infof("Device %d: %d %s *", device, x, fr)
-				} else {
-					glog.Infof("Device %d: %d %s", device, x, fr)
-				}
-			}
+			n := min(int(bw.TransferRate.NumSupported), len(bw.TransferRate.Frequency))
+			deviceClockInfo.Clocks = append(deviceClockInfo.Clocks, ClockFrequencies{
+				Type:        "pcie",
+				Current:     int(bw.TransferRate.Current),
+				Frequencies: append([]uint64{}, bw.TransferRate.Frequency[:n]...),
+				Lanes:       append([]uint32{}, bw.Lanes[:n]...),
+			})
+		} else {
+			glog.Errorf("device:%v pcie frequency is unsupported", device)
 		}
+		deviceClockInfos = append(deviceClockInfos, deviceClockInfo)
 	}
+	glog.Infof("deviceClockInfos:%v", dataToJson(deviceClockInfos))
+	return
 }
 
 // ShowCurrentFans 展示风扇转速和风扇级别
@@ -1741,12 +1763,10 @@ func ShowClocks(dvIdList []int) {
 // @Accept  json
 // @Produce  json
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Param printJSON query bool true "是否以 JSON 格式打印输出"
-// @Success 200 {string} string "成功信息"
+// @Success 200 {object} []FanInfo "设备风扇信息列表"
 // @Failure 400 {string} string "失败信息"
 // @Router /ShowCurrentFans [post]
-func ShowCurrentFans(dvIdList []int, printJSON bool) {
-	glog.Info("--------- Current Fan Metric ---------")
+func ShowCurrentFans(dvIdList []int) (fanInfos []FanInfo, err error) {
 	var sensorInd uint32 = 0
 
 	for _, device := range dvIdList {
@@ -1756,30 +1776,28 @@ func ShowCurrentFans(dvIdList []int, printJSON bool) {
 			continue
 		}
 
-		fanSpeed = float64(int64(fanSpeed + 0.5)) // 四舍五入
-
-		if fanLevel == 0 || fanSpeed == 0 {
-			glog.Infof("Device %v: Unable to detect fan speed", device)
-			glog.Infof("Current fan speed is: %v", fanSpeed)
-			glog.Infof("Current fan level is: %v", fanLevel)
-			glog.Infof("GPU might be cooled with a non-PWM fan")
-			continue
+		fanInfo := FanInfo{
+			DeviceID:     device,
+			Level:        fanLevel,
+			SpeedPercent: float64(int64(fanSpeed + 0.5)), // 四舍五入
 		}
-		if printJSON {
-			glog.Infof("Device %v: Fan speed (level): %v", device, fanLevel)
-			glog.Infof("Device %v: Fan speed (%%): %.0f", device, fanSpeed)
-		} else {
-			glog.Infof("Device %v: Fan Level: %d (%.0f%%)", device, fanLevel, fanSpeed)
+		if fanInfo.Level == 0 || fanInfo.SpeedPercent == 0 {
+			// 设备可能使用非PWM风扇散热，不再读取转速
+			glog.Infof("Device %v: Unable to detect fan speed, GPU might be cooled with a non-PWM fan", device)
+			fanInfos = append(fanInfos, fanInfo)
+			continue
 		}
 
 		rpmSpeed, err := rsmiDevFanRpmsGet(device, int(sensorInd))
 		if err == nil {
-			glog.Infof("Device %v: Fan RPM: %v", device, rpmSpeed)
+			fanInfo.RPM = rpmSpeed
 		} else {
 			glog.Errorf("Device %v: Error getting fan RPM: %v", device, err)
 		}
+		fanInfos = append(fanInfos, fanInfo)
 	}
-	glog.Info("--------------------------------------")
+	glog.Infof("fanInfos:%v", dataToJson(fanInfos))
+	return
 }
 
 // ShowCurrentTemps 显示所有设备的所有可用温度传感器的温度
@@ -1982,19 +2000,26 @@ func ShowGpuUse(dvIdList []int) (deviceUseInfos []DeviceUseInfo, err error) {
 // @Description 获取并展示指定设备的能量消耗情况。
 // @Tags 设备
 // @Param dvIdList query []int true "设备ID列表"
-// @Success 200 {string} string "成功返回设备的能量消耗信息"
+// @Success 200 {object} []DeviceEnergyInfo "设备的能量消耗信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /showEnergy [get]
-func ShowEnergy(dvIdList []int) {
+func ShowEnergy(dvIdList []int) (deviceEnergyInfos []DeviceEnergyInfo, err error) {
 	for _, device := range dvIdList {
-		power, counterResolution, _, err := rsmiDevEnergyCountGet(device)
+		power, counterResolution, timestamp, err := rsmiDevEnergyCountGet(device)
 		if err != nil {
-			glog.Errorf("Error getting energy count for device %d: %v\n", device, err)
+			glog.Errorf("Error getting energy count for device %d: %v", device, err)
 			continue
 		}
-		fmt.Printf("Device %d Energy counter: %d\n", device, power)
-		fmt.Printf("Device %d Accumulated Energy (uJ): %.2f\n", device, float64(power)*float64(counterResolution))
+		deviceEnergyInfos = append(deviceEnergyInfos, DeviceEnergyInfo{
+			DeviceID:          device,
+			Counter:           power,
+			CounterResolution: counterResolution,
+			Energy:            float64(power) * float64(counterResolution),
+			Timestamp:         timestamp,
+		})
 	}
+	glog.Infof("deviceEnergyInfos:%v", dataToJson(deviceEnergyInfos))
+	return
 }
 
 // ShowMemInfo 展示设备的内存信息
@@ -2003,38 +2028,42 @@ func ShowEnergy(dvIdList []int) {
 // @Tags 设备
 // @Param dvIdList query []int true "设备ID列表"
 // @Param memTypes query []string true "内存类型列表，如 'all' 或指定类型"
-// @Success 200 {string} string "成功返回设备的内存信息"
+// @Success 200 {object} []DeviceMemInfo "设备的内存信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /showMemInfo [get]
-func ShowMemInfo(dvIdList []int, memTypes []string) {
+func ShowMemInfo(dvIdList []int, memTypes []string) (deviceMemInfos []DeviceMemInfo, err error) {
 	var returnTypes []string
 
-	if len(memTypes) == 1 && memTypes[0] == "all" {
+	if len(memTypes) == 0 || (len(memTypes) == 1 && strings.ToLower(memTypes[0]) == "all") {
 		returnTypes = memoryTypeL
 	} else {
 		for _, memType := range memTypes {
-			if contains(memoryTypeL, memType) {
-				returnTypes = append(returnTypes, memType)
-			} else {
-				log.Printf("Invalid memory type: %s", memType)
-				return
+			memType = strings.ToUpper(memType)
+			if !contains(memoryTypeL, memType) {
+				return nil, fmt.Errorf("Error ShowMemInfo:invalid memory type %s", memType)
 			}
+			returnTypes = append(returnTypes, memType)
 		}
 	}
 
-	fmt.Println(" Memory Usage (Bytes) ")
 	for _, device := range dvIdList {
+		deviceMemInfo := DeviceMemInfo{DeviceID: device}
 		for _, mem := range returnTypes {
 			memInfoUsed, memInfoTotal, err := MemInfo(device, mem)
 			if err != nil {
-				log.Printf("Error getting memory info for device %d: %v", device, err)
+				glog.Errorf("Error getting %s memory info for device %d: %v", mem, device, err)
 				continue
 			}
-			fmt.Println("device ", device, fmt.Sprintf("%s Total Memory (B)", mem), memInfoTotal)
-			fmt.Println("device ", device, fmt.Sprintf("%s Total Used Memory (B)", mem), memInfoUsed)
+			deviceMemInfo.Memory = append(deviceMemInfo.Memory, MemoryUsage{
+				Type:  mem,
+				Used:  memInfoUsed,
+				Total: memInfoTotal,
+			})
 		}
+		deviceMemInfos = append(deviceMemInfos, deviceMemInfo)
 	}
-	fmt.Println("End of Memory Usage")
+	glog.Infof("deviceMemInfos:%v", dataToJson(deviceMemInfos))
+	return
 }
 
 // ShowMemUse 展示设备的内存使用情况
@@ -2042,26 +2071,33 @@ func ShowMemInfo(dvIdList []int, memTypes []string) {
 // @Description 获取并展示指定设备的当前内存使用百分比和其他相关的利用率数据。
 // @Tags 设备
 // @Param dvIdList query []int true "设备ID列表"
-// @Success 200 {string} string "成功返回设备的内存使用信息"
+// @Success 200 {object} []DeviceMemUseInfo "设备的内存使用信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /showMemUse [get]
-func ShowMemUse(dvIdList []int) {
-	fmt.Println("Current Memory Use")
+func ShowMemUse(dvIdList []int) (deviceMemUseInfos []DeviceMemUseInfo, err error) {
 	for _, device := range dvIdList {
 		busyPercent, err := rsmiDevMemoryBusyPercentGet(device)
-		if err == nil {
-			fmt.Println("device: ", device, "GPU memory use (%)", busyPercent)
+		if err != nil {
+			glog.Errorf("Device %d: Failed to get memory busy percent: %v", device, err)
+			continue
 		}
-		typeName := "Memory Activity"
-		utilCounters, err := GetCoarseGrainUtil(device, typeName)
+		deviceMemUseInfo := DeviceMemUseInfo{
+			DeviceID:          device,
+			MemoryBusyPercent: busyPercent,
+			Utilization:       make(map[string]uint64),
+		}
+		utilCounters, err := GetCoarseGrainUtil(device, "Memory Activity")
 		if err == nil {
 			for _, utCounter := range utilCounters {
-				fmt.Println("device: ", device, utilizationCounterName[utCounter.Type], utCounter.Value)
+				deviceMemUseInfo.Utilization[utilizationCounterName[utCounter.Type]] = utCounter.Value
 			}
 		} else {
 			glog.Errorf("Device %d: Failed to get coarse grain util counters: %v", device, err)
 		}
+		deviceMemUseInfos = append(deviceMemUseInfos, deviceMemUseInfo)
 	}
+	glog.Infof("deviceMemUseInfos:%v", dataToJson(deviceMemUseInfos))
+	return
 }
 
 // ShowMemVendor 展示设备供应商信息
@@ -2422,46 +2458,49 @@ func ShowProfile(dvIdList []int) (deviceProfiles []DeviceProfile, err error) {
 // @Tags 设备
 // @Param dvIdList query []int true "设备ID列表"
 // @Param rangeType query string true "范围类型 (sclk, mclk, voltage)"
-// @Success 200 {string} string "设备的电流或电压范围信息"
+// @Success 200 {object} []DeviceRangeInfo "设备的电流或电压范围信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /showRange [get]
-func ShowRange(dvIdList []int, rangeType string) {
+func ShowRange(dvIdList []int, rangeType string) (deviceRangeInfos []DeviceRangeInfo, err error) {
 	if rangeType != "sclk" && rangeType != "mclk" && rangeType != "voltage" {
-		fmt.Println(0, fmt.Sprintf("Invalid range identifier %s", rangeType))
-		return
+		return nil, fmt.Errorf("Error ShowRange:invalid range identifier %s", rangeType)
 	}
 
-	fmt.Println(fmt.Sprintf(" Show Valid %s Range ", rangeType))
-
 	for _, device := range dvIdList {
 		odvf, err := rsmiDevOdVoltInfoGet(device)
 		if err != nil {
-			log.Printf("Error getting OD volt info: %v", err)
-			fmt.Println(device, fmt.Sprintf("Unable to display %s range", rangeType))
+			glog.Errorf("Device %d: Unable to display %s range: %v", device, rangeType, err)
 			continue
 		}
+		deviceRangeInfo := DeviceRangeInfo{DeviceID: device, RangeType: rangeType}
 		switch rangeType {
 		case "sclk":
-			fmt.Println(device, fmt.Sprintf("Valid sclk range: %dMhz - %dMhz",
-				odvf.CurrSclkRange.LowerBound/1000000, odvf.CurrSclkRange.UpperBound/1000000))
+			deviceRangeInfo.Ranges = []ValueRange{{
+				Lower: odvf.CurrSclkRange.LowerBound / 1000000,
+				Upper: odvf.CurrSclkRange.UpperBound / 1000000,
+			}}
 		case "mclk":
-			fmt.Println(device, fmt.Sprintf("Valid mclk range: %dMhz - %dMhz",
-				odvf.CurrMclkRange.LowerBound/1000000, odvf.CurrMclkRange.UpperBound/1000000))
+			deviceRangeInfo.Ranges = []ValueRange{{
+				Lower: odvf.CurrMclkRange.LowerBound / 1000000,
+				Upper: odvf.CurrMclkRange.UpperBound / 1000000,
+			}}
 		case "voltage":
 			numRegions, regions, err := rsmiDevOdVoltCurveRegionsGet(device)
 			if err != nil {
-				log.Printf("Error getting OD volt curve regions: %v", err)
-				fmt.Println(device, fmt.Sprintf("Unable to display %s range", rangeType))
+				glog.Errorf("Device %d: Unable to display %s range: %v", device, rangeType, err)
 				continue
 			}
-			for i := 0; i < numRegions; i++ {
-				fmt.Println(device, fmt.Sprintf("Region %d: Valid voltage range: %dmV - %dmV",
-					i, regions[i], regions[i].VoltRange.UpperBound))
+			for i := 0; i < numRegions && i < len(regions); i++ {
+				deviceRangeInfo.Ranges = append(deviceRangeInfo.Ranges, ValueRange{
+					Lower: regions[i].VoltRange.LowerBound,
+					Upper: regions[i].VoltRange.UpperBound,
+				})
 			}
 		}
+		deviceRangeInfos = append(deviceRangeInfos, deviceRangeInfo)
 	}
-
-	fmt.Println(" End of Range Display ")
+	glog.Infof("deviceRangeInfos:%v", dataToJson(deviceRangeInfos))
+	return
 }
 
 // ShowRetiredPages 显示设备列表中指定类型的退役页
@@ -2470,11 +2509,10 @@ func ShowRange(dvIdList []int, rangeType string) {
 // @Tags 设备
 // @Param dvIdList query []int true "设备ID列表"
 // @Param retiredType query string false "退役类型 (默认为'all')"
-// @Success 200 {string} string "设备的退役页信息"
+// @Success 200 {object} []DeviceRetiredPagesInfo "设备的退役页信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /showRetiredPages [get]
-func ShowRetiredPages(dvIdList []int, retiredType string) {
-	fmt.Println(" Pages Info ")
+func ShowRetiredPages(dvIdList []int, retiredType string) (deviceRetiredPagesInfos []DeviceRetiredPagesInfo, err error) {
 	if retiredType == "" {
 		retiredType = "all"
 	}
@@ -2482,27 +2520,25 @@ func ShowRetiredPages(dvIdList []int, retiredType string) {
 	for _, device := range dvIdList {
 		_, records, err := rsmiDevMemoryReservedPagesGet(device)
 		if err != nil {
-			log.Printf("Unable to retrieve reserved page info for device %d: %v", device, err)
+			glog.Errorf("Unable to retrieve reserved page info for device %d: %v", device, err)
 			continue
 		}
 
-		var data [][]string
+		deviceRetiredPagesInfo := DeviceRetiredPagesInfo{DeviceID: device, Pages: []RetiredPage{}}
 		for _, rec := range records {
 			status := MemoryPageStatus[rec.Status]
 			if status == retiredType || retiredType == "all" {
-				data = append(data, []string{
-					fmt.Sprintf("0x%X", rec.PageAddress),
-					fmt.Sprintf("0x%X", rec.PageSize),
-					status,
+				deviceRetiredPagesInfo.Pages = append(deviceRetiredPagesInfo.Pages, RetiredPage{
+					Address: rec.PageAddress,
+					Size:    rec.PageSize,
+					Status:  status,
 				})
 			}
 		}
-
-		if len(data) > 0 {
-			printTableLog([]string{"Page address", "Page size", "Status"}, data, device, retiredType+" PAGES INFO")
-		}
+		deviceRetiredPagesInfos = append(deviceRetiredPagesInfos, deviceRetiredPagesInfo)
 	}
-	fmt.Println(" Pages Info ")
+	glog.Infof("deviceRetiredPagesInfos:%v", dataToJson(deviceRetiredPagesInfos))
+	return
 }
 
 // ShowSerialNumber 设备序列号
@@ -2591,7 +2627,7 @@ func ShowVbiosVersion(dvIdList []int) (deviceVBIOSInfos []DeviceVBIOSInfo, err e
 	return
 }
 
-// ShowEvents 显示设备的事件
+// ShowEvents 显示设备的事件，阻塞直到 ShutDown 关闭订阅，退出信号由调用方处理
 // @Summary 显示设备的事件
 // @Description 获取并显示指定设备的事件信息。
 // @Tags 设备
@@ -2602,48 +2638,19 @@ func ShowVbiosVersion(dvIdList []int) (deviceVBIOSInfos []DeviceVBIOSInfo, err e
 // @Router /showEvents [get]
 func ShowEvents(dvIdList []int, eventTypes []string) {
 	fmt.Println("----- Show Events -----")
-	fmt.Println("Press 'q' or 'ctrl + c' to quit")
 
-	var eventTypeList []string
+	var eventTypeList []EventType
 	for _, event := range eventTypes { // 清理列表中的错误值
 		cleanEvent := strings.ReplaceAll(event, ",", "")
-		if contains(notificationTypeNames, strings.ToUpper(cleanEvent)) {
-			eventTypeList = append(eventTypeList, strings.ToUpper(cleanEvent))
-		} else {
+		types, err := ParseNotificationTypes(cleanEvent)
+		if err != nil || strings.TrimSpace(cleanEvent) == "" {
 			fmt.Printf("Ignoring unrecognized event type %s\n", cleanEvent)
+			continue
 		}
+		eventTypeList = append(eventTypeList, types...)
 	}
 
-	if len(eventTypeList) == 0 {
-		eventTypeList = notificationTypeNames
-	}
-
-	var wg sync.WaitGroup
-	for _, device := range dvIdList {
-		wg.Add(1)
-		go func(device int) {
-			defer wg.Done()
-			printEventList(device, 1000, eventTypeList)
-		}(device)
-		time.Sleep(250 * time.Millisecond)
-	}
-
-	go func() {
-		var input string
-		for {
-			fmt.Scanln(&input)
-			if input == "q" {
-				for _, device := range dvIdList {
-					if err := rsmiEventNotificationStop(device); err != nil {
-						fmt.Printf("GPU[%d]: Unable to end event notifications: %v\n", device, err)
-					}
-				}
-				break
-			}
-		}
-	}()
-
-	wg.Wait()
+	printEvents(dvIdList, eventTypeList)
 }
 
 // ShowVoltage 当前电压信息
@@ -2679,23 +2686,28 @@ func ShowVoltage(dvIdList []int) (deviceVoltageInfos []DeviceVoltageInfo, err er
 // @Description 获取并显示指定设备的电压曲线点信息。
 // @Tags 设备
 // @Param dvIdList query []int true "设备ID列表"
-// @Success 200 {string} string "设备的电压曲线点信息"
+// @Success 200 {object} []DeviceVoltageCurveInfo "设备的电压曲线点信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /showVoltageCurve [get]
-func ShowVoltageCurve(dvIdList []int) {
-	fmt.Println("------------ Voltage Curve Points ------------")
+func ShowVoltageCurve(dvIdList []int) (deviceVoltageCurveInfos []DeviceVoltageCurveInfo, err error) {
 	for _, device := range dvIdList {
 		odv, err := rsmiDevOdVoltInfoGet(device)
 		if err != nil {
-			log.Printf("GPU %d: Voltage Curve is not supported: %v\n", device, err)
+			glog.Errorf("GPU %d: Voltage Curve is not supported: %v", device, err)
 			continue
 		}
 
-		for position, point := range odv.Curve.VcPoints {
-			fmt.Printf("Device %d: Voltage point %d: %d MHz %d mV\n", device, position, point.Frequency/1000000, point.Voltage)
+		deviceVoltageCurveInfo := DeviceVoltageCurveInfo{DeviceID: device}
+		for _, point := range odv.Curve.VcPoints {
+			deviceVoltageCurveInfo.Points = append(deviceVoltageCurveInfo.Points, VoltageCurvePoint{
+				Frequency: point.Frequency / 1000000,
+				Voltage:   point.Voltage,
+			})
 		}
+		deviceVoltageCurveInfos = append(deviceVoltageCurveInfos, deviceVoltageCurveInfo)
 	}
-	fmt.Println("----------------------------------------------")
+	glog.Infof("deviceVoltageCurveInfos:%v", dataToJson(deviceVoltageCurveInfos))
+	return
 }
 
 // ShowXgmiErr 显示指定设备的 XGMI 错误状态。
@@ -2704,15 +2716,13 @@ func ShowVoltageCurve(dvIdList []int) {
 // @Description 显示一组 GPU 设备的 XGMI 错误状态。
 // @Tags Topology
 // @Param dvIdList query []int true "设备 ID 列表"
-// @Param printJSON query bool false "是否以 JSON 格式输出"
-// @Success 200 {string} string "XGMI 错误状态信息"
+// @Success 200 {object} []XgmiErrorInfo "XGMI 错误状态信息列表"
 // @Router /showXgmiErr [get]
-func ShowXgmiErr(dvIdList []int, printJSON bool) {
-	fmt.Println("------------ XGMI Error Status ------------")
+func ShowXgmiErr(dvIdList []int) (xgmiErrorInfos []XgmiErrorInfo, err error) {
 	for _, device := range dvIdList {
 		status, err := rsmiDevXGMIErrorStatus(device)
 		if err != nil {
-			log.Printf("Error retrieving XGMI status for device %d: %v\n", device, err)
+			glog.Errorf("Error retrieving XGMI status for device %d: %v", device, err)
 			continue
 		}
 
@@ -2725,17 +2735,17 @@ func ShowXgmiErr(dvIdList []int, printJSON bool) {
 		case RSMIXGMIStatusMultipleErrors:
 			desc = "Multiple errors detected since last read"
 		default:
-			log.Printf("Invalid return value from xgmi_error for device %d\n", device)
+			glog.Errorf("Invalid return value from xgmi_error for device %d: %d", device, status)
 			continue
 		}
-
-		if printJSON {
-			fmt.Printf("Device %d: XGMI Error count: %d\n", device, status)
-		} else {
-			fmt.Printf("Device %d: XGMI Error count: %d (%s)\n", device, status, desc)
-		}
+		xgmiErrorInfos = append(xgmiErrorInfos, XgmiErrorInfo{
+			DeviceID:    device,
+			Status:      status,
+			Description: desc,
+		})
 	}
-	fmt.Println("-------------------------------------------")
+	glog.Infof("xgmiErrorInfos:%v", dataToJson(xgmiErrorInfos))
+	return
 }
 
 // ShowWeightTopology 显示 GPU 拓扑中两台设备之间的权重。
@@ -2743,55 +2753,26 @@ func ShowXgmiErr(dvIdList []int, printJSON bool) {
 // @Description 显示 GPU 设备之间的权重信息。
 // @Tags Topology
 // @Param dvIdList query []int true "设备 ID 列表"
-// @Param printJSON query bool false "是否以 JSON 格式输出"
-// @Success 200 {string} string "GPU 拓扑权重信息"
+// @Success 200 {object} TopologyMatrix "GPU 拓扑权重矩阵"
 // @Router /showWeightTopology [get]
-func ShowWeightTopology(dvIdList []int, printJSON bool) {
-	// 初始化矩阵存储设备间的权重
-	gpuLinksWeight := make([][]int64, len(dvIdList))
-	for i := range gpuLinksWeight {
-		gpuLinksWeight[i] = make([]int64, len(dvIdList))
-	}
-
-	fmt.Println("------------ Weight between two GPUs ------------")
-	for _, srcDevice := range dvIdList {
-		for _, destDevice := range dvIdList {
+func ShowWeightTopology(dvIdList []int) (weights TopologyMatrix, err error) {
+	weights = TopologyMatrix{Devices: dvIdList, Values: make([][]int64, len(dvIdList))}
+	for i, srcDevice := range dvIdList {
+		weights.Values[i] = make([]int64, len(dvIdList))
+		for j, destDevice := range dvIdList {
 			if srcDevice == destDevice {
-				gpuLinksWeight[srcDevice][destDevice] = 0
-			} else {
-				weight, err := rsmiTopoGetLinkWeight(srcDevice, destDevice)
-				if err != nil {
-					log.Printf("Cannot read Link Weight between device %d and %d: %v\n", srcDevice, destDevice, err)
-					continue
-				}
-				gpuLinksWeight[srcDevice][destDevice] = weight
+				continue
 			}
-		}
-	}
-
-	if printJSON {
-		formatMatrixToJSON(dvIdList, gpuLinksWeight, "(Topology) Weight between DRM devices %d and %d")
-		return
-	}
-
-	// 打印矩阵表格
-	printTableRow("", "      ")
-	for _, row := range dvIdList {
-		printTableRow("%-12s", fmt.Sprintf("GPU%d", row))
-	}
-	fmt.Println()
-	for _, gpu1 := range dvIdList {
-		printTableRow("%-6s", fmt.Sprintf("GPU%d", gpu1))
-		for _, gpu2 := range dvIdList {
-			if gpu1 == gpu2 {
-				printTableRow("%-12s", "0")
-			} else {
-				printTableRow("%-12d", gpuLinksWeight[gpu1][gpu2])
+			weight, err := rsmiTopoGetLinkWeight(srcDevice, destDevice)
+			if err != nil {
+				glog.Errorf("Cannot read Link Weight between device %d and %d: %v", srcDevice, destDevice, err)
+				weight = -1
 			}
+			weights.Values[i][j] = weight
 		}
-		fmt.Println()
 	}
-	fmt.Println("-------------------------------------------------")
+	glog.Infof("weights:%v", dataToJson(weights))
+	return
 }
 
 // ShowHopsTopology 显示 GPU 拓扑中两台设备之间的跳数。
@@ -2799,56 +2780,26 @@ func ShowWeightTopology(dvIdList []int, printJSON bool) {
 // @Description 显示 GPU 设备之间的跳数信息。
 // @Tags Topology
 // @Param dvIdList query []int true "设备 ID 列表"
-// @Param printJSON query bool false "是否以 JSON 格式输出"
-// @Success 200 {string} string "GPU 拓扑跳数信息"
+// @Success 200 {object} TopologyMatrix "GPU 拓扑跳数矩阵"
 // @Router /showHopsTopology [get]
-
-func ShowHopsTopology(dvIdList []int, printJSON bool) {
-	// 初始化矩阵存储设备间的跳数
-	gpuLinksHops := make([][]int64, len(dvIdList))
-	for i := range gpuLinksHops {
-		gpuLinksHops[i] = make([]int64, len(dvIdList))
-	}
-
-	fmt.Println("------------ Hops between two GPUs ------------")
-	for _, srcDevice := range dvIdList {
-		for _, destDevice := range dvIdList {
+func ShowHopsTopology(dvIdList []int) (hops TopologyMatrix, err error) {
+	hops = TopologyMatrix{Devices: dvIdList, Values: make([][]int64, len(dvIdList))}
+	for i, srcDevice := range dvIdList {
+		hops.Values[i] = make([]int64, len(dvIdList))
+		for j, destDevice := range dvIdList {
 			if srcDevice == destDevice {
-				gpuLinksHops[srcDevice][destDevice] = 0
-			} else {
-				hops, _, err := rsmiTopoGetLinkType(srcDevice, destDevice)
-				if err != nil {
-					log.Printf("Cannot read Link Hops between device %d and %d: %v\n", srcDevice, destDevice, err)
-					continue
-				}
-				gpuLinksHops[srcDevice][destDevice] = hops
+				continue
 			}
-		}
-	}
-
-	if printJSON {
-		formatMatrixToJSON(dvIdList, gpuLinksHops, "(Topology) Hops between DRM devices %d and %d")
-		return
-	}
-
-	// 打印矩阵表格
-	printTableRow("", "      ")
-	for _, row := range dvIdList {
-		printTableRow("%-12s", fmt.Sprintf("GPU%d", row))
-	}
-	fmt.Println()
-	for _, gpu1 := range dvIdList {
-		printTableRow("%-6s", fmt.Sprintf("GPU%d", gpu1))
-		for _, gpu2 := range dvIdList {
-			if gpu1 == gpu2 {
-				printTableRow("%-12s", "0")
-			} else {
-				printTableRow("%-12d", gpuLinksHops[gpu1][gpu2])
+			linkHops, _, err := rsmiTopoGetLinkType(srcDevice, destDevice)
+			if err != nil {
+				glog.Errorf("Cannot read Link Hops between device %d and %d: %v", srcDevice, destDevice, err)
+				linkHops = -1
 			}
+			hops.Values[i][j] = linkHops
 		}
-		fmt.Println()
 	}
-	fmt.Println("-------------------------------------------------")
+	glog.Infof("hops:%v", dataToJson(hops))
+	return
 }
 
 // ShowTypeTopology 显示 GPU 拓扑中两台设备之间的链接类型。
@@ -2856,62 +2807,26 @@ func ShowHopsTopology(dvIdList []int, printJSON bool) {
 // @Description 显示 GPU 设备之间的链接类型信息。
 // @Tags Topology
 // @Param dvIdList query []int true "设备 ID 列表"
-// @Param printJSON query bool false "是否以 JSON 格式输出"
-// @Success 200 {string} string "GPU 拓扑链接类型信息"
+// @Success 200 {object} LinkTypeMatrix "GPU 拓扑链接类型矩阵"
 // @Router /showTypeTopology [get]
-func ShowTypeTopology(dvIdList []int, printJSON bool) {
-	// 初始化矩阵存储设备间的链接类型
-	gpuLinksType := make([][]string, len(dvIdList))
-	for i := range gpuLinksType {
-		gpuLinksType[i] = make([]string, len(dvIdList))
-	}
-
-	fmt.Println("------------ Link Type between two GPUs ------------")
-	for _, srcDevice := range dvIdList {
-		for _, destDevice := range dvIdList {
+func ShowTypeTopology(dvIdList []int) (linkTypes LinkTypeMatrix, err error) {
+	linkTypes = LinkTypeMatrix{Devices: dvIdList, Types: make([][]string, len(dvIdList))}
+	for i, srcDevice := range dvIdList {
+		linkTypes.Types[i] = make([]string, len(dvIdList))
+		for j, destDevice := range dvIdList {
 			if srcDevice == destDevice {
-				gpuLinksType[srcDevice][destDevice] = "0"
-			} else {
-				_, linkType, err := rsmiTopoGetLinkType(srcDevice, destDevice)
-				if err != nil {
-					log.Printf("Cannot read Link Type between device %d and %d: %v\n", srcDevice, destDevice, err)
-					continue
-				}
-				switch linkType {
-				case 1:
-					gpuLinksType[srcDevice][destDevice] = LinkTypePCIE
-				case 2:
-					gpuLinksType[srcDevice][destDevice] = LinkTypeXGMI
-				default:
-					gpuLinksType[srcDevice][destDevice] = LinkTypeUnknown
-				}
+				continue
 			}
-		}
-	}
-
-	if printJSON {
-		formatMatrixToStrJSON(dvIdList, gpuLinksType, "(Topology) Link type between DRM devices %d and %d")
-		return
-	}
-
-	// 打印矩阵表格
-	printTableRow("", "      ")
-	for _, row := range dvIdList {
-		printTableRow("%-12s", fmt.Sprintf("GPU%d", row))
-	}
-	fmt.Println()
-	for _, gpu1 := range dvIdList {
-		printTableRow("%-6s", fmt.Sprintf("GPU%d", gpu1))
-		for _, gpu2 := range dvIdList {
-			if gpu1 == gpu2 {
-				printTableRow("%-12s", "0")
-			} else {
-				printTableRow("%-12s", gpuLinksType[gpu1][gpu2])
+			_, linkType, err := rsmiTopoGetLinkType(srcDevice, destDevice)
+			if err != nil {
+				glog.Errorf("Cannot read Link Type between device %d and %d: %v", srcDevice, destDevice, err)
+				continue
 			}
+			linkTypes.Types[i][j] = linkTypeName(linkType)
 		}
-		fmt.Println()
 	}
-	fmt.Println("----------------------------------------------------")
+	glog.Infof("linkTypes:%v", dataToJson(linkTypes))
+	return
 }
 
 // ShowNumaTopology 显示指定设备的 NUMA 节点信息。
@@ -2922,22 +2837,16 @@ func ShowTypeTopology(dvIdList []int, printJSON bool) {
 // @Success 200 {string} string "NUMA 节点信息"
 // @Router /showNumaTopology [get]
 func ShowNumaTopology(dvIdList []int) (numaInfos []NumaInfo, err error) {
-	fmt.Println("---------- Numa Nodes ----------")
-
 	for _, device := range dvIdList {
 		// 获取 NUMA 节点编号
 		numaNode, err := rsmiTopoGetNumaBodeBumber(device)
-		if err == nil {
-			fmt.Printf("Device %d: (Topology) Numa Node: %d\n", device, numaNode)
-		} else {
+		if err != nil {
 			glog.Errorf("device:%v Cannot read Numa Node", device)
 		}
 
 		// 获取 NUMA 关联信息
 		numaAffinity, err := rsmiTopoNumaAffinityGet(device)
-		if err == nil {
-			fmt.Println(device, "(Topology) Numa Affinity", numaAffinity)
-		} else {
+		if err != nil {
 			glog.Errorf("device:%v Cannot read Numa Affinity", device)
 		}
 		// 将设备和 NUMA 信息存储在结构体中并添加到切片中
@@ -2958,16 +2867,88 @@ func ShowNumaTopology(dvIdList []int) (numaInfos []NumaInfo, err error) {
 // @Description 显示一组 GPU 设备的权重、跳数、链接类型和 NUMA 节点信息。
 // @Tags Topology
 // @Param dvIdList query []int true "设备 ID 列表"
-// @Success 200 {string} string "完整的硬件拓扑信息"
+// @Success 200 {object} HwTopologyInfo "完整的硬件拓扑信息"
 // @Router /showHwTopology [get]
-func ShowHwTopology(dvIdList []int) {
-	ShowWeightTopology(dvIdList, true)
+func ShowHwTopology(dvIdList []int) (hwTopologyInfo HwTopologyInfo, err error) {
+	if hwTopologyInfo.Weight, err = ShowWeightTopology(dvIdList); err != nil {
+		return
+	}
+	if hwTopologyInfo.Hops, err = ShowHopsTopology(dvIdList); err != nil {
+		return
+	}
+	if hwTopologyInfo.LinkType, err = ShowTypeTopology(dvIdList); err != nil {
+		return
+	}
+	hwTopologyInfo.Numa, err = ShowNumaTopology(dvIdList)
+	return
+}
 
-	ShowHopsTopology(dvIdList, true)
+// linkTypeName 返回链接类型的名称
+func linkTypeName(linkType RSMIIOLinkType) string {
+	switch linkType {
+	case RSMIIOLinkTypePCIExpress:
+		return LinkTypePCIE
+	case RSMIIOLinkTypeXGMI:
+		return LinkTypeXGMI
+	}
+	return LinkTypeUnknown
+}
 
-	ShowTypeTopology(dvIdList, true)
+// ShowNodeTopology 返回一组设备两两之间的完整拓扑：链接类型、跳数、权重、理论最小/最大带宽以及每个设备的 NUMA 节点。
+// 单个元素读取失败时记录日志并标记为未知，不影响其余元素
+// @Summary 显示节点拓扑矩阵
+// @Description 显示一组 DCU 设备两两之间的链接类型、跳数、权重、min/max 带宽与 NUMA 节点。
+// @Tags Topology
+// @Param dvIdList query []int true "设备 ID 列表"
+// @Success 200 {object} NodeTopology "节点拓扑"
+// @Router /topology [get]
+func ShowNodeTopology(dvIdList []int) (topology NodeTopology, err error) {
+	for _, device := range dvIdList {
+		topologyDevice := TopologyDevice{DeviceID: device, NumaNode: -1, NumaAffinity: -1}
+		if topologyDevice.PciBusID, err = pciBusNumber(device); err != nil {
+			glog.Errorf("device:%v Cannot read PCI bus id: %v", device, err)
+		}
+		if topologyDevice.NumaNode, err = rsmiTopoGetNumaBodeBumber(device); err != nil {
+			glog.Errorf("device:%v Cannot read Numa Node: %v", device, err)
+			topologyDevice.NumaNode = -1
+		}
+		if topologyDevice.NumaAffinity, err = rsmiTopoNumaAffinityGet(device); err != nil {
+			glog.Errorf("device:%v Cannot read Numa Affinity: %v", device, err)
+			topologyDevice.NumaAffinity = -1
+		}
+		topology.Devices = append(topology.Devices, topologyDevice)
+	}
 
-	ShowNumaTopology(dvIdList)
+	topology.Links = make([][]TopologyLink, len(dvIdList))
+	for i, srcDevice := range dvIdList {
+		topology.Links[i] = make([]TopologyLink, len(dvIdList))
+		for j, destDevice := range dvIdList {
+			if srcDevice == destDevice {
+				continue
+			}
+			link := TopologyLink{Hops: -1, Weight: -1}
+			hops, linkType, err := rsmiTopoGetLinkType(srcDevice, destDevice)
+			if err != nil {
+				glog.Errorf("Cannot read Link Type between device %d and %d: %v", srcDevice, destDevice, err)
+			} else {
+				link.Hops, link.LinkType = hops, linkTypeName(linkType)
+			}
+			if link.Weight, err = rsmiTopoGetLinkWeight(srcDevice, destDevice); err != nil {
+				glog.Errorf("Cannot read Link Weight between device %d and %d: %v", srcDevice, destDevice, err)
+				link.Weight = -1
+			}
+			// rsmi 只给出跳数为 1 的 XGMI 链接的带宽
+			if link.Hops == 1 && link.LinkType == LinkTypeXGMI {
+				if link.MinBandwidth, link.MaxBandwidth, err = rsmiMinmaxBandwidthGet(srcDevice, destDevice); err != nil {
+					glog.V(2).Infof("Cannot read Link Bandwidth between device %d and %d: %v", srcDevice, destDevice, err)
+					link.MinBandwidth, link.MaxBandwidth = 0, 0
+				}
+			}
+			topology.Links[i][j] = link
+		}
+	}
+	glog.V(2).Infof("topology:%v", dataToJson(topology))
+	return topology, nil
 }
 
 /*************************************VDCU******************************************/
@@ -3005,6 +2986,35 @@ func VDeviceSingleInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
 // @Router /vDeviceCount [get]
 func VDeviceCount() (count int, err error) { return dmiGetVDeviceCount() }
 
+// VDeviceInfos 返回所有已创建的虚拟设备信息，包含使用百分比、虚拟设备索引号与所属物理设备的总线编号
+func VDeviceInfos() (vDeviceInfos []DMIVDeviceInfo, err error) {
+	deviceCount, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return nil, err
+	}
+	// 虚拟设备索引按物理设备分段，每个物理设备最多 maxVDeviceCount 个
+	maxVDeviceCount, err := dmiGetMaxVDeviceCount()
+	if err != nil || maxVDeviceCount <= 0 {
+		maxVDeviceCount = 4
+	}
+	pciBusNumbers := make(map[int]string, deviceCount)
+	for i := 0; i < deviceCount; i++ {
+		pciBusNumbers[i], _ = pciBusNumber(i)
+	}
+	for j := 0; j < deviceCount*maxVDeviceCount; j++ {
+		vDeviceInfo, err := dmiGetVDeviceInfo(j)
+		if err != nil {
+			// 未创建的虚拟设备索引返回错误，直接跳过
+			continue
+		}
+		vDeviceInfo.Percent, _ = dmiGetVDevBusyPercent(j)
+		vDeviceInfo.VMinorNumber = j
+		vDeviceInfo.PciBusNumber = pciBusNumbers[vDeviceInfo.DeviceID]
+		vDeviceInfos = append(vDeviceInfos, vDeviceInfo)
+	}
+	return vDeviceInfos, nil
+}
+
 // DeviceRemainingInfo 返回指定物理设备的剩余计算单元（CU）和内存信息。
 // @Summary 获取设备剩余信息
 // @Description 获取指定设备的剩余计算单元和内存信息。
@@ -3137,6 +3147,8 @@ func EncryptionVMStatus() (status bool, err error) {
 // @Success 200 {string} string "操作成功"
 // @Failure 400 {string} string "操作失败"
 // @Router /PrintEventList/{device} [get]
+//
+// Deprecated: 使用 Subscribe 获取事件，delay 已不再使用
 func PrintEventList(device int, delay int, eventList []string) {
 	printEventList(device, delay, eventList)
 }
@@ -0,0 +1,656 @@
+package dcgm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+)
+
+// Backend 抽象了 pkg/dcgm 依赖的全部 rsmi_* 与 dmi* 原语。
+// 默认实现为基于 cgo 的 librocm_smi64/libhydmi 绑定，也可以通过 SetBackend
+// 或 InitWithBackend 注入其他实现（例如模拟设备），以便在没有 DCU 的机器上运行。
+type Backend interface {
+	// ProbeDeviceCount 返回系统中探测到的 DCU 数量，Init 用它校验 rsmi 枚举到的设备数量
+	ProbeDeviceCount() int
+
+	// 初始化与关闭
+	RsmiInit() (err error)
+	RsmiShutdown() (err error)
+
+	// 设备信息、PCIe、功耗与内存
+	RsmiNumMonitorDevices() (gpuNum int, err error)
+	RsmiDevSkuGet(dvInd int) (sku int, err error)
+	RsmiDevVendorIdGet(dvInd int) uint
+	RsmiDevIdGet(dvInd int) (id int, err error)
+	RsmiDevNameGet(dvInd int) (nameStr string, err error)
+	RsmiDevBrandGet(dvInd int) (brand string, err error)
+	RsmiDevVendorNameGet(dvInd int) (bname string, err error)
+	RsmiDevVramVendorGet(dvInd int) (result string, err error)
+	RsmiDevSerialNumberGet(dvInd int) (serialNumber string, err error)
+	RsmiDevSubsystemIdGet(dvInd int) int
+	RsmiDevSubsystemNameGet(dvInd int) (subSystemName string, err error)
+	RsmiDevDrmRenderMinorGet(dvInd int) int
+	RsmiDevUniqueIdGet(dvInd int) (uniqueId int64, err error)
+	RsmiDevSubsystemVendorIdGet(dvInd int) int
+	RsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err error)
+	RsmiDevPciIdGet(dvInd int) (bdfid int64, err error)
+	RsmiTopoNumaAffinityGet(dvInd int) (namaNode int, err error)
+	RsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz int64, err error)
+	RsmiDevPciReplayCounterGet(dvInd int) (counter int64, err error)
+	RsmiDevPciBandwidthSet(dvInd int, bwBitmask int64) (err error)
+	RsmiDevPowerAveGet(dvInd int, senserId int) (power int64, err error)
+	RsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32, timestamp uint64, err error)
+	RsmiDevPowerCapGet(dvInd int, senserId int) (power int64, err error)
+	RsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error)
+	RsmiDevMemoryTotalGet(dvInd int, memoryType RSMIMemoryType) (total int64, err error)
+	RsmiDevMemoryUsageGet(dvInd int, memoryType RSMIMemoryType) (used int64, err error)
+	RsmiDevMemoryBusyPercentGet(dvInd int) (busyPercent int, err error)
+	RsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetiredPageRecord, err error)
+	RsmiDevFanRpmsGet(dvInd, sensorInd int) (speed int64, err error)
+	RsmiDevFanSpeedGet(dvInd, sensorInd int) (speed int64, err error)
+	RsmiDevFanSpeedMaxGet(dvInd, sensorInd int) (maxSpeed int64, err error)
+	RsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreqVoltRegion, err error)
+	RsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSMPowerProfileStatus, err error)
+	RsmiVersionGet() (version RSMIVersion, err error)
+	RsmiVersionStrGet(component RSMISwComponent, len int) (varStr string, err error)
+	RsmiDevVbiosVersionGet(dvInd, len int) (vbios string, err error)
+	RsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64, err error)
+
+	// vDCU (libhydmi)
+	DmiGetDeviceCount() (count int, err error)
+	DmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error)
+	DmiGetMaxVDeviceCount() (count int, err error)
+	DmiGetVDeviceCount() (count int, err error)
+	DmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error)
+	DmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error)
+	DmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []int) (vdevIDs []int, err error)
+	DmiDestroyVDevices(dvInd int) (err error)
+	DmiDestroySingleVDevice(vDvInd int) (err error)
+	DmiUpdateSingleVDevice(vDvInd int, vDevCUs int, vDevMemSize int) (err error)
+	DmiStartVDevice(vDvInd int) (err error)
+	DmiStopVDevice(vDvInd int) (err error)
+	DmiGetDevBusyPercent(dvInd int) (percent int, err error)
+	DmiGetVDevBusyPercent(vDvInd int) (percent int, err error)
+	DmiSetEncryptionVMStatus(status bool) (err error)
+	DmiGetEncryptionVMStatus() (status bool, err error)
+
+	// 设备状态
+	RsmiDevTempMetricGet(dvInd int, sensorType int, metric RSMITemperatureMetric) (temp int64, err error)
+	RsmiDevVoltMetricGet(dvInd int, voltageType RSMIVoltageType, metric RSMIVoltageMetric) int64
+	RsmiDevFanSpeedSet(dvInd, sensorInd int, speed int64) (err error)
+	RsmiDevBusyPercentGet(dvInd int) (busyPercent int, err error)
+	RsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCounter, count int) (timestamp int64, err error)
+	RsmiDevPerfLevelGet(dvInd int) (perf RSMIDevPerfLevel, err error)
+	RsmiPerfDeterminismModeSet(dvInd int, clkValue int64) (err error)
+	RsmiDevOverdriveLevelGet(dvInd int) (od int, err error)
+	RsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequencies, err error)
+	RsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error)
+	RsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error)
+	// GpuMetricsTableGet 返回设备原始的 gpu_metrics 度量表，由 DecodeGpuMetrics 按版本解码
+	GpuMetricsTableGet(dvInd int) (table []byte, err error)
+	RsmiDevEccStatusGet(dvInd int, block RSMIGpuBlock) (state RSMIRasErrState, err error)
+	RsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorCount, err error)
+	RsmiDevEccEnabledGet(dvInd int) (enabledBlocks int64, err error)
+
+	// 控制与计数器
+	RsmiDevPerfLevelSet(dvInd int, devPerfLevel RSMIDevPerfLevel) (err error)
+	RsmiDevClkRangeSet(dvInd int, minClkValue, maxClkValue int64, clkType RSMIClkType) (err error)
+	RsmiDevOdVoltInfoSet(dvInd, vPoint, clkValue, voltValue int) (err error)
+	RsmiDevOverdriveLevelSet(dvInd, od int) (err error)
+	RsmiDevGpuClkFreqSet(dvInd int, clkType RSMIClkType, freqBitmask int64) (err error)
+	RsmiDevCounterGroupSupported(dvInd int, group RSMIEventGroup) (err error)
+	RsmiDevCounterCreate(dvInd int, eventType RSMIEventType) (eventHandle EventHandle, err error)
+	RsmiDevCounterDestroy(handle EventHandle) (err error)
+	RsmiCounterControl(evtHandle EventHandle, cmd RSMICounterCommand) (err error)
+	RsmiCounterRead(handle EventHandle) (counterValue RSMICounterValue, err error)
+	RsmiCounterAvailableCountersGet(dvInd int, group RSMIEventGroup) (availAble int, err error)
+	RsmiDevFanReset(dvInd, sensorInd int) (err error)
+	RsmiDevPowerProfileSet(dvInd int, reserved int, profile RSNIPowerProfilePresetMasks) (err error)
+	RsmiDevXgmiErrorReset(dvInd int) (err error)
+	RsmiDevXGMIErrorStatus(dvInd int) (status RSMIXGMIStatus, err error)
+	RsmiDevXgmiHiveIdGet(dvInd int) (hiveId int64, err error)
+
+	// 进程与事件通知
+	RsmiComputeProcessInfoGet() (processInfo []RSMIProcessInfo, numItems int, err error)
+	RsmiComputeProcessInfoByPidGet(pid int) (proc RSMIProcessInfo, err error)
+	RsmiComputeProcessGpusGet(pid int) (dvIndices []int, err error)
+	RsmiDevSupportedFuncIteratorOpen(dvInd int) (iterHandle RSMIFuncIDIterHandle, err error)
+	RsmiDevSupportedVariantIteratorOpen(iterHandle RSMIFuncIDIterHandle) (handle RSMIFuncIDIterHandle, err error)
+	RsmiFuncIterNext(handle RSMIFuncIDIterHandle) (err error)
+	RsmiDevSupportedFuncIteratorClose(handle RSMIFuncIDIterHandle) (err error)
+	RsmiEventNotificationInit(deInd int) (err error)
+	RsmiEventNotificationMaskSet(dvInd int, mask int64) (err error)
+	RsmiEventNotificationGet(timeoutMs int) (numElem int, datas []RSMIEEvtNotificationData, err error)
+	RsmiEventNotificationStop(dvInd int) (err error)
+
+	// 拓扑
+	RsmiTopoGetLinkWeight(dvIndSrc, dvIndDst int) (weight int64, err error)
+	RsmiTopoGetLinkType(dvIndSrc, dvIndDst int) (hops int64, linkType RSMIIOLinkType, err error)
+	RsmiTopoGetNumaBodeBumber(dvInd int) (numaNode int, err error)
+	RsmiMinmaxBandwidthGet(dvIndSrc, dvIndDst int) (minBandwidth, maxBandwidth int64, err error)
+}
+
+// BackendFactory 创建一个后端实例，config 为后端名称中冒号之后的部分（例如场景文件路径），可以为空
+type BackendFactory func(config string) (Backend, error)
+
+// ErrNotSupported 后端不支持请求的原语时返回的错误，可用 errors.Is 判断
+var ErrNotSupported = errors.New("not supported")
+
+// ErrNoBackend 不启用 cgo 构建时默认后端返回的错误，需要通过 InitWithBackendName 等选择其他后端
+var ErrNoBackend = errors.New("no dcgm backend: built without cgo, use the fake, sysfs or replay backend")
+
+// NotSupportedError 原语不被支持时的具体错误：动态库缺少对应符号，或库返回了
+// RSMI_STATUS_NOT_SUPPORTED/DMI_STATUS_NOT_SUPPORTED。errors.Is(err, ErrNotSupported) 为 true。
+type NotSupportedError struct {
+	Symbol  string // 缺失的符号，库返回不支持状态时为空
+	Library string // 符号所属的库
+	Status  string // 库返回的状态描述
+}
+
+func (e *NotSupportedError) Error() string {
+	if e.Symbol != "" {
+		return fmt.Sprintf("not supported: symbol %s not found in %s", e.Symbol, e.Library)
+	}
+	if e.Status != "" {
+		return e.Status
+	}
+	return ErrNotSupported.Error()
+}
+
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+const (
+	// BackendCgo 基于 cgo 的 librocm_smi64/libhydmi 后端，默认后端
+	BackendCgo = "cgo"
+)
+
+var (
+	backendMu      sync.RWMutex
+	currentBackend Backend = newCgoBackend()
+	factoriesMu    sync.RWMutex
+	factories      = map[string]BackendFactory{
+		BackendCgo: func(string) (Backend, error) { return newCgoBackend(), nil },
+	}
+)
+
+// RegisterBackend 按名称注册后端，供 NewBackend 和 InitWithBackendName 使用
+func RegisterBackend(name string, factory BackendFactory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[name] = factory
+}
+
+// BackendNames 返回已注册的后端名称
+func BackendNames() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewBackend 根据名称创建已注册的后端，spec 格式为 "name" 或 "name:config"，
+// 例如 "fake:/etc/dcgm/scenario.yaml"
+func NewBackend(spec string) (Backend, error) {
+	name, config, _ := strings.Cut(spec, ":")
+	factoriesMu.RLock()
+	factory, ok := factories[name]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("Error unknown backend:%s, available:%v", name, BackendNames())
+	}
+	return factory(config)
+}
+
+// SetBackend 替换当前使用的后端，应在 Init 之前调用；传入 nil 恢复默认的 cgo 后端
+func SetBackend(b Backend) {
+	if b == nil {
+		b = newCgoBackend()
+	}
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	currentBackend = b
+	glog.Infof("dcgm backend set to %T", b)
+}
+
+// GetBackend 返回当前使用的后端
+func GetBackend() Backend {
+	return getBackend()
+}
+
+func getBackend() Backend {
+	backendMu.RLock()
+	defer backendMu.RUnlock()
+	return currentBackend
+}
+
+// InitWithBackend 使用指定的后端初始化 DCGM
+func InitWithBackend(b Backend) error {
+	SetBackend(b)
+	return Init()
+}
+
+// InitWithBackendName 使用按名称注册的后端初始化 DCGM，spec 格式同 NewBackend
+func InitWithBackendName(spec string) error {
+	b, err := NewBackend(spec)
+	if err != nil {
+		return err
+	}
+	return InitWithBackend(b)
+}
+
+/****************************************** 原语分发 *********************************************/
+// 以下函数保留原有的 rsmiXxx/dmiXxx 名称，将调用转发给当前后端
+
+func rsmiInit() (err error) {
+	return getBackend().RsmiInit()
+}
+
+func rsmiShutdown() (err error) {
+	return getBackend().RsmiShutdown()
+}
+
+func rsmiNumMonitorDevices() (gpuNum int, err error) {
+	return getBackend().RsmiNumMonitorDevices()
+}
+
+func rsmiDevSkuGet(dvInd int) (sku int, err error) {
+	return getBackend().RsmiDevSkuGet(dvInd)
+}
+
+func rsmiDevVendorIdGet(dvInd int) uint {
+	return getBackend().RsmiDevVendorIdGet(dvInd)
+}
+
+func rsmiDevIdGet(dvInd int) (id int, err error) {
+	return getBackend().RsmiDevIdGet(dvInd)
+}
+
+func rsmiDevNameGet(dvInd int) (nameStr string, err error) {
+	return getBackend().RsmiDevNameGet(dvInd)
+}
+
+func rsmiDevBrandGet(dvInd int) (brand string, err error) {
+	return getBackend().RsmiDevBrandGet(dvInd)
+}
+
+func rsmiDevVendorNameGet(dvInd int) (bname string, err error) {
+	return getBackend().RsmiDevVendorNameGet(dvInd)
+}
+
+func rsmiDevVramVendorGet(dvInd int) (result string, err error) {
+	return getBackend().RsmiDevVramVendorGet(dvInd)
+}
+
+func rsmiDevSerialNumberGet(dvInd int) (serialNumber string, err error) {
+	return getBackend().RsmiDevSerialNumberGet(dvInd)
+}
+
+func rsmiDevSubsystemIdGet(dvInd int) int {
+	return getBackend().RsmiDevSubsystemIdGet(dvInd)
+}
+
+func rsmiDevSubsystemNameGet(dvInd int) (subSystemName string, err error) {
+	return getBackend().RsmiDevSubsystemNameGet(dvInd)
+}
+
+func rsmiDevDrmRenderMinorGet(dvInd int) int {
+	return getBackend().RsmiDevDrmRenderMinorGet(dvInd)
+}
+
+func rsmiDevUniqueIdGet(dvInd int) (uniqueId int64, err error) {
+	return getBackend().RsmiDevUniqueIdGet(dvInd)
+}
+
+func rsmiDevSubsystemVendorIdGet(dvInd int) int {
+	return getBackend().RsmiDevSubsystemVendorIdGet(dvInd)
+}
+
+func rsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err error) {
+	return getBackend().RsmiDevPciBandwidthGet(dvInd)
+}
+
+func rsmiDevPciIdGet(dvInd int) (bdfid int64, err error) {
+	return getBackend().RsmiDevPciIdGet(dvInd)
+}
+
+func rsmiTopoNumaAffinityGet(dvInd int) (namaNode int, err error) {
+	return getBackend().RsmiTopoNumaAffinityGet(dvInd)
+}
+
+func rsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz int64, err error) {
+	return getBackend().RsmiDevPciThroughputGet(dvInd)
+}
+
+func rsmiDevPciReplayCounterGet(dvInd int) (counter int64, err error) {
+	return getBackend().RsmiDevPciReplayCounterGet(dvInd)
+}
+
+func rsmiDevPciBandwidthSet(dvInd int, bwBitmask int64) (err error) {
+	return getBackend().RsmiDevPciBandwidthSet(dvInd, bwBitmask)
+}
+
+func rsmiDevPowerAveGet(dvInd int, senserId int) (power int64, err error) {
+	return getBackend().RsmiDevPowerAveGet(dvInd, senserId)
+}
+
+func rsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32, timestamp uint64, err error) {
+	return getBackend().RsmiDevEnergyCountGet(dvInd)
+}
+
+func rsmiDevPowerCapGet(dvInd int, senserId int) (power int64, err error) {
+	return getBackend().RsmiDevPowerCapGet(dvInd, senserId)
+}
+
+func rsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error) {
+	return getBackend().RsmiDevPowerCapRangeGet(dvInd, senserId)
+}
+
+func rsmiDevMemoryTotalGet(dvInd int, memoryType RSMIMemoryType) (total int64, err error) {
+	return getBackend().RsmiDevMemoryTotalGet(dvInd, memoryType)
+}
+
+func rsmiDevMemoryUsageGet(dvInd int, memoryType RSMIMemoryType) (used int64, err error) {
+	return getBackend().RsmiDevMemoryUsageGet(dvInd, memoryType)
+}
+
+func rsmiDevMemoryBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	return getBackend().RsmiDevMemoryBusyPercentGet(dvInd)
+}
+
+func rsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetiredPageRecord, err error) {
+	return getBackend().RsmiDevMemoryReservedPagesGet(dvInd)
+}
+
+func rsmiDevFanRpmsGet(dvInd, sensorInd int) (speed int64, err error) {
+	return getBackend().RsmiDevFanRpmsGet(dvInd, sensorInd)
+}
+
+func rsmiDevFanSpeedGet(dvInd, sensorInd int) (speed int64, err error) {
+	return getBackend().RsmiDevFanSpeedGet(dvInd, sensorInd)
+}
+
+func rsmiDevFanSpeedMaxGet(dvInd, sensorInd int) (maxSpeed int64, err error) {
+	return getBackend().RsmiDevFanSpeedMaxGet(dvInd, sensorInd)
+}
+
+func rsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreqVoltRegion, err error) {
+	return getBackend().RsmiDevOdVoltCurveRegionsGet(dvInd)
+}
+
+func rsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSMPowerProfileStatus, err error) {
+	return getBackend().RsmiDevPowerProfilePresetsGet(dvInd, sensorInd)
+}
+
+func rsmiVersionGet() (version RSMIVersion, err error) {
+	return getBackend().RsmiVersionGet()
+}
+
+func rsmiVersionStrGet(component RSMISwComponent, len int) (varStr string, err error) {
+	return getBackend().RsmiVersionStrGet(component, len)
+}
+
+func rsmiDevVbiosVersionGet(dvInd, len int) (vbios string, err error) {
+	return getBackend().RsmiDevVbiosVersionGet(dvInd, len)
+}
+
+func rsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64, err error) {
+	return getBackend().RsmiDevFirmwareVersionGet(dvInd, fwBlock)
+}
+
+func dmiGetDeviceCount() (count int, err error) {
+	return getBackend().DmiGetDeviceCount()
+}
+
+func dmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error) {
+	return getBackend().DmiGetDeviceInfo(dvInd)
+}
+
+func dmiGetMaxVDeviceCount() (count int, err error) {
+	return getBackend().DmiGetMaxVDeviceCount()
+}
+
+func dmiGetVDeviceCount() (count int, err error) {
+	return getBackend().DmiGetVDeviceCount()
+}
+
+func dmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
+	return getBackend().DmiGetVDeviceInfo(vDvInd)
+}
+
+func dmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error) {
+	return getBackend().DmiGetDeviceRemainingInfo(dvInd)
+}
+
+func dmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []int) (vdevIDs []int, err error) {
+	return getBackend().DmiCreateVDevices(dvInd, vDevCount, vDevCUs, vDevMemSize)
+}
+
+func dmiDestroyVDevices(dvInd int) (err error) {
+	return getBackend().DmiDestroyVDevices(dvInd)
+}
+
+func dmiDestroySingleVDevice(vDvInd int) (err error) {
+	return getBackend().DmiDestroySingleVDevice(vDvInd)
+}
+
+func dmiUpdateSingleVDevice(vDvInd int, vDevCUs int, vDevMemSize int) (err error) {
+	return getBackend().DmiUpdateSingleVDevice(vDvInd, vDevCUs, vDevMemSize)
+}
+
+func dmiStartVDevice(vDvInd int) (err error) {
+	return getBackend().DmiStartVDevice(vDvInd)
+}
+
+func dmiStopVDevice(vDvInd int) (err error) {
+	return getBackend().DmiStopVDevice(vDvInd)
+}
+
+func dmiGetDevBusyPercent(dvInd int) (percent int, err error) {
+	return getBackend().DmiGetDevBusyPercent(dvInd)
+}
+
+func dmiGetVDevBusyPercent(vDvInd int) (percent int, err error) {
+	return getBackend().DmiGetVDevBusyPercent(vDvInd)
+}
+
+func dmiSetEncryptionVMStatus(status bool) (err error) {
+	return getBackend().DmiSetEncryptionVMStatus(status)
+}
+
+func dmiGetEncryptionVMStatus() (status bool, err error) {
+	return getBackend().DmiGetEncryptionVMStatus()
+}
+
+func rsmiDevTempMetricGet(dvInd int, sensorType int, metric RSMITemperatureMetric) (temp int64, err error) {
+	return getBackend().RsmiDevTempMetricGet(dvInd, sensorType, metric)
+}
+
+func rsmiDevVoltMetricGet(dvInd int, voltageType RSMIVoltageType, metric RSMIVoltageMetric) int64 {
+	return getBackend().RsmiDevVoltMetricGet(dvInd, voltageType, metric)
+}
+
+func rsmiDevFanSpeedSet(dvInd, sensorInd int, speed int64) (err error) {
+	return getBackend().RsmiDevFanSpeedSet(dvInd, sensorInd, speed)
+}
+
+func rsmiDevBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	return getBackend().RsmiDevBusyPercentGet(dvInd)
+}
+
+func rsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCounter, count int) (timestamp int64, err error) {
+	return getBackend().RsmiUtilizationCountGet(dvInd, utilizationCounters, count)
+}
+
+func rsmiDevPerfLevelGet(dvInd int) (perf RSMIDevPerfLevel, err error) {
+	return getBackend().RsmiDevPerfLevelGet(dvInd)
+}
+
+func rsmiPerfDeterminismModeSet(dvInd int, clkValue int64) (err error) {
+	return getBackend().RsmiPerfDeterminismModeSet(dvInd, clkValue)
+}
+
+func rsmiDevOverdriveLevelGet(dvInd int) (od int, err error) {
+	return getBackend().RsmiDevOverdriveLevelGet(dvInd)
+}
+
+func rsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequencies, err error) {
+	return getBackend().RsmiDevGpuClkFreqGet(dvInd, clkType)
+}
+
+func rsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error) {
+	return getBackend().RsmiDevOdVoltInfoGet(dvInd)
+}
+
+func rsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error) {
+	return getBackend().RsmiDevGpuMetricsInfoGet(dvInd)
+}
+
+func gpuMetricsTableGet(dvInd int) (table []byte, err error) {
+	return getBackend().GpuMetricsTableGet(dvInd)
+}
+
+func rsmiDevEccStatusGet(dvInd int, block RSMIGpuBlock) (state RSMIRasErrState, err error) {
+	return getBackend().RsmiDevEccStatusGet(dvInd, block)
+}
+
+func rsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorCount, err error) {
+	return getBackend().RsmiDevEccCountGet(dvInd, gpuBlock)
+}
+
+func rsmiDevEccEnabledGet(dvInd int) (enabledBlocks int64, err error) {
+	return getBackend().RsmiDevEccEnabledGet(dvInd)
+}
+
+func rsmiDevPerfLevelSet(dvInd int, devPerfLevel RSMIDevPerfLevel) (err error) {
+	return getBackend().RsmiDevPerfLevelSet(dvInd, devPerfLevel)
+}
+
+func rsmiDevClkRangeSet(dvInd int, minClkValue, maxClkValue int64, clkType RSMIClkType) (err error) {
+	return getBackend().RsmiDevClkRangeSet(dvInd, minClkValue, maxClkValue, clkType)
+}
+
+func rsmiDevOdVoltInfoSet(dvInd, vPoint, clkValue, voltValue int) (err error) {
+	return getBackend().RsmiDevOdVoltInfoSet(dvInd, vPoint, clkValue, voltValue)
+}
+
+func rsmiDevOverdriveLevelSet(dvInd, od int) (err error) {
+	return getBackend().RsmiDevOverdriveLevelSet(dvInd, od)
+}
+
+func rsmiDevGpuClkFreqSet(dvInd int, clkType RSMIClkType, freqBitmask int64) (err error) {
+	return getBackend().RsmiDevGpuClkFreqSet(dvInd, clkType, freqBitmask)
+}
+
+func rsmiDevCounterGroupSupported(dvInd int, group RSMIEventGroup) (err error) {
+	return getBackend().RsmiDevCounterGroupSupported(dvInd, group)
+}
+
+func rsmiDevCounterCreate(dvInd int, eventType RSMIEventType) (eventHandle EventHandle, err error) {
+	return getBackend().RsmiDevCounterCreate(dvInd, eventType)
+}
+
+func rsmiDevCounterDestroy(handle EventHandle) (err error) {
+	return getBackend().RsmiDevCounterDestroy(handle)
+}
+
+func rsmiCounterControl(evtHandle EventHandle, cmd RSMICounterCommand) (err error) {
+	return getBackend().RsmiCounterControl(evtHandle, cmd)
+}
+
+func rsmiCounterRead(handle EventHandle) (counterValue RSMICounterValue, err error) {
+	return getBackend().RsmiCounterRead(handle)
+}
+
+func rsmiCounterAvailableCountersGet(dvInd int, group RSMIEventGroup) (availAble int, err error) {
+	return getBackend().RsmiCounterAvailableCountersGet(dvInd, group)
+}
+
+func rsmiDevFanReset(dvInd, sensorInd int) (err error) {
+	return getBackend().RsmiDevFanReset(dvInd, sensorInd)
+}
+
+func rsmiDevPowerProfileSet(dvInd int, reserved int, profile RSNIPowerProfilePresetMasks) (err error) {
+	return getBackend().RsmiDevPowerProfileSet(dvInd, reserved, profile)
+}
+
+func rsmiDevXgmiErrorReset(dvInd int) (err error) {
+	return getBackend().RsmiDevXgmiErrorReset(dvInd)
+}
+
+func rsmiDevXGMIErrorStatus(dvInd int) (status RSMIXGMIStatus, err error) {
+	return getBackend().RsmiDevXGMIErrorStatus(dvInd)
+}
+
+func rsmiDevXgmiHiveIdGet(dvInd int) (hiveId int64, err error) {
+	return getBackend().RsmiDevXgmiHiveIdGet(dvInd)
+}
+
+func rsmiComputeProcessInfoGet() (processInfo []RSMIProcessInfo, numItems int, err error) {
+	return getBackend().RsmiComputeProcessInfoGet()
+}
+
+func rsmiComputeProcessInfoByPidGet(pid int) (proc RSMIProcessInfo, err error) {
+	return getBackend().RsmiComputeProcessInfoByPidGet(pid)
+}
+
+func rsmiComputeProcessGpusGet(pid int) (dvIndices []int, err error) {
+	return getBackend().RsmiComputeProcessGpusGet(pid)
+}
+
+func rsmiDevSupportedFuncIteratorOpen(dvInd int) (iterHandle RSMIFuncIDIterHandle, err error) {
+	return getBackend().RsmiDevSupportedFuncIteratorOpen(dvInd)
+}
+
+func rsmiDevSupportedVariantIteratorOpen(iterHandle RSMIFuncIDIterHandle) (handle RSMIFuncIDIterHandle, err error) {
+	return getBackend().RsmiDevSupportedVariantIteratorOpen(iterHandle)
+}
+
+func rsmiFuncIterNext(handle RSMIFuncIDIterHandle) (err error) {
+	return getBackend().RsmiFuncIterNext(handle)
+}
+
+func rsmiDevSupportedFuncIteratorClose(handle RSMIFuncIDIterHandle) (err error) {
+	return getBackend().RsmiDevSupportedFuncIteratorClose(handle)
+}
+
+func rsmiEventNotificationInit(deInd int) (err error) {
+	return getBackend().RsmiEventNotificationInit(deInd)
+}
+
+func rsmiEventNotificationMaskSet(dvInd int, mask int64) (err error) {
+	return getBackend().RsmiEventNotificationMaskSet(dvInd, mask)
+}
+
+func rsmiEventNotificationGet(timeoutMs int) (numElem int, datas []RSMIEEvtNotificationData, err error) {
+	return getBackend().RsmiEventNotificationGet(timeoutMs)
+}
+
+func rsmiEventNotificationStop(dvInd int) (err error) {
+	return getBackend().RsmiEventNotificationStop(dvInd)
+}
+
+func rsmiTopoGetLinkWeight(dvIndSrc, dvIndDst int) (weight int64, err error) {
+	return getBackend().RsmiTopoGetLinkWeight(dvIndSrc, dvIndDst)
+}
+
+func rsmiTopoGetLinkType(dvIndSrc, dvIndDst int) (hops int64, linkType RSMIIOLinkType, err error) {
+	return getBackend().RsmiTopoGetLinkType(dvIndSrc, dvIndDst)
+}
+
+func rsmiTopoGetNumaBodeBumber(dvInd int) (numaNode int, err error) {
+	return getBackend().RsmiTopoGetNumaBodeBumber(dvInd)
+}
+
+func rsmiMinmaxBandwidthGet(dvIndSrc, dvIndDst int) (minBandwidth, maxBandwidth int64, err error) {
+	return getBackend().RsmiMinmaxBandwidthGet(dvIndSrc, dvIndDst)
+}
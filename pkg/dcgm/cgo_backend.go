@@ -0,0 +1,81 @@
+package dcgm
+
+/*
+#cgo CFLAGS: -Wall -I./include
+#cgo LDFLAGS: -ldl
+#include <stdint.h>
+#include <kfd_ioctl.h>
+#include <rocm_smi64Config.h>
+#include <rocm_smi.h>
+#include <dmi_virtual.h>
+#include <dmi_error.h>
+#include <dmi.h>
+#include <dmi_mig.h>
+*/
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+// cgoBackend 通过 cgo 直接调用 librocm_smi64 与 libhydmi，方法实现分布在
+// admin.go、device_info.go、device_status.go、policy.go、process_info.go 和 topology.go 中
+type cgoBackend struct{}
+
+func newCgoBackend() *cgoBackend {
+	return &cgoBackend{}
+}
+
+// ProbeDeviceCount 扫描 /sys/devices 下的 PCI 设备
+func (b *cgoBackend) ProbeDeviceCount() int {
+	return listFilesInDevDri()
+}
+
+func errorString(result C.rsmi_status_t) error {
+	if RSMIStatus(result) == RSMI_STATUS_SUCCESS {
+		return nil
+	}
+	if err := missingSymbolError(int64(result)); err != nil {
+		return err
+	}
+	var cStatusString *C.char
+	statusCode := C.rsmi_status_string(result, (**C.char)(unsafe.Pointer(&cStatusString)))
+	if RSMIStatus(statusCode) != RSMI_STATUS_SUCCESS {
+		return fmt.Errorf("error: %d", statusCode)
+	}
+	goStatusString := C.GoString(cStatusString)
+	if RSMIStatus(result) == RSMI_STATUS_NOT_SUPPORTED {
+		return &NotSupportedError{Library: LibraryRsmi, Status: goStatusString}
+	}
+	return fmt.Errorf("%s", goStatusString)
+}
+
+func dmiErrorString(result C.dmiStatus) error {
+	if DMIStatus(result) == DMI_STATUS_SUCCESS {
+		return nil
+	}
+	if err := missingSymbolError(int64(result)); err != nil {
+		return err
+	}
+	var cStatusString *C.char
+	statusCode := C.dmiGetStatusString(result, (**C.char)(unsafe.Pointer(&cStatusString)))
+	if DMIStatus(statusCode) != DMI_STATUS_SUCCESS {
+		return fmt.Errorf("error: %d", statusCode)
+	}
+	goStatusString := C.GoString(cStatusString)
+	if DMIStatus(result) == DMI_STATUS_NOT_SUPPORTED {
+		return &NotSupportedError{Library: LibraryDmi, Status: goStatusString}
+	}
+	return fmt.Errorf("%s", goStatusString)
+}
+
+// 获取所提供的RSMI错误状态的描述
+func go_rsmi_status_string(status RSMIStatus) (statusStr string, err error) {
+	var cstatusStr *C.char
+	ret := C.rsmi_status_string(C.rsmi_status_t(status), (**C.char)(unsafe.Pointer(&cstatusStr)))
+	if err = errorString(ret); err != nil {
+		return statusStr, fmt.Errorf("Error go_rsmi_status_string:%s", err)
+	}
+	statusStr = C.GoString(cstatusStr)
+	return
+}
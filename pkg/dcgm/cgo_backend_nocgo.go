@@ -0,0 +1,535 @@
+//go:build !cgo
+
+package dcgm
+
+import "fmt"
+
+// noCgoBackend 不启用 cgo 构建时的默认后端，所有原语返回 ErrNoBackend；
+// 此时可通过 InitWithBackendName 选择 fake、sysfs、replay 等不依赖 cgo 的后端
+type noCgoBackend struct{}
+
+func newCgoBackend() Backend {
+	return noCgoBackend{}
+}
+
+func noCgoError(fn string) error {
+	return fmt.Errorf("Error %s:%w", fn, ErrNoBackend)
+}
+
+func (noCgoBackend) ProbeDeviceCount() int {
+	return 0
+}
+
+/****************************************** 初始化与关闭 *********************************************/
+
+func (noCgoBackend) RsmiInit() (err error) {
+	err = noCgoError("RsmiInit")
+	return
+}
+
+func (noCgoBackend) RsmiShutdown() (err error) {
+	err = noCgoError("RsmiShutdown")
+	return
+}
+
+/****************************************** 设备信息、PCIe、功耗与内存 *********************************************/
+
+func (noCgoBackend) RsmiNumMonitorDevices() (gpuNum int, err error) {
+	err = noCgoError("RsmiNumMonitorDevices")
+	return
+}
+
+func (noCgoBackend) RsmiDevSkuGet(dvInd int) (sku int, err error) {
+	err = noCgoError("RsmiDevSkuGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevVendorIdGet(dvInd int) uint {
+	return 0
+}
+
+func (noCgoBackend) RsmiDevIdGet(dvInd int) (id int, err error) {
+	err = noCgoError("RsmiDevIdGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevNameGet(dvInd int) (nameStr string, err error) {
+	err = noCgoError("RsmiDevNameGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevBrandGet(dvInd int) (brand string, err error) {
+	err = noCgoError("RsmiDevBrandGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevVendorNameGet(dvInd int) (bname string, err error) {
+	err = noCgoError("RsmiDevVendorNameGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevVramVendorGet(dvInd int) (result string, err error) {
+	err = noCgoError("RsmiDevVramVendorGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevSerialNumberGet(dvInd int) (serialNumber string, err error) {
+	err = noCgoError("RsmiDevSerialNumberGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevSubsystemIdGet(dvInd int) int {
+	return 0
+}
+
+func (noCgoBackend) RsmiDevSubsystemNameGet(dvInd int) (subSystemName string, err error) {
+	err = noCgoError("RsmiDevSubsystemNameGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevDrmRenderMinorGet(dvInd int) int {
+	return 0
+}
+
+func (noCgoBackend) RsmiDevUniqueIdGet(dvInd int) (uniqueId int64, err error) {
+	err = noCgoError("RsmiDevUniqueIdGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevSubsystemVendorIdGet(dvInd int) int {
+	return 0
+}
+
+func (noCgoBackend) RsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err error) {
+	err = noCgoError("RsmiDevPciBandwidthGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevPciIdGet(dvInd int) (bdfid int64, err error) {
+	err = noCgoError("RsmiDevPciIdGet")
+	return
+}
+
+func (noCgoBackend) RsmiTopoNumaAffinityGet(dvInd int) (namaNode int, err error) {
+	err = noCgoError("RsmiTopoNumaAffinityGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz int64, err error) {
+	err = noCgoError("RsmiDevPciThroughputGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevPciReplayCounterGet(dvInd int) (counter int64, err error) {
+	err = noCgoError("RsmiDevPciReplayCounterGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevPciBandwidthSet(dvInd int, bwBitmask int64) (err error) {
+	err = noCgoError("RsmiDevPciBandwidthSet")
+	return
+}
+
+func (noCgoBackend) RsmiDevPowerAveGet(dvInd int, senserId int) (power int64, err error) {
+	err = noCgoError("RsmiDevPowerAveGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32, timestamp uint64, err error) {
+	err = noCgoError("RsmiDevEnergyCountGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevPowerCapGet(dvInd int, senserId int) (power int64, err error) {
+	err = noCgoError("RsmiDevPowerCapGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error) {
+	err = noCgoError("RsmiDevPowerCapRangeGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevMemoryTotalGet(dvInd int, memoryType RSMIMemoryType) (total int64, err error) {
+	err = noCgoError("RsmiDevMemoryTotalGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevMemoryUsageGet(dvInd int, memoryType RSMIMemoryType) (used int64, err error) {
+	err = noCgoError("RsmiDevMemoryUsageGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevMemoryBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	err = noCgoError("RsmiDevMemoryBusyPercentGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetiredPageRecord, err error) {
+	err = noCgoError("RsmiDevMemoryReservedPagesGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevFanRpmsGet(dvInd, sensorInd int) (speed int64, err error) {
+	err = noCgoError("RsmiDevFanRpmsGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevFanSpeedGet(dvInd, sensorInd int) (speed int64, err error) {
+	err = noCgoError("RsmiDevFanSpeedGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevFanSpeedMaxGet(dvInd, sensorInd int) (maxSpeed int64, err error) {
+	err = noCgoError("RsmiDevFanSpeedMaxGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreqVoltRegion, err error) {
+	err = noCgoError("RsmiDevOdVoltCurveRegionsGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSMPowerProfileStatus, err error) {
+	err = noCgoError("RsmiDevPowerProfilePresetsGet")
+	return
+}
+
+func (noCgoBackend) RsmiVersionGet() (version RSMIVersion, err error) {
+	err = noCgoError("RsmiVersionGet")
+	return
+}
+
+func (noCgoBackend) RsmiVersionStrGet(component RSMISwComponent, len int) (varStr string, err error) {
+	err = noCgoError("RsmiVersionStrGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevVbiosVersionGet(dvInd, len int) (vbios string, err error) {
+	err = noCgoError("RsmiDevVbiosVersionGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64, err error) {
+	err = noCgoError("RsmiDevFirmwareVersionGet")
+	return
+}
+
+/****************************************** vDCU (libhydmi) *********************************************/
+
+func (noCgoBackend) DmiGetDeviceCount() (count int, err error) {
+	err = noCgoError("DmiGetDeviceCount")
+	return
+}
+
+func (noCgoBackend) DmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error) {
+	err = noCgoError("DmiGetDeviceInfo")
+	return
+}
+
+func (noCgoBackend) DmiGetMaxVDeviceCount() (count int, err error) {
+	err = noCgoError("DmiGetMaxVDeviceCount")
+	return
+}
+
+func (noCgoBackend) DmiGetVDeviceCount() (count int, err error) {
+	err = noCgoError("DmiGetVDeviceCount")
+	return
+}
+
+func (noCgoBackend) DmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
+	err = noCgoError("DmiGetVDeviceInfo")
+	return
+}
+
+func (noCgoBackend) DmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error) {
+	err = noCgoError("DmiGetDeviceRemainingInfo")
+	return
+}
+
+func (noCgoBackend) DmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []int) (vdevIDs []int, err error) {
+	err = noCgoError("DmiCreateVDevices")
+	return
+}
+
+func (noCgoBackend) DmiDestroyVDevices(dvInd int) (err error) {
+	err = noCgoError("DmiDestroyVDevices")
+	return
+}
+
+func (noCgoBackend) DmiDestroySingleVDevice(vDvInd int) (err error) {
+	err = noCgoError("DmiDestroySingleVDevice")
+	return
+}
+
+func (noCgoBackend) DmiUpdateSingleVDevice(vDvInd int, vDevCUs int, vDevMemSize int) (err error) {
+	err = noCgoError("DmiUpdateSingleVDevice")
+	return
+}
+
+func (noCgoBackend) DmiStartVDevice(vDvInd int) (err error) {
+	err = noCgoError("DmiStartVDevice")
+	return
+}
+
+func (noCgoBackend) DmiStopVDevice(vDvInd int) (err error) {
+	err = noCgoError("DmiStopVDevice")
+	return
+}
+
+func (noCgoBackend) DmiGetDevBusyPercent(dvInd int) (percent int, err error) {
+	err = noCgoError("DmiGetDevBusyPercent")
+	return
+}
+
+func (noCgoBackend) DmiGetVDevBusyPercent(vDvInd int) (percent int, err error) {
+	err = noCgoError("DmiGetVDevBusyPercent")
+	return
+}
+
+func (noCgoBackend) DmiSetEncryptionVMStatus(status bool) (err error) {
+	err = noCgoError("DmiSetEncryptionVMStatus")
+	return
+}
+
+func (noCgoBackend) DmiGetEncryptionVMStatus() (status bool, err error) {
+	err = noCgoError("DmiGetEncryptionVMStatus")
+	return
+}
+
+/****************************************** 设备状态 *********************************************/
+
+func (noCgoBackend) RsmiDevTempMetricGet(dvInd int, sensorType int, metric RSMITemperatureMetric) (temp int64, err error) {
+	err = noCgoError("RsmiDevTempMetricGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevVoltMetricGet(dvInd int, voltageType RSMIVoltageType, metric RSMIVoltageMetric) int64 {
+	return 0
+}
+
+func (noCgoBackend) RsmiDevFanSpeedSet(dvInd, sensorInd int, speed int64) (err error) {
+	err = noCgoError("RsmiDevFanSpeedSet")
+	return
+}
+
+func (noCgoBackend) RsmiDevBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	err = noCgoError("RsmiDevBusyPercentGet")
+	return
+}
+
+func (noCgoBackend) RsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCounter, count int) (timestamp int64, err error) {
+	err = noCgoError("RsmiUtilizationCountGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevPerfLevelGet(dvInd int) (perf RSMIDevPerfLevel, err error) {
+	err = noCgoError("RsmiDevPerfLevelGet")
+	return
+}
+
+func (noCgoBackend) RsmiPerfDeterminismModeSet(dvInd int, clkValue int64) (err error) {
+	err = noCgoError("RsmiPerfDeterminismModeSet")
+	return
+}
+
+func (noCgoBackend) RsmiDevOverdriveLevelGet(dvInd int) (od int, err error) {
+	err = noCgoError("RsmiDevOverdriveLevelGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequencies, err error) {
+	err = noCgoError("RsmiDevGpuClkFreqGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error) {
+	err = noCgoError("RsmiDevOdVoltInfoGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error) {
+	err = noCgoError("RsmiDevGpuMetricsInfoGet")
+	return
+}
+
+func (noCgoBackend) GpuMetricsTableGet(dvInd int) (table []byte, err error) {
+	err = noCgoError("GpuMetricsTableGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevEccStatusGet(dvInd int, block RSMIGpuBlock) (state RSMIRasErrState, err error) {
+	err = noCgoError("RsmiDevEccStatusGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorCount, err error) {
+	err = noCgoError("RsmiDevEccCountGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevEccEnabledGet(dvInd int) (enabledBlocks int64, err error) {
+	err = noCgoError("RsmiDevEccEnabledGet")
+	return
+}
+
+/****************************************** 控制与计数器 *********************************************/
+
+func (noCgoBackend) RsmiDevPerfLevelSet(dvInd int, devPerfLevel RSMIDevPerfLevel) (err error) {
+	err = noCgoError("RsmiDevPerfLevelSet")
+	return
+}
+
+func (noCgoBackend) RsmiDevClkRangeSet(dvInd int, minClkValue, maxClkValue int64, clkType RSMIClkType) (err error) {
+	err = noCgoError("RsmiDevClkRangeSet")
+	return
+}
+
+func (noCgoBackend) RsmiDevOdVoltInfoSet(dvInd, vPoint, clkValue, voltValue int) (err error) {
+	err = noCgoError("RsmiDevOdVoltInfoSet")
+	return
+}
+
+func (noCgoBackend) RsmiDevOverdriveLevelSet(dvInd, od int) (err error) {
+	err = noCgoError("RsmiDevOverdriveLevelSet")
+	return
+}
+
+func (noCgoBackend) RsmiDevGpuClkFreqSet(dvInd int, clkType RSMIClkType, freqBitmask int64) (err error) {
+	err = noCgoError("RsmiDevGpuClkFreqSet")
+	return
+}
+
+func (noCgoBackend) RsmiDevCounterGroupSupported(dvInd int, group RSMIEventGroup) (err error) {
+	err = noCgoError("RsmiDevCounterGroupSupported")
+	return
+}
+
+func (noCgoBackend) RsmiDevCounterCreate(dvInd int, eventType RSMIEventType) (eventHandle EventHandle, err error) {
+	err = noCgoError("RsmiDevCounterCreate")
+	return
+}
+
+func (noCgoBackend) RsmiDevCounterDestroy(handle EventHandle) (err error) {
+	err = noCgoError("RsmiDevCounterDestroy")
+	return
+}
+
+func (noCgoBackend) RsmiCounterControl(evtHandle EventHandle, cmd RSMICounterCommand) (err error) {
+	err = noCgoError("RsmiCounterControl")
+	return
+}
+
+func (noCgoBackend) RsmiCounterRead(handle EventHandle) (counterValue RSMICounterValue, err error) {
+	err = noCgoError("RsmiCounterRead")
+	return
+}
+
+func (noCgoBackend) RsmiCounterAvailableCountersGet(dvInd int, group RSMIEventGroup) (availAble int, err error) {
+	err = noCgoError("RsmiCounterAvailableCountersGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevFanReset(dvInd, sensorInd int) (err error) {
+	err = noCgoError("RsmiDevFanReset")
+	return
+}
+
+func (noCgoBackend) RsmiDevPowerProfileSet(dvInd int, reserved int, profile RSNIPowerProfilePresetMasks) (err error) {
+	err = noCgoError("RsmiDevPowerProfileSet")
+	return
+}
+
+func (noCgoBackend) RsmiDevXgmiErrorReset(dvInd int) (err error) {
+	err = noCgoError("RsmiDevXgmiErrorReset")
+	return
+}
+
+func (noCgoBackend) RsmiDevXGMIErrorStatus(dvInd int) (status RSMIXGMIStatus, err error) {
+	err = noCgoError("RsmiDevXGMIErrorStatus")
+	return
+}
+
+func (noCgoBackend) RsmiDevXgmiHiveIdGet(dvInd int) (hiveId int64, err error) {
+	err = noCgoError("RsmiDevXgmiHiveIdGet")
+	return
+}
+
+/****************************************** 进程与事件通知 *********************************************/
+
+func (noCgoBackend) RsmiComputeProcessInfoGet() (processInfo []RSMIProcessInfo, numItems int, err error) {
+	err = noCgoError("RsmiComputeProcessInfoGet")
+	return
+}
+
+func (noCgoBackend) RsmiComputeProcessInfoByPidGet(pid int) (proc RSMIProcessInfo, err error) {
+	err = noCgoError("RsmiComputeProcessInfoByPidGet")
+	return
+}
+
+func (noCgoBackend) RsmiComputeProcessGpusGet(pid int) (dvIndices []int, err error) {
+	err = noCgoError("RsmiComputeProcessGpusGet")
+	return
+}
+
+func (noCgoBackend) RsmiDevSupportedFuncIteratorOpen(dvInd int) (iterHandle RSMIFuncIDIterHandle, err error) {
+	err = noCgoError("RsmiDevSupportedFuncIteratorOpen")
+	return
+}
+
+func (noCgoBackend) RsmiDevSupportedVariantIteratorOpen(iterHandle RSMIFuncIDIterHandle) (handle RSMIFuncIDIterHandle, err error) {
+	err = noCgoError("RsmiDevSupportedVariantIteratorOpen")
+	return
+}
+
+func (noCgoBackend) RsmiFuncIterNext(handle RSMIFuncIDIterHandle) (err error) {
+	err = noCgoError("RsmiFuncIterNext")
+	return
+}
+
+func (noCgoBackend) RsmiDevSupportedFuncIteratorClose(handle RSMIFuncIDIterHandle) (err error) {
+	err = noCgoError("RsmiDevSupportedFuncIteratorClose")
+	return
+}
+
+func (noCgoBackend) RsmiEventNotificationInit(deInd int) (err error) {
+	err = noCgoError("RsmiEventNotificationInit")
+	return
+}
+
+func (noCgoBackend) RsmiEventNotificationMaskSet(dvInd int, mask int64) (err error) {
+	err = noCgoError("RsmiEventNotificationMaskSet")
+	return
+}
+
+func (noCgoBackend) RsmiEventNotificationGet(timeoutMs int) (numElem int, datas []RSMIEEvtNotificationData, err error) {
+	err = noCgoError("RsmiEventNotificationGet")
+	return
+}
+
+func (noCgoBackend) RsmiEventNotificationStop(dvInd int) (err error) {
+	err = noCgoError("RsmiEventNotificationStop")
+	return
+}
+
+/****************************************** 拓扑 *********************************************/
+
+func (noCgoBackend) RsmiTopoGetLinkWeight(dvIndSrc, dvIndDst int) (weight int64, err error) {
+	err = noCgoError("RsmiTopoGetLinkWeight")
+	return
+}
+
+func (noCgoBackend) RsmiTopoGetLinkType(dvIndSrc, dvIndDst int) (hops int64, linkType RSMIIOLinkType, err error) {
+	err = noCgoError("RsmiTopoGetLinkType")
+	return
+}
+
+func (noCgoBackend) RsmiTopoGetNumaBodeBumber(dvInd int) (numaNode int, err error) {
+	err = noCgoError("RsmiTopoGetNumaBodeBumber")
+	return
+}
+
+func (noCgoBackend) RsmiMinmaxBandwidthGet(dvIndSrc, dvIndDst int) (minBandwidth, maxBandwidth int64, err error) {
+	err = noCgoError("RsmiMinmaxBandwidthGet")
+	return
+}
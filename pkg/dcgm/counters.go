@@ -0,0 +1,433 @@
+package dcgm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+var (
+	// ErrCounterSessionNotFound 计数器会话不存在或已关闭时返回的错误
+	ErrCounterSessionNotFound = errors.New("counter session not found")
+	// ErrCounterSessionClosed 对已关闭的计数器会话操作时返回的错误
+	ErrCounterSessionClosed = errors.New("counter session closed")
+	// ErrCountersUnavailable 设备剩余的硬件计数器不足时返回的错误
+	ErrCountersUnavailable = errors.New("not enough counters available")
+)
+
+// xgmiBeatBytes XGMI 计数器每个 beat 传输的字节数
+const xgmiBeatBytes = 32
+
+var eventTypeNames = map[RSMIEventType]string{
+	RSMIEventXGmi0NopTx:      "xgmi0_nop_tx",
+	RSMIEventXGmi0RequestTx:  "xgmi0_request_tx",
+	RSMIEventXGmi0ResponseTx: "xgmi0_response_tx",
+	RSMIEventXGmi0BeatsTx:    "xgmi0_beats_tx",
+	RSMIEventXGmi1NopTx:      "xgmi1_nop_tx",
+	RSMIEventXGmi1RequestTx:  "xgmi1_request_tx",
+	RSMIEventXGmi1ResponseTx: "xgmi1_response_tx",
+	RSMIEventXGmi1BeatsTx:    "xgmi1_beats_tx",
+	RSMIEventXGmiDataOut0:    "xgmi_data_out_0",
+	RSMIEventXGmiDataOut1:    "xgmi_data_out_1",
+	RSMIEventXGmiDataOut2:    "xgmi_data_out_2",
+	RSMIEventXGmiDataOut3:    "xgmi_data_out_3",
+	RSMIEventXGmiDataOut4:    "xgmi_data_out_4",
+	RSMIEventXGmiDataOut5:    "xgmi_data_out_5",
+}
+
+// String 返回事件类型的名称，例如 xgmi0_beats_tx、xgmi_data_out_3
+func (t RSMIEventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("event_%d", uint32(t))
+}
+
+// Group 返回事件类型所属的事件组，未知事件返回 RSMI_EVNT_GRP_INVALID
+func (t RSMIEventType) Group() RSMIEventGroup {
+	switch {
+	case t >= RSMIEventXGmiFirst && t <= RSMIEventXGmiLast:
+		return RSMI_EVNT_GRP_XGMI
+	case t >= RSMIEventXGmiDataOutFirst && t <= RSMIEventXGmiDataOutLast:
+		return RSMI_EVNT_GRP_XGMI_DATA_OUT
+	}
+	return RSMI_EVNT_GRP_INVALID
+}
+
+// xgmiLink 返回统计 XGMI 链路流量的事件对应的链路号，其他事件返回 false
+func (t RSMIEventType) xgmiLink() (link int, ok bool) {
+	switch {
+	case t == RSMIEventXGmi0BeatsTx:
+		return 0, true
+	case t == RSMIEventXGmi1BeatsTx:
+		return 1, true
+	case t.Group() == RSMI_EVNT_GRP_XGMI_DATA_OUT:
+		return int(t - RSMIEventXGmiDataOutFirst), true
+	}
+	return 0, false
+}
+
+// ParseEventTypes 解析逗号分隔的事件列表，元素可以是事件名称（xgmi0_beats_tx）或事件编号
+func ParseEventTypes(s string) (eventTypes []RSMIEventType, err error) {
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		eventType, ok := parseEventType(item)
+		if !ok {
+			return nil, fmt.Errorf("Error ParseEventTypes:unknown event %q", item)
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("Error ParseEventTypes:empty event list")
+	}
+	return eventTypes, nil
+}
+
+func parseEventType(s string) (RSMIEventType, bool) {
+	for t, name := range eventTypeNames {
+		if strings.EqualFold(s, name) {
+			return t, true
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	_, ok := eventTypeNames[RSMIEventType(n)]
+	return RSMIEventType(n), ok
+}
+
+// CounterReading 单个性能计数器的读数。计数器与其他事件分时复用硬件时 TimeRunning 小于 TimeEnabled，
+// Scaled 为按 TimeEnabled/TimeRunning 折算后的估计值
+type CounterReading struct {
+	//  EventType 事件类型
+	EventType RSMIEventType
+	//  Name 事件名称
+	Name string
+	//  Value 计数器原始值
+	Value uint64
+	//  TimeEnabled 计数器启用的时长（纳秒）
+	TimeEnabled uint64
+	//  TimeRunning 计数器实际计数的时长（纳秒）
+	TimeRunning uint64
+	//  Scaled 按启用时长折算的计数值
+	Scaled float64
+}
+
+// XGMILinkBandwidth 单条 XGMI 链路的发送流量
+type XGMILinkBandwidth struct {
+	//  Link 链路号
+	Link int
+	//  EventType 统计该链路的事件
+	EventType RSMIEventType
+	//  Bytes 计数期间发送的字节数（已折算）
+	Bytes float64
+	//  Seconds 计数时长（秒）
+	Seconds float64
+	//  BytesPerSecond 平均发送带宽
+	BytesPerSecond float64
+}
+
+type sessionCounter struct {
+	eventType RSMIEventType
+	handle    EventHandle
+}
+
+// CounterSession 设备上的一组性能计数器。NewCounterSession 创建全部计数器句柄，Start/Stop 控制计数，
+// Read 读取当前值；使用完毕必须调用 Close 释放硬件计数器，ShutDown 会关闭仍未关闭的会话
+type CounterSession struct {
+	mu       sync.Mutex
+	id       int
+	dvInd    int
+	counters []sessionCounter
+	running  bool
+	closed   bool
+}
+
+// counterSessions 所有未关闭的会话，供 REST 接口按 ID 查找以及 ShutDown 时统一释放
+var counterSessions = struct {
+	mu       sync.Mutex
+	nextID   int
+	sessions map[int]*CounterSession
+}{nextID: 1, sessions: map[int]*CounterSession{}}
+
+// NewCounterSession 在设备上为一组事件创建性能计数器：先检查事件组是否受支持、剩余计数器是否足够，
+// 创建失败时释放已创建的句柄
+func NewCounterSession(dvInd int, eventTypes []RSMIEventType) (session *CounterSession, err error) {
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("Error NewCounterSession:empty event list")
+	}
+	needed := map[RSMIEventGroup]int{}
+	seen := map[RSMIEventType]bool{}
+	for _, eventType := range eventTypes {
+		group := eventType.Group()
+		if group == RSMI_EVNT_GRP_INVALID {
+			return nil, fmt.Errorf("Error NewCounterSession:unknown event %d", uint32(eventType))
+		}
+		if seen[eventType] {
+			return nil, fmt.Errorf("Error NewCounterSession:duplicate event %s", eventType)
+		}
+		seen[eventType] = true
+		needed[group]++
+	}
+	for group, n := range needed {
+		if err = rsmiDevCounterGroupSupported(dvInd, group); err != nil {
+			return nil, fmt.Errorf("Error NewCounterSession:device %d event group %d:%w", dvInd, group, err)
+		}
+		available, err := rsmiCounterAvailableCountersGet(dvInd, group)
+		if err != nil {
+			return nil, fmt.Errorf("Error NewCounterSession:%w", err)
+		}
+		if available < n {
+			return nil, fmt.Errorf("Error NewCounterSession:device %d event group %d needs %d, %d available:%w", dvInd, group, n, available, ErrCountersUnavailable)
+		}
+	}
+
+	session = &CounterSession{dvInd: dvInd}
+	for _, eventType := range eventTypes {
+		handle, err := rsmiDevCounterCreate(dvInd, eventType)
+		if err != nil {
+			if derr := session.destroy(); derr != nil {
+				glog.Warningf("release counters on device %d: %v", dvInd, derr)
+			}
+			return nil, fmt.Errorf("Error NewCounterSession:create %s:%w", eventType, err)
+		}
+		session.counters = append(session.counters, sessionCounter{eventType: eventType, handle: handle})
+	}
+
+	counterSessions.mu.Lock()
+	session.id = counterSessions.nextID
+	counterSessions.nextID++
+	counterSessions.sessions[session.id] = session
+	counterSessions.mu.Unlock()
+	return session, nil
+}
+
+// CounterSessionByID 按 ID 查找未关闭的计数器会话
+func CounterSessionByID(id int) (session *CounterSession, err error) {
+	counterSessions.mu.Lock()
+	defer counterSessions.mu.Unlock()
+	session, ok := counterSessions.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("Error CounterSessionByID:%d:%w", id, ErrCounterSessionNotFound)
+	}
+	return session, nil
+}
+
+// ID 返回会话的 ID
+func (s *CounterSession) ID() int {
+	return s.id
+}
+
+// Device 返回会话所在的设备索引
+func (s *CounterSession) Device() int {
+	return s.dvInd
+}
+
+// EventTypes 返回会话中的事件
+func (s *CounterSession) EventTypes() []RSMIEventType {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	eventTypes := make([]RSMIEventType, len(s.counters))
+	for i, c := range s.counters {
+		eventTypes[i] = c.eventType
+	}
+	return eventTypes
+}
+
+// Running 返回会话是否正在计数
+func (s *CounterSession) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// Start 开始计数，已开始时不做任何操作
+func (s *CounterSession) Start() error {
+	return s.control(RSMI_CNTR_CMD_START, true)
+}
+
+// Stop 停止计数，计数值保留到下一次 Start
+func (s *CounterSession) Stop() error {
+	return s.control(RSMI_CNTR_CMD_STOP, false)
+}
+
+func (s *CounterSession) control(cmd RSMICounterCommand, running bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return fmt.Errorf("Error CounterSession:%d:%w", s.id, ErrCounterSessionClosed)
+	}
+	if s.running == running {
+		return nil
+	}
+	for _, c := range s.counters {
+		if err := rsmiCounterControl(c.handle, cmd); err != nil {
+			return fmt.Errorf("Error CounterSession:%s:%w", c.eventType, err)
+		}
+	}
+	s.running = running
+	return nil
+}
+
+// Read 读取会话中每个计数器的当前值
+func (s *CounterSession) Read() (readings []CounterReading, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, fmt.Errorf("Error CounterSession:%d:%w", s.id, ErrCounterSessionClosed)
+	}
+	for _, c := range s.counters {
+		value, err := rsmiCounterRead(c.handle)
+		if err != nil {
+			return nil, fmt.Errorf("Error CounterSession:%s:%w", c.eventType, err)
+		}
+		readings = append(readings, CounterReading{
+			EventType:   c.eventType,
+			Name:        c.eventType.String(),
+			Value:       value.Value,
+			TimeEnabled: value.TimeEnabled,
+			TimeRunning: value.TimeRunning,
+			Scaled:      scaledCounterValue(value),
+		})
+	}
+	return readings, nil
+}
+
+// XGMIBandwidth 读取会话并计算每条 XGMI 链路自开始计数以来的平均发送带宽，
+// 只统计会话中的 xgmiN_beats_tx 与 xgmi_data_out_N 事件
+func (s *CounterSession) XGMIBandwidth() (links []XGMILinkBandwidth, err error) {
+	readings, err := s.Read()
+	if err != nil {
+		return nil, err
+	}
+	return XGMIBandwidthFromReadings(readings), nil
+}
+
+// Close 停止计数并释放全部计数器句柄，重复调用不做任何操作
+func (s *CounterSession) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	var errs []error
+	if s.running {
+		for _, c := range s.counters {
+			if err := rsmiCounterControl(c.handle, RSMI_CNTR_CMD_STOP); err != nil {
+				errs = append(errs, fmt.Errorf("Error CounterSession:%s:%w", c.eventType, err))
+			}
+		}
+		s.running = false
+	}
+	if err := s.destroy(); err != nil {
+		errs = append(errs, err)
+	}
+	s.closed = true
+
+	counterSessions.mu.Lock()
+	delete(counterSessions.sessions, s.id)
+	counterSessions.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// destroy 释放全部计数器句柄，某个句柄释放失败时继续释放其余句柄
+func (s *CounterSession) destroy() error {
+	var errs []error
+	for _, c := range s.counters {
+		if err := rsmiDevCounterDestroy(c.handle); err != nil {
+			errs = append(errs, fmt.Errorf("Error CounterSession:%s:%w", c.eventType, err))
+		}
+	}
+	s.counters = nil
+	return errors.Join(errs...)
+}
+
+// closeCounterSessions 关闭所有未关闭的会话
+func closeCounterSessions() {
+	counterSessions.mu.Lock()
+	sessions := make([]*CounterSession, 0, len(counterSessions.sessions))
+	for _, session := range counterSessions.sessions {
+		sessions = append(sessions, session)
+	}
+	counterSessions.mu.Unlock()
+	for _, session := range sessions {
+		if err := session.Close(); err != nil {
+			glog.Warningf("close counter session %d: %v", session.id, err)
+		}
+	}
+}
+
+func scaledCounterValue(value RSMICounterValue) float64 {
+	if value.TimeRunning == 0 {
+		return 0
+	}
+	if value.TimeRunning >= value.TimeEnabled {
+		return float64(value.Value)
+	}
+	return float64(value.Value) * float64(value.TimeEnabled) / float64(value.TimeRunning)
+}
+
+// XGMIBandwidthFromReadings 由计数器读数计算每条 XGMI 链路的平均发送带宽，按链路号排序
+func XGMIBandwidthFromReadings(readings []CounterReading) (links []XGMILinkBandwidth) {
+	for _, r := range readings {
+		link, ok := r.EventType.xgmiLink()
+		if !ok {
+			continue
+		}
+		bw := XGMILinkBandwidth{
+			Link:      link,
+			EventType: r.EventType,
+			Bytes:     r.Scaled * xgmiBeatBytes,
+			Seconds:   float64(r.TimeEnabled) / float64(time.Second),
+		}
+		if bw.Seconds > 0 {
+			bw.BytesPerSecond = bw.Bytes / bw.Seconds
+		}
+		links = append(links, bw)
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].Link < links[j].Link })
+	return links
+}
+
+// MeasureXGMIBandwidth 在设备上计数 interval 时长，返回每条 XGMI 链路的平均发送带宽。
+// 优先使用 xgmi_data_out 事件组（每条链路一个计数器），不支持或计数器不足时使用 xgmi 事件组的两条链路 beats 计数器。
+// interval 为零时取 1 秒，调用会阻塞 interval
+func MeasureXGMIBandwidth(dvInd int, interval time.Duration) (links []XGMILinkBandwidth, err error) {
+	if interval == 0 {
+		interval = time.Second
+	}
+	var dataOut []RSMIEventType
+	for t := RSMIEventXGmiDataOutFirst; t <= RSMIEventXGmiDataOutLast; t++ {
+		dataOut = append(dataOut, t)
+	}
+	session, err := NewCounterSession(dvInd, dataOut)
+	if err != nil {
+		glog.V(2).Infof("xgmi data out counters on device %d: %v", dvInd, err)
+		if session, err = NewCounterSession(dvInd, []RSMIEventType{RSMIEventXGmi0BeatsTx, RSMIEventXGmi1BeatsTx}); err != nil {
+			return nil, fmt.Errorf("Error MeasureXGMIBandwidth:%w", err)
+		}
+	}
+	defer func() {
+		if cerr := session.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("Error MeasureXGMIBandwidth:%w", cerr)
+		}
+	}()
+	if err = session.Start(); err != nil {
+		return nil, fmt.Errorf("Error MeasureXGMIBandwidth:%w", err)
+	}
+	time.Sleep(interval)
+	if err = session.Stop(); err != nil {
+		return nil, fmt.Errorf("Error MeasureXGMIBandwidth:%w", err)
+	}
+	return session.XGMIBandwidth()
+}
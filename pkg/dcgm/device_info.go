@@ -2,7 +2,7 @@ package dcgm
 
 /*
 #cgo CFLAGS: -Wall -I./include
-#cgo LDFLAGS: -L./lib -lrocm_smi64 -lhydmi -Wl,--unresolved-symbols=ignore-in-object-files
+#cgo LDFLAGS: -ldl
 #include <stdio.h>
 #include <stdlib.h>
 #include <stdint.h>
@@ -24,21 +24,21 @@ import (
 	"github.com/golang/glog"
 )
 
-// rsmiNumMonitorDevices 获取gpu数量 *
-func rsmiNumMonitorDevices() (gpuNum int, err error) {
+// RsmiNumMonitorDevices 获取gpu数量 *
+func (b *cgoBackend) RsmiNumMonitorDevices() (gpuNum int, err error) {
 	var p C.uint
 	ret := C.rsmi_num_monitor_devices(&p)
 	//glog.Info("go_rsmi_num_monitor_devices_ret:", ret)
 	if err = errorString(ret); err != nil {
-		return 0, fmt.Errorf("Error go_rsmi_num_monitor_devices_ret: %s", err)
+		return 0, fmt.Errorf("Error go_rsmi_num_monitor_devices_ret: %w", err)
 	}
 	gpuNum = int(p)
 	//glog.Info("go_rsmi_num_monitor_devices:", gpuNum)
 	return gpuNum, nil
 }
 
-// rsmiDevSkuGet 获取设备sku
-func rsmiDevSkuGet(dvInd int) (sku int, err error) {
+// RsmiDevSkuGet 获取设备sku
+func (b *cgoBackend) RsmiDevSkuGet(dvInd int) (sku int, err error) {
 	var csku C.uint16_t
 	ret := C.rsmi_dev_sku_get(C.uint32_t(dvInd), &csku)
 	if err = errorString(ret); err != nil {
@@ -49,20 +49,20 @@ func rsmiDevSkuGet(dvInd int) (sku int, err error) {
 	return
 }
 
-// rsmiDevVendorIdGet 获取设备供应商id
-func rsmiDevVendorIdGet(dvInd int) uint {
+// RsmiDevVendorIdGet 获取设备供应商id
+func (b *cgoBackend) RsmiDevVendorIdGet(dvInd int) uint {
 	var vid C.uint16_t
 	C.rsmi_dev_vendor_id_get(C.uint32_t(dvInd), &vid)
 	return uint(vid)
 }
 
-// rsmiDevIdGet 获取设备类型标识id
-func rsmiDevIdGet(dvInd int) (id int, err error) {
+// RsmiDevIdGet 获取设备类型标识id
+func (b *cgoBackend) RsmiDevIdGet(dvInd int) (id int, err error) {
 	var cid C.uint16_t
 	ret := C.rsmi_dev_id_get(C.uint32_t(dvInd), &cid)
 	if err = errorString(ret); err != nil {
 		glog.Errorf("Error rsmiDevIdGet:%v,retStr:%v", err, errorString(ret))
-		return 0, fmt.Errorf("Error rsmiDevIdGet:%v", err)
+		return 0, fmt.Errorf("Error rsmiDevIdGet:%w", err)
 	}
 	//glog.Infof("rsmiDevIdGet cid:%v", cid)
 	id = int(cid)
@@ -70,20 +70,20 @@ func rsmiDevIdGet(dvInd int) (id int, err error) {
 	return
 }
 
-// rsmiDevNameGet 获取设备名称
-func rsmiDevNameGet(dvInd int) (nameStr string, err error) {
+// RsmiDevNameGet 获取设备名称
+func (b *cgoBackend) RsmiDevNameGet(dvInd int) (nameStr string, err error) {
 	name := make([]C.char, uint32(256))
 	ret := C.rsmi_dev_name_get(C.uint32_t(dvInd), &name[0], 256)
 	if err = errorString(ret); err != nil {
-		return nameStr, fmt.Errorf("Error go_rsmi_dev_name_get: %s", err)
+		return nameStr, fmt.Errorf("Error go_rsmi_dev_name_get: %w", err)
 	}
 	nameStr = C.GoString(&name[0])
 	//glog.Info("rsmiDevNameGet:", nameStr)
 	return
 }
 
-// rsmiDevBrandGet 获取设备品牌名称
-func rsmiDevBrandGet(dvInd int) (brand string, err error) {
+// RsmiDevBrandGet 获取设备品牌名称
+func (b *cgoBackend) RsmiDevBrandGet(dvInd int) (brand string, err error) {
 	brands := make([]C.char, uint32(256))
 	C.rsmi_dev_brand_get(C.uint32_t(dvInd), &brands[0], 256)
 	brand = C.GoString(&brands[0])
@@ -91,85 +91,85 @@ func rsmiDevBrandGet(dvInd int) (brand string, err error) {
 	return
 }
 
-// rsmiDevVendorNameGet 获取设备供应商名称
-func rsmiDevVendorNameGet(dvInd int) (bname string, err error) {
+// RsmiDevVendorNameGet 获取设备供应商名称
+func (b *cgoBackend) RsmiDevVendorNameGet(dvInd int) (bname string, err error) {
 	cbname := make([]C.char, uint32(256))
 	ret := C.rsmi_dev_vendor_name_get(C.uint32_t(dvInd), &cbname[0], 80)
 	if err = errorString(ret); err != nil {
 		glog.Errorf("Error rsmi_dev_vendor_name_get:%v", err)
-		return bname, fmt.Errorf("Error rsmi_dev_vendor_name_get:%v", err)
+		return bname, fmt.Errorf("Error rsmi_dev_vendor_name_get:%w", err)
 	}
 	bname = C.GoString(&cbname[0])
 	//glog.Infof("rsmiDevVendorNameGet:%v", bname)
 	return
 }
 
-// rsmiDevVramVendorGet 获取设备显存供应商名称
-func rsmiDevVramVendorGet(dvInd int) (result string, err error) {
+// RsmiDevVramVendorGet 获取设备显存供应商名称
+func (b *cgoBackend) RsmiDevVramVendorGet(dvInd int) (result string, err error) {
 	bname := make([]C.char, uint32(256))
 	ret := C.rsmi_dev_vram_vendor_get(C.uint32_t(dvInd), &bname[0], 80)
 	if err = errorString(ret); err != nil {
-		return "", fmt.Errorf("Error rsmi_dev_vram_vendor_get:%s", err)
+		return "", fmt.Errorf("Error rsmi_dev_vram_vendor_get:%w", err)
 	}
 	result = C.GoString(&bname[0])
 	glog.Infof("rsmiDevVramVendorGet: %v", result)
 	return
 }
 
-// rsmiDevSerialNumberGet 获取设备序列号
-func rsmiDevSerialNumberGet(dvInd int) (serialNumber string, err error) {
+// RsmiDevSerialNumberGet 获取设备序列号
+func (b *cgoBackend) RsmiDevSerialNumberGet(dvInd int) (serialNumber string, err error) {
 	cserialNumber := make([]C.char, uint32(256))
 	ret := C.rsmi_dev_serial_number_get(C.uint32_t(dvInd), &cserialNumber[0], 256)
 	if err = errorString(ret); err != nil {
 		glog.Errorf("Error rsmi_dev_serial_number_get:%v, errstr:%v", err, errorString(ret))
-		return "", fmt.Errorf("Error rsmi_dev_serial_number_get:%s", err)
+		return "", fmt.Errorf("Error rsmi_dev_serial_number_get:%w", err)
 	}
 	serialNumber = C.GoString(&cserialNumber[0])
 	//glog.Infof("Serial number: %v", serialNumber)
 	return
 }
 
-// rsmiDevSubsystemIdGet 获取设备子系统id
-func rsmiDevSubsystemIdGet(dvInd int) int {
+// RsmiDevSubsystemIdGet 获取设备子系统id
+func (b *cgoBackend) RsmiDevSubsystemIdGet(dvInd int) int {
 	var id C.uint16_t
 	C.rsmi_dev_subsystem_id_get(C.uint32_t(dvInd), &id)
 	return int(id)
 }
 
-// rsmiDevSubsystemNameGet 获取设备子系统名称
-func rsmiDevSubsystemNameGet(dvInd int) (subSystemName string, err error) {
+// RsmiDevSubsystemNameGet 获取设备子系统名称
+func (b *cgoBackend) RsmiDevSubsystemNameGet(dvInd int) (subSystemName string, err error) {
 	csubSystemName := make([]C.char, uint32(256))
 	ret := C.rsmi_dev_subsystem_name_get(C.uint32_t(dvInd), &csubSystemName[0], 256)
 	if err = errorString(ret); err != nil {
 		glog.Errorf("Error rsmi_dev_subsystem_name_get:%v", err)
-		return subSystemName, fmt.Errorf("Error rsmi_dev_subsystem_name_get:%s", err)
+		return subSystemName, fmt.Errorf("Error rsmi_dev_subsystem_name_get:%w", err)
 	}
 	subSystemName = C.GoString(&csubSystemName[0])
 	//glog.Infof("rsmiDevSubsystemNameGet:%v", subSystemName)
 	return
 }
 
-// rsmiDevDrmRenderMinorGet 获取设备drm次编号
-func rsmiDevDrmRenderMinorGet(dvInd int) int {
+// RsmiDevDrmRenderMinorGet 获取设备drm次编号
+func (b *cgoBackend) RsmiDevDrmRenderMinorGet(dvInd int) int {
 	var id C.uint32_t
 	C.rsmi_dev_drm_render_minor_get(C.uint32_t(dvInd), &id)
 	return int(id)
 }
 
-// rsmiDevUniqueIdGet 获取设备唯一id
-func rsmiDevUniqueIdGet(dvInd int) (uniqueId int64, err error) {
+// RsmiDevUniqueIdGet 获取设备唯一id
+func (b *cgoBackend) RsmiDevUniqueIdGet(dvInd int) (uniqueId int64, err error) {
 	var cuniqueId C.uint64_t
 	ret := C.rsmi_dev_unique_id_get(C.uint32_t(dvInd), &cuniqueId)
 	if err = errorString(ret); err != nil {
 		glog.Errorf("Error rsmi_dev_unique_id_get:%v, retstr:%v", ret, errorString(ret))
-		return uniqueId, fmt.Errorf("Error rsmi_dev_unique_id_get:%s", err)
+		return uniqueId, fmt.Errorf("Error rsmi_dev_unique_id_get:%w", err)
 	}
 	uniqueId = int64(cuniqueId)
 	return
 }
 
-// rsmiDevSubsystemVendorIdGet 获取设备子系统供应商id
-func rsmiDevSubsystemVendorIdGet(dvInd int) int {
+// RsmiDevSubsystemVendorIdGet 获取设备子系统供应商id
+func (b *cgoBackend) RsmiDevSubsystemVendorIdGet(dvInd int) int {
 	var id C.uint16_t
 	C.rsmi_dev_subsystem_vendor_id_get(C.uint32_t(dvInd), &id)
 	return int(id)
@@ -177,12 +177,12 @@ func rsmiDevSubsystemVendorIdGet(dvInd int) int {
 
 /****************************************** PCIe *********************************************/
 
-// rsmiDevPciBandwidthGet 获取可用的pcie带宽列表
-func rsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err error) {
+// RsmiDevPciBandwidthGet 获取可用的pcie带宽列表
+func (b *cgoBackend) RsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err error) {
 	var bandwidth C.rsmi_pcie_bandwidth_t
 	ret := C.rsmi_dev_pci_bandwidth_get(C.uint32_t(dvInd), &bandwidth)
 	if err = errorString(ret); err != nil {
-		return rsmiPcieBandwidth, fmt.Errorf("Error rsmi_dev_pci_bandwidth_get%s", err)
+		return rsmiPcieBandwidth, fmt.Errorf("Error rsmi_dev_pci_bandwidth_get%w", err)
 	}
 	rsmiPcieBandwidth = RSMIPcieBandwidth{
 		TransferRate: RSMIFrequencies{
@@ -196,8 +196,8 @@ func rsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err
 	return
 }
 
-// rsmiDevPciIdGet 获取唯一pci设备标识符
-func rsmiDevPciIdGet(dvInd int) (bdfid int64, err error) {
+// RsmiDevPciIdGet 获取唯一pci设备标识符
+func (b *cgoBackend) RsmiDevPciIdGet(dvInd int) (bdfid int64, err error) {
 	var cbdfid C.uint64_t
 	ret := C.rsmi_dev_pci_id_get(C.uint32_t(dvInd), &cbdfid)
 	//glog.Infof("rsmi_dev_pci_id_get ret:%v, retStr:%v", ret, errorString(ret))
@@ -210,25 +210,25 @@ func rsmiDevPciIdGet(dvInd int) (bdfid int64, err error) {
 	return
 }
 
-// rsmiTopoNumaAffinityGet 获取与设备关联的numa节点
-func rsmiTopoNumaAffinityGet(dvInd int) (namaNode int, err error) {
+// RsmiTopoNumaAffinityGet 获取与设备关联的numa节点
+func (b *cgoBackend) RsmiTopoNumaAffinityGet(dvInd int) (namaNode int, err error) {
 	var cnamaNode C.uint32_t
 	ret := C.rsmi_topo_numa_affinity_get(C.uint32_t(dvInd), &cnamaNode)
 	if err = errorString(ret); err != nil {
 		glog.Errorf("Error rsmi_topo_numa_affinity_get ret:%v, retstr:%v", ret, errorString(ret))
-		return namaNode, fmt.Errorf("Error rsmi_topo_numa_affinity_get:%s", err)
+		return namaNode, fmt.Errorf("Error rsmi_topo_numa_affinity_get:%w", err)
 	}
 	namaNode = int(cnamaNode)
 	return
 }
 
-// rsmiDevPciThroughputGet 获取pcie流量信息
-func rsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz int64, err error) {
+// RsmiDevPciThroughputGet 获取pcie流量信息
+func (b *cgoBackend) RsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz int64, err error) {
 	var csent, creceived, cmaxpktsz C.uint64_t
 	ret := C.rsmi_dev_pci_throughput_get(C.uint32_t(dvInd), &csent, &creceived, &cmaxpktsz)
 	//glog.Infof("rsmi_dev_pci_throughput_get ret:%v ,retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return 0, 0, 0, fmt.Errorf("Error rsmi_dev_pci_throughput_get:%s", err)
+		return 0, 0, 0, fmt.Errorf("Error rsmi_dev_pci_throughput_get:%w", err)
 	}
 	//glog.Infof("csent: %v, creceived: %v, cmaxpktsz: %v", csent, creceived, cmaxpktsz)
 	sent = int64(csent)
@@ -238,44 +238,44 @@ func rsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz in
 	return
 }
 
-// rsmiDevPciReplayCounterGet 获取pcie重放计数
-func rsmiDevPciReplayCounterGet(dvInd int) (counter int64, err error) {
+// RsmiDevPciReplayCounterGet 获取pcie重放计数
+func (b *cgoBackend) RsmiDevPciReplayCounterGet(dvInd int) (counter int64, err error) {
 	var ccounter C.uint64_t
 	ret := C.rsmi_dev_pci_replay_counter_get(C.uint32_t(dvInd), &ccounter)
 	if err = errorString(ret); err != nil {
-		return counter, fmt.Errorf("Error rsmi_dev_pci_replay_counter_get:%s", err)
+		return counter, fmt.Errorf("Error rsmi_dev_pci_replay_counter_get:%w", err)
 	}
 	counter = int64(ccounter)
 	glog.Infof("counter:%v", ccounter)
 	return
 }
 
-// rsmiDevPciBandwidthSet 设置可使用的pcie带宽集
-func rsmiDevPciBandwidthSet(dvInd int, bwBitmask int64) (err error) {
+// RsmiDevPciBandwidthSet 设置可使用的pcie带宽集
+func (b *cgoBackend) RsmiDevPciBandwidthSet(dvInd int, bwBitmask int64) (err error) {
 	ret := C.rsmi_dev_pci_bandwidth_set(C.uint32_t(dvInd), C.uint64_t(bwBitmask))
 	glog.Infof("rsmiDevPciBandwidthSet, ret:%v ,retStr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return fmt.Errorf("Error rsmiDevPciBandwidthSet:%v", err)
+		return fmt.Errorf("Error rsmiDevPciBandwidthSet:%w", err)
 	}
 	return
 }
 
 /****************************************** Power *********************************************/
 
-// rsmiDevPowerAveGet 获取设备平均功耗
-func rsmiDevPowerAveGet(dvInd int, senserId int) (power int64, err error) {
+// RsmiDevPowerAveGet 获取设备平均功耗
+func (b *cgoBackend) RsmiDevPowerAveGet(dvInd int, senserId int) (power int64, err error) {
 	var cpower C.uint64_t
 	ret := C.rsmi_dev_power_ave_get(C.uint32_t(dvInd), C.uint32_t(senserId), &cpower)
 	//glog.Infof("rsmi_dev_power_ave_get, ret:%v, retStr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return power, fmt.Errorf("Error rsmiDevPowerAveGet:%v", err)
+		return power, fmt.Errorf("Error rsmiDevPowerAveGet:%w", err)
 	}
 	power = int64(cpower)
 	return
 }
 
-// rsmiDevEnergyCountGet 获取设备的能量累加计数
-func rsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32, timestamp uint64, err error) {
+// RsmiDevEnergyCountGet 获取设备的能量累加计数
+func (b *cgoBackend) RsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32, timestamp uint64, err error) {
 	var cPower C.uint64_t
 	var cCounterResolution C.float
 	var cTimestamp C.uint64_t
@@ -286,25 +286,25 @@ func rsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32,
 	return uint64(cPower), float32(cCounterResolution), uint64(cTimestamp), nil
 }
 
-// rsmiDevPowerCapGet 获取设备功率上限
-func rsmiDevPowerCapGet(dvInd int, senserId int) (power int64, err error) {
+// RsmiDevPowerCapGet 获取设备功率上限
+func (b *cgoBackend) RsmiDevPowerCapGet(dvInd int, senserId int) (power int64, err error) {
 	var cpower C.uint64_t
 	ret := C.rsmi_dev_power_cap_get(C.uint32_t(dvInd), C.uint32_t(senserId), &cpower)
 	//glog.Infof("rsmi_dev_power_cap_get ret:%v, retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return power, fmt.Errorf("Error rsmiDevPowerCapGet:%s", err)
+		return power, fmt.Errorf("Error rsmiDevPowerCapGet:%w", err)
 	}
 	power = int64(cpower)
 	return
 }
 
-// rsmiDevPowerCapRangeGet 获取设备功率有效值范围
-func rsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error) {
+// RsmiDevPowerCapRangeGet 获取设备功率有效值范围
+func (b *cgoBackend) RsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error) {
 	var cmax, cmin C.uint64_t
 	ret := C.rsmi_dev_power_cap_range_get(C.uint32_t(dvInd), C.uint32_t(senserId), &cmax, &cmin)
 	glog.Infof("rsmiDevPowerCapRangeGet ret:%v ,retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return max, min, fmt.Errorf("Error rsmiDevPowerCapRangeGet:%s", err)
+		return max, min, fmt.Errorf("Error rsmiDevPowerCapRangeGet:%w", err)
 	}
 	max, min = int64(cmax), int64(cmin)
 	glog.Infof("rsmiDevPowerCapRangeGet max:%v, min:%v", max, min)
@@ -313,46 +313,46 @@ func rsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error
 
 /****************************************** Memory *********************************************/
 
-// rsmiDevMemoryTotalGet 获取设备内存总量 *
-func rsmiDevMemoryTotalGet(dvInd int, memoryType RSMIMemoryType) (total int64, err error) {
+// RsmiDevMemoryTotalGet 获取设备内存总量 *
+func (b *cgoBackend) RsmiDevMemoryTotalGet(dvInd int, memoryType RSMIMemoryType) (total int64, err error) {
 	var ctotal C.uint64_t
 	ret := C.rsmi_dev_memory_total_get(C.uint32_t(dvInd), C.rsmi_memory_type_t(memoryType), &ctotal)
 	//glog.Infof("rsmi_dev_memory_total_get ret:%v ,retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return total, fmt.Errorf("Error rsmiDevMemoryTotalGet:%s", err)
+		return total, fmt.Errorf("Error rsmiDevMemoryTotalGet:%w", err)
 	}
 	total = int64(ctotal)
 	//glog.Info("memory_total:", total)
 	return
 }
 
-// rsmiDevMemoryUsageGet 获取当前设备内存使用情况 *
-func rsmiDevMemoryUsageGet(dvInd int, memoryType RSMIMemoryType) (used int64, err error) {
+// RsmiDevMemoryUsageGet 获取当前设备内存使用情况 *
+func (b *cgoBackend) RsmiDevMemoryUsageGet(dvInd int, memoryType RSMIMemoryType) (used int64, err error) {
 	var cused C.uint64_t
 	ret := C.rsmi_dev_memory_usage_get(C.uint32_t(dvInd), C.rsmi_memory_type_t(memoryType), &cused)
 	//glog.Infof("rsmi_dev_memory_usage_get ret:%v ,retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return used, fmt.Errorf("Error rsmiDevMemoryUsageGet:%s", err)
+		return used, fmt.Errorf("Error rsmiDevMemoryUsageGet:%w", err)
 	}
 	used = int64(cused)
 	//glog.Info("memory_used:", used)
 	return
 }
 
-// rsmiDevMemoryBusyPercentGet 获取设备内存使用的百分比
-func rsmiDevMemoryBusyPercentGet(dvInd int) (busyPercent int, err error) {
+// RsmiDevMemoryBusyPercentGet 获取设备内存使用的百分比
+func (b *cgoBackend) RsmiDevMemoryBusyPercentGet(dvInd int) (busyPercent int, err error) {
 	var cbusyPercent C.uint32_t
 	ret := C.rsmi_dev_memory_busy_percent_get(C.uint32_t(dvInd), &cbusyPercent)
 	if err = errorString(ret); err != nil {
-		return busyPercent, fmt.Errorf("Error rsmi_dev_memory_busy_percent_get:%s", err)
+		return busyPercent, fmt.Errorf("Error rsmi_dev_memory_busy_percent_get:%w", err)
 	}
 	busyPercent = int(cbusyPercent)
 	glog.Info("busy_percent:", busyPercent)
 	return
 }
 
-// rsmiDevMemoryReservedPagesGet 获取有关保留的(“已退休”)内存页的信息
-func rsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetiredPageRecord, err error) {
+// RsmiDevMemoryReservedPagesGet 获取有关保留的(“已退休”)内存页的信息
+func (b *cgoBackend) RsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetiredPageRecord, err error) {
 	var cnumPages C.uint32_t
 	ret := C.rsmi_dev_memory_reserved_pages_get(C.uint32_t(dvInd), &cnumPages, nil)
 	if ret != 0 {
@@ -383,55 +383,55 @@ func rsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetir
 	return
 }
 
-// rsmiDevFanRpmsGet 获取设备的风扇速度，实际转速
-func rsmiDevFanRpmsGet(dvInd, sensorInd int) (speed int64, err error) {
+// RsmiDevFanRpmsGet 获取设备的风扇速度，实际转速
+func (b *cgoBackend) RsmiDevFanRpmsGet(dvInd, sensorInd int) (speed int64, err error) {
 	var cspeed C.int64_t
 	ret := C.rsmi_dev_fan_rpms_get(C.uint32_t(dvInd), C.uint32_t(sensorInd), &cspeed)
 	glog.Infof("rsmi_dev_fan_rpms_get: ret:%v ,retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return speed, fmt.Errorf("Error rsmi_dev_fan_rpms_get:%s", err)
+		return speed, fmt.Errorf("Error rsmi_dev_fan_rpms_get:%w", err)
 	}
 	speed = int64(cspeed)
 	glog.Infof("rsmi_dev_fan_rpms_get speed value: %v", speed)
 	return
 }
 
-// rsmiDevFanSpeedGet 获取设备的风扇速度，相对速度值
-func rsmiDevFanSpeedGet(dvInd, sensorInd int) (speed int64, err error) {
+// RsmiDevFanSpeedGet 获取设备的风扇速度，相对速度值
+func (b *cgoBackend) RsmiDevFanSpeedGet(dvInd, sensorInd int) (speed int64, err error) {
 	var cspeed C.int64_t
 	ret := C.rsmi_dev_fan_speed_get(C.uint32_t(dvInd), C.uint32_t(sensorInd), &cspeed)
 	glog.Infof("rsmi_dev_fan_speed_get ret:%v ,retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return speed, fmt.Errorf("Error rsmiDevFanSpeedGet:%s", err)
+		return speed, fmt.Errorf("Error rsmiDevFanSpeedGet:%w", err)
 	}
 	speed = int64(cspeed)
 	return
 }
 
-// rsmiDevFanSpeedMaxGet 获取设备的风扇速度，最大风速
-func rsmiDevFanSpeedMaxGet(dvInd, sensorInd int) (maxSpeed int64, err error) {
+// RsmiDevFanSpeedMaxGet 获取设备的风扇速度，最大风速
+func (b *cgoBackend) RsmiDevFanSpeedMaxGet(dvInd, sensorInd int) (maxSpeed int64, err error) {
 	var cmaxSpeed C.uint64_t
 	ret := C.rsmi_dev_fan_speed_max_get(C.uint32_t(dvInd), C.uint32_t(sensorInd), &cmaxSpeed)
 	glog.Infof("rsmi_dev_fan_speed_max_get ret:%v ,retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return maxSpeed, fmt.Errorf("Error rsmiDevFanSpeedMaxGet:%s", err)
+		return maxSpeed, fmt.Errorf("Error rsmiDevFanSpeedMaxGet:%w", err)
 	}
 	maxSpeed = int64(cmaxSpeed)
 	return
 }
 
-// rsmiDevOdVoltCurveRegionsGet
-func rsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreqVoltRegion, err error) {
+// RsmiDevOdVoltCurveRegionsGet
+func (b *cgoBackend) RsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreqVoltRegion, err error) {
 	var cnumRegions C.uint32_t
 	ret := C.rsmi_dev_od_volt_curve_regions_get(C.uint32_t(dvInd), &cnumRegions, nil)
 	if err = errorString(ret); err != nil {
-		return 0, nil, fmt.Errorf("Error dev_od_volt_curve_regions_get: %v", err)
+		return 0, nil, fmt.Errorf("Error dev_od_volt_curve_regions_get: %w", err)
 	}
 
 	cbuffer := make([]C.rsmi_freq_volt_region_t, cnumRegions)
 	ret = C.rsmi_dev_od_volt_curve_regions_get(C.uint32_t(dvInd), &cnumRegions, &cbuffer[0])
 	if err = errorString(ret); err != nil {
-		return 0, nil, fmt.Errorf("Error dev_od_volt_curve_regions_get: %v", err)
+		return 0, nil, fmt.Errorf("Error dev_od_volt_curve_regions_get: %w", err)
 	}
 
 	regions = make([]RSMIFreqVoltRegion, cnumRegions)
@@ -451,13 +451,13 @@ func rsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreq
 	return
 }
 
-// rsmiDevPowerProfilePresetsGet 获取可用预设电源配置文件列表并指示当前活动的配置文件
-func rsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSMPowerProfileStatus, err error) {
+// RsmiDevPowerProfilePresetsGet 获取可用预设电源配置文件列表并指示当前活动的配置文件
+func (b *cgoBackend) RsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSMPowerProfileStatus, err error) {
 	var cpowerProfileStatus C.rsmi_power_profile_status_t
 	ret := C.rsmi_dev_power_profile_presets_get(C.uint32_t(dvInd), C.uint32_t(sensorInd), &cpowerProfileStatus)
 	glog.Infof("rsmi_dev_power_profile_presets_get ret:%v, retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return powerProfileStatus, fmt.Errorf("Error dev_power_profile_presets_get:%s", err)
+		return powerProfileStatus, fmt.Errorf("Error dev_power_profile_presets_get:%w", err)
 	}
 	powerProfileStatus = RSMPowerProfileStatus{
 		AvailableProfiles: RSMIBitField(cpowerProfileStatus.available_profiles),
@@ -468,13 +468,13 @@ func rsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSM
 	return
 }
 
-// rsmiVersionGet 获取当前运行的RSMI版本
-func rsmiVersionGet() (version RSMIVersion, err error) {
+// RsmiVersionGet 获取当前运行的RSMI版本
+func (b *cgoBackend) RsmiVersionGet() (version RSMIVersion, err error) {
 
 	var cVersion C.rsmi_version_t
 	ret := C.rsmi_version_get(&cVersion)
 	if err = errorString(ret); err != nil {
-		return version, fmt.Errorf("Error to get version: %s", err)
+		return version, fmt.Errorf("Error to get version: %w", err)
 	}
 	version = RSMIVersion{
 		Major: uint32(cVersion.major),
@@ -485,34 +485,34 @@ func rsmiVersionGet() (version RSMIVersion, err error) {
 	return
 }
 
-// rsmiVersionStrGet 获取当前系统的驱动程序版本
-func rsmiVersionStrGet(component RSMISwComponent, len int) (varStr string, err error) {
+// RsmiVersionStrGet 获取当前系统的驱动程序版本
+func (b *cgoBackend) RsmiVersionStrGet(component RSMISwComponent, len int) (varStr string, err error) {
 	cvarStr := make([]C.char, len)
 	ret := C.rsmi_version_str_get(C.rsmi_sw_component_t(component), &cvarStr[0], C.uint32_t(len))
 	if err = errorString(ret); err != nil {
-		return "", fmt.Errorf("Error rsmi_version_str_get:%s", err)
+		return "", fmt.Errorf("Error rsmi_version_str_get:%w", err)
 	}
 	varStr = C.GoString(&cvarStr[0])
 	return
 }
 
-// rsmiDevVbiosVersionGet 获取VBIOS版本
-func rsmiDevVbiosVersionGet(dvInd, len int) (vbios string, err error) {
+// RsmiDevVbiosVersionGet 获取VBIOS版本
+func (b *cgoBackend) RsmiDevVbiosVersionGet(dvInd, len int) (vbios string, err error) {
 	cvbios := make([]C.char, len)
 	ret := C.rsmi_dev_vbios_version_get(C.uint32_t(dvInd), &cvbios[0], C.uint32_t(len))
 	if err = errorString(ret); err != nil {
-		return vbios, fmt.Errorf("Error rsmi_dev_vbios_version_get:%s", err)
+		return vbios, fmt.Errorf("Error rsmi_dev_vbios_version_get:%w", err)
 	}
 	vbios = C.GoString(&cvbios[0])
 	return
 }
 
-// rsmiDevFirmwareVersionGet 获取设备的固件版本
-func rsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64, err error) {
+// RsmiDevFirmwareVersionGet 获取设备的固件版本
+func (b *cgoBackend) RsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64, err error) {
 	var cfwBlock C.uint64_t
 	ret := C.rsmi_dev_firmware_version_get(C.uint32_t(dvInd), C.rsmi_fw_block_t(fwBlock), &cfwBlock)
 	if err = errorString(ret); err != nil {
-		return fwVersion, fmt.Errorf("Error rsmi_dev_firmware_version_get:%s", err)
+		return fwVersion, fmt.Errorf("Error rsmi_dev_firmware_version_get:%w", err)
 	}
 	fwVersion = int64(cfwBlock)
 	return
@@ -520,12 +520,12 @@ func rsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64,
 
 /*************************************VDCU******************************************/
 // 设备数量
-func dmiGetDeviceCount() (count int, err error) {
+func (b *cgoBackend) DmiGetDeviceCount() (count int, err error) {
 	var ccount C.int
 	ret := C.dmiGetDeviceCount(&ccount)
 	glog.Infof("dmiGetDeviceCount:%v", ret)
 	if err = dmiErrorString(ret); err != nil {
-		return 0, fmt.Errorf("Error vDeviceCount:%s", err)
+		return 0, fmt.Errorf("Error vDeviceCount:%w", err)
 	}
 	count = int(ccount)
 	glog.Infof("dmiDeviceCount:%v", count)
@@ -533,12 +533,12 @@ func dmiGetDeviceCount() (count int, err error) {
 }
 
 // 设备信息
-func dmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error) {
+func (b *cgoBackend) DmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error) {
 	var cdeviceInfo C.dmiDeviceInfo
 	ret := C.dmiGetDeviceInfo(C.int(dvInd), &cdeviceInfo)
 	glog.Infof("dmiDeviceInfo ret:%v,cdeviceInfo:%v", ret, cdeviceInfo)
 	if err = dmiErrorString(ret); err != nil {
-		return deviceInfo, fmt.Errorf("Error dmiGetDeviceInfo:%s", err)
+		return deviceInfo, fmt.Errorf("Error dmiGetDeviceInfo:%w", err)
 	}
 	// 创建一个新的变量来存储 name 字段
 	var deviceName [DMI_NAME_SIZE]byte
@@ -559,22 +559,22 @@ func dmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error) {
 }
 
 // 物理设备支持最大虚拟化设备数量
-func dmiGetMaxVDeviceCount() (count int, err error) {
+func (b *cgoBackend) DmiGetMaxVDeviceCount() (count int, err error) {
 	var ccount C.int
 	ret := C.dmiGetMaxVDeviceCount(&ccount)
 	if err = dmiErrorString(ret); err != nil {
-		return 0, fmt.Errorf("Error dmiGetMaxVDeviceCount:%s", err)
+		return 0, fmt.Errorf("Error dmiGetMaxVDeviceCount:%w", err)
 	}
 	count = int(ccount)
 	return
 }
 
 // 虚拟设备数量
-func dmiGetVDeviceCount() (count int, err error) {
+func (b *cgoBackend) DmiGetVDeviceCount() (count int, err error) {
 	var ccount C.int
 	ret := C.dmiGetVDeviceCount(&ccount)
 	if err = dmiErrorString(ret); err != nil {
-		return 0, fmt.Errorf("Error dmiGetVDeviceCount:%s", err)
+		return 0, fmt.Errorf("Error dmiGetVDeviceCount:%w", err)
 	}
 	count = int(ccount)
 	glog.Infof("dmiGetVDeviceCount:%v", count)
@@ -582,13 +582,13 @@ func dmiGetVDeviceCount() (count int, err error) {
 }
 
 // 虚拟设备信息
-func dmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
+func (b *cgoBackend) DmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
 	var cvDeviceInfo C.dmiDeviceInfo
 	ret := C.dmiGetVDeviceInfo(C.int(vDvInd), &cvDeviceInfo)
 	//glog.Infof("dmiGetVDeviceInfo ret:%v", ret)
 	//glog.Infof("cgo cvDeviceInfo:%v", dataToJson(cvDeviceInfo))
 	if err = dmiErrorString(ret); err != nil {
-		return vDeviceInfo, fmt.Errorf("Error dmiGetVDeviceInfo:%s", err)
+		return vDeviceInfo, fmt.Errorf("Error dmiGetVDeviceInfo:%w", err)
 	}
 	// 创建一个新的变量来存储 name 字段
 	var deviceName [DMI_NAME_SIZE]byte
@@ -608,12 +608,12 @@ func dmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
 }
 
 // 指定物理设备剩余的CU和内存
-func dmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error) {
+func (b *cgoBackend) DmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error) {
 	var ccus, cmemories C.size_t
 	ret := C.dmiGetDeviceRemainingInfo(C.int(dvInd), &ccus, &cmemories)
 	glog.Infof("dmiGetDeviceRemainingInfo ret:%v, retstr:%v", ret, dmiErrorString(ret))
 	if err = dmiErrorString(ret); err != nil {
-		return cus, memories, fmt.Errorf("Error dmiGetDeviceRemainingInfo:%s", err)
+		return cus, memories, fmt.Errorf("Error dmiGetDeviceRemainingInfo:%w", err)
 	}
 	cus = uint64(ccus)
 	memories = uint64(cmemories)
@@ -636,7 +636,7 @@ func dmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error) {
 //	└── 虚拟设备 2
 //	     ├── 计算单元: 4
 //	     └── 内存大小: 2048 字节
-func dmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []int) (vdevIDs []int, err error) {
+func (b *cgoBackend) DmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []int) (vdevIDs []int, err error) {
 	if len(vDevCUs) != vDevCount || len(vDevMemSize) != vDevCount {
 		return vdevIDs, fmt.Errorf("Invalid args")
 	}
@@ -646,7 +646,7 @@ func dmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []in
 	// 获取调用前的配置文件列表
 	beforeFiles, err := getConfigFiles("/etc/vdev")
 	if err != nil {
-		return vdevIDs, fmt.Errorf("Failed to get config files: %v", err)
+		return vdevIDs, fmt.Errorf("Failed to get config files: %w", err)
 	}
 	fmt.Println("Before processing, the files in /etc/vdev are:")
 	for _, file := range beforeFiles {
@@ -680,13 +680,13 @@ func dmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []in
 		cVdevCus, cVdevMemSize)
 	glog.Infof("dmiCreateVDevices ret:%v ,err:%v", ret, dmiErrorString(ret))
 	if err = dmiErrorString(ret); err != nil {
-		return vdevIDs, fmt.Errorf("Error dmiCreateVDevices:%s", err)
+		return vdevIDs, fmt.Errorf("Error dmiCreateVDevices:%w", err)
 	}
 
 	// 获取调用后的配置文件列表
 	afterFiles, err := getConfigFiles("/etc/vdev")
 	if err != nil {
-		return vdevIDs, fmt.Errorf("Failed to get config files: %v", err)
+		return vdevIDs, fmt.Errorf("Failed to get config files: %w", err)
 	}
 	fmt.Println("After processing, the files in /etc/vdev are:")
 	for _, file := range afterFiles {
@@ -726,61 +726,61 @@ func dmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []in
 }
 
 // 销毁指定物理设备上的所有虚拟设备
-func dmiDestroyVDevices(dvInd int) (err error) {
+func (b *cgoBackend) DmiDestroyVDevices(dvInd int) (err error) {
 	ret := C.dmiDestroyVDevices(C.int(dvInd))
 	glog.Infof("dmiDestroyVDevices ret:%v", ret)
 	if err = dmiErrorString(ret); err != nil {
-		return fmt.Errorf("Error dmiDestroyVDevices:%s", err)
+		return fmt.Errorf("Error dmiDestroyVDevices:%w", err)
 	}
 	return
 }
 
 // 销毁指定虚拟设备
-func dmiDestroySingleVDevice(vDvInd int) (err error) {
+func (b *cgoBackend) DmiDestroySingleVDevice(vDvInd int) (err error) {
 	ret := C.dmiDestroySingleVDevice(C.int(vDvInd))
 	glog.Infof("dmiDestroySingleVDevice ret:%v", ret)
 	if err = dmiErrorString(ret); err != nil {
-		return fmt.Errorf("Error dmiDestroySingleVDevice:%s", err)
+		return fmt.Errorf("Error dmiDestroySingleVDevice:%w", err)
 	}
 	return
 }
 
 // 更新指定设备资源大小，vDevCUs和vDevMemSize为-1是不更改
-func dmiUpdateSingleVDevice(vDvInd int, vDevCUs int, vDevMemSize int) (err error) {
+func (b *cgoBackend) DmiUpdateSingleVDevice(vDvInd int, vDevCUs int, vDevMemSize int) (err error) {
 	ret := C.dmiUpdateSingleVDevice(C.int(vDvInd), C.int(vDevCUs), C.int(vDevMemSize))
 	glog.Infof("dmiUpdateSingleVDevice ret:%v, retstr:%v", ret, dmiErrorString(ret))
 	if err = dmiErrorString(ret); err != nil {
-		return fmt.Errorf("Error dmiUpdateSingleVDevice:%s", err)
+		return fmt.Errorf("Error dmiUpdateSingleVDevice:%w", err)
 	}
 	return
 }
 
 // 启动虚拟设备
-func dmiStartVDevice(vDvInd int) (err error) {
+func (b *cgoBackend) DmiStartVDevice(vDvInd int) (err error) {
 	ret := C.dmiStartVDevice(C.int(vDvInd))
 	glog.Infof("StartVDevice ret:%v", ret)
 	if err = dmiErrorString(ret); err != nil {
-		return fmt.Errorf("Error dmiStartVDevice:%s", err)
+		return fmt.Errorf("Error dmiStartVDevice:%w", err)
 	}
 	return
 }
 
 // 停止虚拟设备
-func dmiStopVDevice(vDvInd int) (err error) {
+func (b *cgoBackend) DmiStopVDevice(vDvInd int) (err error) {
 	ret := C.dmiStopVDevice(C.int(vDvInd))
 	glog.Infof("dmiStopVDevice ret:%v,retmessage:%v", ret, dmiErrorString(ret))
 	if err = dmiErrorString(ret); err != nil {
-		return fmt.Errorf("Error dmiStopVDevice:%s", err)
+		return fmt.Errorf("Error dmiStopVDevice:%w", err)
 	}
 	return
 }
 
 // 返回物理设备使用百分比
-func dmiGetDevBusyPercent(dvInd int) (percent int, err error) {
+func (b *cgoBackend) DmiGetDevBusyPercent(dvInd int) (percent int, err error) {
 	var cpercent C.int
 	ret := C.dmiGetDevBusyPercent(C.int(dvInd), &cpercent)
 	if err = dmiErrorString(ret); err != nil {
-		return percent, fmt.Errorf("Error dmiGetDevBusyPercent:%s", err)
+		return percent, fmt.Errorf("Error dmiGetDevBusyPercent:%w", err)
 	}
 	percent = int(cpercent)
 	glog.Infof("dmiGetDevBusyPercent: %v", percent)
@@ -788,11 +788,11 @@ func dmiGetDevBusyPercent(dvInd int) (percent int, err error) {
 }
 
 // 返回虚拟设备使用百分比
-func dmiGetVDevBusyPercent(vDvInd int) (percent int, err error) {
+func (b *cgoBackend) DmiGetVDevBusyPercent(vDvInd int) (percent int, err error) {
 	var cpercent C.int
 	ret := C.dmiGetVDevBusyPercent(C.int(vDvInd), &cpercent)
 	if err = dmiErrorString(ret); err != nil {
-		return percent, fmt.Errorf("Error dmiGetVDevBusyPercent:%s", err)
+		return percent, fmt.Errorf("Error dmiGetVDevBusyPercent:%w", err)
 	}
 	percent = int(cpercent)
 	glog.Infof("dmiGetVDevBusyPercent: %v", percent)
@@ -800,20 +800,20 @@ func dmiGetVDevBusyPercent(vDvInd int) (percent int, err error) {
 }
 
 // 设置虚拟机加密状态 status为true，则开启加密虚拟机，否则关闭
-func dmiSetEncryptionVMStatus(status bool) (err error) {
+func (b *cgoBackend) DmiSetEncryptionVMStatus(status bool) (err error) {
 	ret := C.dmiSetEncryptionVMStatus(C.bool(status))
 	if err = dmiErrorString(ret); err != nil {
-		return fmt.Errorf("Error dmiSetEncryptionVMStatus:%s", err)
+		return fmt.Errorf("Error dmiSetEncryptionVMStatus:%w", err)
 	}
 	return
 }
 
 // 获取加密虚拟机状态
-func dmiGetEncryptionVMStatus() (status bool, err error) {
+func (b *cgoBackend) DmiGetEncryptionVMStatus() (status bool, err error) {
 	var cstatus C.bool
 	ret := C.dmiGetEncryptionVMStatus(&cstatus)
 	if err = dmiErrorString(ret); err != nil {
-		return false, fmt.Errorf("Error dmiGetEncryptionVMStatus:%s", err)
+		return false, fmt.Errorf("Error dmiGetEncryptionVMStatus:%w", err)
 	}
 	status = bool(cstatus)
 	glog.Infof("DmiGetEncryptionVMStatus: %v", status)
@@ -2,7 +2,7 @@ package dcgm
 
 /*
 #cgo CFLAGS: -Wall -I./include
-#cgo LDFLAGS: -L./lib -lrocm_smi64 -Wl,--unresolved-symbols=ignore-in-object-files
+#cgo LDFLAGS: -ldl
 #include <stdint.h>
 #include <kfd_ioctl.h>
 #include <rocm_smi64Config.h>
@@ -11,54 +11,55 @@ package dcgm
 import "C"
 import (
 	"fmt"
+	"os"
 	"unsafe"
 
 	"github.com/golang/glog"
 )
 
-// rsmiDevTempMetricGet 获取设备的温度度量值 *
-func rsmiDevTempMetricGet(dvInd int, sensorType int, metric RSMITemperatureMetric) (temp int64, err error) {
+// RsmiDevTempMetricGet 获取设备的温度度量值 *
+func (b *cgoBackend) RsmiDevTempMetricGet(dvInd int, sensorType int, metric RSMITemperatureMetric) (temp int64, err error) {
 	var temperature C.int64_t
 	ret := C.rsmi_dev_temp_metric_get(C.uint32_t(dvInd), C.uint32_t(sensorType), C.rsmi_temperature_metric_t(metric), &temperature)
 	//glog.Infof("rsmi_dev_temp_metric_get ret:%v, retStr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return 0, fmt.Errorf("rsmiDevTempMetricGet:%s", err)
+		return 0, fmt.Errorf("rsmiDevTempMetricGet:%w", err)
 	}
 	temp = int64(temperature)
 	return
 }
 
-// rsmiDevVoltMetricGet 获取设备的电压度量值
-func rsmiDevVoltMetricGet(dvInd int, voltageType RSMIVoltageType, metric RSMIVoltageMetric) int64 {
+// RsmiDevVoltMetricGet 获取设备的电压度量值
+func (b *cgoBackend) RsmiDevVoltMetricGet(dvInd int, voltageType RSMIVoltageType, metric RSMIVoltageMetric) int64 {
 	var voltage C.int64_t
 	C.rsmi_dev_volt_metric_get(C.uint32_t(dvInd), C.rsmi_voltage_type_t(voltageType), C.rsmi_voltage_metric_t(metric), &voltage)
 	return int64(voltage)
 }
 
-// rsmiDevFanSpeedSet 设置设备风扇转速，以rpm为单位
-func rsmiDevFanSpeedSet(dvInd, sensorInd int, speed int64) (err error) {
+// RsmiDevFanSpeedSet 设置设备风扇转速，以rpm为单位
+func (b *cgoBackend) RsmiDevFanSpeedSet(dvInd, sensorInd int, speed int64) (err error) {
 	ret := C.rsmi_dev_fan_speed_set(C.uint32_t(dvInd), C.uint32_t(sensorInd), C.uint64_t(speed))
 	glog.Infof("rsmi_dev_fan_speed_set_ret:%v, retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return fmt.Errorf("Error rsmi_dev_fan_speed_set: %s", err)
+		return fmt.Errorf("Error rsmi_dev_fan_speed_set: %w", err)
 	}
 	return nil
 }
 
-// rsmiDevBusyPercentGet 获取设备设备忙碌时间百分比
-func rsmiDevBusyPercentGet(dvInd int) (busyPercent int, err error) {
+// RsmiDevBusyPercentGet 获取设备设备忙碌时间百分比
+func (b *cgoBackend) RsmiDevBusyPercentGet(dvInd int) (busyPercent int, err error) {
 	var cbusyPercent C.uint32_t
 	ret := C.rsmi_dev_busy_percent_get(C.uint32_t(dvInd), &cbusyPercent)
 	//glog.Infof("rsmi_dev_busy_percent_get ret:%v ,retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return 0, fmt.Errorf("Error rsmi_dev_busy_percent_get:%s", err)
+		return 0, fmt.Errorf("Error rsmi_dev_busy_percent_get:%w", err)
 	}
 	busyPercent = int(cbusyPercent)
 	return busyPercent, nil
 }
 
-// rsmiUtilizationCountGet 获取设备利用率计数器
-func rsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCounter, count int) (timestamp int64, err error) {
+// RsmiUtilizationCountGet 获取设备利用率计数器
+func (b *cgoBackend) RsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCounter, count int) (timestamp int64, err error) {
 	// 转换 Go 结构体数组到 C 结构体数组
 	cUtilizationCounters := make([]C.rsmi_utilization_counter_t, len(utilizationCounters))
 	for i, uc := range utilizationCounters {
@@ -78,7 +79,7 @@ func rsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCou
 	)
 	//glog.Infof("rsmi_utilization_count_get ret:%v ,retstr:%v ", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return 0, fmt.Errorf("Error rsmi_utilization_count_get:%s", err)
+		return 0, fmt.Errorf("Error rsmi_utilization_count_get:%w", err)
 	}
 	// 更新 Go 结构体数组中的值
 	for i := range utilizationCounters {
@@ -89,48 +90,48 @@ func rsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCou
 	return int64(ctimestamp), nil
 }
 
-// rsmiDevPerfLevelGet 获取设备的性能级别
-func rsmiDevPerfLevelGet(dvInd int) (perf RSMIDevPerfLevel, err error) {
+// RsmiDevPerfLevelGet 获取设备的性能级别
+func (b *cgoBackend) RsmiDevPerfLevelGet(dvInd int) (perf RSMIDevPerfLevel, err error) {
 	var cPerfLevel C.rsmi_dev_perf_level_t
 	ret := C.rsmi_dev_perf_level_get(C.uint32_t(dvInd), &cPerfLevel)
 	if err = errorString(ret); err != nil {
-		return RSMIDevPerfLevel(cPerfLevel), fmt.Errorf("Error rsmi_dev_perf_level_get:%s", err)
+		return RSMIDevPerfLevel(cPerfLevel), fmt.Errorf("Error rsmi_dev_perf_level_get:%w", err)
 	}
 	perf = RSMIDevPerfLevel(cPerfLevel)
 	glog.Info("dev_perf_level:", perf)
 	return perf, nil
 }
 
-// rsmiPerfDeterminismModeSet 设置设备的性能确定性模式
-func rsmiPerfDeterminismModeSet(dvInd int, clkValue int64) (err error) {
+// RsmiPerfDeterminismModeSet 设置设备的性能确定性模式
+func (b *cgoBackend) RsmiPerfDeterminismModeSet(dvInd int, clkValue int64) (err error) {
 	ret := C.rsmi_perf_determinism_mode_set(C.uint32_t(dvInd), C.uint64_t(clkValue))
 	glog.Infof("dev_perf_determinism_mode ret:%v, retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return fmt.Errorf("Error rsmi_perf_determinism_mode_set:%s", err)
+		return fmt.Errorf("Error rsmi_perf_determinism_mode_set:%w", err)
 	}
 	return
 }
 
-// rsmiDevOverdriveLevelGet 获取设备的超速百分比
-func rsmiDevOverdriveLevelGet(dvInd int) (od int, err error) {
+// RsmiDevOverdriveLevelGet 获取设备的超速百分比
+func (b *cgoBackend) RsmiDevOverdriveLevelGet(dvInd int) (od int, err error) {
 	var cod C.uint32_t
 	ret := C.rsmi_dev_overdrive_level_get(C.uint32_t(dvInd), &cod)
 	glog.Infof("rsmi_dev_overdrive_level_get:ret:%v, retStr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return int(cod), fmt.Errorf("Error rsmi_dev_overdrive_level_get:%s", err)
+		return int(cod), fmt.Errorf("Error rsmi_dev_overdrive_level_get:%w", err)
 	}
 	od = int(cod)
 	glog.Infof("rsmiDevOverdriveLevelGet od:%v", od)
 	return
 }
 
-// rsmiDevGpuClkFreqGet 获取设备系统时钟速度列表
-func rsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequencies, err error) {
+// RsmiDevGpuClkFreqGet 获取设备系统时钟速度列表
+func (b *cgoBackend) RsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequencies, err error) {
 	var cfrequencies C.rsmi_frequencies_t
 	ret := C.rsmi_dev_gpu_clk_freq_get(C.uint32_t(dvInd), C.rsmi_clk_type_t(clkType), &cfrequencies)
 	//glog.Infof("rsmi_dev_gpu_clk_freq_get ret:%v ,retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return frequencies, fmt.Errorf("Error rsmi_dev_gpu_clk_freq_get:%s", err)
+		return frequencies, fmt.Errorf("Error rsmi_dev_gpu_clk_freq_get:%w", err)
 	}
 	frequencies = RSMIFrequencies{
 		NumSupported: uint32(cfrequencies.num_supported),
@@ -141,13 +142,13 @@ func rsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequ
 	return
 }
 
-// rsmiDevOdVoltInfoGet 获取设备电压/频率曲线信息
-func rsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error) {
+// RsmiDevOdVoltInfoGet 获取设备电压/频率曲线信息
+func (b *cgoBackend) RsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error) {
 	var codv C.rsmi_od_volt_freq_data_t
 	ret := C.rsmi_dev_od_volt_info_get(C.uint32_t(dvInd), &codv)
 	glog.Infof("rsmi_dev_od_volt_info_get ret:%v, retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return odv, fmt.Errorf("Error rsmi_dev_od_volt_info_get:%s", err)
+		return odv, fmt.Errorf("Error rsmi_dev_od_volt_info_get:%w", err)
 	}
 	odv = RSMIOdVoltFreqData{
 		CurrMclkRange: RSMIRange{
@@ -178,12 +179,22 @@ func rsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error) {
 	return
 }
 
-// rsmiDevGpuMetricsInfoGet 获取gpu度量信息
-func rsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error) {
+// RsmiDevGpuMetricsInfoGet 获取gpu度量信息
+func (b *cgoBackend) RsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error) {
 	var cgpuMetrics C.rsmi_gpu_metrics_t
 	ret := C.rsmi_dev_gpu_metrics_info_get(C.uint32_t(dvInd), &cgpuMetrics)
 	if err = errorString(ret); err != nil {
-		return gpuMetrics, fmt.Errorf("Error rsmi_dev_gpu_metrics_info_get:%s", err)
+		return gpuMetrics, fmt.Errorf("Error rsmi_dev_gpu_metrics_info_get:%w", err)
+	}
+	// rsmi 只认识 v1.x 的布局，其他版本的数据按固定布局拷贝会错位
+	header := MetricsTableHeader{
+		StructureSize:   uint16(cgpuMetrics.common_header.structure_size),
+		FormatRevision:  uint8(cgpuMetrics.common_header.format_revision),
+		ContentRevision: uint8(cgpuMetrics.common_header.content_revision),
+	}
+	if header.FormatRevision != gpuMetricsFormatDGPU || header.ContentRevision > 3 {
+		return gpuMetrics, fmt.Errorf("Error rsmi_dev_gpu_metrics_info_get:gpu metrics v%d.%d:%w",
+			header.FormatRevision, header.ContentRevision, ErrUnknownMetricsRevision)
 	}
 	gpuMetrics = RSMIGPUMetrics{
 		CommonHeader: MetricsTableHeader{
@@ -230,27 +241,42 @@ func rsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error)
 	return
 }
 
-// rsmiDevEccStatusGet 获取GPU块的ECC状态
-func rsmiDevEccStatusGet(dvInd int, block RSMIGpuBlock) (state RSMIRasErrState, err error) {
+// GpuMetricsTableGet 读取设备 PCI 目录下的 gpu_metrics 文件，rsmi 不提供原始度量表
+func (b *cgoBackend) GpuMetricsTableGet(dvInd int) (table []byte, err error) {
+	bdfid, err := b.RsmiDevPciIdGet(dvInd)
+	if err != nil {
+		return nil, fmt.Errorf("Error gpu_metrics:%w", err)
+	}
+	path := fmt.Sprintf("/sys/bus/pci/devices/%04x:%02x:%02x.%x/gpu_metrics",
+		(bdfid>>32)&0xffffffff, (bdfid>>8)&0xff, (bdfid>>3)&0x1f, bdfid&0x7)
+	table, err = os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error gpu_metrics:%s", err)
+	}
+	return table, nil
+}
+
+// RsmiDevEccStatusGet 获取GPU块的ECC状态
+func (b *cgoBackend) RsmiDevEccStatusGet(dvInd int, block RSMIGpuBlock) (state RSMIRasErrState, err error) {
 	//glog.Infof("rsmiDevEccStatusGet: %d,%d", dvInd, block)
 	var sstate C.rsmi_ras_err_state_t
 	ret := C.rsmi_dev_ecc_status_get(C.uint32_t(dvInd), C.rsmi_gpu_block_t(block), &sstate)
 	//glog.Infof("rsmi_dev_ecc_status_get ret:%v", ret)
 	if err = errorString(ret); err != nil {
-		return state, fmt.Errorf("Error rsmi_dev_ecc_status_get:%s", err)
+		return state, fmt.Errorf("Error rsmi_dev_ecc_status_get:%w", err)
 	}
 	state = RSMIRasErrState(sstate)
 	//glog.Infof("rsmiDevEccStatusGet:%v", sstate)
 	return
 }
 
-// rsmiDevEccCountGet 获取GPU块的错误计数
-func rsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorCount, err error) {
+// RsmiDevEccCountGet 获取GPU块的错误计数
+func (b *cgoBackend) RsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorCount, err error) {
 	var cerrorCount C.rsmi_error_count_t
 	ret := C.rsmi_dev_ecc_count_get(C.uint32_t(dvInd), C.rsmi_gpu_block_t(gpuBlock), &cerrorCount)
 	//glog.Infof("rsmiDevEccCountGet:%v,ret retstr:%v", ret, errorString(ret))
 	if err = errorString(ret); err != nil {
-		return errorCount, fmt.Errorf("Error rsmi_dev_ecc_count_get:%s", err)
+		return errorCount, fmt.Errorf("Error rsmi_dev_ecc_count_get:%w", err)
 	}
 	errorCount = RSMIErrorCount{
 		CorrectableErr:   uint64(cerrorCount.correctable_err),
@@ -260,12 +286,12 @@ func rsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorC
 	return
 }
 
-// rsmiDevEccEnabledGet 获取已启用的ECC位掩码
-func rsmiDevEccEnabledGet(dvInd int) (enabledBlocks int64, err error) {
+// RsmiDevEccEnabledGet 获取已启用的ECC位掩码
+func (b *cgoBackend) RsmiDevEccEnabledGet(dvInd int) (enabledBlocks int64, err error) {
 	var cenabledBlocks C.uint64_t
 	ret := C.rsmi_dev_ecc_enabled_get(C.uint32_t(dvInd), &cenabledBlocks)
 	if err = errorString(ret); err != nil {
-		return enabledBlocks, fmt.Errorf("Error rsmi_dev_ecc_enabled_get:%s", err)
+		return enabledBlocks, fmt.Errorf("Error rsmi_dev_ecc_enabled_get:%w", err)
 	}
 	enabledBlocks = int64(cenabledBlocks)
 	glog.Infof("DCUBlockType:%v", enabledBlocks)
@@ -0,0 +1,387 @@
+package dcgm
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DiagResult 诊断项的结论
+type DiagResult string
+
+const (
+	DiagPass DiagResult = "PASS"
+	DiagFail DiagResult = "FAIL"
+	// DiagSkip 诊断项不适用于当前环境，例如非 cgo 后端不加载动态库，不影响整体结论
+	DiagSkip DiagResult = "SKIP"
+)
+
+// 软件诊断项名称
+const (
+	DiagCheckLibraries      = "libraries"
+	DiagCheckLibraryVersion = "library-versions"
+	DiagCheckDeviceNodes    = "device-nodes"
+	DiagCheckDriverVersion  = "driver-version"
+	DiagCheckDeviceCount    = "device-count"
+	DiagCheckFirmware       = "firmware"
+	DiagCheckVdev           = "vdev-config"
+)
+
+// LibraryVersionPair 一组相互兼容的 librocm_smi64 与 libhydmi 版本，版本取自库文件名 .so. 之后的部分，
+// 按版本号分段前缀匹配，例如 2.8 匹配 2.8 与 2.8.1
+type LibraryVersionPair struct {
+	Rsmi string
+	Dmi  string
+}
+
+// DefaultLibraryVersionPairs 默认的兼容版本组合：pkg/dcgm/lib 中附带的库版本，以及此前安装说明中的 libhydmi 1.4
+var DefaultLibraryVersionPairs = []LibraryVersionPair{
+	{Rsmi: "2.8", Dmi: "1.5"},
+	{Rsmi: "2.8", Dmi: "1.4"},
+}
+
+// DiagOptions 软件诊断的参数
+type DiagOptions struct {
+	// DriverAllowList 允许的驱动版本，支持 path.Match 通配符（例如 6.3.*），为空时跳过驱动版本检查
+	DriverAllowList []string
+	// LibraryVersionPairs 兼容的库版本组合，为空时使用 DefaultLibraryVersionPairs
+	LibraryVersionPairs []LibraryVersionPair
+	// DevDir 设备节点所在目录，为空时为 /dev
+	DevDir string
+	// VdevDir vDCU 配置文件目录，为空时为 /etc/vdev
+	VdevDir string
+}
+
+// ParseDriverAllowList 解析逗号分隔的驱动版本允许列表，忽略空项
+func ParseDriverAllowList(s string) (allowList []string) {
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			allowList = append(allowList, item)
+		}
+	}
+	return allowList
+}
+
+// DiagCheck 单个诊断项的结果
+type DiagCheck struct {
+	//  Name 诊断项名称
+	Name string
+	//  Result 结论
+	Result DiagResult
+	//  Message 结论说明
+	Message string
+	//  Details 逐项的检查明细，例如每个设备节点或每个固件块
+	Details []string `json:",omitempty"`
+}
+
+// DiagReport 软件诊断报告，任一诊断项为 FAIL 时 Result 为 FAIL
+type DiagReport struct {
+	//  Result 整体结论
+	Result DiagResult
+	//  StartedAt 开始时间
+	StartedAt time.Time
+	//  Duration 耗时
+	Duration time.Duration
+	//  Checks 各诊断项的结果，顺序固定
+	Checks []DiagCheck
+}
+
+// RunSoftwareDiag 运行软件层面的快速诊断（相当于 dcgmi diag -r 1），依次检查动态库是否加载及其版本是否兼容、
+// /dev/kfd 与 /dev/dri/renderD* 是否存在且可读写、驱动版本是否在允许列表中、探测到的设备数量与 rsmi 枚举的数量是否一致、
+// 同型号设备的固件版本是否一致，以及 /etc/vdev 是否可读。需要先完成 Init
+func RunSoftwareDiag(opts DiagOptions) DiagReport {
+	report := DiagReport{Result: DiagPass, StartedAt: time.Now()}
+	checks := []func(DiagOptions) DiagCheck{
+		diagLibraries,
+		diagLibraryVersions,
+		diagDeviceNodes,
+		diagDriverVersion,
+		diagDeviceCount,
+		diagFirmware,
+		diagVdev,
+	}
+	for _, check := range checks {
+		result := check(opts)
+		if result.Result == DiagFail {
+			report.Result = DiagFail
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	report.Duration = time.Since(report.StartedAt)
+	return report
+}
+
+func diagPass(name, format string, args ...any) DiagCheck {
+	return DiagCheck{Name: name, Result: DiagPass, Message: fmt.Sprintf(format, args...)}
+}
+
+func diagFail(name, format string, args ...any) DiagCheck {
+	return DiagCheck{Name: name, Result: DiagFail, Message: fmt.Sprintf(format, args...)}
+}
+
+func diagSkip(name, format string, args ...any) DiagCheck {
+	return DiagCheck{Name: name, Result: DiagSkip, Message: fmt.Sprintf(format, args...)}
+}
+
+// diagLibraries 两个动态库均已加载，且绑定使用的符号全部找到
+func diagLibraries(opts DiagOptions) DiagCheck {
+	if !backendLoadsLibraries() {
+		return diagSkip(DiagCheckLibraries, "backend %T does not load the rsmi/dmi libraries", getBackend())
+	}
+	check := diagPass(DiagCheckLibraries, "%s and %s loaded with all symbols", LibraryRsmi, LibraryDmi)
+	for _, lib := range Libraries() {
+		switch {
+		case !lib.Loaded:
+			check.Details = append(check.Details, fmt.Sprintf("%s: not loaded: %s", lib.Name, lib.Error))
+			check.Result = DiagFail
+		case lib.Missing > 0:
+			check.Details = append(check.Details, fmt.Sprintf("%s %s from %s: %d symbols missing", lib.Name, lib.Version, lib.Path, lib.Missing))
+			check.Result = DiagFail
+		default:
+			check.Details = append(check.Details, fmt.Sprintf("%s %s from %s: %d symbols", lib.Name, lib.Version, lib.Path, lib.Found))
+		}
+	}
+	if check.Result == DiagFail {
+		check.Message = "libraries not loaded or missing symbols, calls will return not supported"
+	}
+	return check
+}
+
+// diagLibraryVersions 两个动态库的文件版本属于同一组兼容版本
+func diagLibraryVersions(opts DiagOptions) DiagCheck {
+	if !backendLoadsLibraries() {
+		return diagSkip(DiagCheckLibraryVersion, "backend %T does not load the rsmi/dmi libraries", getBackend())
+	}
+	versions := map[string]string{}
+	for _, lib := range Libraries() {
+		if !lib.Loaded {
+			return diagFail(DiagCheckLibraryVersion, "%s not loaded", lib.Name)
+		}
+		versions[lib.Name] = libraryFileVersion(lib.Path)
+		if versions[lib.Name] == "" {
+			return diagFail(DiagCheckLibraryVersion, "version of %s unknown, %s has no .so.<version> suffix", lib.Name, lib.Path)
+		}
+	}
+	pairs := opts.LibraryVersionPairs
+	if len(pairs) == 0 {
+		pairs = DefaultLibraryVersionPairs
+	}
+	rsmi, dmi := versions[LibraryRsmi], versions[LibraryDmi]
+	for _, pair := range pairs {
+		if versionHasPrefix(rsmi, pair.Rsmi) && versionHasPrefix(dmi, pair.Dmi) {
+			return diagPass(DiagCheckLibraryVersion, "%s %s matches %s %s", LibraryRsmi, rsmi, LibraryDmi, dmi)
+		}
+	}
+	check := diagFail(DiagCheckLibraryVersion, "%s %s does not match %s %s", LibraryRsmi, rsmi, LibraryDmi, dmi)
+	for _, pair := range pairs {
+		check.Details = append(check.Details, fmt.Sprintf("compatible: %s %s with %s %s", LibraryRsmi, pair.Rsmi, LibraryDmi, pair.Dmi))
+	}
+	return check
+}
+
+// versionHasPrefix 按版本号分段判断前缀，例如 2.8.1 以 2.8 开头，2.81 不是
+func versionHasPrefix(version, prefix string) bool {
+	return version == prefix || strings.HasPrefix(version, prefix+".")
+}
+
+// diagDeviceNodes /dev/kfd 与每个设备的 /dev/dri/renderD<minor> 为字符设备且当前进程可读写
+func diagDeviceNodes(opts DiagOptions) DiagCheck {
+	if !backendLoadsLibraries() {
+		return diagSkip(DiagCheckDeviceNodes, "backend %T does not use the device nodes", getBackend())
+	}
+	devDir := opts.DevDir
+	if devDir == "" {
+		devDir = "/dev"
+	}
+	nodes := []string{filepath.Join(devDir, "kfd")}
+	// 设备数量由 device-count 检查，获取失败时只按目录检查设备节点
+	numDevices, _ := rsmiNumMonitorDevices()
+	for dvInd := 0; dvInd < numDevices; dvInd++ {
+		if minor := rsmiDevDrmRenderMinorGet(dvInd); minor > 0 {
+			nodes = append(nodes, filepath.Join(devDir, "dri", fmt.Sprintf("renderD%d", minor)))
+		}
+	}
+	// 无法获取设备数量或 render minor 时检查全部 renderD 节点
+	if len(nodes) == 1 {
+		matches, _ := filepath.Glob(filepath.Join(devDir, "dri", "renderD*"))
+		if len(matches) == 0 {
+			return diagFail(DiagCheckDeviceNodes, "no render node found in %s", filepath.Join(devDir, "dri"))
+		}
+		nodes = append(nodes, matches...)
+	}
+	check := diagPass(DiagCheckDeviceNodes, "%d device nodes accessible", len(nodes))
+	for _, node := range nodes {
+		detail, ok := diagDeviceNode(node)
+		check.Details = append(check.Details, detail)
+		if !ok {
+			check.Result = DiagFail
+		}
+	}
+	if check.Result == DiagFail {
+		check.Message = "device nodes missing or not accessible, add the user to the video and render groups or fix the udev rules"
+	}
+	return check
+}
+
+// access(2) 的 R_OK 与 W_OK
+const (
+	accessRead  = 0x4
+	accessWrite = 0x2
+)
+
+// diagDeviceNode 检查单个设备节点，返回明细与是否通过
+func diagDeviceNode(node string) (string, bool) {
+	info, err := os.Stat(node)
+	if err != nil {
+		return fmt.Sprintf("%s: %v", node, err), false
+	}
+	if info.Mode()&os.ModeCharDevice == 0 {
+		return fmt.Sprintf("%s: not a character device", node), false
+	}
+	var gid uint32
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		gid = st.Gid
+	}
+	if err := syscall.Access(node, accessRead|accessWrite); err != nil {
+		return fmt.Sprintf("%s: mode %v gid %d: not readable and writable: %v", node, info.Mode().Perm(), gid, err), false
+	}
+	return fmt.Sprintf("%s: mode %v gid %d", node, info.Mode().Perm(), gid), true
+}
+
+// diagDriverVersion 驱动版本匹配允许列表中的任一项
+func diagDriverVersion(opts DiagOptions) DiagCheck {
+	version, err := Version(RSMISwCompDriver)
+	if err != nil {
+		return diagFail(DiagCheckDriverVersion, "Error get driver version:%v", err)
+	}
+	if len(opts.DriverAllowList) == 0 {
+		return diagSkip(DiagCheckDriverVersion, "driver %s, no allow-list configured", version)
+	}
+	for _, pattern := range opts.DriverAllowList {
+		if ok, _ := path.Match(pattern, version); ok {
+			return diagPass(DiagCheckDriverVersion, "driver %s allowed by %s", version, pattern)
+		}
+	}
+	check := diagFail(DiagCheckDriverVersion, "driver %s is not on the allow-list", version)
+	check.Details = append(check.Details, "allowed: "+strings.Join(opts.DriverAllowList, ", "))
+	return check
+}
+
+// diagDeviceCount 后端探测到的设备数量（cgo 后端扫描 /sys/devices）与 rsmi 枚举的数量一致
+func diagDeviceCount(opts DiagOptions) DiagCheck {
+	probed := getBackend().ProbeDeviceCount()
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return diagFail(DiagCheckDeviceCount, "Error get device count:%v", err)
+	}
+	if probed != numDevices {
+		return diagFail(DiagCheckDeviceCount, "%d devices found on the PCI bus but rsmi reports %d", probed, numDevices)
+	}
+	if numDevices == 0 {
+		return diagFail(DiagCheckDeviceCount, "no device found")
+	}
+	return diagPass(DiagCheckDeviceCount, "%d devices", numDevices)
+}
+
+// diagFirmware 同型号设备各固件块的版本一致，读取失败的固件块不参与比较
+func diagFirmware(opts DiagOptions) DiagCheck {
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return diagFail(DiagCheckFirmware, "Error get device count:%v", err)
+	}
+	// 型号 -> 固件块 -> 版本 -> 设备列表
+	models := map[int]map[string]map[int64][]int{}
+	for dvInd := 0; dvInd < numDevices; dvInd++ {
+		id, err := rsmiDevIdGet(dvInd)
+		if err != nil {
+			return diagFail(DiagCheckFirmware, "Error get device id of device %d:%v", dvInd, err)
+		}
+		if models[id] == nil {
+			models[id] = map[string]map[int64][]int{}
+		}
+		for block, name := range fwBlockNames {
+			version, err := rsmiDevFirmwareVersionGet(dvInd, RSMIFwBlock(block))
+			if err != nil {
+				continue
+			}
+			if models[id][name] == nil {
+				models[id][name] = map[int64][]int{}
+			}
+			models[id][name][version] = append(models[id][name][version], dvInd)
+		}
+	}
+	ids := make([]int, 0, len(models))
+	for id := range models {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	check := diagPass(DiagCheckFirmware, "firmware consistent across devices of the same model")
+	blocks := 0
+	for _, id := range ids {
+		for _, name := range fwBlockNames {
+			versions := models[id][name]
+			if len(versions) == 0 {
+				continue
+			}
+			blocks++
+			if len(versions) == 1 {
+				continue
+			}
+			check.Result = DiagFail
+			var parts []string
+			for version, devices := range versions {
+				parts = append(parts, fmt.Sprintf("%d on %v", version, devices))
+			}
+			sort.Strings(parts)
+			check.Details = append(check.Details, fmt.Sprintf("device id 0x%x %s: %s", id, name, strings.Join(parts, ", ")))
+		}
+	}
+	if check.Result == DiagFail {
+		check.Message = fmt.Sprintf("%d firmware blocks differ between devices of the same model", len(check.Details))
+	} else if blocks == 0 {
+		return diagSkip(DiagCheckFirmware, "no firmware version available")
+	}
+	return check
+}
+
+// diagVdev vDCU 配置目录及其中的配置文件可读，目录不存在说明未配置 vDCU
+func diagVdev(opts DiagOptions) DiagCheck {
+	dir := opts.VdevDir
+	if dir == "" {
+		dir = "/etc/vdev"
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return diagSkip(DiagCheckVdev, "%s does not exist, no vDCU configured", dir)
+	}
+	if err != nil {
+		return diagFail(DiagCheckVdev, "%s is not readable: %v", dir, err)
+	}
+	check := diagPass(DiagCheckVdev, "%s readable", dir)
+	files := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		files++
+		file := filepath.Join(dir, entry.Name())
+		f, err := os.Open(file)
+		if err != nil {
+			check.Result = DiagFail
+			check.Details = append(check.Details, err.Error())
+			continue
+		}
+		f.Close()
+	}
+	if check.Result == DiagFail {
+		check.Message = fmt.Sprintf("%d of %d files in %s are not readable", len(check.Details), files, dir)
+	} else {
+		check.Message = fmt.Sprintf("%s readable, %d config files", dir, files)
+	}
+	return check
+}
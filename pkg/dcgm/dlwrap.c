@@ -0,0 +1,1026 @@
+//go:build cgo
+
+// 运行时通过 dlopen/dlsym 加载 librocm_smi64 与 libhydmi。
+// 本文件为 Go 代码使用到的每个 rsmi_*/dmi* 函数提供同名的包装函数：符号已解析时转发调用，
+// 未解析时返回 DCGM_DL_STATUS_MISSING + 符号下标，由 Go 侧转换为 NotSupportedError，
+// 避免旧版本库缺少符号时在调用处崩溃。
+#define _GNU_SOURCE
+#include <dlfcn.h>
+#include <stdio.h>
+#include <stdint.h>
+#include <stdbool.h>
+#include <kfd_ioctl.h>
+#include <rocm_smi64Config.h>
+#include <rocm_smi.h>
+#include <dmi_virtual.h>
+#include <dmi_error.h>
+#include <dmi.h>
+#include <dmi_mig.h>
+#include "dlwrap.h"
+
+#define DCGM_DL_HIDDEN __attribute__((visibility("hidden")))
+
+static void *dcgm_dl_handles[DCGM_DL_LIB_COUNT];
+
+static rsmi_status_t (*p_rsmi_compute_process_gpus_get)(uint32_t pid, uint32_t *dv_indices, uint32_t *num_devices);
+static rsmi_status_t (*p_rsmi_compute_process_info_by_pid_get)(uint32_t pid, rsmi_process_info_t *proc);
+static rsmi_status_t (*p_rsmi_compute_process_info_get)(rsmi_process_info_t *procs, uint32_t *num_items);
+static rsmi_status_t (*p_rsmi_counter_available_counters_get)(uint32_t dv_ind, rsmi_event_group_t grp, uint32_t *available);
+static rsmi_status_t (*p_rsmi_counter_control)(rsmi_event_handle_t evt_handle, rsmi_counter_command_t cmd, void *cmd_args);
+static rsmi_status_t (*p_rsmi_counter_read)(rsmi_event_handle_t evt_handle, rsmi_counter_value_t *value);
+static rsmi_status_t (*p_rsmi_dev_brand_get)(uint32_t dv_ind, char *brand, uint32_t len);
+static rsmi_status_t (*p_rsmi_dev_busy_percent_get)(uint32_t dv_ind, uint32_t *busy_percent);
+static rsmi_status_t (*p_rsmi_dev_clk_range_set)(uint32_t dv_ind, uint64_t minclkvalue, uint64_t maxclkvalue, rsmi_clk_type_t clkType);
+static rsmi_status_t (*p_rsmi_dev_counter_create)(uint32_t dv_ind, rsmi_event_type_t type, rsmi_event_handle_t *evnt_handle);
+static rsmi_status_t (*p_rsmi_dev_counter_destroy)(rsmi_event_handle_t evnt_handle);
+static rsmi_status_t (*p_rsmi_dev_counter_group_supported)(uint32_t dv_ind, rsmi_event_group_t group);
+static rsmi_status_t (*p_rsmi_dev_drm_render_minor_get)(uint32_t dv_ind, uint32_t *minor);
+static rsmi_status_t (*p_rsmi_dev_ecc_count_get)(uint32_t dv_ind, rsmi_gpu_block_t block, rsmi_error_count_t *ec);
+static rsmi_status_t (*p_rsmi_dev_ecc_enabled_get)(uint32_t dv_ind, uint64_t *enabled_blocks);
+static rsmi_status_t (*p_rsmi_dev_ecc_status_get)(uint32_t dv_ind, rsmi_gpu_block_t block, rsmi_ras_err_state_t *state);
+static rsmi_status_t (*p_rsmi_dev_energy_count_get)(uint32_t dv_ind, uint64_t *power, float *counter_resolution, uint64_t *timestamp);
+static rsmi_status_t (*p_rsmi_dev_fan_reset)(uint32_t dv_ind, uint32_t sensor_ind);
+static rsmi_status_t (*p_rsmi_dev_fan_rpms_get)(uint32_t dv_ind, uint32_t sensor_ind, int64_t *speed);
+static rsmi_status_t (*p_rsmi_dev_fan_speed_get)(uint32_t dv_ind, uint32_t sensor_ind, int64_t *speed);
+static rsmi_status_t (*p_rsmi_dev_fan_speed_max_get)(uint32_t dv_ind, uint32_t sensor_ind, uint64_t *max_speed);
+static rsmi_status_t (*p_rsmi_dev_fan_speed_set)(uint32_t dv_ind, uint32_t sensor_ind, uint64_t speed);
+static rsmi_status_t (*p_rsmi_dev_firmware_version_get)(uint32_t dv_ind, rsmi_fw_block_t block, uint64_t *fw_version);
+static rsmi_status_t (*p_rsmi_dev_gpu_clk_freq_get)(uint32_t dv_ind, rsmi_clk_type_t clk_type, rsmi_frequencies_t *f);
+static rsmi_status_t (*p_rsmi_dev_gpu_clk_freq_set)(uint32_t dv_ind, rsmi_clk_type_t clk_type, uint64_t freq_bitmask);
+static rsmi_status_t (*p_rsmi_dev_gpu_metrics_info_get)(uint32_t dv_ind, rsmi_gpu_metrics_t *pgpu_metrics);
+static rsmi_status_t (*p_rsmi_dev_id_get)(uint32_t dv_ind, uint16_t *id);
+static rsmi_status_t (*p_rsmi_dev_memory_busy_percent_get)(uint32_t dv_ind, uint32_t *busy_percent);
+static rsmi_status_t (*p_rsmi_dev_memory_reserved_pages_get)(uint32_t dv_ind, uint32_t *num_pages, rsmi_retired_page_record_t *records);
+static rsmi_status_t (*p_rsmi_dev_memory_total_get)(uint32_t dv_ind, rsmi_memory_type_t mem_type, uint64_t *total);
+static rsmi_status_t (*p_rsmi_dev_memory_usage_get)(uint32_t dv_ind, rsmi_memory_type_t mem_type, uint64_t *used);
+static rsmi_status_t (*p_rsmi_dev_name_get)(uint32_t dv_ind, char *name, size_t len);
+static rsmi_status_t (*p_rsmi_dev_od_volt_curve_regions_get)(uint32_t dv_ind, uint32_t *num_regions, rsmi_freq_volt_region_t *buffer);
+static rsmi_status_t (*p_rsmi_dev_od_volt_info_get)(uint32_t dv_ind, rsmi_od_volt_freq_data_t *odv);
+static rsmi_status_t (*p_rsmi_dev_od_volt_info_set)(uint32_t dv_ind, uint32_t vpoint, uint64_t clkvalue, uint64_t voltvalue);
+static rsmi_status_t (*p_rsmi_dev_overdrive_level_get)(uint32_t dv_ind, uint32_t *od);
+static rsmi_status_t (*p_rsmi_dev_overdrive_level_set)(int32_t dv_ind, uint32_t od);
+static rsmi_status_t (*p_rsmi_dev_pci_bandwidth_get)(uint32_t dv_ind, rsmi_pcie_bandwidth_t *bandwidth);
+static rsmi_status_t (*p_rsmi_dev_pci_bandwidth_set)(uint32_t dv_ind, uint64_t bw_bitmask);
+static rsmi_status_t (*p_rsmi_dev_pci_id_get)(uint32_t dv_ind, uint64_t *bdfid);
+static rsmi_status_t (*p_rsmi_dev_pci_replay_counter_get)(uint32_t dv_ind, uint64_t *counter);
+static rsmi_status_t (*p_rsmi_dev_pci_throughput_get)(uint32_t dv_ind, uint64_t *sent, uint64_t *received, uint64_t *max_pkt_sz);
+static rsmi_status_t (*p_rsmi_dev_perf_level_get)(uint32_t dv_ind, rsmi_dev_perf_level_t *perf);
+static rsmi_status_t (*p_rsmi_dev_perf_level_set)(int32_t dv_ind, rsmi_dev_perf_level_t perf_lvl);
+static rsmi_status_t (*p_rsmi_dev_power_ave_get)(uint32_t dv_ind, uint32_t sensor_ind, uint64_t *power);
+static rsmi_status_t (*p_rsmi_dev_power_cap_get)(uint32_t dv_ind, uint32_t sensor_ind, uint64_t *cap);
+static rsmi_status_t (*p_rsmi_dev_power_cap_range_get)(uint32_t dv_ind, uint32_t sensor_ind, uint64_t *max, uint64_t *min);
+static rsmi_status_t (*p_rsmi_dev_power_profile_presets_get)(uint32_t dv_ind, uint32_t sensor_ind, rsmi_power_profile_status_t *status);
+static rsmi_status_t (*p_rsmi_dev_power_profile_set)(uint32_t dv_ind, uint32_t reserved, rsmi_power_profile_preset_masks_t profile);
+static rsmi_status_t (*p_rsmi_dev_serial_number_get)(uint32_t dv_ind, char *serial_num, uint32_t len);
+static rsmi_status_t (*p_rsmi_dev_sku_get)(uint32_t dv_ind, uint16_t *sku);
+static rsmi_status_t (*p_rsmi_dev_subsystem_id_get)(uint32_t dv_ind, uint16_t *id);
+static rsmi_status_t (*p_rsmi_dev_subsystem_name_get)(uint32_t dv_ind, char *name, size_t len);
+static rsmi_status_t (*p_rsmi_dev_subsystem_vendor_id_get)(uint32_t dv_ind, uint16_t *id);
+static rsmi_status_t (*p_rsmi_dev_supported_func_iterator_close)(rsmi_func_id_iter_handle_t *handle);
+static rsmi_status_t (*p_rsmi_dev_supported_func_iterator_open)(uint32_t dv_ind, rsmi_func_id_iter_handle_t *handle);
+static rsmi_status_t (*p_rsmi_dev_supported_variant_iterator_open)(rsmi_func_id_iter_handle_t obj_h, rsmi_func_id_iter_handle_t *var_iter);
+static rsmi_status_t (*p_rsmi_dev_temp_metric_get)(uint32_t dv_ind, uint32_t sensor_type, rsmi_temperature_metric_t metric, int64_t *temperature);
+static rsmi_status_t (*p_rsmi_dev_unique_id_get)(uint32_t dv_ind, uint64_t *id);
+static rsmi_status_t (*p_rsmi_dev_vbios_version_get)(uint32_t dv_ind, char *vbios, uint32_t len);
+static rsmi_status_t (*p_rsmi_dev_vendor_id_get)(uint32_t dv_ind, uint16_t *id);
+static rsmi_status_t (*p_rsmi_dev_vendor_name_get)(uint32_t dv_ind, char *name, size_t len);
+static rsmi_status_t (*p_rsmi_dev_volt_metric_get)(uint32_t dv_ind, rsmi_voltage_type_t sensor_type, rsmi_voltage_metric_t metric, int64_t *voltage);
+static rsmi_status_t (*p_rsmi_dev_vram_vendor_get)(uint32_t dv_ind, char *brand, uint32_t len);
+static rsmi_status_t (*p_rsmi_dev_xgmi_error_reset)(uint32_t dv_ind);
+static rsmi_status_t (*p_rsmi_dev_xgmi_error_status)(uint32_t dv_ind, rsmi_xgmi_status_t *status);
+static rsmi_status_t (*p_rsmi_dev_xgmi_hive_id_get)(uint32_t dv_ind, uint64_t *hive_id);
+static rsmi_status_t (*p_rsmi_event_notification_get)(int timeout_ms, uint32_t *num_elem, rsmi_evt_notification_data_t *data);
+static rsmi_status_t (*p_rsmi_event_notification_init)(uint32_t dv_ind);
+static rsmi_status_t (*p_rsmi_event_notification_mask_set)(uint32_t dv_ind, uint64_t mask);
+static rsmi_status_t (*p_rsmi_event_notification_stop)(uint32_t dv_ind);
+static rsmi_status_t (*p_rsmi_func_iter_next)(rsmi_func_id_iter_handle_t handle);
+static rsmi_status_t (*p_rsmi_func_iter_value_get)(rsmi_func_id_iter_handle_t handle, rsmi_func_id_value_t *value);
+static rsmi_status_t (*p_rsmi_init)(uint64_t init_flags);
+static rsmi_status_t (*p_rsmi_minmax_bandwidth_get)(uint32_t dv_ind_src, uint32_t dv_ind_dst, uint64_t *min_bandwidth, uint64_t *max_bandwidth);
+static rsmi_status_t (*p_rsmi_num_monitor_devices)(uint32_t *num_devices);
+static rsmi_status_t (*p_rsmi_perf_determinism_mode_set)(uint32_t dv_ind, uint64_t clkvalue);
+static rsmi_status_t (*p_rsmi_shut_down)(void);
+static rsmi_status_t (*p_rsmi_status_string)(rsmi_status_t status, const char **status_string);
+static rsmi_status_t (*p_rsmi_topo_get_link_type)(uint32_t dv_ind_src, uint32_t dv_ind_dst, uint64_t *hops, RSMI_IO_LINK_TYPE *type);
+static rsmi_status_t (*p_rsmi_topo_get_link_weight)(uint32_t dv_ind_src, uint32_t dv_ind_dst, uint64_t *weight);
+static rsmi_status_t (*p_rsmi_topo_get_numa_node_number)(uint32_t dv_ind, uint32_t *numa_node);
+static rsmi_status_t (*p_rsmi_topo_numa_affinity_get)(uint32_t dv_ind, uint32_t *numa_node);
+static rsmi_status_t (*p_rsmi_utilization_count_get)(uint32_t dv_ind, rsmi_utilization_counter_t utilization_counters[], uint32_t count, uint64_t *timestamp);
+static rsmi_status_t (*p_rsmi_version_get)(rsmi_version_t *version);
+static rsmi_status_t (*p_rsmi_version_str_get)(rsmi_sw_component_t component, char *ver_str, uint32_t len);
+static dmiStatus (*p_dmiCreateVDevices)(int device_id, int vdev_count, int *vdev_cus, int *vdev_mem_size);
+static dmiStatus (*p_dmiDestroySingleVDevice)(int vDeviceId);
+static dmiStatus (*p_dmiDestroyVDevices)(int deviceId);
+static dmiStatus (*p_dmiGetDevBusyPercent)(int device_id, int *busy_percent);
+static dmiStatus (*p_dmiGetDeviceCount)(int *count);
+static dmiStatus (*p_dmiGetDeviceInfo)(int device_id, dmiDeviceInfo *device_info);
+static dmiStatus (*p_dmiGetDeviceRemainingInfo)(int device_id, size_t *cus, size_t *memories);
+static dmiStatus (*p_dmiGetEncryptionVMStatus)(bool *status);
+static dmiStatus (*p_dmiGetMaxVDeviceCount)(int *count);
+static dmiStatus (*p_dmiGetStatusString)(dmiStatus status, const char** status_string);
+static dmiStatus (*p_dmiGetVDevBusyPercent)(int vdevice_id, int *busy_percent);
+static dmiStatus (*p_dmiGetVDeviceCount)(int *count);
+static dmiStatus (*p_dmiGetVDeviceInfo)(int vdevice_id, dmiDeviceInfo *device_info);
+static dmiStatus (*p_dmiSetEncryptionVMStatus)(bool status);
+static dmiStatus (*p_dmiStartVDevice)(int deviceId);
+static dmiStatus (*p_dmiStopVDevice)(int deviceId);
+static dmiStatus (*p_dmiUpdateSingleVDevice)(int vdeviceId, int vdev_cus, int vdev_mem_size);
+
+static dcgm_dl_sym_t dcgm_dl_syms[] = {
+    {"rsmi_compute_process_gpus_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_compute_process_gpus_get},
+    {"rsmi_compute_process_info_by_pid_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_compute_process_info_by_pid_get},
+    {"rsmi_compute_process_info_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_compute_process_info_get},
+    {"rsmi_counter_available_counters_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_counter_available_counters_get},
+    {"rsmi_counter_control", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_counter_control},
+    {"rsmi_counter_read", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_counter_read},
+    {"rsmi_dev_brand_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_brand_get},
+    {"rsmi_dev_busy_percent_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_busy_percent_get},
+    {"rsmi_dev_clk_range_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_clk_range_set},
+    {"rsmi_dev_counter_create", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_counter_create},
+    {"rsmi_dev_counter_destroy", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_counter_destroy},
+    {"rsmi_dev_counter_group_supported", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_counter_group_supported},
+    {"rsmi_dev_drm_render_minor_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_drm_render_minor_get},
+    {"rsmi_dev_ecc_count_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_ecc_count_get},
+    {"rsmi_dev_ecc_enabled_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_ecc_enabled_get},
+    {"rsmi_dev_ecc_status_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_ecc_status_get},
+    {"rsmi_dev_energy_count_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_energy_count_get},
+    {"rsmi_dev_fan_reset", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_fan_reset},
+    {"rsmi_dev_fan_rpms_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_fan_rpms_get},
+    {"rsmi_dev_fan_speed_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_fan_speed_get},
+    {"rsmi_dev_fan_speed_max_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_fan_speed_max_get},
+    {"rsmi_dev_fan_speed_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_fan_speed_set},
+    {"rsmi_dev_firmware_version_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_firmware_version_get},
+    {"rsmi_dev_gpu_clk_freq_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_gpu_clk_freq_get},
+    {"rsmi_dev_gpu_clk_freq_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_gpu_clk_freq_set},
+    {"rsmi_dev_gpu_metrics_info_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_gpu_metrics_info_get},
+    {"rsmi_dev_id_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_id_get},
+    {"rsmi_dev_memory_busy_percent_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_memory_busy_percent_get},
+    {"rsmi_dev_memory_reserved_pages_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_memory_reserved_pages_get},
+    {"rsmi_dev_memory_total_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_memory_total_get},
+    {"rsmi_dev_memory_usage_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_memory_usage_get},
+    {"rsmi_dev_name_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_name_get},
+    {"rsmi_dev_od_volt_curve_regions_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_od_volt_curve_regions_get},
+    {"rsmi_dev_od_volt_info_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_od_volt_info_get},
+    {"rsmi_dev_od_volt_info_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_od_volt_info_set},
+    {"rsmi_dev_overdrive_level_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_overdrive_level_get},
+    {"rsmi_dev_overdrive_level_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_overdrive_level_set},
+    {"rsmi_dev_pci_bandwidth_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_pci_bandwidth_get},
+    {"rsmi_dev_pci_bandwidth_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_pci_bandwidth_set},
+    {"rsmi_dev_pci_id_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_pci_id_get},
+    {"rsmi_dev_pci_replay_counter_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_pci_replay_counter_get},
+    {"rsmi_dev_pci_throughput_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_pci_throughput_get},
+    {"rsmi_dev_perf_level_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_perf_level_get},
+    {"rsmi_dev_perf_level_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_perf_level_set},
+    {"rsmi_dev_power_ave_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_power_ave_get},
+    {"rsmi_dev_power_cap_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_power_cap_get},
+    {"rsmi_dev_power_cap_range_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_power_cap_range_get},
+    {"rsmi_dev_power_profile_presets_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_power_profile_presets_get},
+    {"rsmi_dev_power_profile_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_power_profile_set},
+    {"rsmi_dev_serial_number_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_serial_number_get},
+    {"rsmi_dev_sku_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_sku_get},
+    {"rsmi_dev_subsystem_id_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_subsystem_id_get},
+    {"rsmi_dev_subsystem_name_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_subsystem_name_get},
+    {"rsmi_dev_subsystem_vendor_id_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_subsystem_vendor_id_get},
+    {"rsmi_dev_supported_func_iterator_close", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_supported_func_iterator_close},
+    {"rsmi_dev_supported_func_iterator_open", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_supported_func_iterator_open},
+    {"rsmi_dev_supported_variant_iterator_open", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_supported_variant_iterator_open},
+    {"rsmi_dev_temp_metric_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_temp_metric_get},
+    {"rsmi_dev_unique_id_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_unique_id_get},
+    {"rsmi_dev_vbios_version_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_vbios_version_get},
+    {"rsmi_dev_vendor_id_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_vendor_id_get},
+    {"rsmi_dev_vendor_name_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_vendor_name_get},
+    {"rsmi_dev_volt_metric_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_volt_metric_get},
+    {"rsmi_dev_vram_vendor_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_vram_vendor_get},
+    {"rsmi_dev_xgmi_error_reset", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_xgmi_error_reset},
+    {"rsmi_dev_xgmi_error_status", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_xgmi_error_status},
+    {"rsmi_dev_xgmi_hive_id_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_dev_xgmi_hive_id_get},
+    {"rsmi_event_notification_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_event_notification_get},
+    {"rsmi_event_notification_init", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_event_notification_init},
+    {"rsmi_event_notification_mask_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_event_notification_mask_set},
+    {"rsmi_event_notification_stop", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_event_notification_stop},
+    {"rsmi_func_iter_next", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_func_iter_next},
+    {"rsmi_func_iter_value_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_func_iter_value_get},
+    {"rsmi_init", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_init},
+    {"rsmi_minmax_bandwidth_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_minmax_bandwidth_get},
+    {"rsmi_num_monitor_devices", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_num_monitor_devices},
+    {"rsmi_perf_determinism_mode_set", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_perf_determinism_mode_set},
+    {"rsmi_shut_down", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_shut_down},
+    {"rsmi_status_string", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_status_string},
+    {"rsmi_topo_get_link_type", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_topo_get_link_type},
+    {"rsmi_topo_get_link_weight", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_topo_get_link_weight},
+    {"rsmi_topo_get_numa_node_number", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_topo_get_numa_node_number},
+    {"rsmi_topo_numa_affinity_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_topo_numa_affinity_get},
+    {"rsmi_utilization_count_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_utilization_count_get},
+    {"rsmi_version_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_version_get},
+    {"rsmi_version_str_get", DCGM_DL_LIB_RSMI, (void **)&p_rsmi_version_str_get},
+    {"dmiCreateVDevices", DCGM_DL_LIB_DMI, (void **)&p_dmiCreateVDevices},
+    {"dmiDestroySingleVDevice", DCGM_DL_LIB_DMI, (void **)&p_dmiDestroySingleVDevice},
+    {"dmiDestroyVDevices", DCGM_DL_LIB_DMI, (void **)&p_dmiDestroyVDevices},
+    {"dmiGetDevBusyPercent", DCGM_DL_LIB_DMI, (void **)&p_dmiGetDevBusyPercent},
+    {"dmiGetDeviceCount", DCGM_DL_LIB_DMI, (void **)&p_dmiGetDeviceCount},
+    {"dmiGetDeviceInfo", DCGM_DL_LIB_DMI, (void **)&p_dmiGetDeviceInfo},
+    {"dmiGetDeviceRemainingInfo", DCGM_DL_LIB_DMI, (void **)&p_dmiGetDeviceRemainingInfo},
+    {"dmiGetEncryptionVMStatus", DCGM_DL_LIB_DMI, (void **)&p_dmiGetEncryptionVMStatus},
+    {"dmiGetMaxVDeviceCount", DCGM_DL_LIB_DMI, (void **)&p_dmiGetMaxVDeviceCount},
+    {"dmiGetStatusString", DCGM_DL_LIB_DMI, (void **)&p_dmiGetStatusString},
+    {"dmiGetVDevBusyPercent", DCGM_DL_LIB_DMI, (void **)&p_dmiGetVDevBusyPercent},
+    {"dmiGetVDeviceCount", DCGM_DL_LIB_DMI, (void **)&p_dmiGetVDeviceCount},
+    {"dmiGetVDeviceInfo", DCGM_DL_LIB_DMI, (void **)&p_dmiGetVDeviceInfo},
+    {"dmiSetEncryptionVMStatus", DCGM_DL_LIB_DMI, (void **)&p_dmiSetEncryptionVMStatus},
+    {"dmiStartVDevice", DCGM_DL_LIB_DMI, (void **)&p_dmiStartVDevice},
+    {"dmiStopVDevice", DCGM_DL_LIB_DMI, (void **)&p_dmiStopVDevice},
+    {"dmiUpdateSingleVDevice", DCGM_DL_LIB_DMI, (void **)&p_dmiUpdateSingleVDevice},
+};
+
+int dcgm_dl_sym_count(void) {
+    return (int)(sizeof(dcgm_dl_syms) / sizeof(dcgm_dl_syms[0]));
+}
+
+const dcgm_dl_sym_t *dcgm_dl_sym_at(int i) {
+    if (i < 0 || i >= dcgm_dl_sym_count()) {
+        return NULL;
+    }
+    return &dcgm_dl_syms[i];
+}
+
+int dcgm_dl_sym_found(int i) {
+    const dcgm_dl_sym_t *sym = dcgm_dl_sym_at(i);
+    return sym != NULL && *sym->ptr != NULL;
+}
+
+int dcgm_dl_open(int lib, const char *path, char *err, size_t err_len) {
+    int i;
+    void *handle;
+    if (lib < 0 || lib >= DCGM_DL_LIB_COUNT) {
+        snprintf(err, err_len, "invalid library %d", lib);
+        return -1;
+    }
+    handle = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+    if (handle == NULL) {
+        const char *msg = dlerror();
+        snprintf(err, err_len, "%s", msg != NULL ? msg : "dlopen failed");
+        return -1;
+    }
+    if (dcgm_dl_handles[lib] != NULL) {
+        dcgm_dl_close(lib);
+    }
+    dcgm_dl_handles[lib] = handle;
+    for (i = 0; i < dcgm_dl_sym_count(); i++) {
+        if (dcgm_dl_syms[i].lib == lib) {
+            *dcgm_dl_syms[i].ptr = dlsym(handle, dcgm_dl_syms[i].name);
+        }
+    }
+    return 0;
+}
+
+void dcgm_dl_close(int lib) {
+    int i;
+    if (lib < 0 || lib >= DCGM_DL_LIB_COUNT || dcgm_dl_handles[lib] == NULL) {
+        return;
+    }
+    for (i = 0; i < dcgm_dl_sym_count(); i++) {
+        if (dcgm_dl_syms[i].lib == lib) {
+            *dcgm_dl_syms[i].ptr = NULL;
+        }
+    }
+    dlclose(dcgm_dl_handles[lib]);
+    dcgm_dl_handles[lib] = NULL;
+}
+
+int dcgm_dl_is_open(int lib) {
+    return lib >= 0 && lib < DCGM_DL_LIB_COUNT && dcgm_dl_handles[lib] != NULL;
+}
+
+const char *dcgm_dl_path(int lib) {
+    const dcgm_dl_sym_t *sym;
+    Dl_info info;
+    int i;
+    for (i = 0; i < dcgm_dl_sym_count(); i++) {
+        sym = &dcgm_dl_syms[i];
+        if (sym->lib == lib && *sym->ptr != NULL && dladdr(*sym->ptr, &info) != 0) {
+            return info.dli_fname;
+        }
+    }
+    return NULL;
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_compute_process_gpus_get(uint32_t pid, uint32_t *dv_indices, uint32_t *num_devices) {
+    if (p_rsmi_compute_process_gpus_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 0);
+    }
+    return p_rsmi_compute_process_gpus_get(pid, dv_indices, num_devices);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_compute_process_info_by_pid_get(uint32_t pid, rsmi_process_info_t *proc) {
+    if (p_rsmi_compute_process_info_by_pid_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 1);
+    }
+    return p_rsmi_compute_process_info_by_pid_get(pid, proc);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_compute_process_info_get(rsmi_process_info_t *procs, uint32_t *num_items) {
+    if (p_rsmi_compute_process_info_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 2);
+    }
+    return p_rsmi_compute_process_info_get(procs, num_items);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_counter_available_counters_get(uint32_t dv_ind, rsmi_event_group_t grp, uint32_t *available) {
+    if (p_rsmi_counter_available_counters_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 3);
+    }
+    return p_rsmi_counter_available_counters_get(dv_ind, grp, available);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_counter_control(rsmi_event_handle_t evt_handle, rsmi_counter_command_t cmd, void *cmd_args) {
+    if (p_rsmi_counter_control == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 4);
+    }
+    return p_rsmi_counter_control(evt_handle, cmd, cmd_args);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_counter_read(rsmi_event_handle_t evt_handle, rsmi_counter_value_t *value) {
+    if (p_rsmi_counter_read == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 5);
+    }
+    return p_rsmi_counter_read(evt_handle, value);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_brand_get(uint32_t dv_ind, char *brand, uint32_t len) {
+    if (p_rsmi_dev_brand_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 6);
+    }
+    return p_rsmi_dev_brand_get(dv_ind, brand, len);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_busy_percent_get(uint32_t dv_ind, uint32_t *busy_percent) {
+    if (p_rsmi_dev_busy_percent_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 7);
+    }
+    return p_rsmi_dev_busy_percent_get(dv_ind, busy_percent);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_clk_range_set(uint32_t dv_ind, uint64_t minclkvalue, uint64_t maxclkvalue, rsmi_clk_type_t clkType) {
+    if (p_rsmi_dev_clk_range_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 8);
+    }
+    return p_rsmi_dev_clk_range_set(dv_ind, minclkvalue, maxclkvalue, clkType);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_counter_create(uint32_t dv_ind, rsmi_event_type_t type, rsmi_event_handle_t *evnt_handle) {
+    if (p_rsmi_dev_counter_create == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 9);
+    }
+    return p_rsmi_dev_counter_create(dv_ind, type, evnt_handle);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_counter_destroy(rsmi_event_handle_t evnt_handle) {
+    if (p_rsmi_dev_counter_destroy == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 10);
+    }
+    return p_rsmi_dev_counter_destroy(evnt_handle);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_counter_group_supported(uint32_t dv_ind, rsmi_event_group_t group) {
+    if (p_rsmi_dev_counter_group_supported == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 11);
+    }
+    return p_rsmi_dev_counter_group_supported(dv_ind, group);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_drm_render_minor_get(uint32_t dv_ind, uint32_t *minor) {
+    if (p_rsmi_dev_drm_render_minor_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 12);
+    }
+    return p_rsmi_dev_drm_render_minor_get(dv_ind, minor);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_ecc_count_get(uint32_t dv_ind, rsmi_gpu_block_t block, rsmi_error_count_t *ec) {
+    if (p_rsmi_dev_ecc_count_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 13);
+    }
+    return p_rsmi_dev_ecc_count_get(dv_ind, block, ec);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_ecc_enabled_get(uint32_t dv_ind, uint64_t *enabled_blocks) {
+    if (p_rsmi_dev_ecc_enabled_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 14);
+    }
+    return p_rsmi_dev_ecc_enabled_get(dv_ind, enabled_blocks);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_ecc_status_get(uint32_t dv_ind, rsmi_gpu_block_t block, rsmi_ras_err_state_t *state) {
+    if (p_rsmi_dev_ecc_status_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 15);
+    }
+    return p_rsmi_dev_ecc_status_get(dv_ind, block, state);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_energy_count_get(uint32_t dv_ind, uint64_t *power, float *counter_resolution, uint64_t *timestamp) {
+    if (p_rsmi_dev_energy_count_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 16);
+    }
+    return p_rsmi_dev_energy_count_get(dv_ind, power, counter_resolution, timestamp);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_fan_reset(uint32_t dv_ind, uint32_t sensor_ind) {
+    if (p_rsmi_dev_fan_reset == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 17);
+    }
+    return p_rsmi_dev_fan_reset(dv_ind, sensor_ind);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_fan_rpms_get(uint32_t dv_ind, uint32_t sensor_ind, int64_t *speed) {
+    if (p_rsmi_dev_fan_rpms_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 18);
+    }
+    return p_rsmi_dev_fan_rpms_get(dv_ind, sensor_ind, speed);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_fan_speed_get(uint32_t dv_ind, uint32_t sensor_ind, int64_t *speed) {
+    if (p_rsmi_dev_fan_speed_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 19);
+    }
+    return p_rsmi_dev_fan_speed_get(dv_ind, sensor_ind, speed);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_fan_speed_max_get(uint32_t dv_ind, uint32_t sensor_ind, uint64_t *max_speed) {
+    if (p_rsmi_dev_fan_speed_max_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 20);
+    }
+    return p_rsmi_dev_fan_speed_max_get(dv_ind, sensor_ind, max_speed);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_fan_speed_set(uint32_t dv_ind, uint32_t sensor_ind, uint64_t speed) {
+    if (p_rsmi_dev_fan_speed_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 21);
+    }
+    return p_rsmi_dev_fan_speed_set(dv_ind, sensor_ind, speed);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_firmware_version_get(uint32_t dv_ind, rsmi_fw_block_t block, uint64_t *fw_version) {
+    if (p_rsmi_dev_firmware_version_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 22);
+    }
+    return p_rsmi_dev_firmware_version_get(dv_ind, block, fw_version);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_gpu_clk_freq_get(uint32_t dv_ind, rsmi_clk_type_t clk_type, rsmi_frequencies_t *f) {
+    if (p_rsmi_dev_gpu_clk_freq_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 23);
+    }
+    return p_rsmi_dev_gpu_clk_freq_get(dv_ind, clk_type, f);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_gpu_clk_freq_set(uint32_t dv_ind, rsmi_clk_type_t clk_type, uint64_t freq_bitmask) {
+    if (p_rsmi_dev_gpu_clk_freq_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 24);
+    }
+    return p_rsmi_dev_gpu_clk_freq_set(dv_ind, clk_type, freq_bitmask);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_gpu_metrics_info_get(uint32_t dv_ind, rsmi_gpu_metrics_t *pgpu_metrics) {
+    if (p_rsmi_dev_gpu_metrics_info_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 25);
+    }
+    return p_rsmi_dev_gpu_metrics_info_get(dv_ind, pgpu_metrics);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_id_get(uint32_t dv_ind, uint16_t *id) {
+    if (p_rsmi_dev_id_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 26);
+    }
+    return p_rsmi_dev_id_get(dv_ind, id);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_memory_busy_percent_get(uint32_t dv_ind, uint32_t *busy_percent) {
+    if (p_rsmi_dev_memory_busy_percent_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 27);
+    }
+    return p_rsmi_dev_memory_busy_percent_get(dv_ind, busy_percent);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_memory_reserved_pages_get(uint32_t dv_ind, uint32_t *num_pages, rsmi_retired_page_record_t *records) {
+    if (p_rsmi_dev_memory_reserved_pages_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 28);
+    }
+    return p_rsmi_dev_memory_reserved_pages_get(dv_ind, num_pages, records);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_memory_total_get(uint32_t dv_ind, rsmi_memory_type_t mem_type, uint64_t *total) {
+    if (p_rsmi_dev_memory_total_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 29);
+    }
+    return p_rsmi_dev_memory_total_get(dv_ind, mem_type, total);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_memory_usage_get(uint32_t dv_ind, rsmi_memory_type_t mem_type, uint64_t *used) {
+    if (p_rsmi_dev_memory_usage_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 30);
+    }
+    return p_rsmi_dev_memory_usage_get(dv_ind, mem_type, used);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_name_get(uint32_t dv_ind, char *name, size_t len) {
+    if (p_rsmi_dev_name_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 31);
+    }
+    return p_rsmi_dev_name_get(dv_ind, name, len);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_od_volt_curve_regions_get(uint32_t dv_ind, uint32_t *num_regions, rsmi_freq_volt_region_t *buffer) {
+    if (p_rsmi_dev_od_volt_curve_regions_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 32);
+    }
+    return p_rsmi_dev_od_volt_curve_regions_get(dv_ind, num_regions, buffer);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_od_volt_info_get(uint32_t dv_ind, rsmi_od_volt_freq_data_t *odv) {
+    if (p_rsmi_dev_od_volt_info_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 33);
+    }
+    return p_rsmi_dev_od_volt_info_get(dv_ind, odv);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_od_volt_info_set(uint32_t dv_ind, uint32_t vpoint, uint64_t clkvalue, uint64_t voltvalue) {
+    if (p_rsmi_dev_od_volt_info_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 34);
+    }
+    return p_rsmi_dev_od_volt_info_set(dv_ind, vpoint, clkvalue, voltvalue);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_overdrive_level_get(uint32_t dv_ind, uint32_t *od) {
+    if (p_rsmi_dev_overdrive_level_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 35);
+    }
+    return p_rsmi_dev_overdrive_level_get(dv_ind, od);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_overdrive_level_set(int32_t dv_ind, uint32_t od) {
+    if (p_rsmi_dev_overdrive_level_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 36);
+    }
+    return p_rsmi_dev_overdrive_level_set(dv_ind, od);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_pci_bandwidth_get(uint32_t dv_ind, rsmi_pcie_bandwidth_t *bandwidth) {
+    if (p_rsmi_dev_pci_bandwidth_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 37);
+    }
+    return p_rsmi_dev_pci_bandwidth_get(dv_ind, bandwidth);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_pci_bandwidth_set(uint32_t dv_ind, uint64_t bw_bitmask) {
+    if (p_rsmi_dev_pci_bandwidth_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 38);
+    }
+    return p_rsmi_dev_pci_bandwidth_set(dv_ind, bw_bitmask);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_pci_id_get(uint32_t dv_ind, uint64_t *bdfid) {
+    if (p_rsmi_dev_pci_id_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 39);
+    }
+    return p_rsmi_dev_pci_id_get(dv_ind, bdfid);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_pci_replay_counter_get(uint32_t dv_ind, uint64_t *counter) {
+    if (p_rsmi_dev_pci_replay_counter_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 40);
+    }
+    return p_rsmi_dev_pci_replay_counter_get(dv_ind, counter);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_pci_throughput_get(uint32_t dv_ind, uint64_t *sent, uint64_t *received, uint64_t *max_pkt_sz) {
+    if (p_rsmi_dev_pci_throughput_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 41);
+    }
+    return p_rsmi_dev_pci_throughput_get(dv_ind, sent, received, max_pkt_sz);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_perf_level_get(uint32_t dv_ind, rsmi_dev_perf_level_t *perf) {
+    if (p_rsmi_dev_perf_level_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 42);
+    }
+    return p_rsmi_dev_perf_level_get(dv_ind, perf);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_perf_level_set(int32_t dv_ind, rsmi_dev_perf_level_t perf_lvl) {
+    if (p_rsmi_dev_perf_level_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 43);
+    }
+    return p_rsmi_dev_perf_level_set(dv_ind, perf_lvl);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_power_ave_get(uint32_t dv_ind, uint32_t sensor_ind, uint64_t *power) {
+    if (p_rsmi_dev_power_ave_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 44);
+    }
+    return p_rsmi_dev_power_ave_get(dv_ind, sensor_ind, power);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_power_cap_get(uint32_t dv_ind, uint32_t sensor_ind, uint64_t *cap) {
+    if (p_rsmi_dev_power_cap_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 45);
+    }
+    return p_rsmi_dev_power_cap_get(dv_ind, sensor_ind, cap);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_power_cap_range_get(uint32_t dv_ind, uint32_t sensor_ind, uint64_t *max, uint64_t *min) {
+    if (p_rsmi_dev_power_cap_range_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 46);
+    }
+    return p_rsmi_dev_power_cap_range_get(dv_ind, sensor_ind, max, min);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_power_profile_presets_get(uint32_t dv_ind, uint32_t sensor_ind, rsmi_power_profile_status_t *status) {
+    if (p_rsmi_dev_power_profile_presets_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 47);
+    }
+    return p_rsmi_dev_power_profile_presets_get(dv_ind, sensor_ind, status);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_power_profile_set(uint32_t dv_ind, uint32_t reserved, rsmi_power_profile_preset_masks_t profile) {
+    if (p_rsmi_dev_power_profile_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 48);
+    }
+    return p_rsmi_dev_power_profile_set(dv_ind, reserved, profile);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_serial_number_get(uint32_t dv_ind, char *serial_num, uint32_t len) {
+    if (p_rsmi_dev_serial_number_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 49);
+    }
+    return p_rsmi_dev_serial_number_get(dv_ind, serial_num, len);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_sku_get(uint32_t dv_ind, uint16_t *sku) {
+    if (p_rsmi_dev_sku_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 50);
+    }
+    return p_rsmi_dev_sku_get(dv_ind, sku);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_subsystem_id_get(uint32_t dv_ind, uint16_t *id) {
+    if (p_rsmi_dev_subsystem_id_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 51);
+    }
+    return p_rsmi_dev_subsystem_id_get(dv_ind, id);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_subsystem_name_get(uint32_t dv_ind, char *name, size_t len) {
+    if (p_rsmi_dev_subsystem_name_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 52);
+    }
+    return p_rsmi_dev_subsystem_name_get(dv_ind, name, len);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_subsystem_vendor_id_get(uint32_t dv_ind, uint16_t *id) {
+    if (p_rsmi_dev_subsystem_vendor_id_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 53);
+    }
+    return p_rsmi_dev_subsystem_vendor_id_get(dv_ind, id);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_supported_func_iterator_close(rsmi_func_id_iter_handle_t *handle) {
+    if (p_rsmi_dev_supported_func_iterator_close == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 54);
+    }
+    return p_rsmi_dev_supported_func_iterator_close(handle);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_supported_func_iterator_open(uint32_t dv_ind, rsmi_func_id_iter_handle_t *handle) {
+    if (p_rsmi_dev_supported_func_iterator_open == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 55);
+    }
+    return p_rsmi_dev_supported_func_iterator_open(dv_ind, handle);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_supported_variant_iterator_open(rsmi_func_id_iter_handle_t obj_h, rsmi_func_id_iter_handle_t *var_iter) {
+    if (p_rsmi_dev_supported_variant_iterator_open == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 56);
+    }
+    return p_rsmi_dev_supported_variant_iterator_open(obj_h, var_iter);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_temp_metric_get(uint32_t dv_ind, uint32_t sensor_type, rsmi_temperature_metric_t metric, int64_t *temperature) {
+    if (p_rsmi_dev_temp_metric_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 57);
+    }
+    return p_rsmi_dev_temp_metric_get(dv_ind, sensor_type, metric, temperature);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_unique_id_get(uint32_t dv_ind, uint64_t *id) {
+    if (p_rsmi_dev_unique_id_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 58);
+    }
+    return p_rsmi_dev_unique_id_get(dv_ind, id);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_vbios_version_get(uint32_t dv_ind, char *vbios, uint32_t len) {
+    if (p_rsmi_dev_vbios_version_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 59);
+    }
+    return p_rsmi_dev_vbios_version_get(dv_ind, vbios, len);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_vendor_id_get(uint32_t dv_ind, uint16_t *id) {
+    if (p_rsmi_dev_vendor_id_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 60);
+    }
+    return p_rsmi_dev_vendor_id_get(dv_ind, id);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_vendor_name_get(uint32_t dv_ind, char *name, size_t len) {
+    if (p_rsmi_dev_vendor_name_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 61);
+    }
+    return p_rsmi_dev_vendor_name_get(dv_ind, name, len);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_volt_metric_get(uint32_t dv_ind, rsmi_voltage_type_t sensor_type, rsmi_voltage_metric_t metric, int64_t *voltage) {
+    if (p_rsmi_dev_volt_metric_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 62);
+    }
+    return p_rsmi_dev_volt_metric_get(dv_ind, sensor_type, metric, voltage);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_vram_vendor_get(uint32_t dv_ind, char *brand, uint32_t len) {
+    if (p_rsmi_dev_vram_vendor_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 63);
+    }
+    return p_rsmi_dev_vram_vendor_get(dv_ind, brand, len);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_xgmi_error_reset(uint32_t dv_ind) {
+    if (p_rsmi_dev_xgmi_error_reset == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 64);
+    }
+    return p_rsmi_dev_xgmi_error_reset(dv_ind);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_xgmi_error_status(uint32_t dv_ind, rsmi_xgmi_status_t *status) {
+    if (p_rsmi_dev_xgmi_error_status == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 65);
+    }
+    return p_rsmi_dev_xgmi_error_status(dv_ind, status);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_dev_xgmi_hive_id_get(uint32_t dv_ind, uint64_t *hive_id) {
+    if (p_rsmi_dev_xgmi_hive_id_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 66);
+    }
+    return p_rsmi_dev_xgmi_hive_id_get(dv_ind, hive_id);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_event_notification_get(int timeout_ms, uint32_t *num_elem, rsmi_evt_notification_data_t *data) {
+    if (p_rsmi_event_notification_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 67);
+    }
+    return p_rsmi_event_notification_get(timeout_ms, num_elem, data);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_event_notification_init(uint32_t dv_ind) {
+    if (p_rsmi_event_notification_init == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 68);
+    }
+    return p_rsmi_event_notification_init(dv_ind);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_event_notification_mask_set(uint32_t dv_ind, uint64_t mask) {
+    if (p_rsmi_event_notification_mask_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 69);
+    }
+    return p_rsmi_event_notification_mask_set(dv_ind, mask);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_event_notification_stop(uint32_t dv_ind) {
+    if (p_rsmi_event_notification_stop == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 70);
+    }
+    return p_rsmi_event_notification_stop(dv_ind);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_func_iter_next(rsmi_func_id_iter_handle_t handle) {
+    if (p_rsmi_func_iter_next == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 71);
+    }
+    return p_rsmi_func_iter_next(handle);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_func_iter_value_get(rsmi_func_id_iter_handle_t handle, rsmi_func_id_value_t *value) {
+    if (p_rsmi_func_iter_value_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 72);
+    }
+    return p_rsmi_func_iter_value_get(handle, value);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_init(uint64_t init_flags) {
+    if (p_rsmi_init == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 73);
+    }
+    return p_rsmi_init(init_flags);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_minmax_bandwidth_get(uint32_t dv_ind_src, uint32_t dv_ind_dst, uint64_t *min_bandwidth, uint64_t *max_bandwidth) {
+    if (p_rsmi_minmax_bandwidth_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 74);
+    }
+    return p_rsmi_minmax_bandwidth_get(dv_ind_src, dv_ind_dst, min_bandwidth, max_bandwidth);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_num_monitor_devices(uint32_t *num_devices) {
+    if (p_rsmi_num_monitor_devices == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 75);
+    }
+    return p_rsmi_num_monitor_devices(num_devices);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_perf_determinism_mode_set(uint32_t dv_ind, uint64_t clkvalue) {
+    if (p_rsmi_perf_determinism_mode_set == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 76);
+    }
+    return p_rsmi_perf_determinism_mode_set(dv_ind, clkvalue);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_shut_down(void) {
+    if (p_rsmi_shut_down == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 77);
+    }
+    return p_rsmi_shut_down();
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_status_string(rsmi_status_t status, const char **status_string) {
+    if (p_rsmi_status_string == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 78);
+    }
+    return p_rsmi_status_string(status, status_string);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_topo_get_link_type(uint32_t dv_ind_src, uint32_t dv_ind_dst, uint64_t *hops, RSMI_IO_LINK_TYPE *type) {
+    if (p_rsmi_topo_get_link_type == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 79);
+    }
+    return p_rsmi_topo_get_link_type(dv_ind_src, dv_ind_dst, hops, type);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_topo_get_link_weight(uint32_t dv_ind_src, uint32_t dv_ind_dst, uint64_t *weight) {
+    if (p_rsmi_topo_get_link_weight == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 80);
+    }
+    return p_rsmi_topo_get_link_weight(dv_ind_src, dv_ind_dst, weight);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_topo_get_numa_node_number(uint32_t dv_ind, uint32_t *numa_node) {
+    if (p_rsmi_topo_get_numa_node_number == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 81);
+    }
+    return p_rsmi_topo_get_numa_node_number(dv_ind, numa_node);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_topo_numa_affinity_get(uint32_t dv_ind, uint32_t *numa_node) {
+    if (p_rsmi_topo_numa_affinity_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 82);
+    }
+    return p_rsmi_topo_numa_affinity_get(dv_ind, numa_node);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_utilization_count_get(uint32_t dv_ind, rsmi_utilization_counter_t utilization_counters[], uint32_t count, uint64_t *timestamp) {
+    if (p_rsmi_utilization_count_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 83);
+    }
+    return p_rsmi_utilization_count_get(dv_ind, utilization_counters, count, timestamp);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_version_get(rsmi_version_t *version) {
+    if (p_rsmi_version_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 84);
+    }
+    return p_rsmi_version_get(version);
+}
+
+DCGM_DL_HIDDEN rsmi_status_t rsmi_version_str_get(rsmi_sw_component_t component, char *ver_str, uint32_t len) {
+    if (p_rsmi_version_str_get == NULL) {
+        return (rsmi_status_t)(DCGM_DL_STATUS_MISSING + 85);
+    }
+    return p_rsmi_version_str_get(component, ver_str, len);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiCreateVDevices(int device_id, int vdev_count, int *vdev_cus, int *vdev_mem_size) {
+    if (p_dmiCreateVDevices == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 86);
+    }
+    return p_dmiCreateVDevices(device_id, vdev_count, vdev_cus, vdev_mem_size);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiDestroySingleVDevice(int vDeviceId) {
+    if (p_dmiDestroySingleVDevice == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 87);
+    }
+    return p_dmiDestroySingleVDevice(vDeviceId);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiDestroyVDevices(int deviceId) {
+    if (p_dmiDestroyVDevices == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 88);
+    }
+    return p_dmiDestroyVDevices(deviceId);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetDevBusyPercent(int device_id, int *busy_percent) {
+    if (p_dmiGetDevBusyPercent == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 89);
+    }
+    return p_dmiGetDevBusyPercent(device_id, busy_percent);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetDeviceCount(int *count) {
+    if (p_dmiGetDeviceCount == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 90);
+    }
+    return p_dmiGetDeviceCount(count);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetDeviceInfo(int device_id, dmiDeviceInfo *device_info) {
+    if (p_dmiGetDeviceInfo == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 91);
+    }
+    return p_dmiGetDeviceInfo(device_id, device_info);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetDeviceRemainingInfo(int device_id, size_t *cus, size_t *memories) {
+    if (p_dmiGetDeviceRemainingInfo == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 92);
+    }
+    return p_dmiGetDeviceRemainingInfo(device_id, cus, memories);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetEncryptionVMStatus(bool *status) {
+    if (p_dmiGetEncryptionVMStatus == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 93);
+    }
+    return p_dmiGetEncryptionVMStatus(status);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetMaxVDeviceCount(int *count) {
+    if (p_dmiGetMaxVDeviceCount == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 94);
+    }
+    return p_dmiGetMaxVDeviceCount(count);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetStatusString(dmiStatus status, const char** status_string) {
+    if (p_dmiGetStatusString == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 95);
+    }
+    return p_dmiGetStatusString(status, status_string);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetVDevBusyPercent(int vdevice_id, int *busy_percent) {
+    if (p_dmiGetVDevBusyPercent == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 96);
+    }
+    return p_dmiGetVDevBusyPercent(vdevice_id, busy_percent);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetVDeviceCount(int *count) {
+    if (p_dmiGetVDeviceCount == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 97);
+    }
+    return p_dmiGetVDeviceCount(count);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiGetVDeviceInfo(int vdevice_id, dmiDeviceInfo *device_info) {
+    if (p_dmiGetVDeviceInfo == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 98);
+    }
+    return p_dmiGetVDeviceInfo(vdevice_id, device_info);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiSetEncryptionVMStatus(bool status) {
+    if (p_dmiSetEncryptionVMStatus == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 99);
+    }
+    return p_dmiSetEncryptionVMStatus(status);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiStartVDevice(int deviceId) {
+    if (p_dmiStartVDevice == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 100);
+    }
+    return p_dmiStartVDevice(deviceId);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiStopVDevice(int deviceId) {
+    if (p_dmiStopVDevice == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 101);
+    }
+    return p_dmiStopVDevice(deviceId);
+}
+
+DCGM_DL_HIDDEN dmiStatus dmiUpdateSingleVDevice(int vdeviceId, int vdev_cus, int vdev_mem_size) {
+    if (p_dmiUpdateSingleVDevice == NULL) {
+        return (dmiStatus)(DCGM_DL_STATUS_MISSING + 102);
+    }
+    return p_dmiUpdateSingleVDevice(vdeviceId, vdev_cus, vdev_mem_size);
+}
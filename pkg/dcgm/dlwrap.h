@@ -0,0 +1,29 @@
+#ifndef DCGM_DLWRAP_H
+#define DCGM_DLWRAP_H
+
+#include <stddef.h>
+
+// 缺失符号时包装函数返回的状态码基数，实际返回值为基数加符号下标
+#define DCGM_DL_STATUS_MISSING 0x10000000
+
+enum {
+    DCGM_DL_LIB_RSMI = 0,
+    DCGM_DL_LIB_DMI = 1,
+    DCGM_DL_LIB_COUNT = 2,
+};
+
+typedef struct {
+    const char *name;
+    int lib;
+    void **ptr;
+} dcgm_dl_sym_t;
+
+int dcgm_dl_open(int lib, const char *path, char *err, size_t err_len);
+void dcgm_dl_close(int lib);
+int dcgm_dl_is_open(int lib);
+const char *dcgm_dl_path(int lib);
+int dcgm_dl_sym_count(void);
+const dcgm_dl_sym_t *dcgm_dl_sym_at(int i);
+int dcgm_dl_sym_found(int i);
+
+#endif
@@ -0,0 +1,407 @@
+package dcgm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+var (
+	// ErrEnergyNotTracked 查询未启动能耗统计的设备时返回的错误
+	ErrEnergyNotTracked = errors.New("energy accounting not started for device")
+	// ErrEnergyMarkNotFound 查询不存在的能耗标记时返回的错误
+	ErrEnergyMarkNotFound = errors.New("energy mark not found")
+)
+
+// 默认的能耗统计配置
+const (
+	DefaultEnergyInterval  = 10 * time.Second
+	DefaultEnergyRetention = 24 * time.Hour
+)
+
+const (
+	// energyCounterWrap 硬件能量累加器为 32 位，驱动直接透传时每 2^32 个计数回绕一次；
+	// 分辨率约 15.3 µJ 时 300 W 下约 3.6 分钟回绕一次，采样间隔必须明显短于回绕周期
+	energyCounterWrap = 1 << 32
+	// energyMaxWatts 判定计数是否合理的功率上限，超过时视为驱动重置而不是回绕
+	energyMaxWatts = 5000
+	joulesPerKWh   = 3.6e6
+)
+
+// EnergyConfig 能耗统计配置：按 Interval 读取能量累加器，保留 Retention 内的累计值用于按时间窗口查询
+type EnergyConfig struct {
+	Devices   []int
+	Interval  time.Duration
+	Retention time.Duration
+}
+
+// EnergyReading 一段时间内设备消耗的能量，Wraps 与 Resets 为期间检测到的计数器回绕与驱动重置次数
+type EnergyReading struct {
+	Device int
+	Start  time.Time
+	End    time.Time
+	Joules float64
+	KWh    float64
+	Wraps  int
+	Resets int
+}
+
+// EnergyMark 调用方定义的能耗标记，记录打标记时每个设备的累计能量
+type EnergyMark struct {
+	Name string
+	Time time.Time
+}
+
+// energyPoint 某一时刻自统计开始以来的累计值
+type energyPoint struct {
+	t      time.Time
+	joules float64
+	wraps  int
+	resets int
+}
+
+// energyTrack 单个设备的累加器状态与累计值历史
+type energyTrack struct {
+	raw    uint64
+	res    float32
+	start  time.Time
+	cur    energyPoint
+	points []energyPoint
+}
+
+// accumulate 根据新的累加器读数更新累计值：读数变小且按 32 位回绕计算的功率合理时视为回绕，
+// 否则视为驱动重置（累加器从 0 重新计数）；计算出的功率超过 energyMaxWatts 的增量被丢弃
+func (t *energyTrack) accumulate(raw uint64, res float32, now time.Time) {
+	if !now.After(t.cur.t) {
+		return
+	}
+	maxJoules := energyMaxWatts * now.Sub(t.cur.t).Seconds()
+	toJoules := func(counts uint64) float64 { return float64(counts) * float64(res) / 1e6 }
+	var delta float64
+	switch {
+	case res != t.res:
+		// 分辨率变化说明驱动重新加载
+		t.cur.resets++
+		delta = toJoules(raw)
+	case raw >= t.raw:
+		delta = toJoules(raw - t.raw)
+		if delta > maxJoules {
+			t.cur.resets++
+			delta = 0
+		}
+	case t.raw < energyCounterWrap && toJoules(energyCounterWrap-t.raw+raw) <= maxJoules:
+		t.cur.wraps++
+		delta = toJoules(energyCounterWrap - t.raw + raw)
+	default:
+		t.cur.resets++
+		delta = toJoules(raw)
+	}
+	if delta > maxJoules {
+		delta = 0
+	}
+	t.raw, t.res = raw, res
+	t.cur.t = now
+	t.cur.joules += delta
+}
+
+// at 返回 ts 时刻的累计值，在相邻两个采样之间线性插值；ts 早于最早的采样时返回最早的采样
+func (t *energyTrack) at(ts time.Time) energyPoint {
+	points := append(append([]energyPoint{}, t.points...), t.cur)
+	if !ts.After(points[0].t) {
+		return points[0]
+	}
+	i := sort.Search(len(points), func(i int) bool { return points[i].t.After(ts) })
+	if i == len(points) {
+		return points[len(points)-1]
+	}
+	p0, p1 := points[i-1], points[i]
+	frac := float64(ts.Sub(p0.t)) / float64(p1.t.Sub(p0.t))
+	return energyPoint{t: ts, joules: p0.joules + (p1.joules-p0.joules)*frac, wraps: p0.wraps, resets: p0.resets}
+}
+
+// energyAccountant 后台读取能量累加器的能耗统计
+type energyAccountant struct {
+	mu     sync.Mutex
+	cfg    EnergyConfig
+	tracks map[int]*energyTrack
+	marks  map[string]energyMark
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// energyMark 标记时刻每个设备的累计值
+type energyMark struct {
+	t      time.Time
+	points map[int]energyPoint
+}
+
+var defaultEnergy = &energyAccountant{}
+
+// StartEnergyAccounting 启动能耗统计，记录此刻的累加器读数作为起点，后台按 cfg.Interval 读取以检测回绕；
+// 配置中为零的项取默认值，Devices 为空时统计所有设备。已经启动时先停止并丢弃已有数据与标记。
+func StartEnergyAccounting(cfg EnergyConfig) error {
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultEnergyInterval
+	}
+	if cfg.Retention == 0 {
+		cfg.Retention = DefaultEnergyRetention
+	}
+	if cfg.Interval < 0 || cfg.Retention < cfg.Interval {
+		return fmt.Errorf("Error StartEnergyAccounting:interval %v must be positive and not longer than retention %v", cfg.Interval, cfg.Retention)
+	}
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return fmt.Errorf("Error StartEnergyAccounting:%w", err)
+	}
+	if len(cfg.Devices) == 0 {
+		for i := 0; i < numDevices; i++ {
+			cfg.Devices = append(cfg.Devices, i)
+		}
+	}
+	tracks := map[int]*energyTrack{}
+	for _, dvInd := range cfg.Devices {
+		if dvInd < 0 || dvInd >= numDevices {
+			return fmt.Errorf("Error StartEnergyAccounting:device %d out of range [0, %d)", dvInd, numDevices)
+		}
+		raw, res, _, err := rsmiDevEnergyCountGet(dvInd)
+		if err != nil {
+			return fmt.Errorf("Error StartEnergyAccounting:device %d:%w", dvInd, err)
+		}
+		now := time.Now()
+		start := energyPoint{t: now}
+		tracks[dvInd] = &energyTrack{raw: raw, res: res, start: now, cur: start, points: []energyPoint{start}}
+	}
+	cfg.Devices = append([]int{}, cfg.Devices...)
+
+	StopEnergyAccounting()
+	e := defaultEnergy
+	e.mu.Lock()
+	e.cfg = cfg
+	e.tracks = tracks
+	e.marks = map[string]energyMark{}
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+	go e.run(cfg, e.stop, e.done)
+	e.mu.Unlock()
+	return nil
+}
+
+// StopEnergyAccounting 停止能耗统计并丢弃所有数据与标记，未启动时不做任何事
+func StopEnergyAccounting() {
+	e := defaultEnergy
+	e.mu.Lock()
+	stop, done := e.stop, e.done
+	e.stop, e.done = nil, nil
+	e.tracks, e.marks = nil, nil
+	e.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// EnergySinceStart 返回设备自能耗统计启动以来消耗的能量
+func EnergySinceStart(dvInd int) (EnergyReading, error) {
+	if err := defaultEnergy.update(dvInd); err != nil {
+		return EnergyReading{}, fmt.Errorf("Error EnergySinceStart:%w", err)
+	}
+	e := defaultEnergy
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	track, ok := e.tracks[dvInd]
+	if !ok {
+		return EnergyReading{}, fmt.Errorf("Error EnergySinceStart:device %d:%w", dvInd, ErrEnergyNotTracked)
+	}
+	return energyReading(dvInd, energyPoint{t: track.start}, track.cur), nil
+}
+
+// EnergyOverWindow 返回设备在最近 window 内消耗的能量；窗口超出保留时间或统计启动时间时从最早的数据开始，
+// 实际起点见 EnergyReading.Start
+func EnergyOverWindow(dvInd int, window time.Duration) (EnergyReading, error) {
+	if window <= 0 {
+		return EnergyReading{}, fmt.Errorf("Error EnergyOverWindow:window must be positive, got %v", window)
+	}
+	if err := defaultEnergy.update(dvInd); err != nil {
+		return EnergyReading{}, fmt.Errorf("Error EnergyOverWindow:%w", err)
+	}
+	e := defaultEnergy
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	track, ok := e.tracks[dvInd]
+	if !ok {
+		return EnergyReading{}, fmt.Errorf("Error EnergyOverWindow:device %d:%w", dvInd, ErrEnergyNotTracked)
+	}
+	return energyReading(dvInd, track.at(track.cur.t.Add(-window)), track.cur), nil
+}
+
+// SetEnergyMark 以 name 记录所有被统计设备此刻的累计能量，同名标记会被覆盖
+func SetEnergyMark(name string) (EnergyMark, error) {
+	if name == "" {
+		return EnergyMark{}, fmt.Errorf("Error SetEnergyMark:empty mark name")
+	}
+	e := defaultEnergy
+	e.mu.Lock()
+	devices := append([]int{}, e.cfg.Devices...)
+	running := e.stop != nil
+	e.mu.Unlock()
+	if !running {
+		return EnergyMark{}, fmt.Errorf("Error SetEnergyMark:%w", ErrEnergyNotTracked)
+	}
+	for _, dvInd := range devices {
+		if err := e.update(dvInd); err != nil {
+			return EnergyMark{}, fmt.Errorf("Error SetEnergyMark:%w", err)
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.marks == nil {
+		return EnergyMark{}, fmt.Errorf("Error SetEnergyMark:%w", ErrEnergyNotTracked)
+	}
+	mark := energyMark{t: time.Now(), points: map[int]energyPoint{}}
+	for dvInd, track := range e.tracks {
+		mark.points[dvInd] = track.cur
+	}
+	e.marks[name] = mark
+	return EnergyMark{Name: name, Time: mark.t}, nil
+}
+
+// EnergyMarks 返回所有能耗标记，按时间排序
+func EnergyMarks() []EnergyMark {
+	e := defaultEnergy
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	marks := make([]EnergyMark, 0, len(e.marks))
+	for name, mark := range e.marks {
+		marks = append(marks, EnergyMark{Name: name, Time: mark.t})
+	}
+	sort.Slice(marks, func(i, j int) bool { return marks[i].Time.Before(marks[j].Time) })
+	return marks
+}
+
+// DeleteEnergyMark 删除能耗标记
+func DeleteEnergyMark(name string) error {
+	e := defaultEnergy
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.marks[name]; !ok {
+		return fmt.Errorf("Error DeleteEnergyMark:%s:%w", name, ErrEnergyMarkNotFound)
+	}
+	delete(e.marks, name)
+	return nil
+}
+
+// EnergyBetweenMarks 返回设备在两个标记之间消耗的能量，to 为空时表示当前时刻
+func EnergyBetweenMarks(dvInd int, from, to string) (EnergyReading, error) {
+	if to == "" {
+		if err := defaultEnergy.update(dvInd); err != nil {
+			return EnergyReading{}, fmt.Errorf("Error EnergyBetweenMarks:%w", err)
+		}
+	}
+	e := defaultEnergy
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	track, ok := e.tracks[dvInd]
+	if !ok {
+		return EnergyReading{}, fmt.Errorf("Error EnergyBetweenMarks:device %d:%w", dvInd, ErrEnergyNotTracked)
+	}
+	point := func(name string) (energyPoint, error) {
+		mark, ok := e.marks[name]
+		if !ok {
+			return energyPoint{}, fmt.Errorf("Error EnergyBetweenMarks:%s:%w", name, ErrEnergyMarkNotFound)
+		}
+		return mark.points[dvInd], nil
+	}
+	start, err := point(from)
+	if err != nil {
+		return EnergyReading{}, err
+	}
+	end := track.cur
+	if to != "" {
+		if end, err = point(to); err != nil {
+			return EnergyReading{}, err
+		}
+	}
+	return energyReading(dvInd, start, end), nil
+}
+
+// EnergyDevices 返回正在统计能耗的设备
+func EnergyDevices() []int {
+	e := defaultEnergy
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stop == nil {
+		return nil
+	}
+	return append([]int{}, e.cfg.Devices...)
+}
+
+func energyReading(dvInd int, start, end energyPoint) EnergyReading {
+	joules := end.joules - start.joules
+	return EnergyReading{
+		Device: dvInd,
+		Start:  start.t,
+		End:    end.t,
+		Joules: joules,
+		KWh:    joules / joulesPerKWh,
+		Wraps:  end.wraps - start.wraps,
+		Resets: end.resets - start.resets,
+	}
+}
+
+// update 读取设备的能量累加器并更新累计值，硬件访问不持有锁
+func (e *energyAccountant) update(dvInd int) error {
+	e.mu.Lock()
+	_, ok := e.tracks[dvInd]
+	e.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("device %d:%w", dvInd, ErrEnergyNotTracked)
+	}
+	raw, res, _, err := rsmiDevEnergyCountGet(dvInd)
+	if err != nil {
+		return fmt.Errorf("device %d:%w", dvInd, err)
+	}
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	// 读取期间统计可能已被停止或重新启动
+	if track, ok := e.tracks[dvInd]; ok {
+		track.accumulate(raw, res, now)
+	}
+	return nil
+}
+
+// run 后台读取循环，每次读取后保存累计值并丢弃超出保留时间的数据（保留一个更早的点用于插值）
+func (e *energyAccountant) run(cfg EnergyConfig, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		for _, dvInd := range cfg.Devices {
+			if err := e.update(dvInd); err != nil {
+				glog.V(2).Infof("energy accounting:%v", err)
+			}
+		}
+		cutoff := time.Now().Add(-cfg.Retention)
+		e.mu.Lock()
+		for _, track := range e.tracks {
+			if len(track.points) == 0 || track.points[len(track.points)-1].t.Before(track.cur.t) {
+				track.points = append(track.points, track.cur)
+			}
+			i := 0
+			for i+1 < len(track.points) && !track.points[i+1].t.After(cutoff) {
+				i++
+			}
+			track.points = track.points[i:]
+		}
+		e.mu.Unlock()
+	}
+}
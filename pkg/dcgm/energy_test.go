@@ -0,0 +1,80 @@
+package dcgm
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEnergyTrackAccumulate(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 分辨率 1000 µJ 时每个计数为 1 mJ
+	tests := []struct {
+		name       string
+		prevRaw    uint64
+		raw        uint64
+		res        float32
+		dt         time.Duration
+		wantJoules float64
+		wantWraps  int
+		wantResets int
+	}{
+		{"increase", 100000, 400000, 1000, time.Second, 300, 0, 0},
+		{"32-bit wrap", energyCounterWrap - 100000, 200000, 1000, time.Second, 300, 1, 0},
+		// 按回绕计算约 1.3 MJ，超过 5000 W，视为驱动重置后从 0 计数
+		{"decrease implausible as a wrap", 3000000000, 1000, 1000, time.Second, 1, 0, 1},
+		{"64-bit counter decrease", energyCounterWrap + 100000, 1000, 1000, time.Second, 1, 0, 1},
+		{"increase above 5000 W", 100000, 100000 + 6000000, 1000, time.Second, 0, 0, 1},
+		{"increase below 5000 W", 100000, 100000 + 4000000, 1000, time.Second, 4000, 0, 0},
+		{"resolution change", 100000, 500, 2000, time.Second, 1, 0, 1},
+		{"resolution change above 5000 W", 100000, 6000000, 2000, time.Second, 0, 0, 1},
+		{"time not advancing", 100000, 400000, 1000, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track := &energyTrack{raw: tt.prevRaw, res: 1000, start: t0, cur: energyPoint{t: t0}}
+			track.accumulate(tt.raw, tt.res, t0.Add(tt.dt))
+			if math.Abs(track.cur.joules-tt.wantJoules) > 1e-6 || track.cur.wraps != tt.wantWraps || track.cur.resets != tt.wantResets {
+				t.Errorf("accumulate = %v J, %d wraps, %d resets, want %v J, %d wraps, %d resets",
+					track.cur.joules, track.cur.wraps, track.cur.resets, tt.wantJoules, tt.wantWraps, tt.wantResets)
+			}
+			if tt.dt > 0 && (track.raw != tt.raw || track.res != tt.res || !track.cur.t.Equal(t0.Add(tt.dt))) {
+				t.Errorf("state after accumulate = raw %d res %v at %v, want raw %d res %v at %v",
+					track.raw, track.res, track.cur.t, tt.raw, tt.res, t0.Add(tt.dt))
+			}
+		})
+	}
+}
+
+func TestEnergyTrackAt(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	track := &energyTrack{
+		start: t0,
+		points: []energyPoint{
+			{t: t0},
+			{t: t0.Add(10 * time.Second), joules: 100},
+		},
+		cur: energyPoint{t: t0.Add(20 * time.Second), joules: 300, wraps: 1},
+	}
+	tests := []struct {
+		name       string
+		ts         time.Time
+		wantT      time.Time
+		wantJoules float64
+		wantWraps  int
+	}{
+		{"before the first sample", t0.Add(-time.Minute), t0, 0, 0},
+		{"between samples", t0.Add(5 * time.Second), t0.Add(5 * time.Second), 50, 0},
+		{"on a sample", t0.Add(10 * time.Second), t0.Add(10 * time.Second), 100, 0},
+		{"between the last sample and the current value", t0.Add(15 * time.Second), t0.Add(15 * time.Second), 200, 0},
+		{"after the current value", t0.Add(time.Minute), t0.Add(20 * time.Second), 300, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := track.at(tt.ts)
+			if !p.t.Equal(tt.wantT) || math.Abs(p.joules-tt.wantJoules) > 1e-9 || p.wraps != tt.wantWraps {
+				t.Errorf("at = %v %v J %d wraps, want %v %v J %d wraps", p.t, p.joules, p.wraps, tt.wantT, tt.wantJoules, tt.wantWraps)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package dcgm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// DefaultEventStreamBuffer 事件流默认保留的最近记录数，供断线重连的客户端补发
+const DefaultEventStreamBuffer = 1000
+
+// EventPolicyViolation 事件流中表示策略违规的类型
+const EventPolicyViolation EventType = "POLICY_VIOLATION"
+
+// ErrEventStreamNotStarted 事件流未启动
+var ErrEventStreamNotStarted = errors.New("event stream not started")
+
+// StreamEvent 事件流中的一条记录，为设备事件通知或策略违规
+type StreamEvent struct {
+	//  ID 递增的序号，客户端重连时据此补发之后的记录，不超过 2^53 以便 JavaScript 客户端精确表示
+	ID uint64
+	//  DeviceID 设备索引号
+	DeviceID int
+	//  Time 发生时间
+	Time time.Time
+	//  Type 事件类型，策略违规为 POLICY_VIOLATION
+	Type EventType
+	//  Message 事件描述
+	Message string
+	//  Violation 策略违规详情，仅 Type 为 POLICY_VIOLATION 时有值
+	Violation *PolicyViolation `json:",omitempty"`
+}
+
+// ParseStreamEventTypes 解析事件流的类型过滤，在 ParseNotificationTypes 的基础上支持 POLICY_VIOLATION；
+// 空字符串或 all 表示全部类型
+func ParseStreamEventTypes(s string) ([]EventType, error) {
+	if strings.TrimSpace(s) == "" || strings.EqualFold(strings.TrimSpace(s), "all") {
+		return append(append([]EventType{}, AllEventTypes...), EventPolicyViolation), nil
+	}
+	var types []EventType
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if strings.EqualFold(item, string(EventPolicyViolation)) {
+			types = append(types, EventPolicyViolation)
+			continue
+		}
+		parsed, err := ParseNotificationTypes(item)
+		if err != nil {
+			return nil, fmt.Errorf("Error ParseStreamEventTypes:unknown event type %q", item)
+		}
+		types = append(types, parsed...)
+	}
+	return types, nil
+}
+
+type streamSubscriber struct {
+	devices map[int]bool
+	types   map[EventType]bool
+	ch      chan StreamEvent
+	// release 注销 ctx 取消时执行的退订
+	release func() bool
+}
+
+func (s *streamSubscriber) match(event StreamEvent) bool {
+	return (len(s.devices) == 0 || s.devices[event.DeviceID]) && (len(s.types) == 0 || s.types[event.Type])
+}
+
+// eventStream 汇总所有设备的事件通知与策略违规，保存最近的记录并推送给订阅者
+type eventStream struct {
+	mu         sync.Mutex
+	size       int
+	buffer     []StreamEvent
+	nextID     uint64
+	subs       map[int]*streamSubscriber
+	nextSub    int
+	cancel     context.CancelFunc
+	unregister func()
+	done       chan struct{}
+}
+
+var defaultEventStream = &eventStream{subs: map[int]*streamSubscriber{}}
+
+// StartEventStream 启动事件流：订阅所有设备的全部事件通知并接收所有策略的违规，保存最近 size 条记录。
+// 后端不支持事件通知时只推送策略违规。已启动时先停止，已保存的记录保留
+func StartEventStream(size int) error {
+	if size == 0 {
+		size = DefaultEventStreamBuffer
+	}
+	if size < 0 {
+		return fmt.Errorf("Error StartEventStream:invalid buffer size %d", size)
+	}
+	StopEventStream()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s := defaultEventStream
+	s.mu.Lock()
+	if s.nextID == 0 {
+		// 序号从启动时的微秒时间戳开始，服务重启后客户端持有的旧序号小于新记录的序号，重连时补发重启后的全部记录
+		s.nextID = uint64(time.Now().UnixMicro())
+	}
+	s.size = size
+	if len(s.buffer) > size {
+		s.buffer = append([]StreamEvent{}, s.buffer[len(s.buffer)-size:]...)
+	}
+	s.cancel = cancel
+	s.done = done
+	s.unregister = RegisterPolicyCallback("", func(v PolicyViolation) {
+		s.publish(StreamEvent{DeviceID: v.DeviceID, Time: v.Time, Type: EventPolicyViolation, Message: v.Message, Violation: &v})
+	})
+	s.mu.Unlock()
+	events, err := Subscribe(ctx, nil, nil)
+	if err != nil {
+		glog.Warningf("事件流不包含设备事件通知: %v", err)
+		close(done)
+		return nil
+	}
+	go s.run(events, done)
+	return nil
+}
+
+// StopEventStream 停止事件流并关闭所有订阅，未启动时不做任何事
+func StopEventStream() {
+	s := defaultEventStream
+	s.mu.Lock()
+	cancel, unregister, done := s.cancel, s.unregister, s.done
+	s.cancel, s.unregister, s.done = nil, nil, nil
+	for id := range s.subs {
+		s.removeLocked(id)
+	}
+	s.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	unregister()
+	cancel()
+	<-done
+}
+
+func (s *eventStream) run(events <-chan Event, done chan struct{}) {
+	defer close(done)
+	for event := range events {
+		s.publish(StreamEvent{DeviceID: event.DeviceID, Time: event.Time, Type: event.Type, Message: event.Message})
+	}
+}
+
+// publish 为记录分配序号、保存并推送给匹配的订阅者；订阅者接收过慢时关闭其通道，
+// 客户端重连后从保存的记录中补发
+func (s *eventStream) publish(event StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel == nil {
+		return
+	}
+	s.nextID++
+	event.ID = s.nextID
+	s.buffer = append(s.buffer, event)
+	if len(s.buffer) > s.size {
+		s.buffer = s.buffer[len(s.buffer)-s.size:]
+	}
+	for id, sub := range s.subs {
+		if !sub.match(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			glog.Warningf("event stream subscriber too slow, closed at event %d", event.ID)
+			s.removeLocked(id)
+		}
+	}
+}
+
+// SubscribeEventStream 订阅事件流，devices 与 eventTypes 为空时不过滤。after 为客户端收到的最后一条记录的序号，
+// 先补发仍保存着的之后的记录再推送新记录；after 大于当前序号（时钟回拨后重启）时补发全部保存的记录。
+// 返回的通道在 ctx 取消、事件流停止或接收过慢时关闭
+func SubscribeEventStream(ctx context.Context, after uint64, devices []int, eventTypes []EventType) (<-chan StreamEvent, error) {
+	sub := &streamSubscriber{devices: map[int]bool{}, types: map[EventType]bool{}}
+	for _, dvInd := range devices {
+		sub.devices[dvInd] = true
+	}
+	for _, t := range eventTypes {
+		if _, ok := t.value(); !ok && t != EventPolicyViolation {
+			return nil, fmt.Errorf("Error SubscribeEventStream:unknown event type %q", t)
+		}
+		sub.types[t] = true
+	}
+	s := defaultEventStream
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel == nil {
+		return nil, fmt.Errorf("Error SubscribeEventStream:%w", ErrEventStreamNotStarted)
+	}
+	if after > s.nextID {
+		after = 0
+	}
+	var replay []StreamEvent
+	for _, event := range s.buffer {
+		if event.ID > after && sub.match(event) {
+			replay = append(replay, event)
+		}
+	}
+	sub.ch = make(chan StreamEvent, len(replay)+eventBufferSize)
+	for _, event := range replay {
+		sub.ch <- event
+	}
+	s.nextSub++
+	id := s.nextSub
+	s.subs[id] = sub
+	sub.release = context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.removeLocked(id)
+	})
+	return sub.ch, nil
+}
+
+// removeLocked 移除订阅并关闭其通道，调用方需持有 s.mu
+func (s *eventStream) removeLocked(id int) {
+	sub, ok := s.subs[id]
+	if !ok {
+		return
+	}
+	delete(s.subs, id)
+	sub.release()
+	close(sub.ch)
+}
+
+// EventStreamLastID 返回最后一条记录的序号，以此订阅可只接收之后的记录且不会遗漏
+func EventStreamLastID() uint64 {
+	s := defaultEventStream
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nextID
+}
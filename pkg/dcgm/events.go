@@ -0,0 +1,357 @@
+package dcgm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// EventType 事件通知类型，库报告了未知编号的事件时为 EVENT_<编号>
+type EventType string
+
+const (
+	// EventVMFault GPU 页错误
+	EventVMFault EventType = "VM_FAULT"
+	// EventThermalThrottle 温度降频
+	EventThermalThrottle EventType = "THERMAL_THROTTLE"
+	// EventGPUPreReset 设备即将复位
+	EventGPUPreReset EventType = "GPU_PRE_RESET"
+	// EventGPUPostReset 设备复位完成
+	EventGPUPostReset EventType = "GPU_POST_RESET"
+)
+
+// AllEventTypes 库定义的全部事件通知类型
+var AllEventTypes = []EventType{EventVMFault, EventThermalThrottle, EventGPUPreReset, EventGPUPostReset}
+
+var eventTypeValues = map[EventType]RSMIEvtNotificationType{
+	EventVMFault:         RSMI_EVT_NOTIF_VMFAULT,
+	EventThermalThrottle: RSMI_EVT_NOTIF_THERMAL_THROTTLE,
+	EventGPUPreReset:     RSMI_EVT_NOTIF_GPU_PRE_RESET,
+	EventGPUPostReset:    RSMI_EVT_NOTIF_GPU_POST_RESET,
+}
+
+// eventTypeAliases 兼容旧名称，GPU_RESET 表示复位前后两个事件
+var eventTypeAliases = map[string][]EventType{
+	"VMFAULT":   {EventVMFault},
+	"GPU_RESET": {EventGPUPreReset, EventGPUPostReset},
+}
+
+// eventPollTimeout 后台收集事件通知的等待时间，也是取消订阅后停止收集的最长延迟
+const eventPollTimeout = 500 * time.Millisecond
+
+// eventBufferSize 每个订阅的事件缓冲区大小，缓冲区满时丢弃新事件
+const eventBufferSize = 256
+
+// Event 设备事件通知
+type Event struct {
+	//  DeviceID 设备索引号
+	DeviceID int
+	//  Time 收到事件的时间
+	Time time.Time
+	//  Type 事件类型
+	Type EventType
+	//  Message 库给出的事件描述
+	Message string
+}
+
+// eventTypeOf 返回事件编号对应的类型
+func eventTypeOf(t RSMIEvtNotificationType) EventType {
+	for name, value := range eventTypeValues {
+		if value == t {
+			return name
+		}
+	}
+	return EventType(fmt.Sprintf("EVENT_%d", t))
+}
+
+// value 返回事件类型的编号，EVENT_<编号> 形式用于订阅库新增的事件
+func (t EventType) value() (RSMIEvtNotificationType, bool) {
+	if v, ok := eventTypeValues[t]; ok {
+		return v, true
+	}
+	if s, ok := strings.CutPrefix(string(t), "EVENT_"); ok {
+		if n, err := strconv.Atoi(s); err == nil && n >= 1 && n <= 64 {
+			return RSMIEvtNotificationType(n), true
+		}
+	}
+	return 0, false
+}
+
+// eventMask 事件通知掩码，第 n-1 位对应编号为 n 的事件
+func eventMask(types []EventType) int64 {
+	var mask int64
+	for _, t := range types {
+		if v, ok := t.value(); ok {
+			mask |= 1 << uint(v-1)
+		}
+	}
+	return mask
+}
+
+// ParseNotificationTypes 解析逗号分隔的事件类型，不区分大小写，支持 VMFAULT、GPU_RESET 等旧名称与 EVENT_<编号>；
+// 空字符串或 all 表示全部类型
+func ParseNotificationTypes(s string) ([]EventType, error) {
+	if strings.TrimSpace(s) == "" || strings.EqualFold(strings.TrimSpace(s), "all") {
+		return append([]EventType{}, AllEventTypes...), nil
+	}
+	var types []EventType
+	seen := map[EventType]bool{}
+	for _, item := range strings.Split(s, ",") {
+		name := strings.ToUpper(strings.TrimSpace(item))
+		if name == "" {
+			continue
+		}
+		matched, ok := eventTypeAliases[name]
+		if !ok {
+			if _, valid := EventType(name).value(); !valid {
+				return nil, fmt.Errorf("Error ParseNotificationTypes:unknown event type %q", item)
+			}
+			matched = []EventType{EventType(name)}
+		}
+		for _, t := range matched {
+			if !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+	}
+	return types, nil
+}
+
+type eventSubscriber struct {
+	id      int
+	devices map[int]bool
+	types   map[EventType]bool
+	ch      chan Event
+	// dropped 缓冲区满时丢弃的事件数，在订阅关闭时报告
+	dropped int
+	// release 注销 ctx 取消时执行的退订
+	release func() bool
+}
+
+// close 关闭订阅的通道，期间丢弃过事件时报告丢弃的数量
+func (s *eventSubscriber) close() {
+	s.release()
+	close(s.ch)
+	if s.dropped > 0 {
+		glog.Warningf("event subscriber %d closed, dropped %d events", s.id, s.dropped)
+	}
+}
+
+// eventDispatcher 事件通知的收集是全局的，由一个后台循环统一收集并分发给各订阅，
+// 设备的通知掩码为订阅该设备的所有订阅所需类型的并集
+type eventDispatcher struct {
+	mu     sync.Mutex
+	subs   map[int]*eventSubscriber
+	nextID int
+	masks  map[int]int64
+	// done 收集循环运行时不为空，循环退出时关闭
+	done chan struct{}
+}
+
+var defaultEvents = &eventDispatcher{subs: map[int]*eventSubscriber{}, masks: map[int]int64{}}
+
+// Subscribe 订阅设备的事件通知，devices 为空时订阅所有设备，eventTypes 为空时订阅全部类型。
+// 返回的通道在 ctx 取消或 ShutDown 时关闭，此时不再被任何订阅使用的设备停止事件通知；
+// 接收方处理过慢导致缓冲区满时丢弃新事件，丢弃的数量在订阅关闭时记录到日志
+func Subscribe(ctx context.Context, devices []int, eventTypes []EventType) (<-chan Event, error) {
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return nil, fmt.Errorf("Error Subscribe:%w", err)
+	}
+	if len(devices) == 0 {
+		for i := 0; i < numDevices; i++ {
+			devices = append(devices, i)
+		}
+	}
+	sub := &eventSubscriber{devices: map[int]bool{}, types: map[EventType]bool{}, ch: make(chan Event, eventBufferSize)}
+	for _, dvInd := range devices {
+		if dvInd < 0 || dvInd >= numDevices {
+			return nil, fmt.Errorf("Error Subscribe:device %d out of range [0, %d)", dvInd, numDevices)
+		}
+		sub.devices[dvInd] = true
+	}
+	if len(eventTypes) == 0 {
+		eventTypes = AllEventTypes
+	}
+	for _, t := range eventTypes {
+		if _, ok := t.value(); !ok {
+			return nil, fmt.Errorf("Error Subscribe:unknown event type %q", t)
+		}
+		sub.types[t] = true
+	}
+	mask := eventMask(eventTypes)
+
+	e := defaultEvents
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for dvInd := range sub.devices {
+		old, ok := e.masks[dvInd]
+		if !ok {
+			if err = rsmiEventNotificationInit(dvInd); err != nil {
+				break
+			}
+			e.masks[dvInd] = 0
+		}
+		if !ok || old|mask != old {
+			if err = rsmiEventNotificationMaskSet(dvInd, old|mask); err != nil {
+				break
+			}
+			e.masks[dvInd] = old | mask
+		}
+	}
+	if err != nil {
+		// 本次订阅尚未登记，按已有订阅恢复掩码，并停止仅为本次订阅初始化的设备
+		for dvInd := range sub.devices {
+			e.updateMaskLocked(dvInd)
+		}
+		return nil, fmt.Errorf("Error Subscribe:%w", err)
+	}
+	e.nextID++
+	id := e.nextID
+	sub.id = id
+	e.subs[id] = sub
+	e.startLoopLocked()
+	sub.release = context.AfterFunc(ctx, func() { e.unsubscribe(id) })
+	return sub.ch, nil
+}
+
+// updateMaskLocked 按剩余订阅重新计算设备的掩码，没有订阅时停止该设备的事件通知，调用方需持有 e.mu
+func (e *eventDispatcher) updateMaskLocked(dvInd int) {
+	old, ok := e.masks[dvInd]
+	if !ok {
+		return
+	}
+	var types []EventType
+	for _, sub := range e.subs {
+		if sub.devices[dvInd] {
+			for t := range sub.types {
+				types = append(types, t)
+			}
+		}
+	}
+	mask := eventMask(types)
+	if mask == 0 {
+		delete(e.masks, dvInd)
+		if err := rsmiEventNotificationStop(dvInd); err != nil {
+			glog.Warningf("device %d: %v", dvInd, err)
+		}
+		return
+	}
+	if mask != old {
+		if err := rsmiEventNotificationMaskSet(dvInd, mask); err != nil {
+			glog.Warningf("device %d: %v", dvInd, err)
+		}
+		e.masks[dvInd] = mask
+	}
+}
+
+func (e *eventDispatcher) unsubscribe(id int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sub, ok := e.subs[id]
+	if !ok {
+		return
+	}
+	delete(e.subs, id)
+	sub.close()
+	devices := make([]int, 0, len(sub.devices))
+	for dvInd := range sub.devices {
+		devices = append(devices, dvInd)
+	}
+	sort.Ints(devices)
+	for _, dvInd := range devices {
+		e.updateMaskLocked(dvInd)
+	}
+}
+
+// closeEvents 关闭所有订阅并停止事件通知，在 ShutDown 时调用
+func closeEvents() {
+	e := defaultEvents
+	e.mu.Lock()
+	for id, sub := range e.subs {
+		delete(e.subs, id)
+		sub.close()
+	}
+	for dvInd := range e.masks {
+		delete(e.masks, dvInd)
+		rsmiEventNotificationStop(dvInd)
+	}
+	// 循环在本次收集返回后发现没有订阅而退出
+	done := e.done
+	e.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+// startLoopLocked 启动收集循环，调用方需持有 e.mu。循环在没有订阅时自行退出，
+// 已退订但仍在等待本次收集返回的循环会被继续使用，保证同一时刻只有一个循环在收集事件
+func (e *eventDispatcher) startLoopLocked() {
+	if e.done != nil {
+		return
+	}
+	e.done = make(chan struct{})
+	go e.run(e.done)
+}
+
+// running 循环继续运行时返回 true，没有订阅时登记循环退出并返回 false
+func (e *eventDispatcher) running() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.subs) == 0 {
+		e.done = nil
+		return false
+	}
+	return true
+}
+
+func (e *eventDispatcher) run(done chan struct{}) {
+	defer close(done)
+	for e.running() {
+		_, datas, err := rsmiEventNotificationGet(int(eventPollTimeout / time.Millisecond))
+		if err != nil {
+			glog.V(2).Infof("collect events:%v", err)
+			time.Sleep(eventPollTimeout)
+			continue
+		}
+		if len(datas) > 0 {
+			e.dispatch(datas, time.Now())
+		}
+	}
+}
+
+// dispatch 将收集到的事件发送给订阅了该设备与类型的订阅
+func (e *eventDispatcher) dispatch(datas []RSMIEEvtNotificationData, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, data := range datas {
+		event := Event{
+			DeviceID: int(data.DvInd),
+			Time:     now,
+			Type:     eventTypeOf(data.Event),
+			Message:  string(bytes.TrimRight(data.Message[:], "\x00")),
+		}
+		for _, sub := range e.subs {
+			if !sub.devices[event.DeviceID] || !sub.types[event.Type] {
+				continue
+			}
+			select {
+			case sub.ch <- event:
+			default:
+				// 只在开始丢弃时记录一次，丢弃总数在订阅关闭时报告
+				if sub.dropped == 0 {
+					glog.Warningf("event subscriber %d too slow, dropping events", sub.id)
+				}
+				sub.dropped++
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package dcgm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// initEventScenario 以示例场景初始化模拟后端，场景时间随真实时间推进，测试期间不会触发场景中的事件
+func initEventScenario(t *testing.T) *FakeBackend {
+	t.Helper()
+	if err := InitWithBackendName(BackendFake + ":" + fakeScenarioPath); err != nil {
+		t.Fatalf("InitWithBackendName: %v", err)
+	}
+	t.Cleanup(func() { ShutDown() })
+	return getBackend().(*FakeBackend)
+}
+
+// fakeEventMasks 返回模拟后端当前启用事件通知的设备与掩码
+func fakeEventMasks(b *FakeBackend) map[int]int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	masks := map[int]int64{}
+	for dvInd, mask := range b.eventMasks {
+		masks[dvInd] = mask
+	}
+	return masks
+}
+
+// waitClosed 等待通道关闭，丢弃其中剩余的事件
+func waitClosed(t *testing.T, name string, ch <-chan Event) {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("%s: channel not closed", name)
+		}
+	}
+}
+
+func TestSubscribeMasks(t *testing.T) {
+	b := initEventScenario(t)
+	thermal := eventMask([]EventType{EventThermalThrottle})
+	vmFault := eventMask([]EventType{EventVMFault})
+	reset := eventMask([]EventType{EventGPUPreReset, EventGPUPostReset})
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	defer cancelA()
+	a, err := Subscribe(ctxA, []int{0}, []EventType{EventThermalThrottle})
+	if err != nil {
+		t.Fatalf("Subscribe a: %v", err)
+	}
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	bCh, err := Subscribe(ctxB, []int{0, 1}, []EventType{EventVMFault, EventGPUPreReset, EventGPUPostReset})
+	if err != nil {
+		t.Fatalf("Subscribe b: %v", err)
+	}
+	// 设备的掩码为订阅该设备的所有订阅所需类型的并集
+	if got, want := fakeEventMasks(b), map[int]int64{0: thermal | vmFault | reset, 1: vmFault | reset}; !reflect.DeepEqual(got, want) {
+		t.Errorf("masks with both subscriptions = %v, want %v", got, want)
+	}
+
+	cancelA()
+	waitClosed(t, "a after cancel", a)
+	if got, want := fakeEventMasks(b), map[int]int64{0: vmFault | reset, 1: vmFault | reset}; !reflect.DeepEqual(got, want) {
+		t.Errorf("masks after cancelling a = %v, want %v", got, want)
+	}
+
+	// 没有订阅的设备停止事件通知
+	cancelB()
+	waitClosed(t, "b after cancel", bCh)
+	if got := fakeEventMasks(b); len(got) != 0 {
+		t.Errorf("masks after cancelling every subscription = %v, want none", got)
+	}
+}
+
+func TestSubscribeInvalid(t *testing.T) {
+	b := initEventScenario(t)
+	tests := []struct {
+		name    string
+		devices []int
+		types   []EventType
+	}{
+		{"device out of range", []int{0, 9}, nil},
+		{"unknown type", []int{0}, []EventType{"NO_SUCH_EVENT"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Subscribe(context.Background(), tt.devices, tt.types); err == nil {
+				t.Fatal("Subscribe succeeded, want an error")
+			}
+			if got := fakeEventMasks(b); len(got) != 0 {
+				t.Errorf("masks after a failed Subscribe = %v, want none", got)
+			}
+		})
+	}
+}
+
+func TestSubscribeShutDown(t *testing.T) {
+	b := initEventScenario(t)
+	events, err := Subscribe(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if got := fakeEventMasks(b); len(got) != 3 {
+		t.Errorf("masks = %v, want all 3 devices", got)
+	}
+	ShutDown()
+	waitClosed(t, "after ShutDown", events)
+	if got := fakeEventMasks(b); len(got) != 0 {
+		t.Errorf("masks after ShutDown = %v, want none", got)
+	}
+}
+
+func TestDispatchDropsWhenFull(t *testing.T) {
+	initEventScenario(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := Subscribe(ctx, []int{0}, []EventType{EventThermalThrottle})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	datas := make([]RSMIEEvtNotificationData, eventBufferSize+3)
+	for i := range datas {
+		datas[i] = RSMIEEvtNotificationData{DvInd: 0, Event: RSMI_EVT_NOTIF_THERMAL_THROTTLE}
+	}
+	// 其他设备与类型的事件不发送给订阅
+	datas = append(datas,
+		RSMIEEvtNotificationData{DvInd: 1, Event: RSMI_EVT_NOTIF_THERMAL_THROTTLE},
+		RSMIEEvtNotificationData{DvInd: 0, Event: RSMI_EVT_NOTIF_VMFAULT})
+	defaultEvents.dispatch(datas, time.Now())
+
+	defaultEvents.mu.Lock()
+	var dropped int
+	for _, sub := range defaultEvents.subs {
+		dropped = sub.dropped
+	}
+	defaultEvents.mu.Unlock()
+	if dropped != 3 || len(events) != eventBufferSize {
+		t.Errorf("dropped %d, buffered %d, want 3 and %d", dropped, len(events), eventBufferSize)
+	}
+	cancel()
+	waitClosed(t, "after cancel", events)
+}
@@ -0,0 +1,1429 @@
+package dcgm
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BackendFake 纯 Go 的模拟后端，配置为场景文件路径，为空时使用 DefaultFakeScenario
+const BackendFake = "fake"
+
+func init() {
+	RegisterBackend(BackendFake, func(config string) (Backend, error) {
+		if config == "" {
+			return NewFakeBackend(DefaultFakeScenario()), nil
+		}
+		return NewFakeBackendFromFile(config)
+	})
+}
+
+const (
+	fakeMiB          = 1024 * 1024
+	fakeVendorID     = 0x1d94
+	fakeMaxPktSize   = 256
+	fakeEnergyResUJ  = 15.3
+	fakeTempCritical = 95
+	fakeTempMax      = 90
+	fakeTempEmerg    = 105
+)
+
+// FakeBackend 基于场景文件的模拟后端，所有随时间变化的数值都以场景开始时间为起点计算
+type FakeBackend struct {
+	mu       sync.Mutex
+	scenario *FakeScenario
+	// clockMu 保护 start 与 nowFunc，与 mu 分开以便持有 mu 时读取场景时间
+	clockMu sync.RWMutex
+	start   time.Time
+	nowFunc func() time.Time
+
+	perfLevels  []RSMIDevPerfLevel
+	overdrive   []int
+	sclkLevel   []int
+	socclkLevel []int
+	mclkLevel   []int
+	fanSpeed    []int64
+	profiles    []RSMIPowerProfilePresetMasks
+	vdevices    map[int]*fakeVDevice
+	encryption  bool
+
+	counters    map[EventHandle]*fakeCounter
+	nextCounter EventHandle
+	eventMasks  map[int]int64
+	eventsSent  []bool
+	xgmiRead    []float64 // 每个设备上一次读取或清除 XGMI 错误状态的场景时间
+}
+
+type fakeVDevice struct {
+	deviceID    int
+	cus         int
+	memBytes    uint64
+	containerID uint64
+	busy        FakeValue
+	running     bool
+}
+
+type fakeCounter struct {
+	dvInd     int
+	eventType RSMIEventType
+	running   bool
+	startedAt time.Time
+	elapsed   time.Duration
+}
+
+// NewFakeBackend 使用场景创建模拟后端
+func NewFakeBackend(scenario *FakeScenario) *FakeBackend {
+	n := len(scenario.Devices)
+	b := &FakeBackend{
+		scenario:    scenario,
+		nowFunc:     time.Now,
+		perfLevels:  make([]RSMIDevPerfLevel, n),
+		overdrive:   make([]int, n),
+		sclkLevel:   make([]int, n),
+		socclkLevel: make([]int, n),
+		mclkLevel:   make([]int, n),
+		fanSpeed:    make([]int64, n),
+		profiles:    make([]RSMIPowerProfilePresetMasks, n),
+		vdevices:    map[int]*fakeVDevice{},
+		encryption:  scenario.EncryptionVM,
+		counters:    map[EventHandle]*fakeCounter{},
+		nextCounter: 1,
+		eventMasks:  map[int]int64{},
+		eventsSent:  make([]bool, len(scenario.Events)),
+		xgmiRead:    make([]float64, n),
+	}
+	b.start = b.nowFunc()
+	for i, d := range scenario.Devices {
+		b.perfLevels[i] = validLevels[strings.ToLower(d.PerfLevel)]
+		b.sclkLevel[i] = d.Sclk.Current
+		b.socclkLevel[i] = d.Socclk.Current
+		b.mclkLevel[i] = d.Mclk.Current
+		b.fanSpeed[i] = -1
+		b.profiles[i] = RSMIPowerProfPrstBootupDefault
+		for slot, v := range d.VDevices {
+			b.vdevices[i*d.MaxVDevices+slot] = &fakeVDevice{
+				deviceID:    i,
+				cus:         v.ComputeUnits,
+				memBytes:    uint64(v.MemoryMiB) * fakeMiB,
+				containerID: v.ContainerID,
+				busy:        v.Busy,
+				running:     true,
+			}
+		}
+	}
+	return b
+}
+
+// NewFakeBackendFromFile 从场景文件创建模拟后端
+func NewFakeBackendFromFile(path string) (*FakeBackend, error) {
+	scenario, err := LoadFakeScenario(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewFakeBackend(scenario), nil
+}
+
+// SetClock 替换时间来源并把场景起点重置为当前时间，便于测试中精确控制时间
+func (b *FakeBackend) SetClock(now func() time.Time) {
+	start := now()
+	b.clockMu.Lock()
+	defer b.clockMu.Unlock()
+	b.nowFunc = now
+	b.start = start
+}
+
+// Scenario 返回当前使用的场景
+func (b *FakeBackend) Scenario() *FakeScenario {
+	return b.scenario
+}
+
+// now 返回时间来源给出的当前时间
+func (b *FakeBackend) now() time.Time {
+	b.clockMu.RLock()
+	nowFunc := b.nowFunc
+	b.clockMu.RUnlock()
+	return nowFunc()
+}
+
+// sinceStart 返回 t 距场景开始的时间
+func (b *FakeBackend) sinceStart(t time.Time) time.Duration {
+	b.clockMu.RLock()
+	defer b.clockMu.RUnlock()
+	return t.Sub(b.start)
+}
+
+// elapsed 返回场景开始以来的秒数
+func (b *FakeBackend) elapsed() float64 {
+	return b.sinceStart(b.now()).Seconds()
+}
+
+func (b *FakeBackend) device(fn string, dvInd int) (*FakeDeviceSpec, error) {
+	if dvInd < 0 || dvInd >= len(b.scenario.Devices) {
+		return nil, fmt.Errorf("Error %s:RSMI_STATUS_INVALID_ARGS: device index %d out of range", fn, dvInd)
+	}
+	return &b.scenario.Devices[dvInd], nil
+}
+
+func fakeNotSupported(fn string) error {
+	return fmt.Errorf("Error %s:%w", fn, ErrNotSupported)
+}
+
+func fakeClamp(v float64, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func (b *FakeBackend) ProbeDeviceCount() int {
+	return len(b.scenario.Devices)
+}
+
+/****************************************** 初始化 *********************************************/
+
+func (b *FakeBackend) RsmiInit() (err error) {
+	return nil
+}
+
+func (b *FakeBackend) RsmiShutdown() (err error) {
+	return nil
+}
+
+/****************************************** 设备信息 *********************************************/
+
+func (b *FakeBackend) RsmiNumMonitorDevices() (gpuNum int, err error) {
+	return len(b.scenario.Devices), nil
+}
+
+func (b *FakeBackend) RsmiDevSkuGet(dvInd int) (sku int, err error) {
+	if _, err = b.device("rsmi_dev_sku_get", dvInd); err != nil {
+		return 0, err
+	}
+	return 0, fakeNotSupported("rsmi_dev_sku_get")
+}
+
+func (b *FakeBackend) RsmiDevVendorIdGet(dvInd int) uint {
+	return fakeVendorID
+}
+
+func (b *FakeBackend) RsmiDevIdGet(dvInd int) (id int, err error) {
+	d, err := b.device("rsmi_dev_id_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	v, _ := strconv.ParseUint(d.Model, 16, 16)
+	return int(v), nil
+}
+
+func (b *FakeBackend) modelName(d *FakeDeviceSpec) string {
+	if name, ok := type2name[d.Model]; ok {
+		return name
+	}
+	return "DCU " + d.Model
+}
+
+func (b *FakeBackend) RsmiDevNameGet(dvInd int) (nameStr string, err error) {
+	d, err := b.device("rsmi_dev_name_get", dvInd)
+	if err != nil {
+		return "", err
+	}
+	return b.modelName(d), nil
+}
+
+func (b *FakeBackend) RsmiDevBrandGet(dvInd int) (brand string, err error) {
+	d, err := b.device("rsmi_dev_brand_get", dvInd)
+	if err != nil {
+		return "", err
+	}
+	return b.modelName(d), nil
+}
+
+func (b *FakeBackend) RsmiDevVendorNameGet(dvInd int) (bname string, err error) {
+	if _, err = b.device("rsmi_dev_vendor_name_get", dvInd); err != nil {
+		return "", err
+	}
+	return "Chengdu Haiguang IC Design Co., Ltd.", nil
+}
+
+func (b *FakeBackend) RsmiDevVramVendorGet(dvInd int) (result string, err error) {
+	d, err := b.device("rsmi_dev_vram_vendor_get", dvInd)
+	if err != nil {
+		return "", err
+	}
+	return d.VramVendor, nil
+}
+
+func (b *FakeBackend) RsmiDevSerialNumberGet(dvInd int) (serialNumber string, err error) {
+	d, err := b.device("rsmi_dev_serial_number_get", dvInd)
+	if err != nil {
+		return "", err
+	}
+	return d.Serial, nil
+}
+
+func (b *FakeBackend) RsmiDevSubsystemIdGet(dvInd int) int {
+	id, _ := b.RsmiDevIdGet(dvInd)
+	return id
+}
+
+func (b *FakeBackend) RsmiDevSubsystemNameGet(dvInd int) (subSystemName string, err error) {
+	return b.RsmiDevNameGet(dvInd)
+}
+
+func (b *FakeBackend) RsmiDevDrmRenderMinorGet(dvInd int) int {
+	return 128 + dvInd
+}
+
+func (b *FakeBackend) RsmiDevUniqueIdGet(dvInd int) (uniqueId int64, err error) {
+	d, err := b.device("rsmi_dev_unique_id_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return d.UniqueID, nil
+}
+
+func (b *FakeBackend) RsmiDevSubsystemVendorIdGet(dvInd int) int {
+	return fakeVendorID
+}
+
+/****************************************** PCIe *********************************************/
+
+func (b *FakeBackend) RsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err error) {
+	if _, err = b.device("rsmi_dev_pci_bandwidth_get", dvInd); err != nil {
+		return
+	}
+	rates := []uint64{2500000000, 5000000000, 8000000000, 16000000000}
+	rsmiPcieBandwidth.TransferRate.NumSupported = uint32(len(rates))
+	rsmiPcieBandwidth.TransferRate.Current = uint32(len(rates) - 1)
+	for i, r := range rates {
+		rsmiPcieBandwidth.TransferRate.Frequency[i] = r
+		rsmiPcieBandwidth.Lanes[i] = 16
+	}
+	return
+}
+
+func (b *FakeBackend) RsmiDevPciIdGet(dvInd int) (bdfid int64, err error) {
+	d, err := b.device("rsmi_dev_pci_id_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return parsePciBus(d.PciBus)
+}
+
+func (b *FakeBackend) RsmiTopoNumaAffinityGet(dvInd int) (namaNode int, err error) {
+	d, err := b.device("rsmi_topo_numa_affinity_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return d.NumaNode, nil
+}
+
+func (b *FakeBackend) RsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz int64, err error) {
+	d, err := b.device("rsmi_dev_pci_throughput_get", dvInd)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	t := b.elapsed()
+	return int64(d.PcieSent.At(t)), int64(d.PcieReceived.At(t)), fakeMaxPktSize, nil
+}
+
+func (b *FakeBackend) RsmiDevPciReplayCounterGet(dvInd int) (counter int64, err error) {
+	d, err := b.device("rsmi_dev_pci_replay_counter_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return int64(d.PcieReplay.Integral(b.elapsed())), nil
+}
+
+func (b *FakeBackend) RsmiDevPciBandwidthSet(dvInd int, bwBitmask int64) (err error) {
+	_, err = b.device("rsmi_dev_pci_bandwidth_set", dvInd)
+	return
+}
+
+/****************************************** 功耗 *********************************************/
+
+func (b *FakeBackend) RsmiDevPowerAveGet(dvInd int, senserId int) (power int64, err error) {
+	d, err := b.device("rsmi_dev_power_ave_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return int64(d.Power.At(b.elapsed()) * 1000000), nil
+}
+
+func (b *FakeBackend) RsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32, timestamp uint64, err error) {
+	d, err := b.device("rsmi_dev_energy_count_get", dvInd)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	now := b.now()
+	joules := d.Power.Integral(b.sinceStart(now).Seconds())
+	return uint64(joules * 1000000 / fakeEnergyResUJ), fakeEnergyResUJ, uint64(now.UnixNano()), nil
+}
+
+func (b *FakeBackend) RsmiDevPowerCapGet(dvInd int, senserId int) (power int64, err error) {
+	d, err := b.device("rsmi_dev_power_cap_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return int64(d.PowerCap * 1000000), nil
+}
+
+func (b *FakeBackend) RsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error) {
+	d, err := b.device("rsmi_dev_power_cap_range_get", dvInd)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int64(d.PowerCap * 1000000), 0, nil
+}
+
+/****************************************** 内存 *********************************************/
+
+func (b *FakeBackend) RsmiDevMemoryTotalGet(dvInd int, memoryType RSMIMemoryType) (total int64, err error) {
+	d, err := b.device("rsmi_dev_memory_total_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	if memoryType == RSMI_MEM_TYPE_GTT {
+		return 0, nil
+	}
+	return d.VramTotalMiB * fakeMiB, nil
+}
+
+func (b *FakeBackend) RsmiDevMemoryUsageGet(dvInd int, memoryType RSMIMemoryType) (used int64, err error) {
+	d, err := b.device("rsmi_dev_memory_usage_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	if memoryType == RSMI_MEM_TYPE_GTT {
+		return 0, nil
+	}
+	usedMiB := fakeClamp(d.VramUsedMiB.At(b.elapsed()), 0, float64(d.VramTotalMiB))
+	return int64(usedMiB * fakeMiB), nil
+}
+
+func (b *FakeBackend) RsmiDevMemoryBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	d, err := b.device("rsmi_dev_memory_busy_percent_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return int(fakeClamp(d.MemBusy.At(b.elapsed()), 0, 100)), nil
+}
+
+func (b *FakeBackend) RsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetiredPageRecord, err error) {
+	d, err := b.device("rsmi_dev_memory_reserved_pages_get", dvInd)
+	if err != nil {
+		return 0, nil, err
+	}
+	t := b.elapsed()
+	for _, page := range d.RetiredPages {
+		if page.At > t {
+			continue
+		}
+		status, _ := memoryPageStatus(page.Status)
+		records = append(records, RSMIRetiredPageRecord{PageAddress: page.Address, PageSize: 4096, Status: status})
+	}
+	return len(records), records, nil
+}
+
+/****************************************** 风扇 *********************************************/
+
+func (b *FakeBackend) RsmiDevFanRpmsGet(dvInd, sensorInd int) (speed int64, err error) {
+	d, err := b.device("rsmi_dev_fan_rpms_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return int64(d.FanRPM.At(b.elapsed())), nil
+}
+
+func (b *FakeBackend) RsmiDevFanSpeedGet(dvInd, sensorInd int) (speed int64, err error) {
+	d, err := b.device("rsmi_dev_fan_speed_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.fanSpeed[dvInd] >= 0 {
+		return b.fanSpeed[dvInd], nil
+	}
+	return int64(fakeClamp(d.Temperature.At(b.elapsed())/100*255, 0, 255)), nil
+}
+
+func (b *FakeBackend) RsmiDevFanSpeedMaxGet(dvInd, sensorInd int) (maxSpeed int64, err error) {
+	_, err = b.device("rsmi_dev_fan_speed_max_get", dvInd)
+	return 255, err
+}
+
+func (b *FakeBackend) RsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreqVoltRegion, err error) {
+	return 0, nil, fakeNotSupported("rsmi_dev_od_volt_curve_regions_get")
+}
+
+func (b *FakeBackend) RsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSMPowerProfileStatus, err error) {
+	if _, err = b.device("rsmi_dev_power_profile_presets_get", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	available := RSMIPowerProfPrstComputeMask | RSMIPowerProfPrstPowerSavingMask | RSMIPowerProfPrstBootupDefault
+	return RSMPowerProfileStatus{
+		AvailableProfiles: RSMIBitField(available),
+		Current:           b.profiles[dvInd],
+		NumProfiles:       3,
+	}, nil
+}
+
+/****************************************** 版本 *********************************************/
+
+func (b *FakeBackend) RsmiVersionGet() (version RSMIVersion, err error) {
+	return RSMIVersion{Major: 6, Minor: 3, Patch: 8, Build: "fake"}, nil
+}
+
+func (b *FakeBackend) RsmiVersionStrGet(component RSMISwComponent, len int) (varStr string, err error) {
+	if component != RSMISwCompDriver {
+		return "", fmt.Errorf("Error rsmi_version_str_get:RSMI_STATUS_INVALID_ARGS")
+	}
+	return b.scenario.DriverVersion, nil
+}
+
+func (b *FakeBackend) RsmiDevVbiosVersionGet(dvInd, len int) (vbios string, err error) {
+	d, err := b.device("rsmi_dev_vbios_version_get", dvInd)
+	if err != nil {
+		return "", err
+	}
+	return d.Vbios, nil
+}
+
+func (b *FakeBackend) RsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64, err error) {
+	d, err := b.device("rsmi_dev_firmware_version_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	if int(fwBlock) < 0 || int(fwBlock) >= len(fwBlockNames) {
+		return 0, fmt.Errorf("Error rsmi_dev_firmware_version_get:RSMI_STATUS_INVALID_ARGS")
+	}
+	version, ok := d.Firmware[fwBlockNames[fwBlock]]
+	if !ok {
+		return 0, fakeNotSupported("rsmi_dev_firmware_version_get")
+	}
+	return version, nil
+}
+
+/****************************************** vDCU *********************************************/
+
+func (b *FakeBackend) DmiGetDeviceCount() (count int, err error) {
+	return len(b.scenario.Devices), nil
+}
+
+// usedResources 返回设备上虚拟设备已占用的 CU 与内存，调用者需持有锁
+func (b *FakeBackend) usedResources(dvInd int) (cus int, mem uint64) {
+	for _, v := range b.vdevices {
+		if v.deviceID == dvInd {
+			cus += v.cus
+			mem += v.memBytes
+		}
+	}
+	return
+}
+
+func (b *FakeBackend) DmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error) {
+	d, err := b.device("dmiGetDeviceInfo", dvInd)
+	if err != nil {
+		return deviceInfo, err
+	}
+	used, _ := b.RsmiDevMemoryUsageGet(dvInd, RSMI_MEM_TYPE_VRAM)
+	return DMIDeviceInfo{
+		Name:             b.modelName(d),
+		ComputeUnitCount: d.ComputeUnits,
+		GlobalMemSize:    uintptr(d.VramTotalMiB * fakeMiB),
+		UsageMemSize:     uintptr(used),
+		DeviceID:         dvInd,
+	}, nil
+}
+
+func (b *FakeBackend) DmiGetMaxVDeviceCount() (count int, err error) {
+	return b.scenario.Devices[0].MaxVDevices, nil
+}
+
+func (b *FakeBackend) DmiGetVDeviceCount() (count int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.vdevices), nil
+}
+
+func (b *FakeBackend) DmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.vdevices[vDvInd]
+	if !ok {
+		return vDeviceInfo, fmt.Errorf("Error dmiGetVDeviceInfo:DMI_STATUS_VDEV_NOT_EXIST")
+	}
+	return DMIVDeviceInfo{
+		Name:             fmt.Sprintf("vdev%d", vDvInd),
+		ComputeUnitCount: v.cus,
+		GlobalMemSize:    uintptr(v.memBytes),
+		ContainerID:      v.containerID,
+		DeviceID:         v.deviceID,
+	}, nil
+}
+
+func (b *FakeBackend) DmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error) {
+	d, err := b.device("dmiGetDeviceRemainingInfo", dvInd)
+	if err != nil {
+		return 0, 0, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	usedCus, usedMem := b.usedResources(dvInd)
+	return uint64(d.ComputeUnits - usedCus), uint64(d.VramTotalMiB*fakeMiB) - usedMem, nil
+}
+
+func (b *FakeBackend) DmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []int) (vdevIDs []int, err error) {
+	if len(vDevCUs) != vDevCount || len(vDevMemSize) != vDevCount {
+		return vdevIDs, fmt.Errorf("Invalid args")
+	}
+	d, err := b.device("dmiCreateVDevices", dvInd)
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	usedCus, usedMem := b.usedResources(dvInd)
+	for i := 0; i < vDevCount; i++ {
+		usedCus += vDevCUs[i]
+		usedMem += uint64(vDevMemSize[i]) * fakeMiB
+	}
+	if usedCus > d.ComputeUnits || usedMem > uint64(d.VramTotalMiB*fakeMiB) {
+		return nil, fmt.Errorf("Error dmiCreateVDevices:DMI_STATUS_OUT_OF_RESOURCES")
+	}
+	var free []int
+	for slot := 0; slot < d.MaxVDevices; slot++ {
+		if _, ok := b.vdevices[dvInd*d.MaxVDevices+slot]; !ok {
+			free = append(free, dvInd*d.MaxVDevices+slot)
+		}
+	}
+	if len(free) < vDevCount {
+		return nil, fmt.Errorf("Error dmiCreateVDevices:DMI_STATUS_OUT_OF_RESOURCES")
+	}
+	for i := 0; i < vDevCount; i++ {
+		b.vdevices[free[i]] = &fakeVDevice{
+			deviceID: dvInd,
+			cus:      vDevCUs[i],
+			memBytes: uint64(vDevMemSize[i]) * fakeMiB,
+		}
+		vdevIDs = append(vdevIDs, free[i])
+	}
+	return vdevIDs, nil
+}
+
+func (b *FakeBackend) DmiDestroyVDevices(dvInd int) (err error) {
+	if _, err = b.device("dmiDestroyVDevices", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for id, v := range b.vdevices {
+		if v.deviceID == dvInd {
+			delete(b.vdevices, id)
+		}
+	}
+	return nil
+}
+
+func (b *FakeBackend) DmiDestroySingleVDevice(vDvInd int) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.vdevices[vDvInd]; !ok {
+		return fmt.Errorf("Error dmiDestroySingleVDevice:DMI_STATUS_VDEV_NOT_EXIST")
+	}
+	delete(b.vdevices, vDvInd)
+	return nil
+}
+
+func (b *FakeBackend) DmiUpdateSingleVDevice(vDvInd int, vDevCUs int, vDevMemSize int) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.vdevices[vDvInd]
+	if !ok {
+		return fmt.Errorf("Error dmiUpdateSingleVDevice:DMI_STATUS_VDEV_NOT_EXIST")
+	}
+	d := &b.scenario.Devices[v.deviceID]
+	usedCus, usedMem := b.usedResources(v.deviceID)
+	cus, mem := v.cus, v.memBytes
+	if vDevCUs != -1 {
+		cus = vDevCUs
+	}
+	if vDevMemSize != -1 {
+		mem = uint64(vDevMemSize) * fakeMiB
+	}
+	if usedCus-v.cus+cus > d.ComputeUnits || usedMem-v.memBytes+mem > uint64(d.VramTotalMiB*fakeMiB) {
+		return fmt.Errorf("Error dmiUpdateSingleVDevice:DMI_STATUS_OUT_OF_RESOURCES")
+	}
+	v.cus, v.memBytes = cus, mem
+	return nil
+}
+
+func (b *FakeBackend) DmiStartVDevice(vDvInd int) (err error) {
+	return b.setVDeviceRunning("dmiStartVDevice", vDvInd, true)
+}
+
+func (b *FakeBackend) DmiStopVDevice(vDvInd int) (err error) {
+	return b.setVDeviceRunning("dmiStopVDevice", vDvInd, false)
+}
+
+func (b *FakeBackend) setVDeviceRunning(fn string, vDvInd int, running bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.vdevices[vDvInd]
+	if !ok {
+		return fmt.Errorf("Error %s:DMI_STATUS_VDEV_NOT_EXIST", fn)
+	}
+	v.running = running
+	return nil
+}
+
+func (b *FakeBackend) DmiGetDevBusyPercent(dvInd int) (percent int, err error) {
+	return b.RsmiDevBusyPercentGet(dvInd)
+}
+
+func (b *FakeBackend) DmiGetVDevBusyPercent(vDvInd int) (percent int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.vdevices[vDvInd]
+	if !ok {
+		return 0, fmt.Errorf("Error dmiGetVDevBusyPercent:DMI_STATUS_VDEV_NOT_EXIST")
+	}
+	if !v.running {
+		return 0, nil
+	}
+	return int(fakeClamp(v.busy.At(b.elapsed()), 0, 100)), nil
+}
+
+func (b *FakeBackend) DmiSetEncryptionVMStatus(status bool) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.encryption = status
+	return nil
+}
+
+func (b *FakeBackend) DmiGetEncryptionVMStatus() (status bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.encryption, nil
+}
+
+/****************************************** 设备状态 *********************************************/
+
+func (b *FakeBackend) RsmiDevTempMetricGet(dvInd int, sensorType int, metric RSMITemperatureMetric) (temp int64, err error) {
+	d, err := b.device("rsmi_dev_temp_metric_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	var celsius float64
+	switch metric {
+	case RSMI_TEMP_CURRENT:
+		celsius = d.Temperature.At(b.elapsed()) + fakeSensorOffset(sensorType)
+	case RSMI_TEMP_MAX:
+		celsius = fakeTempMax
+	case RSMI_TEMP_CRITICAL:
+		celsius = fakeTempCritical
+	case RSMI_TEMP_EMERGENCY:
+		celsius = fakeTempEmerg
+	default:
+		return 0, fakeNotSupported("rsmi_dev_temp_metric_get")
+	}
+	return int64(celsius * 1000), nil
+}
+
+// fakeSensorOffset 各温度传感器相对边缘温度的偏移
+func fakeSensorOffset(sensorType int) float64 {
+	switch sensorType {
+	case SENSOR_JUNCTION:
+		return 8
+	case SENSOR_MEMORY:
+		return 4
+	case SENSOR_HBM0, SENSOR_HBM1, SENSOR_HBM2, SENSOR_HBM3:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func (b *FakeBackend) RsmiDevVoltMetricGet(dvInd int, voltageType RSMIVoltageType, metric RSMIVoltageMetric) int64 {
+	d, err := b.device("rsmi_dev_volt_metric_get", dvInd)
+	if err != nil {
+		return 0
+	}
+	return int64(d.Voltage.At(b.elapsed()))
+}
+
+func (b *FakeBackend) RsmiDevFanSpeedSet(dvInd, sensorInd int, speed int64) (err error) {
+	if _, err = b.device("rsmi_dev_fan_speed_set", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fanSpeed[dvInd] = speed
+	return nil
+}
+
+func (b *FakeBackend) RsmiDevBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	d, err := b.device("rsmi_dev_busy_percent_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return int(fakeClamp(d.Busy.At(b.elapsed()), 0, 100)), nil
+}
+
+func (b *FakeBackend) RsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCounter, count int) (timestamp int64, err error) {
+	d, err := b.device("rsmi_utilization_count_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	now := b.now()
+	t := b.sinceStart(now).Seconds()
+	for i := 0; i < count && i < len(utilizationCounters); i++ {
+		switch utilizationCounters[i].Type {
+		case RSMI_COARSE_GRAIN_GFX_ACTIVITY:
+			utilizationCounters[i].Value = uint64(fakeActivityAcc(d.Busy, t))
+		case RSMI_COARSE_GRAIN_MEM_ACTIVITY:
+			utilizationCounters[i].Value = uint64(fakeActivityAcc(d.MemBusy, t))
+		default:
+			return 0, fmt.Errorf("Error rsmi_utilization_count_get:RSMI_STATUS_INVALID_ARGS")
+		}
+	}
+	return now.UnixNano(), nil
+}
+
+func (b *FakeBackend) RsmiDevPerfLevelGet(dvInd int) (perf RSMIDevPerfLevel, err error) {
+	if _, err = b.device("rsmi_dev_perf_level_get", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.perfLevels[dvInd], nil
+}
+
+func (b *FakeBackend) RsmiPerfDeterminismModeSet(dvInd int, clkValue int64) (err error) {
+	if _, err = b.device("rsmi_perf_determinism_mode_set", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.perfLevels[dvInd] = RSMI_DEV_PERF_LEVEL_DETERMINISM
+	return nil
+}
+
+func (b *FakeBackend) RsmiDevOverdriveLevelGet(dvInd int) (od int, err error) {
+	if _, err = b.device("rsmi_dev_overdrive_level_get", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.overdrive[dvInd], nil
+}
+
+// clock 返回指定时钟的配置和当前档位指针，调用者需持有锁
+func (b *FakeBackend) clock(dvInd int, clkType RSMIClkType) (*FakeClockSpec, *int) {
+	d := &b.scenario.Devices[dvInd]
+	switch clkType {
+	case RSMI_CLK_TYPE_SYS:
+		return &d.Sclk, &b.sclkLevel[dvInd]
+	case RSMI_CLK_TYPE_SOC:
+		return &d.Socclk, &b.socclkLevel[dvInd]
+	case RSMI_CLK_TYPE_MEM:
+		return &d.Mclk, &b.mclkLevel[dvInd]
+	}
+	return nil, nil
+}
+
+func (b *FakeBackend) RsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequencies, err error) {
+	if _, err = b.device("rsmi_dev_gpu_clk_freq_get", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	spec, level := b.clock(dvInd, clkType)
+	if spec == nil {
+		return frequencies, fakeNotSupported("rsmi_dev_gpu_clk_freq_get")
+	}
+	frequencies.NumSupported = uint32(len(spec.LevelsMHz))
+	frequencies.Current = uint32(*level)
+	for i, mhz := range spec.LevelsMHz {
+		frequencies.Frequency[i] = mhz * 1000000
+	}
+	return
+}
+
+func (b *FakeBackend) RsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error) {
+	d, err := b.device("rsmi_dev_od_volt_info_get", dvInd)
+	if err != nil {
+		return odv, err
+	}
+	sclk := d.Sclk.LevelsMHz
+	mclk := d.Mclk.LevelsMHz
+	odv.SclkFreqLimits = RSMIRange{LowerBound: sclk[0] * 1000000, UpperBound: sclk[len(sclk)-1] * 1000000}
+	odv.MclkFreqLimits = RSMIRange{LowerBound: mclk[0] * 1000000, UpperBound: mclk[len(mclk)-1] * 1000000}
+	odv.CurrSclkRange = odv.SclkFreqLimits
+	odv.CurrMclkRange = odv.MclkFreqLimits
+	return odv, nil
+}
+
+func (b *FakeBackend) RsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error) {
+	metrics, err := b.gpuMetrics("rsmi_dev_gpu_metrics_info_get", dvInd)
+	if err != nil {
+		return gpuMetrics, err
+	}
+	// 与 rsmi 一致，只支持 v1.x 的固定布局
+	if metrics.CommonHeader.ContentRevision > 3 {
+		return gpuMetrics, fmt.Errorf("Error rsmi_dev_gpu_metrics_info_get:gpu metrics v%s:%w", metrics.Revision, ErrUnknownMetricsRevision)
+	}
+	return metrics.rsmiGPUMetrics(), nil
+}
+
+func (b *FakeBackend) GpuMetricsTableGet(dvInd int) (table []byte, err error) {
+	metrics, err := b.gpuMetrics("gpu_metrics", dvInd)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeGpuMetrics(metrics)
+}
+
+// fakeActivityAcc 模拟 SMU 的 32 位活动累加器：每毫秒累加一次当前的繁忙百分比，溢出后回绕
+func fakeActivityAcc(busy FakeValue, t float64) uint32 {
+	return uint32(uint64(busy.Integral(t) * 1000))
+}
+
+// gpuMetrics 按场景中的度量表版本生成当前的 gpu metrics
+func (b *FakeBackend) gpuMetrics(fn string, dvInd int) (gpuMetrics GpuMetrics, err error) {
+	d, err := b.device(fn, dvInd)
+	if err != nil {
+		return gpuMetrics, err
+	}
+	header, err := ParseMetricsRevision(d.MetricsRevision)
+	if err != nil {
+		return gpuMetrics, fmt.Errorf("Error %s:%w", fn, err)
+	}
+	now := b.now()
+	t := b.sinceStart(now).Seconds()
+	b.mu.Lock()
+	sclk := d.Sclk.LevelsMHz[b.sclkLevel[dvInd]]
+	socclk := d.Socclk.LevelsMHz[b.socclkLevel[dvInd]]
+	mclk := d.Mclk.LevelsMHz[b.mclkLevel[dvInd]]
+	b.mu.Unlock()
+	// v1.0–v1.3 的温度单位为 0.01℃，v1.4 起为 ℃
+	tempScale := 100.0
+	if header.ContentRevision >= 4 {
+		tempScale = 1
+	}
+	edge := d.Temperature.At(t)
+	hbm := uint16((edge + fakeSensorOffset(SENSOR_HBM0)) * tempScale)
+	busy := uint16(fakeClamp(d.Busy.At(t), 0, 100))
+	gpuMetrics = GpuMetrics{
+		CommonHeader:           header,
+		Revision:               d.MetricsRevision,
+		TemperatureEdge:        uint16(edge * tempScale),
+		TemperatureHotspot:     uint16((edge + fakeSensorOffset(SENSOR_JUNCTION)) * tempScale),
+		TemperatureMem:         uint16((edge + fakeSensorOffset(SENSOR_MEMORY)) * tempScale),
+		AverageGfxActivity:     busy,
+		AverageUmcActivity:     uint16(fakeClamp(d.MemBusy.At(t), 0, 100)),
+		AverageSocketPower:     uint16(d.Power.At(t)),
+		EnergyAccumulator:      uint64(d.Power.Integral(t) * 1000000 / fakeEnergyResUJ),
+		SystemClockCounter:     uint64(b.sinceStart(now).Nanoseconds()),
+		FirmwareTimestamp:      uint64(b.sinceStart(now).Nanoseconds() / 10),
+		AverageGfxclkFrequency: uint16(sclk),
+		AverageSocclkFrequency: uint16(socclk),
+		AverageUclkFrequency:   uint16(mclk),
+		CurrentGfxclk:          uint16(sclk),
+		CurrentSocclk:          uint16(socclk),
+		CurrentUclk:            uint16(mclk),
+		ThrottleStatus:         d.ThrottleStatus,
+		PcieLinkWidth:          16,
+		PcieLinkSpeed:          160,
+		GfxActivityAcc:         fakeActivityAcc(d.Busy, t),
+		MemActivityAcc:         fakeActivityAcc(d.MemBusy, t),
+		TemperatureHBM:         []uint16{hbm, hbm, hbm, hbm},
+		VcnActivity:            make([]uint16, gpuMetricsNumVCN),
+		XgmiReadDataAcc:        make([]uint64, gpuMetricsNumXgmiLinks),
+		XgmiWriteDataAcc:       make([]uint64, gpuMetricsNumXgmiLinks),
+	}
+	// 模拟 VCN0 的活动为图形活动的一半
+	gpuMetrics.VcnActivity[0] = busy / 2
+	return gpuMetrics, nil
+}
+
+// ecc 返回设备上指定块的 ECC 配置
+func (b *FakeBackend) ecc(d *FakeDeviceSpec, block RSMIGpuBlock) *FakeEccSpec {
+	name := ConvertFromRSMIGpuBlock(block)
+	for i := range d.Ecc {
+		if d.Ecc[i].Block == name {
+			return &d.Ecc[i]
+		}
+	}
+	return nil
+}
+
+func (b *FakeBackend) RsmiDevEccStatusGet(dvInd int, block RSMIGpuBlock) (state RSMIRasErrState, err error) {
+	d, err := b.device("rsmi_dev_ecc_status_get", dvInd)
+	if err != nil {
+		return state, err
+	}
+	e := b.ecc(d, block)
+	if e == nil {
+		return RSMIRasErrStateDisabled, nil
+	}
+	return RSMIRasErrState(indexOf(rasErrStaleMachine, e.State)), nil
+}
+
+func (b *FakeBackend) RsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorCount, err error) {
+	d, err := b.device("rsmi_dev_ecc_count_get", dvInd)
+	if err != nil {
+		return errorCount, err
+	}
+	e := b.ecc(d, gpuBlock)
+	if e == nil {
+		return errorCount, fakeNotSupported("rsmi_dev_ecc_count_get")
+	}
+	t := b.elapsed()
+	errorCount = RSMIErrorCount{CorrectableErr: e.CE, UncorrectableErr: e.UE}
+	for _, inj := range e.Inject {
+		if inj.At <= t {
+			errorCount.CorrectableErr += inj.CE
+			errorCount.UncorrectableErr += inj.UE
+		}
+	}
+	return errorCount, nil
+}
+
+func (b *FakeBackend) RsmiDevEccEnabledGet(dvInd int) (enabledBlocks int64, err error) {
+	d, err := b.device("rsmi_dev_ecc_enabled_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range d.Ecc {
+		if e.State == "ENABLED" {
+			block, _ := stringToBlock(e.Block)
+			enabledBlocks |= int64(block)
+		}
+	}
+	return enabledBlocks, nil
+}
+
+/****************************************** 控制 *********************************************/
+
+func (b *FakeBackend) RsmiDevPerfLevelSet(dvInd int, devPerfLevel RSMIDevPerfLevel) (err error) {
+	if _, err = b.device("rsmi_dev_perf_level_set", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.perfLevels[dvInd] = devPerfLevel
+	if devPerfLevel == RSMI_DEV_PERF_LEVEL_AUTO {
+		d := &b.scenario.Devices[dvInd]
+		b.sclkLevel[dvInd] = d.Sclk.Current
+		b.socclkLevel[dvInd] = d.Socclk.Current
+		b.mclkLevel[dvInd] = d.Mclk.Current
+	} else if devPerfLevel == RSMI_DEV_PERF_LEVEL_LOW {
+		b.sclkLevel[dvInd], b.socclkLevel[dvInd], b.mclkLevel[dvInd] = 0, 0, 0
+	}
+	return nil
+}
+
+func (b *FakeBackend) RsmiDevClkRangeSet(dvInd int, minClkValue, maxClkValue int64, clkType RSMIClkType) (err error) {
+	_, err = b.device("rsmi_dev_clk_range_set", dvInd)
+	return
+}
+
+func (b *FakeBackend) RsmiDevOdVoltInfoSet(dvInd, vPoint, clkValue, voltValue int) (err error) {
+	_, err = b.device("rsmi_dev_od_volt_info_set", dvInd)
+	return
+}
+
+func (b *FakeBackend) RsmiDevOverdriveLevelSet(dvInd, od int) (err error) {
+	if _, err = b.device("rsmi_dev_overdrive_level_set", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.overdrive[dvInd] = od
+	return nil
+}
+
+func (b *FakeBackend) RsmiDevGpuClkFreqSet(dvInd int, clkType RSMIClkType, freqBitmask int64) (err error) {
+	if _, err = b.device("rsmi_dev_gpu_clk_freq_set", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	spec, level := b.clock(dvInd, clkType)
+	if spec == nil {
+		return fakeNotSupported("rsmi_dev_gpu_clk_freq_set")
+	}
+	for i := range spec.LevelsMHz {
+		if freqBitmask&(1<<uint(i)) != 0 {
+			*level = i
+			return nil
+		}
+	}
+	return fmt.Errorf("Error rsmi_dev_gpu_clk_freq_set:RSMI_STATUS_INVALID_ARGS")
+}
+
+/****************************************** 计数器 *********************************************/
+
+func (b *FakeBackend) RsmiDevCounterGroupSupported(dvInd int, group RSMIEventGroup) (err error) {
+	if _, err = b.device("rsmi_dev_counter_group_supported", dvInd); err != nil {
+		return
+	}
+	if b.scenario.LinkType != "XGMI" {
+		return fakeNotSupported("rsmi_dev_counter_group_supported")
+	}
+	return nil
+}
+
+func (b *FakeBackend) RsmiDevCounterCreate(dvInd int, eventType RSMIEventType) (eventHandle EventHandle, err error) {
+	group := eventType.Group()
+	if group == RSMI_EVNT_GRP_INVALID {
+		return 0, fmt.Errorf("Error rsmi_dev_counter_create:RSMI_STATUS_INVALID_ARGS")
+	}
+	if err = b.RsmiDevCounterGroupSupported(dvInd, group); err != nil {
+		return 0, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.availableCounters(dvInd, group) == 0 {
+		return 0, fmt.Errorf("Error rsmi_dev_counter_create:RSMI_STATUS_OUT_OF_RESOURCES")
+	}
+	eventHandle = b.nextCounter
+	b.nextCounter++
+	b.counters[eventHandle] = &fakeCounter{dvInd: dvInd, eventType: eventType}
+	return eventHandle, nil
+}
+
+func (b *FakeBackend) RsmiDevCounterDestroy(handle EventHandle) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.counters[handle]; !ok {
+		return fmt.Errorf("Error rsmi_dev_counter_destroy:RSMI_STATUS_INVALID_ARGS")
+	}
+	delete(b.counters, handle)
+	return nil
+}
+
+func (b *FakeBackend) RsmiCounterControl(evtHandle EventHandle, cmd RSMICounterCommand) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.counters[evtHandle]
+	if !ok {
+		return fmt.Errorf("Error rsmi_counter_control:RSMI_STATUS_INVALID_ARGS")
+	}
+	now := b.now()
+	switch cmd {
+	case RSMI_CNTR_CMD_START:
+		if !c.running {
+			c.running, c.startedAt = true, now
+		}
+	case RSMI_CNTR_CMD_STOP:
+		if c.running {
+			c.running, c.elapsed = false, c.elapsed+now.Sub(c.startedAt)
+		}
+	}
+	return nil
+}
+
+func (b *FakeBackend) RsmiCounterRead(handle EventHandle) (counterValue RSMICounterValue, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.counters[handle]
+	if !ok {
+		return counterValue, fmt.Errorf("Error rsmi_counter_read:RSMI_STATUS_INVALID_ARGS")
+	}
+	running := c.elapsed
+	if c.running {
+		running += b.now().Sub(c.startedAt)
+	}
+	busy := b.scenario.Devices[c.dvInd].Busy.At(b.elapsed())
+	// 按设备忙碌程度模拟每秒约 1GB 满载流量，单位为 32 字节 beat
+	counterValue = RSMICounterValue{
+		Value:       uint64(running.Seconds() * busy / 100 * 1e9 / 32),
+		TimeEnabled: uint64(running.Nanoseconds()),
+		TimeRunning: uint64(running.Nanoseconds()),
+	}
+	return counterValue, nil
+}
+
+func (b *FakeBackend) RsmiCounterAvailableCountersGet(dvInd int, group RSMIEventGroup) (availAble int, err error) {
+	if err = b.RsmiDevCounterGroupSupported(dvInd, group); err != nil {
+		return 0, err
+	}
+	if group != RSMI_EVNT_GRP_XGMI && group != RSMI_EVNT_GRP_XGMI_DATA_OUT {
+		return 0, fmt.Errorf("Error rsmi_counter_available_counters_get:RSMI_STATUS_INVALID_ARGS")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.availableCounters(dvInd, group), nil
+}
+
+// availableCounters 模拟每个设备 xgmi 事件组 4 个、xgmi_data_out 事件组 6 个硬件计数器，调用方需持有 b.mu
+func (b *FakeBackend) availableCounters(dvInd int, group RSMIEventGroup) int {
+	total := 4
+	if group == RSMI_EVNT_GRP_XGMI_DATA_OUT {
+		total = 6
+	}
+	for _, c := range b.counters {
+		if c.dvInd == dvInd && c.eventType.Group() == group {
+			total--
+		}
+	}
+	return total
+}
+
+func (b *FakeBackend) RsmiDevFanReset(dvInd, sensorInd int) (err error) {
+	if _, err = b.device("rsmi_dev_fan_reset", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fanSpeed[dvInd] = -1
+	return nil
+}
+
+func (b *FakeBackend) RsmiDevPowerProfileSet(dvInd int, reserved int, profile RSNIPowerProfilePresetMasks) (err error) {
+	if _, err = b.device("rsmi_dev_power_profile_set", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.profiles[dvInd] = RSMIPowerProfilePresetMasks(profile)
+	return nil
+}
+
+func (b *FakeBackend) RsmiDevXgmiErrorReset(dvInd int) (err error) {
+	if _, err = b.device("rsmi_dev_xgmi_error_reset", dvInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.xgmiRead[dvInd] = b.elapsed()
+	return nil
+}
+
+// RsmiDevXGMIErrorStatus 返回上一次读取以来场景中发生的 XGMI 错误，读取后清除
+func (b *FakeBackend) RsmiDevXGMIErrorStatus(dvInd int) (status RSMIXGMIStatus, err error) {
+	d, err := b.device("rsmi_dev_xgmi_error_status", dvInd)
+	if err != nil {
+		return RSMIXGMIStatusNoErrors, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t := b.elapsed()
+	count := 0
+	for _, e := range d.XgmiErrors {
+		if e.At > b.xgmiRead[dvInd] && e.At <= t {
+			count += e.Count
+		}
+	}
+	b.xgmiRead[dvInd] = t
+	switch {
+	case count > 1:
+		return RSMIXGMIStatusMultipleErrors, nil
+	case count == 1:
+		return RSMIXGMIStatusError, nil
+	}
+	return RSMIXGMIStatusNoErrors, nil
+}
+
+func (b *FakeBackend) RsmiDevXgmiHiveIdGet(dvInd int) (hiveId int64, err error) {
+	d, err := b.device("rsmi_dev_xgmi_hive_id_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return d.HiveID, nil
+}
+
+/****************************************** 进程 *********************************************/
+
+// activeProcesses 返回当前时刻仍在运行的进程
+func (b *FakeBackend) activeProcesses() []FakeProcessSpec {
+	t := b.elapsed()
+	var procs []FakeProcessSpec
+	for _, p := range b.scenario.Processes {
+		if p.Start <= t && (p.End == 0 || t < p.End) {
+			procs = append(procs, p)
+		}
+	}
+	return procs
+}
+
+func fakeProcessInfo(p FakeProcessSpec) RSMIProcessInfo {
+	return RSMIProcessInfo{
+		ProcessID:   p.Pid,
+		Pasid:       p.Pasid,
+		VramUsage:   p.VramMiB * fakeMiB,
+		SdmaUsage:   p.SdmaUsage,
+		CuOccupancy: p.CuOccupancy,
+	}
+}
+
+func (b *FakeBackend) RsmiComputeProcessInfoGet() (processInfo []RSMIProcessInfo, numItems int, err error) {
+	for _, p := range b.activeProcesses() {
+		processInfo = append(processInfo, fakeProcessInfo(p))
+	}
+	return processInfo, len(processInfo), nil
+}
+
+func (b *FakeBackend) RsmiComputeProcessInfoByPidGet(pid int) (proc RSMIProcessInfo, err error) {
+	for _, p := range b.activeProcesses() {
+		if int(p.Pid) == pid {
+			return fakeProcessInfo(p), nil
+		}
+	}
+	return proc, fmt.Errorf("Error rsmiComputeProcessInfoByPidGet:RSMI_STATUS_NOT_FOUND")
+}
+
+func (b *FakeBackend) RsmiComputeProcessGpusGet(pid int) (dvIndices []int, err error) {
+	for _, p := range b.activeProcesses() {
+		if int(p.Pid) == pid {
+			dvIndices = append([]int{}, p.Devices...)
+			sort.Ints(dvIndices)
+			return dvIndices, nil
+		}
+	}
+	return nil, fmt.Errorf("Error rsmiComputeProcessGpusGet:RSMI_STATUS_NOT_FOUND")
+}
+
+func (b *FakeBackend) RsmiDevSupportedFuncIteratorOpen(dvInd int) (iterHandle RSMIFuncIDIterHandle, err error) {
+	return iterHandle, fakeNotSupported("rsmi_dev_supported_func_iterator_open")
+}
+
+func (b *FakeBackend) RsmiDevSupportedVariantIteratorOpen(iterHandle RSMIFuncIDIterHandle) (handle RSMIFuncIDIterHandle, err error) {
+	return handle, fakeNotSupported("rsmi_dev_supported_variant_iterator_open")
+}
+
+func (b *FakeBackend) RsmiFuncIterNext(handle RSMIFuncIDIterHandle) (err error) {
+	return fakeNotSupported("rsmi_func_iter_next")
+}
+
+func (b *FakeBackend) RsmiDevSupportedFuncIteratorClose(handle RSMIFuncIDIterHandle) (err error) {
+	return fakeNotSupported("rsmi_dev_supported_func_iterator_close")
+}
+
+/****************************************** 事件 *********************************************/
+
+func (b *FakeBackend) RsmiEventNotificationInit(deInd int) (err error) {
+	if _, err = b.device("rsmi_event_notification_init", deInd); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.eventMasks[deInd]; !ok {
+		b.eventMasks[deInd] = 0
+	}
+	return nil
+}
+
+func (b *FakeBackend) RsmiEventNotificationMaskSet(dvInd int, mask int64) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.eventMasks[dvInd]; !ok {
+		return fmt.Errorf("Error rsmi_event_notification_mask_set:RSMI_STATUS_INIT_ERROR")
+	}
+	b.eventMasks[dvInd] = mask
+	return nil
+}
+
+// pendingEvents 取出已到期且符合掩码的事件，调用者需持有锁
+func (b *FakeBackend) pendingEvents() (datas []RSMIEEvtNotificationData) {
+	t := b.elapsed()
+	for i, e := range b.scenario.Events {
+		if b.eventsSent[i] || e.At > t {
+			continue
+		}
+		mask, ok := b.eventMasks[e.Device]
+		if !ok {
+			continue
+		}
+		evt := eventTypeValues[EventType(strings.ToUpper(e.Type))]
+		if mask&(1<<uint(evt-1)) == 0 {
+			continue
+		}
+		b.eventsSent[i] = true
+		data := RSMIEEvtNotificationData{DvInd: uint32(e.Device), Event: evt}
+		copy(data.Message[:], e.Message)
+		datas = append(datas, data)
+	}
+	return
+}
+
+func (b *FakeBackend) RsmiEventNotificationGet(timeoutMs int) (numElem int, datas []RSMIEEvtNotificationData, err error) {
+	deadline := b.now().Add(time.Duration(timeoutMs) * time.Millisecond)
+	for {
+		b.mu.Lock()
+		datas = b.pendingEvents()
+		b.mu.Unlock()
+		if len(datas) > 0 || !b.now().Before(deadline) {
+			return len(datas), datas, nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (b *FakeBackend) RsmiEventNotificationStop(dvInd int) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.eventMasks, dvInd)
+	return nil
+}
+
+/****************************************** 拓扑 *********************************************/
+
+func (b *FakeBackend) RsmiTopoGetLinkWeight(dvIndSrc, dvIndDst int) (weight int64, err error) {
+	hops, _, err := b.RsmiTopoGetLinkType(dvIndSrc, dvIndDst)
+	if err != nil || hops == 0 {
+		return 0, err
+	}
+	if b.scenario.LinkType == "XGMI" {
+		return 15, nil
+	}
+	return 40, nil
+}
+
+func (b *FakeBackend) RsmiTopoGetLinkType(dvIndSrc, dvIndDst int) (hops int64, linkType RSMIIOLinkType, err error) {
+	src, err := b.device("rsmi_topo_get_link_type", dvIndSrc)
+	if err != nil {
+		return 0, linkType, err
+	}
+	dst, err := b.device("rsmi_topo_get_link_type", dvIndDst)
+	if err != nil {
+		return 0, linkType, err
+	}
+	if dvIndSrc == dvIndDst {
+		return 0, RSMIIOLinkTypeUndefined, nil
+	}
+	if b.scenario.LinkType == "XGMI" {
+		return 1, RSMIIOLinkTypeXGMI, nil
+	}
+	if src.NumaNode == dst.NumaNode {
+		return 2, RSMIIOLinkTypePCIExpress, nil
+	}
+	return 3, RSMIIOLinkTypePCIExpress, nil
+}
+
+func (b *FakeBackend) RsmiTopoGetNumaBodeBumber(dvInd int) (numaNode int, err error) {
+	return b.RsmiTopoNumaAffinityGet(dvInd)
+}
+
+// RsmiMinmaxBandwidthGet 与 rsmi 一致，只有跳数为 1 的 XGMI 链接有带宽，模拟单条 x16 XGMI 链路
+func (b *FakeBackend) RsmiMinmaxBandwidthGet(dvIndSrc, dvIndDst int) (minBandwidth, maxBandwidth int64, err error) {
+	hops, linkType, err := b.RsmiTopoGetLinkType(dvIndSrc, dvIndDst)
+	if err != nil {
+		return 0, 0, err
+	}
+	if hops != 1 || linkType != RSMIIOLinkTypeXGMI {
+		return 0, 0, fmt.Errorf("Error rsmi_minmax_bandwidth_get:RSMI_STATUS_INVALID_ARGS")
+	}
+	return 25000, 50000, nil
+}
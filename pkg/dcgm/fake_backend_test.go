@@ -0,0 +1,172 @@
+package dcgm
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const fakeScenarioPath = "../../samples/fake/scenario.yaml"
+
+// initFakeScenario 以示例场景初始化模拟后端，场景时间固定在起点，返回推进场景时间的函数
+func initFakeScenario(t *testing.T) (advance func(time.Duration)) {
+	t.Helper()
+	if err := InitWithBackendName(BackendFake + ":" + fakeScenarioPath); err != nil {
+		t.Fatalf("InitWithBackendName: %v", err)
+	}
+	t.Cleanup(func() { ShutDown() })
+	b, ok := getBackend().(*FakeBackend)
+	if !ok {
+		t.Fatalf("backend is %T, want *FakeBackend", getBackend())
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.SetClock(func() time.Time { return now })
+	return func(d time.Duration) { now = now.Add(d) }
+}
+
+// initFakeDevices 以 devices 组成的场景初始化模拟后端，场景时间固定在起点；返回的函数推进场景时间并返回推进后的场景时间
+func initFakeDevices(t *testing.T, devices ...FakeDeviceSpec) (advance func(time.Duration) time.Time) {
+	t.Helper()
+	s := &FakeScenario{Devices: devices}
+	if err := s.normalize(); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	b := NewFakeBackend(s)
+	var offset atomic.Int64
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.SetClock(func() time.Time { return start.Add(time.Duration(offset.Load())) })
+	if err := InitWithBackend(b); err != nil {
+		t.Fatalf("InitWithBackend: %v", err)
+	}
+	t.Cleanup(func() { ShutDown() })
+	return func(d time.Duration) time.Time { return start.Add(time.Duration(offset.Add(int64(d)))) }
+}
+
+// byMinorNumber 按设备索引号整理 AllDeviceInfos 的结果，其返回顺序不固定
+func byMinorNumber(t *testing.T, infos []PhysicalDeviceInfo) map[int]PhysicalDeviceInfo {
+	t.Helper()
+	devices := map[int]PhysicalDeviceInfo{}
+	for _, info := range infos {
+		devices[info.Device.MinorNumber] = info
+	}
+	if len(devices) != len(infos) {
+		t.Fatalf("duplicate minor numbers in %d devices", len(infos))
+	}
+	return devices
+}
+
+func TestFakeCollectDeviceMetrics(t *testing.T) {
+	initFakeScenario(t)
+	infos, err := CollectDeviceMetrics()
+	if err != nil {
+		t.Fatalf("CollectDeviceMetrics: %v", err)
+	}
+	want := []MonitorInfo{
+		{MinorNumber: 0, PciBusNumber: "0000:03:00.0", DeviceId: "SN-K100AI-0001", SubSystemName: "K100_AI",
+			Temperature: 48, PowerUsage: 210, PowerCap: 300, MemoryCap: 64 << 30, MemoryUsed: 20 << 30, UtilizationRate: 70},
+		{MinorNumber: 1, PciBusNumber: "0000:43:00.0", DeviceId: "SN-K100AI-0002", SubSystemName: "K100_AI",
+			Temperature: 36, PowerUsage: 82, PowerCap: 300, MemoryCap: 64 << 30, MemoryUsed: 0, UtilizationRate: 5},
+		{MinorNumber: 2, PciBusNumber: "0000:83:00.0", DeviceId: "SN-Z100-0003", SubSystemName: "Z100",
+			Temperature: 33, PowerUsage: 40, PowerCap: 250, MemoryCap: 32 << 30, MemoryUsed: 0, UtilizationRate: 0},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("got %d devices, want %d", len(infos), len(want))
+	}
+	for i, w := range want {
+		got := infos[i]
+		if got.MinorNumber != w.MinorNumber || got.PciBusNumber != w.PciBusNumber || got.DeviceId != w.DeviceId ||
+			got.SubSystemName != w.SubSystemName {
+			t.Errorf("device %d: identity = %d %s %s %s, want %d %s %s %s", i, got.MinorNumber, got.PciBusNumber,
+				got.DeviceId, got.SubSystemName, w.MinorNumber, w.PciBusNumber, w.DeviceId, w.SubSystemName)
+		}
+		if got.Temperature != w.Temperature || got.PowerUsage != w.PowerUsage || got.PowerCap != w.PowerCap {
+			t.Errorf("device %d: temperature/power/cap = %v/%v/%v, want %v/%v/%v", i,
+				got.Temperature, got.PowerUsage, got.PowerCap, w.Temperature, w.PowerUsage, w.PowerCap)
+		}
+		if got.MemoryCap != w.MemoryCap || got.MemoryUsed != w.MemoryUsed || got.UtilizationRate != w.UtilizationRate {
+			t.Errorf("device %d: memory cap/used/utilization = %v/%v/%v, want %v/%v/%v", i,
+				got.MemoryCap, got.MemoryUsed, got.UtilizationRate, w.MemoryCap, w.MemoryUsed, w.UtilizationRate)
+		}
+		if got.PerfLevel != "AUTO" || len(got.SclkFrequency) != 4 || got.Clk != 1500 {
+			t.Errorf("device %d: perf level %q, sclk levels %v, clk %v", i, got.PerfLevel, got.SclkFrequency, got.Clk)
+		}
+	}
+}
+
+func TestFakeAllDeviceInfos(t *testing.T) {
+	advance := initFakeScenario(t)
+	// 第 10 秒与第 30 秒向设备 0 的 UMC 注入错误
+	advance(31 * time.Second)
+	list, err := AllDeviceInfos()
+	if err != nil {
+		t.Fatalf("AllDeviceInfos: %v", err)
+	}
+	infos := byMinorNumber(t, list)
+	if len(infos) != 3 {
+		t.Fatalf("got %d devices, want 3", len(infos))
+	}
+
+	dev0 := infos[0].Device
+	if dev0.ComputeUnitCount != 120 || dev0.MaxVDeviceCount != 4 || len(infos[0].VirtualDevices) != 0 {
+		t.Errorf("device 0: cus %v, max vdevices %v, vdevices %d", dev0.ComputeUnitCount, dev0.MaxVDeviceCount, len(infos[0].VirtualDevices))
+	}
+	blocks := map[string]BlocksInfo{}
+	for _, b := range dev0.BlocksInfos {
+		blocks[b.Block] = b
+	}
+	if umc := blocks["UMC"]; umc.State != "ENABLED" || umc.CE != 2 || umc.UE != 1 {
+		t.Errorf("device 0 UMC = %+v, want ENABLED with 2 CE and 1 UE", umc)
+	}
+	if gfx := blocks["GFX"]; gfx.State != "DISABLED" {
+		t.Errorf("device 0 GFX state = %q, want DISABLED", gfx.State)
+	}
+
+	dev1 := infos[1]
+	if dev1.Device.VDeviceCount != 2 || dev1.Device.ComputeUnitRemainingCount != 30 || dev1.Device.MemoryRemaining != 16<<30 {
+		t.Errorf("device 1: vdevices %v, remaining cus %v, remaining memory %v, want 2, 30, %d",
+			dev1.Device.VDeviceCount, dev1.Device.ComputeUnitRemainingCount, dev1.Device.MemoryRemaining, int64(16<<30))
+	}
+	wantVDevices := []DMIVDeviceInfo{
+		{ComputeUnitCount: 30, ContainerID: 101, DeviceID: 1, Percent: 40, VMinorNumber: 4, PciBusNumber: "0000:43:00.0"},
+		{ComputeUnitCount: 60, ContainerID: 102, DeviceID: 1, Percent: 10, VMinorNumber: 5, PciBusNumber: "0000:43:00.0"},
+	}
+	if len(dev1.VirtualDevices) != len(wantVDevices) {
+		t.Fatalf("device 1: got %d vdevices, want %d", len(dev1.VirtualDevices), len(wantVDevices))
+	}
+	for i, w := range wantVDevices {
+		got := dev1.VirtualDevices[i]
+		if got.ComputeUnitCount != w.ComputeUnitCount || got.ContainerID != w.ContainerID || got.DeviceID != w.DeviceID ||
+			got.Percent != w.Percent || got.VMinorNumber != w.VMinorNumber || got.PciBusNumber != w.PciBusNumber {
+			t.Errorf("device 1 vdevice %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestFakeScenarioMaxVDevices(t *testing.T) {
+	s, err := LoadFakeScenario(fakeScenarioPath)
+	if err != nil {
+		t.Fatalf("LoadFakeScenario: %v", err)
+	}
+	for i := range s.Devices {
+		s.Devices[i].MaxVDevices = 8
+	}
+	// 设备 2 的第一个虚拟设备索引为 16，超出按每设备 4 个计算的范围
+	s.Devices[2].VDevices = []FakeVDeviceSpec{{ComputeUnits: 10, MemoryMiB: 1024, ContainerID: 201}}
+	if err := InitWithBackend(NewFakeBackend(s)); err != nil {
+		t.Fatalf("InitWithBackend: %v", err)
+	}
+	t.Cleanup(func() { ShutDown() })
+	list, err := AllDeviceInfos()
+	if err != nil {
+		t.Fatalf("AllDeviceInfos: %v", err)
+	}
+	infos := byMinorNumber(t, list)
+	if vdevs := infos[2].VirtualDevices; len(vdevs) != 1 || vdevs[0].VMinorNumber != 16 || vdevs[0].ContainerID != 201 {
+		t.Errorf("device 2 vdevices = %+v, want one vdevice with index 16", vdevs)
+	}
+
+	s.Devices[1].MaxVDevices = 4
+	if err := s.normalize(); err == nil {
+		t.Error("normalize accepted devices with different maxVDevices")
+	}
+}
@@ -0,0 +1,482 @@
+package dcgm
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FakeScenario 模拟后端的场景描述，可以从 YAML 或 JSON 文件加载
+type FakeScenario struct {
+	// Devices 模拟的物理设备列表，下标即设备索引 dvInd
+	Devices []FakeDeviceSpec `json:"devices" yaml:"devices"`
+	// Processes 模拟的 KFD 进程
+	Processes []FakeProcessSpec `json:"processes" yaml:"processes"`
+	// Events 按时间触发的事件通知
+	Events []FakeEventSpec `json:"events" yaml:"events"`
+	// LinkType 设备之间的链路类型，PCIE 或 XGMI，默认 PCIE
+	LinkType string `json:"linkType" yaml:"linkType"`
+	// DriverVersion 驱动版本字符串
+	DriverVersion string `json:"driverVersion" yaml:"driverVersion"`
+	// EncryptionVM 加密虚拟机初始状态
+	EncryptionVM bool `json:"encryptionVM" yaml:"encryptionVM"`
+}
+
+// FakeDeviceSpec 单个模拟设备的配置
+type FakeDeviceSpec struct {
+	// Model 设备类型标识 id，取 type2name 中的键，例如 "6210"（K100_AI）
+	Model string `json:"model" yaml:"model"`
+	// Serial 设备序列号，为空时自动生成
+	Serial string `json:"serial" yaml:"serial"`
+	// PciBus PCI 总线号，格式 0000:03:00.0，为空时自动生成
+	PciBus string `json:"pciBus" yaml:"pciBus"`
+	// UniqueID 设备唯一 id，为 0 时自动生成
+	UniqueID int64 `json:"uniqueId" yaml:"uniqueId"`
+	// NumaNode 设备所在 NUMA 节点
+	NumaNode int `json:"numaNode" yaml:"numaNode"`
+	// VramVendor 显存供应商
+	VramVendor string `json:"vramVendor" yaml:"vramVendor"`
+	// Vbios VBIOS 版本
+	Vbios string `json:"vbios" yaml:"vbios"`
+	// Firmware 固件版本，键为 fwBlockNames 中的名称
+	Firmware map[string]int64 `json:"firmware" yaml:"firmware"`
+	// ComputeUnits 计算单元数量，为 0 时取 computeUnitType 中的值
+	ComputeUnits int `json:"computeUnits" yaml:"computeUnits"`
+	// MaxVDevices 支持的最大虚拟设备数量，默认 4，所有设备须相同
+	MaxVDevices int `json:"maxVDevices" yaml:"maxVDevices"`
+	// VramTotalMiB 显存总量（MiB）
+	VramTotalMiB int64 `json:"vramTotalMiB" yaml:"vramTotalMiB"`
+	// VramUsedMiB 显存使用量（MiB）
+	VramUsedMiB FakeValue `json:"vramUsedMiB" yaml:"vramUsedMiB"`
+	// Busy 设备忙碌百分比
+	Busy FakeValue `json:"busy" yaml:"busy"`
+	// MemBusy 显存忙碌百分比
+	MemBusy FakeValue `json:"memBusy" yaml:"memBusy"`
+	// Temperature 边缘温度（摄氏度），结温和显存温度在此基础上偏移
+	Temperature FakeValue `json:"temperature" yaml:"temperature"`
+	// Power 平均功耗（瓦特）
+	Power FakeValue `json:"power" yaml:"power"`
+	// PowerCap 功率上限（瓦特）
+	PowerCap float64 `json:"powerCap" yaml:"powerCap"`
+	// Voltage 电压（毫伏）
+	Voltage FakeValue `json:"voltage" yaml:"voltage"`
+	// FanRPM 风扇转速
+	FanRPM FakeValue `json:"fanRpm" yaml:"fanRpm"`
+	// PcieSent PCIe 发送包数量/秒
+	PcieSent FakeValue `json:"pcieSent" yaml:"pcieSent"`
+	// PcieReceived PCIe 接收包数量/秒
+	PcieReceived FakeValue `json:"pcieReceived" yaml:"pcieReceived"`
+	// PcieReplay PCIe 重放次数/秒，重放计数器取其积分
+	PcieReplay FakeValue `json:"pcieReplay" yaml:"pcieReplay"`
+	// ThrottleStatus gpu metrics 中的节流状态位
+	ThrottleStatus uint32 `json:"throttleStatus" yaml:"throttleStatus"`
+	// MetricsRevision gpu metrics 度量表版本，1.0–1.4，默认 1.3
+	MetricsRevision string `json:"metricsRevision" yaml:"metricsRevision"`
+	// Sclk 系统时钟
+	Sclk FakeClockSpec `json:"sclk" yaml:"sclk"`
+	// Socclk SoC 时钟
+	Socclk FakeClockSpec `json:"socclk" yaml:"socclk"`
+	// Mclk 显存时钟
+	Mclk FakeClockSpec `json:"mclk" yaml:"mclk"`
+	// PerfLevel 初始性能等级，如 auto、low、high、manual
+	PerfLevel string `json:"perfLevel" yaml:"perfLevel"`
+	// HiveID XGMI hive id
+	HiveID int64 `json:"hiveId" yaml:"hiveId"`
+	// Ecc 各 GPU 块的 ECC 配置
+	Ecc []FakeEccSpec `json:"ecc" yaml:"ecc"`
+	// RetiredPages 按时间出现的退役页
+	RetiredPages []FakeRetiredPageSpec `json:"retiredPages" yaml:"retiredPages"`
+	// XgmiErrors 按时间出现的 XGMI 错误，读取一次后清除
+	XgmiErrors []FakeXgmiErrorSpec `json:"xgmiErrors" yaml:"xgmiErrors"`
+	// VDevices 初始的虚拟设备
+	VDevices []FakeVDeviceSpec `json:"vdevices" yaml:"vdevices"`
+}
+
+// FakeClockSpec 时钟频率档位
+type FakeClockSpec struct {
+	// LevelsMHz 支持的频率档位（MHz）
+	LevelsMHz []uint64 `json:"levelsMHz" yaml:"levelsMHz"`
+	// Current 当前档位下标
+	Current int `json:"current" yaml:"current"`
+}
+
+// FakeEccSpec GPU 块的 ECC 状态与错误计数
+type FakeEccSpec struct {
+	// Block 块名称，取 blockToStringMap 中的值，例如 UMC、SDMA、GFX
+	Block string `json:"block" yaml:"block"`
+	// State ECC 状态，取 rasErrStaleMachine 中的值，默认 ENABLED
+	State string `json:"state" yaml:"state"`
+	// CE 初始可纠正错误数
+	CE uint64 `json:"ce" yaml:"ce"`
+	// UE 初始不可纠正错误数
+	UE uint64 `json:"ue" yaml:"ue"`
+	// Inject 按时间注入的错误
+	Inject []FakeEccInjection `json:"inject" yaml:"inject"`
+}
+
+// FakeEccInjection 在场景开始 At 秒后注入的 ECC 错误
+type FakeEccInjection struct {
+	At float64 `json:"at" yaml:"at"`
+	CE uint64  `json:"ce" yaml:"ce"`
+	UE uint64  `json:"ue" yaml:"ue"`
+}
+
+// FakeRetiredPageSpec 在场景开始 At 秒后出现的退役页
+type FakeRetiredPageSpec struct {
+	At float64 `json:"at" yaml:"at"`
+	// Address 页地址
+	Address uint64 `json:"address" yaml:"address"`
+	// Status 页状态，取 MemoryPageStatus 中的值：reserved、pending、unreservable，默认 reserved
+	Status string `json:"status" yaml:"status"`
+}
+
+// FakeXgmiErrorSpec 在场景开始 At 秒后发生的 XGMI 错误
+type FakeXgmiErrorSpec struct {
+	At float64 `json:"at" yaml:"at"`
+	// Count 错误次数，大于 1 时读取到 multiple errors
+	Count int `json:"count" yaml:"count"`
+}
+
+// FakeVDeviceSpec 初始虚拟设备
+type FakeVDeviceSpec struct {
+	ComputeUnits int       `json:"computeUnits" yaml:"computeUnits"`
+	MemoryMiB    int64     `json:"memoryMiB" yaml:"memoryMiB"`
+	ContainerID  uint64    `json:"containerId" yaml:"containerId"`
+	Busy         FakeValue `json:"busy" yaml:"busy"`
+}
+
+// FakeProcessSpec 模拟的 KFD 进程，Start/End 为场景开始后的秒数，End 为 0 表示一直运行
+type FakeProcessSpec struct {
+	Pid         uint32  `json:"pid" yaml:"pid"`
+	Pasid       uint32  `json:"pasid" yaml:"pasid"`
+	Devices     []int   `json:"devices" yaml:"devices"`
+	VramMiB     uint64  `json:"vramMiB" yaml:"vramMiB"`
+	SdmaUsage   uint64  `json:"sdmaUsage" yaml:"sdmaUsage"`
+	CuOccupancy uint32  `json:"cuOccupancy" yaml:"cuOccupancy"`
+	Start       float64 `json:"start" yaml:"start"`
+	End         float64 `json:"end" yaml:"end"`
+}
+
+// FakeEventSpec 在场景开始 At 秒后产生的事件通知
+type FakeEventSpec struct {
+	At      float64 `json:"at" yaml:"at"`
+	Device  int     `json:"device" yaml:"device"`
+	Type    string  `json:"type" yaml:"type"`
+	Message string  `json:"message" yaml:"message"`
+}
+
+// FakeValue 随时间变化的数值。在 YAML/JSON 中既可以写成一个数字，
+// 也可以写成对象：value(t) = base + ramp*t + amplitude*sin(2πt/period)，再限制在 [min, max] 内
+type FakeValue struct {
+	Base      float64  `json:"base" yaml:"base"`
+	Ramp      float64  `json:"ramp" yaml:"ramp"`
+	Amplitude float64  `json:"amplitude" yaml:"amplitude"`
+	Period    float64  `json:"period" yaml:"period"`
+	Min       *float64 `json:"min" yaml:"min"`
+	Max       *float64 `json:"max" yaml:"max"`
+}
+
+// ConstFakeValue 返回一个恒定值
+func ConstFakeValue(v float64) FakeValue {
+	return FakeValue{Base: v}
+}
+
+// At 返回场景开始 t 秒后的取值
+func (v FakeValue) At(t float64) float64 {
+	x := v.Base + v.Ramp*t
+	if v.Amplitude != 0 && v.Period > 0 {
+		x += v.Amplitude * math.Sin(2*math.Pi*t/v.Period)
+	}
+	if v.Min != nil && x < *v.Min {
+		x = *v.Min
+	}
+	if v.Max != nil && x > *v.Max {
+		x = *v.Max
+	}
+	return x
+}
+
+// Integral 返回 [0, t] 内的积分，用于能量和活动累加器
+func (v FakeValue) Integral(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if v.Ramp == 0 && v.Amplitude == 0 {
+		return v.At(0) * t
+	}
+	steps := int(math.Min(math.Ceil(t), 10000))
+	dt := t / float64(steps)
+	sum := 0.0
+	for i := 0; i < steps; i++ {
+		sum += (v.At(float64(i)*dt) + v.At(float64(i+1)*dt)) / 2 * dt
+	}
+	return sum
+}
+
+func (v FakeValue) isZero() bool {
+	return v.Base == 0 && v.Ramp == 0 && v.Amplitude == 0
+}
+
+// UnmarshalJSON 支持数字或对象两种写法
+func (v *FakeValue) UnmarshalJSON(data []byte) error {
+	var f float64
+	if err := json.Unmarshal(data, &f); err == nil {
+		*v = ConstFakeValue(f)
+		return nil
+	}
+	type plain FakeValue
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*v = FakeValue(p)
+	return nil
+}
+
+// UnmarshalYAML 支持数字或对象两种写法
+func (v *FakeValue) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		f, err := strconv.ParseFloat(node.Value, 64)
+		if err != nil {
+			return fmt.Errorf("Error fake value %q:%s", node.Value, err)
+		}
+		*v = ConstFakeValue(f)
+		return nil
+	}
+	type plain FakeValue
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*v = FakeValue(p)
+	return nil
+}
+
+// LoadFakeScenario 从文件加载场景，.json 文件按 JSON 解析，其余按 YAML 解析
+func LoadFakeScenario(path string) (*FakeScenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error read scenario %s:%s", path, err)
+	}
+	scenario := &FakeScenario{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, scenario)
+	} else {
+		err = yaml.Unmarshal(data, scenario)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error parse scenario %s:%s", path, err)
+	}
+	if err = scenario.normalize(); err != nil {
+		return nil, fmt.Errorf("Error scenario %s:%s", path, err)
+	}
+	return scenario, nil
+}
+
+// DefaultFakeScenario 返回内置场景：两张 K100_AI，其中 0 号卡温度缓慢上升，1 号卡带有一个虚拟设备
+func DefaultFakeScenario() *FakeScenario {
+	maxTemp := 85.0
+	scenario := &FakeScenario{
+		Devices: []FakeDeviceSpec{
+			{
+				Model:        "6210",
+				VramTotalMiB: 65536,
+				VramUsedMiB:  ConstFakeValue(12288),
+				Busy:         FakeValue{Base: 60, Amplitude: 20, Period: 60},
+				Temperature:  FakeValue{Base: 45, Ramp: 0.05, Max: &maxTemp},
+				Power:        ConstFakeValue(180),
+				Ecc:          []FakeEccSpec{{Block: "UMC"}, {Block: "SDMA"}, {Block: "GFX"}},
+			},
+			{
+				Model:        "6210",
+				VramTotalMiB: 65536,
+				Busy:         ConstFakeValue(15),
+				Temperature:  ConstFakeValue(38),
+				Power:        ConstFakeValue(95),
+				Ecc:          []FakeEccSpec{{Block: "UMC", Inject: []FakeEccInjection{{At: 120, CE: 1}}}},
+				VDevices:     []FakeVDeviceSpec{{ComputeUnits: 30, MemoryMiB: 16384}},
+			},
+		},
+		Processes: []FakeProcessSpec{{Pid: 4242, Devices: []int{0}, VramMiB: 12288, CuOccupancy: 80}},
+	}
+	scenario.normalize()
+	return scenario
+}
+
+// normalize 校验场景并补全默认值
+func (s *FakeScenario) normalize() error {
+	if len(s.Devices) == 0 {
+		return fmt.Errorf("no devices")
+	}
+	if s.LinkType == "" {
+		s.LinkType = "PCIE"
+	}
+	s.LinkType = strings.ToUpper(s.LinkType)
+	if s.LinkType != "PCIE" && s.LinkType != "XGMI" {
+		return fmt.Errorf("invalid linkType %q", s.LinkType)
+	}
+	if s.DriverVersion == "" {
+		s.DriverVersion = "6.3.8-fake"
+	}
+	for i := range s.Devices {
+		d := &s.Devices[i]
+		if d.Model == "" {
+			d.Model = "6210"
+		}
+		d.Model = strings.ToLower(d.Model)
+		if _, err := strconv.ParseUint(d.Model, 16, 16); err != nil {
+			return fmt.Errorf("device %d: invalid model %q", i, d.Model)
+		}
+		if d.Serial == "" {
+			d.Serial = fmt.Sprintf("FAKE%08d", i)
+		}
+		if d.PciBus == "" {
+			d.PciBus = fmt.Sprintf("0000:%02x:00.0", 0x10+i*0x10)
+		}
+		if _, err := parsePciBus(d.PciBus); err != nil {
+			return fmt.Errorf("device %d: %s", i, err)
+		}
+		if d.UniqueID == 0 {
+			d.UniqueID = 0x5a5a000000000000 + int64(i)
+		}
+		if d.VramVendor == "" {
+			d.VramVendor = "samsung"
+		}
+		if d.Vbios == "" {
+			d.Vbios = "113-FAKE-001"
+		}
+		if d.ComputeUnits == 0 {
+			d.ComputeUnits = int(computeUnitType[type2name[d.Model]])
+			if d.ComputeUnits == 0 {
+				d.ComputeUnits = 120
+			}
+		}
+		if d.MaxVDevices == 0 {
+			d.MaxVDevices = 4
+		}
+		if d.VramTotalMiB == 0 {
+			d.VramTotalMiB = 65536
+		}
+		if d.PowerCap == 0 {
+			d.PowerCap = 300
+		}
+		if d.Voltage.isZero() {
+			d.Voltage = ConstFakeValue(800)
+		}
+		if d.Temperature.isZero() {
+			d.Temperature = ConstFakeValue(40)
+		}
+		if len(d.Sclk.LevelsMHz) == 0 {
+			d.Sclk = FakeClockSpec{LevelsMHz: []uint64{300, 800, 1100, 1500}, Current: 3}
+		}
+		if len(d.Socclk.LevelsMHz) == 0 {
+			d.Socclk = FakeClockSpec{LevelsMHz: []uint64{300, 600, 900, 1000}, Current: 3}
+		}
+		if len(d.Mclk.LevelsMHz) == 0 {
+			d.Mclk = FakeClockSpec{LevelsMHz: []uint64{1000, 1600}, Current: 1}
+		}
+		for _, c := range []*FakeClockSpec{&d.Sclk, &d.Socclk, &d.Mclk} {
+			if c.Current < 0 || c.Current >= len(c.LevelsMHz) {
+				return fmt.Errorf("device %d: clock level %d out of range", i, c.Current)
+			}
+		}
+		if d.MetricsRevision == "" {
+			d.MetricsRevision = "1.3"
+		}
+		if _, err := ParseMetricsRevision(d.MetricsRevision); err != nil {
+			return fmt.Errorf("device %d: %s", i, err)
+		}
+		if d.PerfLevel == "" {
+			d.PerfLevel = "auto"
+		}
+		if _, ok := validLevels[strings.ToLower(d.PerfLevel)]; !ok {
+			return fmt.Errorf("device %d: invalid perfLevel %q", i, d.PerfLevel)
+		}
+		for j := range d.Ecc {
+			e := &d.Ecc[j]
+			e.Block = strings.ToUpper(e.Block)
+			if _, ok := stringToBlock(e.Block); !ok {
+				return fmt.Errorf("device %d: invalid ecc block %q", i, e.Block)
+			}
+			if e.State == "" {
+				e.State = "ENABLED"
+			}
+			e.State = strings.ToUpper(e.State)
+			if indexOf(rasErrStaleMachine, e.State) < 0 {
+				return fmt.Errorf("device %d: invalid ecc state %q", i, e.State)
+			}
+		}
+		for j := range d.RetiredPages {
+			page := &d.RetiredPages[j]
+			if page.Status == "" {
+				page.Status = "reserved"
+			}
+			page.Status = strings.ToLower(page.Status)
+			if _, ok := memoryPageStatus(page.Status); !ok {
+				return fmt.Errorf("device %d: invalid retired page status %q", i, page.Status)
+			}
+		}
+		for j := range d.XgmiErrors {
+			if d.XgmiErrors[j].Count <= 0 {
+				d.XgmiErrors[j].Count = 1
+			}
+		}
+		if len(d.VDevices) > d.MaxVDevices {
+			return fmt.Errorf("device %d: %d vdevices exceed maxVDevices %d", i, len(d.VDevices), d.MaxVDevices)
+		}
+		// 虚拟设备索引按 maxVDevices 分段，库只报告一个全局的最大数量
+		if d.MaxVDevices != s.Devices[0].MaxVDevices {
+			return fmt.Errorf("device %d: maxVDevices %d differs from device 0 (%d)", i, d.MaxVDevices, s.Devices[0].MaxVDevices)
+		}
+	}
+	for _, p := range s.Processes {
+		for _, dv := range p.Devices {
+			if dv < 0 || dv >= len(s.Devices) {
+				return fmt.Errorf("process %d: invalid device %d", p.Pid, dv)
+			}
+		}
+	}
+	for _, e := range s.Events {
+		if e.Device < 0 || e.Device >= len(s.Devices) {
+			return fmt.Errorf("event: invalid device %d", e.Device)
+		}
+		if _, ok := eventTypeValues[EventType(strings.ToUpper(e.Type))]; !ok {
+			return fmt.Errorf("event: invalid type %q", e.Type)
+		}
+	}
+	return nil
+}
+
+// stringToBlock 根据名称查找 GPU 块
+func stringToBlock(name string) (RSMIGpuBlock, bool) {
+	for block, str := range blockToStringMap {
+		if str == name {
+			return block, true
+		}
+	}
+	return RSMIGpuBlockInvalid, false
+}
+
+func memoryPageStatus(name string) (RSMIMemoryPageStatus, bool) {
+	for status, str := range MemoryPageStatus {
+		if str == name {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+// parsePciBus 将 0000:03:00.0 格式的总线号转换为 rsmi 的 bdfid
+func parsePciBus(pciBus string) (int64, error) {
+	var domain, bus, dev, function int64
+	if _, err := fmt.Sscanf(pciBus, "%x:%x:%x.%x", &domain, &bus, &dev, &function); err != nil {
+		return 0, fmt.Errorf("invalid pci bus %q", pciBus)
+	}
+	return domain<<32 | bus<<8 | dev<<3 | function, nil
+}
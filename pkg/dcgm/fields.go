@@ -0,0 +1,658 @@
+package dcgm
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FieldID 字段的数值标识，与 NVIDIA DCGM 含义相同的字段沿用 DCGM 的编号；
+// DCU 独有的设备字段从 2000 开始，虚拟设备字段从 3000 开始，进程字段从 4000 开始。编号一经发布不再改变。
+type FieldID int
+
+const (
+	FieldDevName        FieldID = 50  // 型号名称
+	FieldSerial         FieldID = 53  // 序列号
+	FieldPciBusID       FieldID = 57  // PCI 总线号
+	FieldVbiosVersion   FieldID = 85  // VBIOS 版本
+	FieldSmClock        FieldID = 100 // 系统时钟 sclk，MHz
+	FieldMemClock       FieldID = 101 // 显存时钟 mclk，MHz
+	FieldMemoryTemp     FieldID = 140 // 显存温度，摄氏度
+	FieldGpuTemp        FieldID = 150 // 边缘温度，摄氏度
+	FieldPowerUsage     FieldID = 155 // 平均功耗，瓦
+	FieldTotalEnergy    FieldID = 156 // 能耗统计启动以来消耗的能量，毫焦
+	FieldPowerLimit     FieldID = 160 // 功率上限，瓦
+	FieldFanSpeed       FieldID = 191 // 风扇转速百分比
+	FieldPcieTx         FieldID = 200 // PCIe 发送流量，KB
+	FieldPcieRx         FieldID = 201 // PCIe 接收流量，KB
+	FieldPcieReplay     FieldID = 202 // PCIe 重放计数
+	FieldGpuUtil        FieldID = 203 // 设备忙碌百分比
+	FieldMemCopyUtil    FieldID = 204 // 显存忙碌百分比
+	FieldFbTotal        FieldID = 250 // 显存总量，MiB
+	FieldFbFree         FieldID = 251 // 显存剩余量，MiB
+	FieldFbUsed         FieldID = 252 // 显存使用量，MiB
+	FieldEccSbeVolTotal FieldID = 310 // 各 RAS 块可纠正 ECC 错误之和
+	FieldEccDbeVolTotal FieldID = 311 // 各 RAS 块不可纠正 ECC 错误之和
+	FieldEccSbeAggTotal FieldID = 312 // 各 RAS 块可纠正 ECC 错误之和，DCU 不区分易失与持久计数
+	FieldEccDbeAggTotal FieldID = 313 // 各 RAS 块不可纠正 ECC 错误之和，DCU 不区分易失与持久计数
+
+	FieldSocClock     FieldID = 2000 // SoC 时钟 socclk，MHz
+	FieldJunctionTemp FieldID = 2001 // 结温，摄氏度
+	FieldPerfLevel    FieldID = 2002 // 性能等级
+	FieldComputeUnits FieldID = 2003 // 计算单元数量
+	FieldGfxActivity  FieldID = 2004 // 由活动累加器计算的平均图形活动百分比
+	FieldMemActivity  FieldID = 2005 // 由活动累加器计算的平均内存活动百分比
+
+	FieldVDevUtil        FieldID = 3000 // 虚拟设备使用百分比
+	FieldVDevFbTotal     FieldID = 3001 // 虚拟设备显存总量，MiB
+	FieldVDevFbUsed      FieldID = 3002 // 虚拟设备显存使用量，MiB
+	FieldVDevCUs         FieldID = 3003 // 虚拟设备计算单元数量
+	FieldVDevDeviceID    FieldID = 3004 // 虚拟设备所属物理设备索引
+	FieldVDevContainerID FieldID = 3005 // 虚拟设备所属容器 ID
+
+	FieldProcVramUsage   FieldID = 4000 // 进程显存使用量，MiB
+	FieldProcSdmaUsage   FieldID = 4001 // 进程 SDMA 使用时间，微秒
+	FieldProcCuOccupancy FieldID = 4002 // 进程占用的计算单元数量
+	FieldProcName        FieldID = 4003 // 进程名称
+	FieldProcDevices     FieldID = 4004 // 进程使用的设备索引列表
+)
+
+// 常用的字段组，可直接传给 WatchFields
+var (
+	FieldGroupIdentity    = []FieldID{FieldDevName, FieldSerial, FieldPciBusID, FieldVbiosVersion}
+	FieldGroupTemperature = []FieldID{FieldGpuTemp, FieldJunctionTemp, FieldMemoryTemp}
+	FieldGroupPower       = []FieldID{FieldPowerUsage, FieldPowerLimit}
+	FieldGroupClocks      = []FieldID{FieldSmClock, FieldMemClock, FieldSocClock, FieldPerfLevel}
+	FieldGroupMemory      = []FieldID{FieldFbTotal, FieldFbFree, FieldFbUsed}
+	FieldGroupUtilization = []FieldID{FieldGpuUtil, FieldMemCopyUtil, FieldGfxActivity, FieldMemActivity}
+	FieldGroupPcie        = []FieldID{FieldPcieTx, FieldPcieRx, FieldPcieReplay}
+	FieldGroupEcc         = []FieldID{FieldEccSbeVolTotal, FieldEccDbeVolTotal}
+)
+
+// FieldType 字段值的类型
+type FieldType int
+
+const (
+	FieldTypeInt64 FieldType = iota
+	FieldTypeFloat64
+	FieldTypeString
+)
+
+var fieldTypeNames = []string{"int64", "float64", "string"}
+
+func (t FieldType) String() string {
+	if int(t) < len(fieldTypeNames) {
+		return fieldTypeNames[t]
+	}
+	return "unknown"
+}
+
+// MarshalText 以名称序列化，便于 REST 接口阅读
+func (t FieldType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// FieldScope 字段所属的实体类型，决定读取时实体 ID 的含义
+type FieldScope int
+
+const (
+	FieldScopeDevice  FieldScope = iota // 实体 ID 为物理设备索引
+	FieldScopeVDevice                   // 实体 ID 为虚拟设备索引
+	FieldScopeProcess                   // 实体 ID 为进程 PID
+)
+
+var fieldScopeNames = []string{"device", "vdevice", "process"}
+
+func (s FieldScope) String() string {
+	if int(s) < len(fieldScopeNames) {
+		return fieldScopeNames[s]
+	}
+	return "unknown"
+}
+
+// MarshalText 以名称序列化，便于 REST 接口阅读
+func (s FieldScope) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// FieldMeta 字段的描述信息
+type FieldMeta struct {
+	ID          FieldID
+	Name        string
+	Unit        string
+	Type        FieldType
+	Scope       FieldScope
+	Static      bool // 不随时间变化，监视时只采样一次
+	Description string
+}
+
+// FieldValue 字段的一次读取结果，数值字段使用 Value，字符串字段使用 Text；读取失败时 Err 为错误信息
+type FieldValue struct {
+	FieldID   FieldID
+	EntityID  int
+	Timestamp time.Time
+	Value     float64
+	Text      string
+	Err       string `json:",omitempty"`
+}
+
+// fieldInfo 字段描述与读取函数，entityID 的含义由 Scope 决定
+type fieldInfo struct {
+	FieldMeta
+	get func(entityID int) (FieldValue, error)
+}
+
+// fieldRegistry 所有字段，按编号排列
+var fieldRegistry = []fieldInfo{
+	{FieldMeta{FieldDevName, "DEV_NAME", "", FieldTypeString, FieldScopeDevice, true, "型号名称"}, getDevName},
+	{FieldMeta{FieldSerial, "DEV_SERIAL", "", FieldTypeString, FieldScopeDevice, true, "序列号"}, getSerial},
+	{FieldMeta{FieldPciBusID, "DEV_PCI_BUSID", "", FieldTypeString, FieldScopeDevice, true, "PCI 总线号"}, getPciBusID},
+	{FieldMeta{FieldVbiosVersion, "DEV_VBIOS_VERSION", "", FieldTypeString, FieldScopeDevice, true, "VBIOS 版本"}, getVbiosVersion},
+	{FieldMeta{FieldSmClock, "DEV_SM_CLOCK", "MHz", FieldTypeFloat64, FieldScopeDevice, false, "系统时钟 sclk"}, clockGetter(RSMI_CLK_TYPE_SYS)},
+	{FieldMeta{FieldMemClock, "DEV_MEM_CLOCK", "MHz", FieldTypeFloat64, FieldScopeDevice, false, "显存时钟 mclk"}, clockGetter(RSMI_CLK_TYPE_MEM)},
+	{FieldMeta{FieldMemoryTemp, "DEV_MEMORY_TEMP", "C", FieldTypeFloat64, FieldScopeDevice, false, "显存温度"}, tempGetter(SENSOR_MEMORY)},
+	{FieldMeta{FieldGpuTemp, "DEV_GPU_TEMP", "C", FieldTypeFloat64, FieldScopeDevice, false, "边缘温度"}, tempGetter(SENSOR_EDGE)},
+	{FieldMeta{FieldPowerUsage, "DEV_POWER_USAGE", "W", FieldTypeFloat64, FieldScopeDevice, false, "平均功耗"}, getPowerUsage},
+	{FieldMeta{FieldTotalEnergy, "DEV_TOTAL_ENERGY_CONSUMPTION", "mJ", FieldTypeInt64, FieldScopeDevice, false, "能耗统计启动以来消耗的能量"}, getTotalEnergy},
+	{FieldMeta{FieldPowerLimit, "DEV_POWER_MGMT_LIMIT", "W", FieldTypeFloat64, FieldScopeDevice, false, "功率上限"}, getPowerLimit},
+	{FieldMeta{FieldFanSpeed, "DEV_FAN_SPEED", "%", FieldTypeFloat64, FieldScopeDevice, false, "风扇转速百分比"}, getFanSpeed},
+	{FieldMeta{FieldPcieTx, "DEV_PCIE_TX_THROUGHPUT", "KB", FieldTypeFloat64, FieldScopeDevice, false, "最近一秒 PCIe 发送流量"}, pcieGetter(true)},
+	{FieldMeta{FieldPcieRx, "DEV_PCIE_RX_THROUGHPUT", "KB", FieldTypeFloat64, FieldScopeDevice, false, "最近一秒 PCIe 接收流量"}, pcieGetter(false)},
+	{FieldMeta{FieldPcieReplay, "DEV_PCIE_REPLAY_COUNTER", "", FieldTypeInt64, FieldScopeDevice, false, "PCIe 重放计数"}, getPcieReplay},
+	{FieldMeta{FieldGpuUtil, "DEV_GPU_UTIL", "%", FieldTypeInt64, FieldScopeDevice, false, "设备忙碌百分比"}, getGpuUtil},
+	{FieldMeta{FieldMemCopyUtil, "DEV_MEM_COPY_UTIL", "%", FieldTypeInt64, FieldScopeDevice, false, "显存忙碌百分比"}, getMemCopyUtil},
+	{FieldMeta{FieldFbTotal, "DEV_FB_TOTAL", "MiB", FieldTypeFloat64, FieldScopeDevice, false, "显存总量"}, fbGetter(FieldFbTotal)},
+	{FieldMeta{FieldFbFree, "DEV_FB_FREE", "MiB", FieldTypeFloat64, FieldScopeDevice, false, "显存剩余量"}, fbGetter(FieldFbFree)},
+	{FieldMeta{FieldFbUsed, "DEV_FB_USED", "MiB", FieldTypeFloat64, FieldScopeDevice, false, "显存使用量"}, fbGetter(FieldFbUsed)},
+	{FieldMeta{FieldEccSbeVolTotal, "DEV_ECC_SBE_VOL_TOTAL", "", FieldTypeInt64, FieldScopeDevice, false, "可纠正 ECC 错误总数"}, eccGetter(false)},
+	{FieldMeta{FieldEccDbeVolTotal, "DEV_ECC_DBE_VOL_TOTAL", "", FieldTypeInt64, FieldScopeDevice, false, "不可纠正 ECC 错误总数"}, eccGetter(true)},
+	{FieldMeta{FieldEccSbeAggTotal, "DEV_ECC_SBE_AGG_TOTAL", "", FieldTypeInt64, FieldScopeDevice, false, "可纠正 ECC 错误总数"}, eccGetter(false)},
+	{FieldMeta{FieldEccDbeAggTotal, "DEV_ECC_DBE_AGG_TOTAL", "", FieldTypeInt64, FieldScopeDevice, false, "不可纠正 ECC 错误总数"}, eccGetter(true)},
+	{FieldMeta{FieldSocClock, "DEV_SOC_CLOCK", "MHz", FieldTypeFloat64, FieldScopeDevice, false, "SoC 时钟 socclk"}, clockGetter(RSMI_CLK_TYPE_SOC)},
+	{FieldMeta{FieldJunctionTemp, "DEV_JUNCTION_TEMP", "C", FieldTypeFloat64, FieldScopeDevice, false, "结温"}, tempGetter(SENSOR_JUNCTION)},
+	{FieldMeta{FieldPerfLevel, "DEV_PERF_LEVEL", "", FieldTypeString, FieldScopeDevice, false, "性能等级"}, getPerfLevel},
+	{FieldMeta{FieldComputeUnits, "DEV_COMPUTE_UNITS", "", FieldTypeInt64, FieldScopeDevice, true, "计算单元数量"}, getComputeUnits},
+	{FieldMeta{FieldGfxActivity, "DEV_GFX_ACTIVITY", "%", FieldTypeFloat64, FieldScopeDevice, false, "自上一次读取以来的平均图形活动百分比"}, activityGetter(defaultActivity, true)},
+	{FieldMeta{FieldMemActivity, "DEV_MEM_ACTIVITY", "%", FieldTypeFloat64, FieldScopeDevice, false, "自上一次读取以来的平均内存活动百分比"}, activityGetter(defaultActivity, false)},
+	{FieldMeta{FieldVDevUtil, "VDEV_UTIL", "%", FieldTypeInt64, FieldScopeVDevice, false, "虚拟设备使用百分比"}, getVDevUtil},
+	{FieldMeta{FieldVDevFbTotal, "VDEV_FB_TOTAL", "MiB", FieldTypeFloat64, FieldScopeVDevice, false, "虚拟设备显存总量"}, vDevGetter(FieldVDevFbTotal)},
+	{FieldMeta{FieldVDevFbUsed, "VDEV_FB_USED", "MiB", FieldTypeFloat64, FieldScopeVDevice, false, "虚拟设备显存使用量"}, vDevGetter(FieldVDevFbUsed)},
+	{FieldMeta{FieldVDevCUs, "VDEV_COMPUTE_UNITS", "", FieldTypeInt64, FieldScopeVDevice, false, "虚拟设备计算单元数量"}, vDevGetter(FieldVDevCUs)},
+	{FieldMeta{FieldVDevDeviceID, "VDEV_DEVICE_ID", "", FieldTypeInt64, FieldScopeVDevice, false, "所属物理设备索引"}, vDevGetter(FieldVDevDeviceID)},
+	{FieldMeta{FieldVDevContainerID, "VDEV_CONTAINER_ID", "", FieldTypeString, FieldScopeVDevice, false, "所属容器 ID"}, vDevGetter(FieldVDevContainerID)},
+	{FieldMeta{FieldProcVramUsage, "PROC_VRAM_USAGE", "MiB", FieldTypeFloat64, FieldScopeProcess, false, "进程显存使用量"}, procGetter(FieldProcVramUsage)},
+	{FieldMeta{FieldProcSdmaUsage, "PROC_SDMA_USAGE", "us", FieldTypeInt64, FieldScopeProcess, false, "进程 SDMA 使用时间"}, procGetter(FieldProcSdmaUsage)},
+	{FieldMeta{FieldProcCuOccupancy, "PROC_CU_OCCUPANCY", "", FieldTypeInt64, FieldScopeProcess, false, "进程占用的计算单元数量"}, procGetter(FieldProcCuOccupancy)},
+	{FieldMeta{FieldProcName, "PROC_NAME", "", FieldTypeString, FieldScopeProcess, true, "进程名称"}, getProcName},
+	{FieldMeta{FieldProcDevices, "PROC_DEVICES", "", FieldTypeString, FieldScopeProcess, false, "进程使用的设备索引列表"}, getProcDevices},
+}
+
+var (
+	fieldIndexOnce sync.Once
+	fieldsByID     map[FieldID]*fieldInfo
+	fieldsByName   map[string]*fieldInfo
+)
+
+func fieldIndex() {
+	fieldIndexOnce.Do(func() {
+		fieldsByID = make(map[FieldID]*fieldInfo, len(fieldRegistry))
+		fieldsByName = make(map[string]*fieldInfo, len(fieldRegistry))
+		for i := range fieldRegistry {
+			fieldsByID[fieldRegistry[i].ID] = &fieldRegistry[i]
+			fieldsByName[fieldRegistry[i].Name] = &fieldRegistry[i]
+		}
+	})
+}
+
+// lookupField 按编号查找字段
+func lookupField(id FieldID) (*fieldInfo, bool) {
+	fieldIndex()
+	info, ok := fieldsByID[id]
+	return info, ok
+}
+
+// Fields 返回所有字段的描述，按编号排列
+func Fields() []FieldMeta {
+	metas := make([]FieldMeta, 0, len(fieldRegistry))
+	for _, info := range fieldRegistry {
+		metas = append(metas, info.FieldMeta)
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].ID < metas[j].ID })
+	return metas
+}
+
+// FieldByID 按编号查找字段描述
+func FieldByID(id FieldID) (FieldMeta, bool) {
+	info, ok := lookupField(id)
+	if !ok {
+		return FieldMeta{}, false
+	}
+	return info.FieldMeta, true
+}
+
+// FieldByName 按名称查找字段描述，名称可带 DCGM_FI_ 或 DCU_FI_ 前缀，不区分大小写
+func FieldByName(name string) (FieldMeta, bool) {
+	fieldIndex()
+	name = strings.ToUpper(strings.TrimSpace(name))
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "DCGM_FI_"), "DCU_FI_")
+	info, ok := fieldsByName[name]
+	if !ok {
+		return FieldMeta{}, false
+	}
+	return info.FieldMeta, true
+}
+
+// String 返回字段名称
+func (f FieldID) String() string {
+	if info, ok := lookupField(f); ok {
+		return info.Name
+	}
+	return fmt.Sprintf("FIELD_%d", int(f))
+}
+
+// ParseFieldIDs 解析逗号分隔的字段列表，每项可以是编号或名称，例如 "150,155,DEV_FB_USED"
+func ParseFieldIDs(s string) ([]FieldID, error) {
+	var fields []FieldID
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if id, err := strconv.Atoi(item); err == nil {
+			if _, ok := lookupField(FieldID(id)); !ok {
+				return nil, fmt.Errorf("Error parse fields:unknown field %d", id)
+			}
+			fields = append(fields, FieldID(id))
+			continue
+		}
+		meta, ok := FieldByName(item)
+		if !ok {
+			return nil, fmt.Errorf("Error parse fields:unknown field %s", item)
+		}
+		fields = append(fields, meta.ID)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("Error parse fields:no fields in %q", s)
+	}
+	return fields, nil
+}
+
+// ParseEntityList 解析逗号分隔的实体列表，支持区间，例如 "0-3,6"
+func ParseEntityList(s string) ([]int, error) {
+	var entities []int
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(item, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return nil, fmt.Errorf("Error parse entities:invalid entity %q", item)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(to)); err != nil || end < start {
+				return nil, fmt.Errorf("Error parse entities:invalid range %q", item)
+			}
+		}
+		for i := start; i <= end; i++ {
+			entities = append(entities, i)
+		}
+	}
+	return entities, nil
+}
+
+// scopeEntities 返回某类实体的全部 ID：物理设备索引、已创建的虚拟设备索引或使用 DCU 的进程 PID
+func scopeEntities(scope FieldScope) ([]int, error) {
+	switch scope {
+	case FieldScopeVDevice:
+		vDeviceInfos, err := VDeviceInfos()
+		if err != nil {
+			return nil, err
+		}
+		entities := make([]int, 0, len(vDeviceInfos))
+		for _, info := range vDeviceInfos {
+			entities = append(entities, info.VMinorNumber)
+		}
+		return entities, nil
+	case FieldScopeProcess:
+		processInfos, _, err := rsmiComputeProcessInfoGet()
+		if err != nil {
+			return nil, err
+		}
+		entities := make([]int, 0, len(processInfos))
+		for _, info := range processInfos {
+			entities = append(entities, int(info.ProcessID))
+		}
+		return entities, nil
+	default:
+		count, err := rsmiNumMonitorDevices()
+		if err != nil {
+			return nil, err
+		}
+		entities := make([]int, count)
+		for i := range entities {
+			entities[i] = i
+		}
+		return entities, nil
+	}
+}
+
+// fieldValue 读取单个实体的字段，平均活动字段由 cursor 计算
+func fieldValue(cursor *ActivityCursor, info *fieldInfo, entityID int) (FieldValue, error) {
+	switch info.ID {
+	case FieldGfxActivity, FieldMemActivity:
+		return activityGetter(cursor, info.ID == FieldGfxActivity)(entityID)
+	}
+	return info.get(entityID)
+}
+
+// readField 读取单个实体的字段，失败时错误信息记录在 Err 中
+func readField(cursor *ActivityCursor, info *fieldInfo, entityID int) FieldValue {
+	value, err := fieldValue(cursor, info, entityID)
+	value.FieldID = info.ID
+	value.EntityID = entityID
+	value.Timestamp = time.Now()
+	if err != nil {
+		value.Err = err.Error()
+	}
+	return value
+}
+
+// GetFieldValues 实时读取一组实体的一组字段，entities 为空时读取每个字段所属类型的全部实体；
+// 单个字段读取失败不影响其余字段，错误信息记录在对应 FieldValue.Err 中。
+// 结果按实体、字段的顺序排列，不同实体并发读取。平均活动字段使用包级游标，周期性读取的调用方应使用各自的 ActivityCursor
+func GetFieldValues(entities []int, fields []FieldID) ([]FieldValue, error) {
+	return getFieldValues(defaultActivity, entities, fields)
+}
+
+func getFieldValues(cursor *ActivityCursor, entities []int, fields []FieldID) ([]FieldValue, error) {
+	type task struct {
+		info     *fieldInfo
+		entityID int
+	}
+	var tasks []task
+	scopeCache := map[FieldScope][]int{}
+	for _, field := range fields {
+		info, ok := lookupField(field)
+		if !ok {
+			return nil, fmt.Errorf("Error GetFieldValues:unknown field %d", field)
+		}
+		ids := entities
+		if len(ids) == 0 {
+			cached, ok := scopeCache[info.Scope]
+			if !ok {
+				var err error
+				if cached, err = scopeEntities(info.Scope); err != nil {
+					return nil, fmt.Errorf("Error GetFieldValues:%w", err)
+				}
+				scopeCache[info.Scope] = cached
+			}
+			ids = cached
+		}
+		for _, id := range ids {
+			tasks = append(tasks, task{info, id})
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].entityID < tasks[j].entityID })
+
+	values := make([]FieldValue, len(tasks))
+	var wg sync.WaitGroup
+	for start := 0; start < len(tasks); {
+		end := start
+		for end < len(tasks) && tasks[end].entityID == tasks[start].entityID {
+			end++
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				values[i] = readField(cursor, tasks[i].info, tasks[i].entityID)
+			}
+		}(start, end)
+		start = end
+	}
+	wg.Wait()
+	return values, nil
+}
+
+// pciBusNumber 返回设备的 PCI 总线号，格式与 CollectDeviceMetrics 相同
+func pciBusNumber(dvInd int) (string, error) {
+	bdfid, err := rsmiDevPciIdGet(dvInd)
+	if err != nil {
+		return "", err
+	}
+	domain := (bdfid >> 32) & 0xffffffff
+	bus := (bdfid >> 8) & 0xff
+	dev := (bdfid >> 3) & 0x1f
+	function := bdfid & 0x7
+	return fmt.Sprintf("%04x:%02x:%02x.%x", domain, bus, dev, function), nil
+}
+
+/****************************************** 设备字段 *********************************************/
+
+func getDevName(dvInd int) (FieldValue, error) {
+	devTypeId, err := rsmiDevIdGet(dvInd)
+	if err != nil {
+		return FieldValue{}, err
+	}
+	return FieldValue{Text: type2name[fmt.Sprintf("%x", devTypeId)]}, nil
+}
+
+func getSerial(dvInd int) (FieldValue, error) {
+	serial, err := rsmiDevSerialNumberGet(dvInd)
+	return FieldValue{Text: serial}, err
+}
+
+func getPciBusID(dvInd int) (FieldValue, error) {
+	busID, err := pciBusNumber(dvInd)
+	return FieldValue{Text: busID}, err
+}
+
+func getVbiosVersion(dvInd int) (FieldValue, error) {
+	vbios, err := rsmiDevVbiosVersionGet(dvInd, 256)
+	return FieldValue{Text: vbios}, err
+}
+
+func clockGetter(clkType RSMIClkType) func(dvInd int) (FieldValue, error) {
+	return func(dvInd int) (FieldValue, error) {
+		clk, err := rsmiDevGpuClkFreqGet(dvInd, clkType)
+		if err != nil {
+			return FieldValue{}, err
+		}
+		if clk.Current >= clk.NumSupported || int(clk.Current) >= len(clk.Frequency) {
+			return FieldValue{}, fmt.Errorf("Error clock %d:current level %d out of %d supported", clkType, clk.Current, clk.NumSupported)
+		}
+		return FieldValue{Value: float64(clk.Frequency[clk.Current]) / 1000000.0}, nil
+	}
+}
+
+func tempGetter(sensor int) func(dvInd int) (FieldValue, error) {
+	return func(dvInd int) (FieldValue, error) {
+		temp, err := rsmiDevTempMetricGet(dvInd, sensor, RSMI_TEMP_CURRENT)
+		return FieldValue{Value: float64(temp) / 1000.0}, err
+	}
+}
+
+func getPowerUsage(dvInd int) (FieldValue, error) {
+	power, err := rsmiDevPowerAveGet(dvInd, 0)
+	return FieldValue{Value: float64(power) / 1000000.0}, err
+}
+
+// getTotalEnergy 需要先启动能耗统计（StartEnergyAccounting），累加器的回绕与重置已处理
+func getTotalEnergy(dvInd int) (FieldValue, error) {
+	reading, err := EnergySinceStart(dvInd)
+	return FieldValue{Value: float64(int64(reading.Joules * 1000))}, err
+}
+
+func getPowerLimit(dvInd int) (FieldValue, error) {
+	power, err := rsmiDevPowerCapGet(dvInd, 0)
+	return FieldValue{Value: float64(power) / 1000000.0}, err
+}
+
+func getFanSpeed(dvInd int) (FieldValue, error) {
+	speed, err := rsmiDevFanSpeedGet(dvInd, 0)
+	if err != nil {
+		return FieldValue{}, err
+	}
+	maxSpeed, err := rsmiDevFanSpeedMaxGet(dvInd, 0)
+	if err != nil || maxSpeed == 0 {
+		return FieldValue{}, fmt.Errorf("Error fan speed max:%v", err)
+	}
+	return FieldValue{Value: float64(speed) / float64(maxSpeed) * 100}, nil
+}
+
+func pcieGetter(tx bool) func(dvInd int) (FieldValue, error) {
+	return func(dvInd int) (FieldValue, error) {
+		sent, received, maxPktSz, err := rsmiDevPciThroughputGet(dvInd)
+		if err != nil {
+			return FieldValue{}, err
+		}
+		packets := received
+		if tx {
+			packets = sent
+		}
+		return FieldValue{Value: float64(packets) * float64(maxPktSz) / 1024.0}, nil
+	}
+}
+
+func getPcieReplay(dvInd int) (FieldValue, error) {
+	counter, err := rsmiDevPciReplayCounterGet(dvInd)
+	return FieldValue{Value: float64(counter)}, err
+}
+
+func getGpuUtil(dvInd int) (FieldValue, error) {
+	percent, err := rsmiDevBusyPercentGet(dvInd)
+	return FieldValue{Value: float64(percent)}, err
+}
+
+func getMemCopyUtil(dvInd int) (FieldValue, error) {
+	percent, err := rsmiDevMemoryBusyPercentGet(dvInd)
+	return FieldValue{Value: float64(percent)}, err
+}
+
+// activityGetter 由两次读取之间活动累加器的差值计算平均利用率，首次读取返回瞬时繁忙百分比
+func activityGetter(cursor *ActivityCursor, gfx bool) func(dvInd int) (FieldValue, error) {
+	return func(dvInd int) (FieldValue, error) {
+		util, err := cursor.Utilization(dvInd)
+		if gfx {
+			return FieldValue{Value: util.GfxActivity}, err
+		}
+		return FieldValue{Value: util.MemActivity}, err
+	}
+}
+
+func fbGetter(field FieldID) func(dvInd int) (FieldValue, error) {
+	return func(dvInd int) (FieldValue, error) {
+		total, err := rsmiDevMemoryTotalGet(dvInd, RSMI_MEM_TYPE_VRAM)
+		if err != nil || field == FieldFbTotal {
+			return FieldValue{Value: float64(total) / 1024.0 / 1024.0}, err
+		}
+		used, err := rsmiDevMemoryUsageGet(dvInd, RSMI_MEM_TYPE_VRAM)
+		if err != nil || field == FieldFbUsed {
+			return FieldValue{Value: float64(used) / 1024.0 / 1024.0}, err
+		}
+		return FieldValue{Value: float64(total-used) / 1024.0 / 1024.0}, nil
+	}
+}
+
+func eccGetter(ue bool) func(dvInd int) (FieldValue, error) {
+	return func(dvInd int) (FieldValue, error) {
+		blocksInfos, err := EccBlocksInfo(dvInd)
+		if err != nil {
+			return FieldValue{}, err
+		}
+		var total int64
+		for _, block := range blocksInfos {
+			if ue {
+				total += block.UE
+			} else {
+				total += block.CE
+			}
+		}
+		return FieldValue{Value: float64(total)}, nil
+	}
+}
+
+func getPerfLevel(dvInd int) (FieldValue, error) {
+	level, err := rsmiDevPerfLevelGet(dvInd)
+	if err != nil {
+		return FieldValue{}, err
+	}
+	return FieldValue{Value: float64(level), Text: perfLevelString(int(level))}, nil
+}
+
+func getComputeUnits(dvInd int) (FieldValue, error) {
+	devTypeId, err := rsmiDevIdGet(dvInd)
+	if err != nil {
+		return FieldValue{}, err
+	}
+	return FieldValue{Value: computeUnitType[type2name[fmt.Sprintf("%x", devTypeId)]]}, nil
+}
+
+/****************************************** 虚拟设备字段 *********************************************/
+
+func getVDevUtil(vDvInd int) (FieldValue, error) {
+	percent, err := dmiGetVDevBusyPercent(vDvInd)
+	return FieldValue{Value: float64(percent)}, err
+}
+
+func vDevGetter(field FieldID) func(vDvInd int) (FieldValue, error) {
+	return func(vDvInd int) (FieldValue, error) {
+		info, err := dmiGetVDeviceInfo(vDvInd)
+		if err != nil {
+			return FieldValue{}, err
+		}
+		switch field {
+		case FieldVDevFbTotal:
+			return FieldValue{Value: float64(info.GlobalMemSize) / 1024.0 / 1024.0}, nil
+		case FieldVDevFbUsed:
+			return FieldValue{Value: float64(info.UsageMemSize) / 1024.0 / 1024.0}, nil
+		case FieldVDevCUs:
+			return FieldValue{Value: float64(info.ComputeUnitCount)}, nil
+		case FieldVDevDeviceID:
+			return FieldValue{Value: float64(info.DeviceID)}, nil
+		default:
+			return FieldValue{Text: strconv.FormatUint(info.ContainerID, 10)}, nil
+		}
+	}
+}
+
+/****************************************** 进程字段 *********************************************/
+
+func procGetter(field FieldID) func(pid int) (FieldValue, error) {
+	return func(pid int) (FieldValue, error) {
+		proc, err := rsmiComputeProcessInfoByPidGet(pid)
+		if err != nil {
+			return FieldValue{}, err
+		}
+		switch field {
+		case FieldProcVramUsage:
+			return FieldValue{Value: float64(proc.VramUsage) / 1024.0 / 1024.0}, nil
+		case FieldProcSdmaUsage:
+			return FieldValue{Value: float64(proc.SdmaUsage)}, nil
+		default:
+			return FieldValue{Value: float64(proc.CuOccupancy)}, nil
+		}
+	}
+}
+
+func getProcName(pid int) (FieldValue, error) {
+	return FieldValue{Text: ProcessName(pid)}, nil
+}
+
+func getProcDevices(pid int) (FieldValue, error) {
+	dvIndices, err := rsmiComputeProcessGpusGet(pid)
+	if err != nil {
+		return FieldValue{}, err
+	}
+	devices := make([]string, 0, len(dvIndices))
+	for _, dvInd := range dvIndices {
+		devices = append(devices, strconv.Itoa(dvInd))
+	}
+	return FieldValue{Text: strings.Join(devices, ",")}, nil
+}
@@ -0,0 +1,681 @@
+package dcgm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/golang/glog"
+)
+
+// gpu metrics 度量表中数组的长度，与内核 kgd_pp_interface.h 一致
+const (
+	gpuMetricsNumHBM       = 4
+	gpuMetricsNumVCN       = 4
+	gpuMetricsNumXgmiLinks = 8
+	gpuMetricsMaxGfxClks   = 8
+	gpuMetricsMaxClks      = 4
+)
+
+// GpuMetrics 按度量表版本解码的 gpu metrics。数值保持度量表中的原始单位，
+// v1.0–v1.3 的温度单位为 0.01℃，v1.4 起为 ℃；当前版本不存在的字段为零值，数组为空。
+type GpuMetrics struct {
+	// CommonHeader 度量表头
+	CommonHeader MetricsTableHeader
+	// Revision 度量表版本，如 1.3
+	Revision string
+
+	// TemperatureEdge 边缘温度（v1.4 无）
+	TemperatureEdge uint16
+	// TemperatureHotspot 热点温度
+	TemperatureHotspot uint16
+	// TemperatureMem 显存温度
+	TemperatureMem uint16
+	// TemperatureVRGfx VR 图形温度（v1.4 无）
+	TemperatureVRGfx uint16
+	// TemperatureVRSoc VR SoC 温度
+	TemperatureVRSoc uint16
+	// TemperatureVRMem VR 显存温度（v1.4 无）
+	TemperatureVRMem uint16
+	// TemperatureHBM 每个 HBM 堆栈的温度（v1.1–v1.3）
+	TemperatureHBM []uint16
+
+	// AverageGfxActivity 平均图形活动百分比
+	AverageGfxActivity uint16
+	// AverageUmcActivity 平均内存控制器活动百分比
+	AverageUmcActivity uint16
+	// AverageMmActivity 平均多媒体（UVD/VCN）活动百分比（v1.4 无，见 VcnActivity）
+	AverageMmActivity uint16
+	// VcnActivity 每个 VCN 实例的活动百分比（v1.4 起）
+	VcnActivity []uint16
+
+	// AverageSocketPower 平均插座功率（瓦特），v1.4 为当前插座功率
+	AverageSocketPower uint16
+	// EnergyAccumulator 能量累加器，v1.0 为 32 位
+	EnergyAccumulator uint64
+	// SystemClockCounter 驱动附加的时间戳（纳秒）
+	SystemClockCounter uint64
+	// FirmwareTimestamp PMFW 附加的时间戳（10 纳秒，v1.2 起）
+	FirmwareTimestamp uint64
+
+	// AverageGfxclkFrequency 平均图形时钟（MHz，v1.4 无）
+	AverageGfxclkFrequency uint16
+	// AverageSocclkFrequency 平均 SoC 时钟（MHz，v1.4 无）
+	AverageSocclkFrequency uint16
+	// AverageUclkFrequency 平均显存时钟（MHz，v1.4 无）
+	AverageUclkFrequency uint16
+	// AverageVclk0Frequency 平均视频时钟 0（MHz，v1.4 无）
+	AverageVclk0Frequency uint16
+	// AverageDclk0Frequency 平均显示时钟 0（MHz，v1.4 无）
+	AverageDclk0Frequency uint16
+	// AverageVclk1Frequency 平均视频时钟 1（MHz，v1.4 无）
+	AverageVclk1Frequency uint16
+	// AverageDclk1Frequency 平均显示时钟 1（MHz，v1.4 无）
+	AverageDclk1Frequency uint16
+	// CurrentGfxclk 当前图形时钟（MHz），v1.4 为第一个实例
+	CurrentGfxclk uint16
+	// CurrentSocclk 当前 SoC 时钟（MHz），v1.4 为第一个实例
+	CurrentSocclk uint16
+	// CurrentUclk 当前显存时钟（MHz）
+	CurrentUclk uint16
+	// CurrentVclk0 当前视频时钟 0（MHz），v1.4 为第一个实例
+	CurrentVclk0 uint16
+	// CurrentDclk0 当前显示时钟 0（MHz），v1.4 为第一个实例
+	CurrentDclk0 uint16
+	// CurrentVclk1 当前视频时钟 1（MHz，v1.4 无）
+	CurrentVclk1 uint16
+	// CurrentDclk1 当前显示时钟 1（MHz，v1.4 无）
+	CurrentDclk1 uint16
+	// GfxclkLockStatus 图形时钟锁定状态，每位对应一个时钟实例（v1.4 起）
+	GfxclkLockStatus uint32
+
+	// ThrottleStatus ASIC 相关的原始节流状态位
+	ThrottleStatus uint32
+	// IndepThrottleStatus 与 ASIC 无关的节流状态位（v1.3）
+	IndepThrottleStatus uint64
+
+	// CurrentFanSpeed 当前风扇转速（v1.4 无）
+	CurrentFanSpeed uint16
+	// PcieLinkWidth PCIe 链路宽度
+	PcieLinkWidth uint16
+	// PcieLinkSpeed PCIe 链路速度（0.1 GT/s）
+	PcieLinkSpeed uint16
+	// XgmiLinkWidth XGMI 链路宽度（v1.4 起）
+	XgmiLinkWidth uint16
+	// XgmiLinkSpeed XGMI 链路速率（Gbps，v1.4 起）
+	XgmiLinkSpeed uint16
+
+	// VoltageSoc SoC 电压（mV，v1.3）
+	VoltageSoc uint16
+	// VoltageGfx 图形电压（mV，v1.3）
+	VoltageGfx uint16
+	// VoltageMem 显存电压（mV，v1.3）
+	VoltageMem uint16
+
+	// GfxActivityAcc 图形活动累加器（v1.1 起）
+	GfxActivityAcc uint32
+	// MemActivityAcc 内存活动累加器（v1.1 起）
+	MemActivityAcc uint32
+	// PcieBandwidthAcc PCIe 累计带宽（GB/s，v1.4 起）
+	PcieBandwidthAcc uint64
+	// PcieBandwidthInst PCIe 瞬时带宽（GB/s，v1.4 起）
+	PcieBandwidthInst uint64
+	// PcieL0ToRecovCountAcc PCIe L0 到 recovery 状态的累计切换次数（v1.4 起）
+	PcieL0ToRecovCountAcc uint64
+	// PcieReplayCountAcc PCIe 累计重放次数（v1.4 起）
+	PcieReplayCountAcc uint64
+	// PcieReplayRoverCountAcc PCIe 重放计数累计回绕次数（v1.4 起）
+	PcieReplayRoverCountAcc uint64
+	// XgmiReadDataAcc 每条 XGMI 链路累计读取的数据量（KB，v1.4 起）
+	XgmiReadDataAcc []uint64
+	// XgmiWriteDataAcc 每条 XGMI 链路累计写入的数据量（KB，v1.4 起）
+	XgmiWriteDataAcc []uint64
+}
+
+// gpuMetricsV1_0 内核 gpu_metrics_v1_0 的内存布局，空白字段为结构体对齐填充
+type gpuMetricsV1_0 struct {
+	CommonHeader           MetricsTableHeader
+	_                      [4]byte
+	SystemClockCounter     uint64
+	TemperatureEdge        uint16
+	TemperatureHotspot     uint16
+	TemperatureMem         uint16
+	TemperatureVRGfx       uint16
+	TemperatureVRSoc       uint16
+	TemperatureVRMem       uint16
+	AverageGfxActivity     uint16
+	AverageUmcActivity     uint16
+	AverageMmActivity      uint16
+	AverageSocketPower     uint16
+	EnergyAccumulator      uint32
+	AverageGfxclkFrequency uint16
+	AverageSocclkFrequency uint16
+	AverageUclkFrequency   uint16
+	AverageVclk0Frequency  uint16
+	AverageDclk0Frequency  uint16
+	AverageVclk1Frequency  uint16
+	AverageDclk1Frequency  uint16
+	CurrentGfxclk          uint16
+	CurrentSocclk          uint16
+	CurrentUclk            uint16
+	CurrentVclk0           uint16
+	CurrentDclk0           uint16
+	CurrentVclk1           uint16
+	CurrentDclk1           uint16
+	ThrottleStatus         uint32
+	CurrentFanSpeed        uint16
+	PcieLinkWidth          uint8
+	PcieLinkSpeed          uint8
+	_                      [4]byte
+}
+
+// gpuMetricsV1_1 内核 gpu_metrics_v1_1 的内存布局，与 rsmi_gpu_metrics_t 相同
+type gpuMetricsV1_1 struct {
+	CommonHeader           MetricsTableHeader
+	TemperatureEdge        uint16
+	TemperatureHotspot     uint16
+	TemperatureMem         uint16
+	TemperatureVRGfx       uint16
+	TemperatureVRSoc       uint16
+	TemperatureVRMem       uint16
+	AverageGfxActivity     uint16
+	AverageUmcActivity     uint16
+	AverageMmActivity      uint16
+	AverageSocketPower     uint16
+	EnergyAccumulator      uint64
+	SystemClockCounter     uint64
+	AverageGfxclkFrequency uint16
+	AverageSocclkFrequency uint16
+	AverageUclkFrequency   uint16
+	AverageVclk0Frequency  uint16
+	AverageDclk0Frequency  uint16
+	AverageVclk1Frequency  uint16
+	AverageDclk1Frequency  uint16
+	CurrentGfxclk          uint16
+	CurrentSocclk          uint16
+	CurrentUclk            uint16
+	CurrentVclk0           uint16
+	CurrentDclk0           uint16
+	CurrentVclk1           uint16
+	CurrentDclk1           uint16
+	ThrottleStatus         uint32
+	CurrentFanSpeed        uint16
+	PcieLinkWidth          uint16
+	PcieLinkSpeed          uint16
+	Padding                uint16
+	GfxActivityAcc         uint32
+	MemActivityAcc         uint32
+	TemperatureHBM         [gpuMetricsNumHBM]uint16
+}
+
+// gpuMetricsV1_2 内核 gpu_metrics_v1_2 的内存布局，在 v1.1 之后增加固件时间戳
+type gpuMetricsV1_2 struct {
+	V1_1              gpuMetricsV1_1
+	FirmwareTimestamp uint64
+}
+
+// gpuMetricsV1_3 内核 gpu_metrics_v1_3 的内存布局，在 v1.2 之后增加电压与 ASIC 无关的节流状态
+type gpuMetricsV1_3 struct {
+	V1_2                gpuMetricsV1_2
+	VoltageSoc          uint16
+	VoltageGfx          uint16
+	VoltageMem          uint16
+	Padding1            uint16
+	IndepThrottleStatus uint64
+}
+
+// gpuMetricsV1_4 内核 gpu_metrics_v1_4 的内存布局，温度单位为 ℃，多实例的时钟与 VCN 以数组给出
+type gpuMetricsV1_4 struct {
+	CommonHeader            MetricsTableHeader
+	TemperatureHotspot      uint16
+	TemperatureMem          uint16
+	TemperatureVRSoc        uint16
+	CurrSocketPower         uint16
+	AverageGfxActivity      uint16
+	AverageUmcActivity      uint16
+	VcnActivity             [gpuMetricsNumVCN]uint16
+	EnergyAccumulator       uint64
+	SystemClockCounter      uint64
+	ThrottleStatus          uint32
+	GfxclkLockStatus        uint32
+	PcieLinkWidth           uint16
+	PcieLinkSpeed           uint16
+	XgmiLinkWidth           uint16
+	XgmiLinkSpeed           uint16
+	GfxActivityAcc          uint32
+	MemActivityAcc          uint32
+	PcieBandwidthAcc        uint64
+	PcieBandwidthInst       uint64
+	PcieL0ToRecovCountAcc   uint64
+	PcieReplayCountAcc      uint64
+	PcieReplayRoverCountAcc uint64
+	XgmiReadDataAcc         [gpuMetricsNumXgmiLinks]uint64
+	XgmiWriteDataAcc        [gpuMetricsNumXgmiLinks]uint64
+	FirmwareTimestamp       uint64
+	CurrentGfxclk           [gpuMetricsMaxGfxClks]uint16
+	CurrentSocclk           [gpuMetricsMaxClks]uint16
+	CurrentVclk0            [gpuMetricsMaxClks]uint16
+	CurrentDclk0            [gpuMetricsMaxClks]uint16
+	CurrentUclk             uint16
+	Padding                 uint16
+	_                       [4]byte
+}
+
+// gpuMetricsLayout 返回度量表版本对应的内存布局，未知版本返回 ErrUnknownMetricsRevision
+func gpuMetricsLayout(header MetricsTableHeader) (any, error) {
+	if header.FormatRevision == gpuMetricsFormatDGPU {
+		switch header.ContentRevision {
+		case 0:
+			return &gpuMetricsV1_0{}, nil
+		case 1:
+			return &gpuMetricsV1_1{}, nil
+		case 2:
+			return &gpuMetricsV1_2{}, nil
+		case 3:
+			return &gpuMetricsV1_3{}, nil
+		case 4:
+			return &gpuMetricsV1_4{}, nil
+		}
+	}
+	return nil, fmt.Errorf("gpu metrics v%d.%d:%w", header.FormatRevision, header.ContentRevision, ErrUnknownMetricsRevision)
+}
+
+// ParseMetricsRevision 解析形如 1.3 的度量表版本，返回带有对应结构体大小的表头，未知版本返回 ErrUnknownMetricsRevision
+func ParseMetricsRevision(revision string) (header MetricsTableHeader, err error) {
+	if _, err = fmt.Sscanf(revision, "%d.%d", &header.FormatRevision, &header.ContentRevision); err != nil {
+		return header, fmt.Errorf("Error ParseMetricsRevision:invalid revision %q", revision)
+	}
+	layout, err := gpuMetricsLayout(header)
+	if err != nil {
+		return header, fmt.Errorf("Error ParseMetricsRevision:%w", err)
+	}
+	header.StructureSize = uint16(binary.Size(layout))
+	return header, nil
+}
+
+// DecodeGpuMetrics 按表头中的格式与内容版本解码原始 gpu metrics 度量表（sysfs gpu_metrics 文件的内容），
+// 未知版本返回 ErrUnknownMetricsRevision，数据长度不足时返回错误
+func DecodeGpuMetrics(table []byte) (gpuMetrics GpuMetrics, err error) {
+	var header MetricsTableHeader
+	if err = binary.Read(bytes.NewReader(table), binary.LittleEndian, &header); err != nil {
+		return gpuMetrics, fmt.Errorf("Error DecodeGpuMetrics:truncated header (%d bytes)", len(table))
+	}
+	layout, err := gpuMetricsLayout(header)
+	if err != nil {
+		return gpuMetrics, fmt.Errorf("Error DecodeGpuMetrics:%w", err)
+	}
+	if size := binary.Size(layout); len(table) < size {
+		return gpuMetrics, fmt.Errorf("Error DecodeGpuMetrics:gpu metrics v%d.%d needs %d bytes, got %d",
+			header.FormatRevision, header.ContentRevision, size, len(table))
+	}
+	if err = binary.Read(bytes.NewReader(table), binary.LittleEndian, layout); err != nil {
+		return gpuMetrics, fmt.Errorf("Error DecodeGpuMetrics:%s", err)
+	}
+	switch m := layout.(type) {
+	case *gpuMetricsV1_0:
+		gpuMetrics = m.decode()
+	case *gpuMetricsV1_1:
+		gpuMetrics = m.decode()
+	case *gpuMetricsV1_2:
+		gpuMetrics = m.decode()
+	case *gpuMetricsV1_3:
+		gpuMetrics = m.decode()
+	case *gpuMetricsV1_4:
+		gpuMetrics = m.decode()
+	}
+	gpuMetrics.Revision = fmt.Sprintf("%d.%d", header.FormatRevision, header.ContentRevision)
+	return gpuMetrics, nil
+}
+
+// EncodeGpuMetrics 按 CommonHeader 中的版本将 gpuMetrics 编码为原始度量表，是 DecodeGpuMetrics 的逆操作，
+// 当前版本不存在的字段被忽略，用于模拟设备与录制回放
+func EncodeGpuMetrics(gpuMetrics GpuMetrics) ([]byte, error) {
+	layout, err := gpuMetricsLayout(gpuMetrics.CommonHeader)
+	if err != nil {
+		return nil, fmt.Errorf("Error EncodeGpuMetrics:%w", err)
+	}
+	switch m := layout.(type) {
+	case *gpuMetricsV1_0:
+		m.encode(gpuMetrics)
+	case *gpuMetricsV1_1:
+		m.encode(gpuMetrics)
+	case *gpuMetricsV1_2:
+		m.encode(gpuMetrics)
+	case *gpuMetricsV1_3:
+		m.encode(gpuMetrics)
+	case *gpuMetricsV1_4:
+		m.encode(gpuMetrics)
+	}
+	var buf bytes.Buffer
+	if err = binary.Write(&buf, binary.LittleEndian, layout); err != nil {
+		return nil, fmt.Errorf("Error EncodeGpuMetrics:%s", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *gpuMetricsV1_0) decode() GpuMetrics {
+	return GpuMetrics{
+		CommonHeader:           m.CommonHeader,
+		TemperatureEdge:        m.TemperatureEdge,
+		TemperatureHotspot:     m.TemperatureHotspot,
+		TemperatureMem:         m.TemperatureMem,
+		TemperatureVRGfx:       m.TemperatureVRGfx,
+		TemperatureVRSoc:       m.TemperatureVRSoc,
+		TemperatureVRMem:       m.TemperatureVRMem,
+		AverageGfxActivity:     m.AverageGfxActivity,
+		AverageUmcActivity:     m.AverageUmcActivity,
+		AverageMmActivity:      m.AverageMmActivity,
+		AverageSocketPower:     m.AverageSocketPower,
+		EnergyAccumulator:      uint64(m.EnergyAccumulator),
+		SystemClockCounter:     m.SystemClockCounter,
+		AverageGfxclkFrequency: m.AverageGfxclkFrequency,
+		AverageSocclkFrequency: m.AverageSocclkFrequency,
+		AverageUclkFrequency:   m.AverageUclkFrequency,
+		AverageVclk0Frequency:  m.AverageVclk0Frequency,
+		AverageDclk0Frequency:  m.AverageDclk0Frequency,
+		AverageVclk1Frequency:  m.AverageVclk1Frequency,
+		AverageDclk1Frequency:  m.AverageDclk1Frequency,
+		CurrentGfxclk:          m.CurrentGfxclk,
+		CurrentSocclk:          m.CurrentSocclk,
+		CurrentUclk:            m.CurrentUclk,
+		CurrentVclk0:           m.CurrentVclk0,
+		CurrentDclk0:           m.CurrentDclk0,
+		CurrentVclk1:           m.CurrentVclk1,
+		CurrentDclk1:           m.CurrentDclk1,
+		ThrottleStatus:         m.ThrottleStatus,
+		CurrentFanSpeed:        m.CurrentFanSpeed,
+		PcieLinkWidth:          uint16(m.PcieLinkWidth),
+		PcieLinkSpeed:          uint16(m.PcieLinkSpeed),
+	}
+}
+
+func (m *gpuMetricsV1_0) encode(g GpuMetrics) {
+	*m = gpuMetricsV1_0{
+		CommonHeader:           g.CommonHeader,
+		SystemClockCounter:     g.SystemClockCounter,
+		TemperatureEdge:        g.TemperatureEdge,
+		TemperatureHotspot:     g.TemperatureHotspot,
+		TemperatureMem:         g.TemperatureMem,
+		TemperatureVRGfx:       g.TemperatureVRGfx,
+		TemperatureVRSoc:       g.TemperatureVRSoc,
+		TemperatureVRMem:       g.TemperatureVRMem,
+		AverageGfxActivity:     g.AverageGfxActivity,
+		AverageUmcActivity:     g.AverageUmcActivity,
+		AverageMmActivity:      g.AverageMmActivity,
+		AverageSocketPower:     g.AverageSocketPower,
+		EnergyAccumulator:      uint32(g.EnergyAccumulator),
+		AverageGfxclkFrequency: g.AverageGfxclkFrequency,
+		AverageSocclkFrequency: g.AverageSocclkFrequency,
+		AverageUclkFrequency:   g.AverageUclkFrequency,
+		AverageVclk0Frequency:  g.AverageVclk0Frequency,
+		AverageDclk0Frequency:  g.AverageDclk0Frequency,
+		AverageVclk1Frequency:  g.AverageVclk1Frequency,
+		AverageDclk1Frequency:  g.AverageDclk1Frequency,
+		CurrentGfxclk:          g.CurrentGfxclk,
+		CurrentSocclk:          g.CurrentSocclk,
+		CurrentUclk:            g.CurrentUclk,
+		CurrentVclk0:           g.CurrentVclk0,
+		CurrentDclk0:           g.CurrentDclk0,
+		CurrentVclk1:           g.CurrentVclk1,
+		CurrentDclk1:           g.CurrentDclk1,
+		ThrottleStatus:         g.ThrottleStatus,
+		CurrentFanSpeed:        g.CurrentFanSpeed,
+		PcieLinkWidth:          uint8(g.PcieLinkWidth),
+		PcieLinkSpeed:          uint8(g.PcieLinkSpeed),
+	}
+}
+
+func (m *gpuMetricsV1_1) decode() GpuMetrics {
+	return GpuMetrics{
+		CommonHeader:           m.CommonHeader,
+		TemperatureEdge:        m.TemperatureEdge,
+		TemperatureHotspot:     m.TemperatureHotspot,
+		TemperatureMem:         m.TemperatureMem,
+		TemperatureVRGfx:       m.TemperatureVRGfx,
+		TemperatureVRSoc:       m.TemperatureVRSoc,
+		TemperatureVRMem:       m.TemperatureVRMem,
+		TemperatureHBM:         append([]uint16{}, m.TemperatureHBM[:]...),
+		AverageGfxActivity:     m.AverageGfxActivity,
+		AverageUmcActivity:     m.AverageUmcActivity,
+		AverageMmActivity:      m.AverageMmActivity,
+		AverageSocketPower:     m.AverageSocketPower,
+		EnergyAccumulator:      m.EnergyAccumulator,
+		SystemClockCounter:     m.SystemClockCounter,
+		AverageGfxclkFrequency: m.AverageGfxclkFrequency,
+		AverageSocclkFrequency: m.AverageSocclkFrequency,
+		AverageUclkFrequency:   m.AverageUclkFrequency,
+		AverageVclk0Frequency:  m.AverageVclk0Frequency,
+		AverageDclk0Frequency:  m.AverageDclk0Frequency,
+		AverageVclk1Frequency:  m.AverageVclk1Frequency,
+		AverageDclk1Frequency:  m.AverageDclk1Frequency,
+		CurrentGfxclk:          m.CurrentGfxclk,
+		CurrentSocclk:          m.CurrentSocclk,
+		CurrentUclk:            m.CurrentUclk,
+		CurrentVclk0:           m.CurrentVclk0,
+		CurrentDclk0:           m.CurrentDclk0,
+		CurrentVclk1:           m.CurrentVclk1,
+		CurrentDclk1:           m.CurrentDclk1,
+		ThrottleStatus:         m.ThrottleStatus,
+		CurrentFanSpeed:        m.CurrentFanSpeed,
+		PcieLinkWidth:          m.PcieLinkWidth,
+		PcieLinkSpeed:          m.PcieLinkSpeed,
+		GfxActivityAcc:         m.GfxActivityAcc,
+		MemActivityAcc:         m.MemActivityAcc,
+	}
+}
+
+func (m *gpuMetricsV1_1) encode(g GpuMetrics) {
+	*m = gpuMetricsV1_1{
+		CommonHeader:           g.CommonHeader,
+		TemperatureEdge:        g.TemperatureEdge,
+		TemperatureHotspot:     g.TemperatureHotspot,
+		TemperatureMem:         g.TemperatureMem,
+		TemperatureVRGfx:       g.TemperatureVRGfx,
+		TemperatureVRSoc:       g.TemperatureVRSoc,
+		TemperatureVRMem:       g.TemperatureVRMem,
+		AverageGfxActivity:     g.AverageGfxActivity,
+		AverageUmcActivity:     g.AverageUmcActivity,
+		AverageMmActivity:      g.AverageMmActivity,
+		AverageSocketPower:     g.AverageSocketPower,
+		EnergyAccumulator:      g.EnergyAccumulator,
+		SystemClockCounter:     g.SystemClockCounter,
+		AverageGfxclkFrequency: g.AverageGfxclkFrequency,
+		AverageSocclkFrequency: g.AverageSocclkFrequency,
+		AverageUclkFrequency:   g.AverageUclkFrequency,
+		AverageVclk0Frequency:  g.AverageVclk0Frequency,
+		AverageDclk0Frequency:  g.AverageDclk0Frequency,
+		AverageVclk1Frequency:  g.AverageVclk1Frequency,
+		AverageDclk1Frequency:  g.AverageDclk1Frequency,
+		CurrentGfxclk:          g.CurrentGfxclk,
+		CurrentSocclk:          g.CurrentSocclk,
+		CurrentUclk:            g.CurrentUclk,
+		CurrentVclk0:           g.CurrentVclk0,
+		CurrentDclk0:           g.CurrentDclk0,
+		CurrentVclk1:           g.CurrentVclk1,
+		CurrentDclk1:           g.CurrentDclk1,
+		ThrottleStatus:         g.ThrottleStatus,
+		CurrentFanSpeed:        g.CurrentFanSpeed,
+		PcieLinkWidth:          g.PcieLinkWidth,
+		PcieLinkSpeed:          g.PcieLinkSpeed,
+		GfxActivityAcc:         g.GfxActivityAcc,
+		MemActivityAcc:         g.MemActivityAcc,
+	}
+	copy(m.TemperatureHBM[:], g.TemperatureHBM)
+}
+
+func (m *gpuMetricsV1_2) decode() GpuMetrics {
+	gpuMetrics := m.V1_1.decode()
+	gpuMetrics.FirmwareTimestamp = m.FirmwareTimestamp
+	return gpuMetrics
+}
+
+func (m *gpuMetricsV1_2) encode(g GpuMetrics) {
+	m.V1_1.encode(g)
+	m.FirmwareTimestamp = g.FirmwareTimestamp
+}
+
+func (m *gpuMetricsV1_3) decode() GpuMetrics {
+	gpuMetrics := m.V1_2.decode()
+	gpuMetrics.VoltageSoc = m.VoltageSoc
+	gpuMetrics.VoltageGfx = m.VoltageGfx
+	gpuMetrics.VoltageMem = m.VoltageMem
+	gpuMetrics.IndepThrottleStatus = m.IndepThrottleStatus
+	return gpuMetrics
+}
+
+func (m *gpuMetricsV1_3) encode(g GpuMetrics) {
+	m.V1_2.encode(g)
+	m.VoltageSoc = g.VoltageSoc
+	m.VoltageGfx = g.VoltageGfx
+	m.VoltageMem = g.VoltageMem
+	m.IndepThrottleStatus = g.IndepThrottleStatus
+}
+
+func (m *gpuMetricsV1_4) decode() GpuMetrics {
+	return GpuMetrics{
+		CommonHeader:            m.CommonHeader,
+		TemperatureHotspot:      m.TemperatureHotspot,
+		TemperatureMem:          m.TemperatureMem,
+		TemperatureVRSoc:        m.TemperatureVRSoc,
+		AverageSocketPower:      m.CurrSocketPower,
+		AverageGfxActivity:      m.AverageGfxActivity,
+		AverageUmcActivity:      m.AverageUmcActivity,
+		VcnActivity:             append([]uint16{}, m.VcnActivity[:]...),
+		EnergyAccumulator:       m.EnergyAccumulator,
+		SystemClockCounter:      m.SystemClockCounter,
+		ThrottleStatus:          m.ThrottleStatus,
+		GfxclkLockStatus:        m.GfxclkLockStatus,
+		PcieLinkWidth:           m.PcieLinkWidth,
+		PcieLinkSpeed:           m.PcieLinkSpeed,
+		XgmiLinkWidth:           m.XgmiLinkWidth,
+		XgmiLinkSpeed:           m.XgmiLinkSpeed,
+		GfxActivityAcc:          m.GfxActivityAcc,
+		MemActivityAcc:          m.MemActivityAcc,
+		PcieBandwidthAcc:        m.PcieBandwidthAcc,
+		PcieBandwidthInst:       m.PcieBandwidthInst,
+		PcieL0ToRecovCountAcc:   m.PcieL0ToRecovCountAcc,
+		PcieReplayCountAcc:      m.PcieReplayCountAcc,
+		PcieReplayRoverCountAcc: m.PcieReplayRoverCountAcc,
+		XgmiReadDataAcc:         append([]uint64{}, m.XgmiReadDataAcc[:]...),
+		XgmiWriteDataAcc:        append([]uint64{}, m.XgmiWriteDataAcc[:]...),
+		FirmwareTimestamp:       m.FirmwareTimestamp,
+		CurrentGfxclk:           m.CurrentGfxclk[0],
+		CurrentSocclk:           m.CurrentSocclk[0],
+		CurrentVclk0:            m.CurrentVclk0[0],
+		CurrentDclk0:            m.CurrentDclk0[0],
+		CurrentUclk:             m.CurrentUclk,
+	}
+}
+
+func (m *gpuMetricsV1_4) encode(g GpuMetrics) {
+	*m = gpuMetricsV1_4{
+		CommonHeader:            g.CommonHeader,
+		TemperatureHotspot:      g.TemperatureHotspot,
+		TemperatureMem:          g.TemperatureMem,
+		TemperatureVRSoc:        g.TemperatureVRSoc,
+		CurrSocketPower:         g.AverageSocketPower,
+		AverageGfxActivity:      g.AverageGfxActivity,
+		AverageUmcActivity:      g.AverageUmcActivity,
+		EnergyAccumulator:       g.EnergyAccumulator,
+		SystemClockCounter:      g.SystemClockCounter,
+		ThrottleStatus:          g.ThrottleStatus,
+		GfxclkLockStatus:        g.GfxclkLockStatus,
+		PcieLinkWidth:           g.PcieLinkWidth,
+		PcieLinkSpeed:           g.PcieLinkSpeed,
+		XgmiLinkWidth:           g.XgmiLinkWidth,
+		XgmiLinkSpeed:           g.XgmiLinkSpeed,
+		GfxActivityAcc:          g.GfxActivityAcc,
+		MemActivityAcc:          g.MemActivityAcc,
+		PcieBandwidthAcc:        g.PcieBandwidthAcc,
+		PcieBandwidthInst:       g.PcieBandwidthInst,
+		PcieL0ToRecovCountAcc:   g.PcieL0ToRecovCountAcc,
+		PcieReplayCountAcc:      g.PcieReplayCountAcc,
+		PcieReplayRoverCountAcc: g.PcieReplayRoverCountAcc,
+		FirmwareTimestamp:       g.FirmwareTimestamp,
+		CurrentUclk:             g.CurrentUclk,
+	}
+	copy(m.VcnActivity[:], g.VcnActivity)
+	copy(m.XgmiReadDataAcc[:], g.XgmiReadDataAcc)
+	copy(m.XgmiWriteDataAcc[:], g.XgmiWriteDataAcc)
+	m.CurrentGfxclk[0] = g.CurrentGfxclk
+	m.CurrentSocclk[0] = g.CurrentSocclk
+	m.CurrentVclk0[0] = g.CurrentVclk0
+	m.CurrentDclk0[0] = g.CurrentDclk0
+}
+
+// rsmiGPUMetrics 转换为 rsmi_gpu_metrics_t 的固定布局，新版本独有的字段丢弃
+func (g GpuMetrics) rsmiGPUMetrics() RSMIGPUMetrics {
+	gpuMetrics := RSMIGPUMetrics{
+		CommonHeader:           g.CommonHeader,
+		TemperatureEdge:        g.TemperatureEdge,
+		TemperatureHotspot:     g.TemperatureHotspot,
+		TemperatureMem:         g.TemperatureMem,
+		TemperatureVRGfx:       g.TemperatureVRGfx,
+		TemperatureVRSoc:       g.TemperatureVRSoc,
+		TemperatureVRMem:       g.TemperatureVRMem,
+		AverageGfxActivity:     g.AverageGfxActivity,
+		AverageUmcActivity:     g.AverageUmcActivity,
+		AverageMmActivity:      g.AverageMmActivity,
+		AverageSocketPower:     g.AverageSocketPower,
+		EnergyAccumulator:      g.EnergyAccumulator,
+		SystemClockCounter:     g.SystemClockCounter,
+		AverageGfxclkFrequency: g.AverageGfxclkFrequency,
+		AverageSocclkFrequency: g.AverageSocclkFrequency,
+		AverageUclkFrequency:   g.AverageUclkFrequency,
+		AverageVclk0Frequency:  g.AverageVclk0Frequency,
+		AverageDclk0Frequency:  g.AverageDclk0Frequency,
+		AverageVclk1Frequency:  g.AverageVclk1Frequency,
+		AverageDclk1Frequency:  g.AverageDclk1Frequency,
+		CurrentGfxclk:          g.CurrentGfxclk,
+		CurrentSocclk:          g.CurrentSocclk,
+		CurrentUclk:            g.CurrentUclk,
+		CurrentVclk0:           g.CurrentVclk0,
+		CurrentDclk0:           g.CurrentDclk0,
+		CurrentVclk1:           g.CurrentVclk1,
+		CurrentDclk1:           g.CurrentDclk1,
+		ThrottleStatus:         g.ThrottleStatus,
+		CurrentFanSpeed:        g.CurrentFanSpeed,
+		PcieLinkWidth:          g.PcieLinkWidth,
+		PcieLinkSpeed:          g.PcieLinkSpeed,
+		GfxActivityAcc:         g.GfxActivityAcc,
+		MemActivityAcc:         g.MemActivityAcc,
+	}
+	copy(gpuMetrics.TempetureHBM[:], g.TemperatureHBM)
+	return gpuMetrics
+}
+
+// DevGpuMetrics 读取设备的原始 gpu metrics 度量表并按版本解码，
+// 与 DevGpuMetricsInfo 不同，新版本独有的字段（VCN 活动、XGMI 与 PCIe 累加器等）也会返回
+func DevGpuMetrics(dvInd int) (gpuMetrics GpuMetrics, err error) {
+	table, err := gpuMetricsTableGet(dvInd)
+	if err != nil {
+		return gpuMetrics, err
+	}
+	return DecodeGpuMetrics(table)
+}
+
+// DeviceGpuMetrics 设备按版本解码的 gpu metrics
+type DeviceGpuMetrics struct {
+	//  DeviceID 设备索引号
+	DeviceID int
+	//  Metrics 解码后的度量表
+	Metrics GpuMetrics
+}
+
+// ShowGpuMetrics 读取一组设备的 gpu metrics 度量表并按版本解码，不支持的设备跳过，
+// 度量表版本未知时返回 ErrUnknownMetricsRevision
+func ShowGpuMetrics(dvIdList []int) (deviceGpuMetrics []DeviceGpuMetrics, err error) {
+	for _, device := range dvIdList {
+		gpuMetrics, err := DevGpuMetrics(device)
+		if errors.Is(err, ErrUnknownMetricsRevision) {
+			return nil, fmt.Errorf("Error ShowGpuMetrics:device %d:%w", device, err)
+		}
+		if err != nil {
+			glog.Errorf("GPU %d: gpu metrics is not supported: %v", device, err)
+			continue
+		}
+		deviceGpuMetrics = append(deviceGpuMetrics, DeviceGpuMetrics{DeviceID: device, Metrics: gpuMetrics})
+	}
+	return
+}
@@ -0,0 +1,334 @@
+package dcgm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+const gpuMetricsFixture = "../../samples/sysfs/fixture/class/drm/%s/device/gpu_metrics"
+
+// metricsBlob 按内核结构体的字节偏移构造原始度量表，不依赖 EncodeGpuMetrics
+type metricsBlob []byte
+
+func newMetricsBlob(size int, format, content uint8) metricsBlob {
+	b := make(metricsBlob, size)
+	binary.LittleEndian.PutUint16(b, uint16(size))
+	b[2], b[3] = format, content
+	return b
+}
+
+func (b metricsBlob) u8(off int, v uint8) metricsBlob { b[off] = v; return b }
+func (b metricsBlob) u16(off int, v ...uint16) metricsBlob {
+	for i, x := range v {
+		binary.LittleEndian.PutUint16(b[off+2*i:], x)
+	}
+	return b
+}
+func (b metricsBlob) u32(off int, v uint32) metricsBlob {
+	binary.LittleEndian.PutUint32(b[off:], v)
+	return b
+}
+func (b metricsBlob) u64(off int, v ...uint64) metricsBlob {
+	for i, x := range v {
+		binary.LittleEndian.PutUint64(b[off+8*i:], x)
+	}
+	return b
+}
+
+func readGpuMetricsFixture(t *testing.T, card string) []byte {
+	t.Helper()
+	table, err := os.ReadFile(fmt.Sprintf(gpuMetricsFixture, card))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	return table
+}
+
+// normalizeGpuMetrics 将空数组统一为 nil，便于比较
+func normalizeGpuMetrics(m GpuMetrics) GpuMetrics {
+	if len(m.TemperatureHBM) == 0 {
+		m.TemperatureHBM = nil
+	}
+	if len(m.VcnActivity) == 0 {
+		m.VcnActivity = nil
+	}
+	if len(m.XgmiReadDataAcc) == 0 {
+		m.XgmiReadDataAcc = nil
+	}
+	if len(m.XgmiWriteDataAcc) == 0 {
+		m.XgmiWriteDataAcc = nil
+	}
+	return m
+}
+
+// gpuMetricsCases 每个支持的版本一个度量表：v1.1 与 v1.3 为 sysfs 样例中采集的文件，其余按内核布局构造
+func gpuMetricsCases(t *testing.T) []struct {
+	name  string
+	table []byte
+	want  GpuMetrics
+} {
+	return []struct {
+		name  string
+		table []byte
+		want  GpuMetrics
+	}{
+		{
+			name: "v1.0",
+			table: newMetricsBlob(80, 1, 0).
+				u64(8, 1000000000).
+				u16(16, 3000, 3500, 3200, 3100, 3050, 3150, 10, 5, 2, 90).
+				u32(36, 4000000000).
+				u16(40, 1000, 700, 1200, 400, 300, 0, 0, 1100, 750, 1250, 410, 310, 0, 0).
+				u32(68, 4).
+				u16(72, 1500).
+				u8(74, 16).u8(75, 80),
+			want: GpuMetrics{
+				CommonHeader: MetricsTableHeader{StructureSize: 80, FormatRevision: 1, ContentRevision: 0}, Revision: "1.0",
+				TemperatureEdge: 3000, TemperatureHotspot: 3500, TemperatureMem: 3200,
+				TemperatureVRGfx: 3100, TemperatureVRSoc: 3050, TemperatureVRMem: 3150,
+				AverageGfxActivity: 10, AverageUmcActivity: 5, AverageMmActivity: 2, AverageSocketPower: 90,
+				EnergyAccumulator: 4000000000, SystemClockCounter: 1000000000,
+				AverageGfxclkFrequency: 1000, AverageSocclkFrequency: 700, AverageUclkFrequency: 1200,
+				AverageVclk0Frequency: 400, AverageDclk0Frequency: 300,
+				CurrentGfxclk: 1100, CurrentSocclk: 750, CurrentUclk: 1250, CurrentVclk0: 410, CurrentDclk0: 310,
+				ThrottleStatus: 4, CurrentFanSpeed: 1500, PcieLinkWidth: 16, PcieLinkSpeed: 80,
+			},
+		},
+		{
+			name:  "v1.1",
+			table: readGpuMetricsFixture(t, "card1"),
+			want: GpuMetrics{
+				CommonHeader: MetricsTableHeader{StructureSize: 96, FormatRevision: 1, ContentRevision: 1}, Revision: "1.1",
+				TemperatureEdge: 5200, TemperatureHotspot: 6000, TemperatureMem: 5600,
+				TemperatureHBM:     []uint16{5500, 5500, 5550, 5550},
+				AverageGfxActivity: 75, AverageUmcActivity: 40, AverageSocketPower: 220,
+				EnergyAccumulator: 12345678, SystemClockCounter: 1234567890123,
+				CurrentGfxclk: 1200, CurrentSocclk: 800, CurrentUclk: 1600,
+				PcieLinkWidth: 16, PcieLinkSpeed: 160, GfxActivityAcc: 23456, MemActivityAcc: 9876,
+			},
+		},
+		{
+			name: "v1.2",
+			table: newMetricsBlob(104, 1, 2).
+				u16(4, 4000, 4600, 4300, 4100, 4000, 3900, 50, 25, 1, 180).
+				u64(24, 7777777777, 2000000000).
+				u16(40, 1300, 900, 1500, 0, 0, 0, 0, 1400, 950, 1550, 0, 0, 0, 0).
+				u32(68, 1).
+				u16(72, 0, 16, 80).
+				u32(80, 4444).u32(84, 5555).
+				u16(88, 4200, 4250, 4300, 4350).
+				u64(96, 555555555),
+			want: GpuMetrics{
+				CommonHeader: MetricsTableHeader{StructureSize: 104, FormatRevision: 1, ContentRevision: 2}, Revision: "1.2",
+				TemperatureEdge: 4000, TemperatureHotspot: 4600, TemperatureMem: 4300,
+				TemperatureVRGfx: 4100, TemperatureVRSoc: 4000, TemperatureVRMem: 3900,
+				TemperatureHBM:     []uint16{4200, 4250, 4300, 4350},
+				AverageGfxActivity: 50, AverageUmcActivity: 25, AverageMmActivity: 1, AverageSocketPower: 180,
+				EnergyAccumulator: 7777777777, SystemClockCounter: 2000000000, FirmwareTimestamp: 555555555,
+				AverageGfxclkFrequency: 1300, AverageSocclkFrequency: 900, AverageUclkFrequency: 1500,
+				CurrentGfxclk: 1400, CurrentSocclk: 950, CurrentUclk: 1550,
+				ThrottleStatus: 1, PcieLinkWidth: 16, PcieLinkSpeed: 80, GfxActivityAcc: 4444, MemActivityAcc: 5555,
+			},
+		},
+		{
+			name:  "v1.3",
+			table: readGpuMetricsFixture(t, "card0"),
+			want: GpuMetrics{
+				CommonHeader: MetricsTableHeader{StructureSize: 120, FormatRevision: 1, ContentRevision: 3}, Revision: "1.3",
+				TemperatureEdge: 4500, TemperatureHotspot: 5300, TemperatureMem: 4900,
+				TemperatureVRGfx: 4500, TemperatureVRSoc: 4300, TemperatureVRMem: 4100,
+				TemperatureHBM:     []uint16{4800, 4750, 4900, 4850},
+				AverageGfxActivity: 35, AverageUmcActivity: 20, AverageSocketPower: 125,
+				EnergyAccumulator: 98765432, SystemClockCounter: 1234567890123, FirmwareTimestamp: 123456789012,
+				AverageGfxclkFrequency: 1190, AverageSocclkFrequency: 800, AverageUclkFrequency: 1600,
+				CurrentGfxclk: 1200, CurrentSocclk: 800, CurrentUclk: 1600,
+				ThrottleStatus: 65, PcieLinkWidth: 16, PcieLinkSpeed: 160,
+				VoltageSoc: 850, VoltageGfx: 900, VoltageMem: 1200,
+				GfxActivityAcc: 1543210, MemActivityAcc: 765432,
+			},
+		},
+		{
+			name: "v1.4",
+			table: newMetricsBlob(288, 1, 4).
+				u16(4, 65, 60, 55, 350, 80, 30, 10, 20, 30, 40).
+				u64(24, 5000000000000, 3000000000).
+				u32(40, 0x10).u32(44, 1).
+				u16(48, 16, 32, 16, 25).
+				u32(56, 111111).u32(60, 222222).
+				u64(64, 1<<40, 2048, 3, 4, 5).
+				u64(104, 1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000).
+				u64(168, 10, 20, 30, 40, 50, 60, 70, 80).
+				u64(232, 987654321).
+				u16(240, 1700).
+				u16(256, 1100).u16(264, 900).u16(272, 800).u16(280, 1300),
+			want: GpuMetrics{
+				CommonHeader: MetricsTableHeader{StructureSize: 288, FormatRevision: 1, ContentRevision: 4}, Revision: "1.4",
+				TemperatureHotspot: 65, TemperatureMem: 60, TemperatureVRSoc: 55,
+				AverageSocketPower: 350, AverageGfxActivity: 80, AverageUmcActivity: 30,
+				VcnActivity:       []uint16{10, 20, 30, 40},
+				EnergyAccumulator: 5000000000000, SystemClockCounter: 3000000000, FirmwareTimestamp: 987654321,
+				ThrottleStatus: 0x10, GfxclkLockStatus: 1,
+				PcieLinkWidth: 16, PcieLinkSpeed: 32, XgmiLinkWidth: 16, XgmiLinkSpeed: 25,
+				GfxActivityAcc: 111111, MemActivityAcc: 222222,
+				PcieBandwidthAcc: 1 << 40, PcieBandwidthInst: 2048,
+				PcieL0ToRecovCountAcc: 3, PcieReplayCountAcc: 4, PcieReplayRoverCountAcc: 5,
+				XgmiReadDataAcc:  []uint64{1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000},
+				XgmiWriteDataAcc: []uint64{10, 20, 30, 40, 50, 60, 70, 80},
+				CurrentGfxclk:    1700, CurrentSocclk: 1100, CurrentVclk0: 900, CurrentDclk0: 800, CurrentUclk: 1300,
+			},
+		},
+	}
+}
+
+func TestDecodeGpuMetrics(t *testing.T) {
+	for _, tt := range gpuMetricsCases(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeGpuMetrics(tt.table)
+			if err != nil {
+				t.Fatalf("DecodeGpuMetrics: %v", err)
+			}
+			if got, want := normalizeGpuMetrics(got), normalizeGpuMetrics(tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("DecodeGpuMetrics =\n%+v\nwant\n%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeGpuMetricsRoundTrip(t *testing.T) {
+	for _, tt := range gpuMetricsCases(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := EncodeGpuMetrics(tt.want)
+			if err != nil {
+				t.Fatalf("EncodeGpuMetrics: %v", err)
+			}
+			if !bytes.Equal(table, tt.table) {
+				t.Errorf("EncodeGpuMetrics =\n%x\nwant\n%x", table, tt.table)
+			}
+			got, err := DecodeGpuMetrics(table)
+			if err != nil {
+				t.Fatalf("DecodeGpuMetrics: %v", err)
+			}
+			if got, want := normalizeGpuMetrics(got), normalizeGpuMetrics(tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip =\n%+v\nwant\n%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeGpuMetricsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   []byte
+		unknown bool
+	}{
+		{"unknown content revision", newMetricsBlob(120, 1, 9), true},
+		{"unknown format revision", newMetricsBlob(120, 2, 1), true},
+		{"truncated header", []byte{120, 0}, false},
+		{"short v1.3 table", newMetricsBlob(120, 1, 3)[:100], false},
+		{"short v1.4 table", newMetricsBlob(288, 1, 4)[:120], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeGpuMetrics(tt.table)
+			if err == nil {
+				t.Fatal("DecodeGpuMetrics succeeded")
+			}
+			if errors.Is(err, ErrUnknownMetricsRevision) != tt.unknown {
+				t.Errorf("DecodeGpuMetrics error %v, unknown revision = %v, want %v", err, !tt.unknown, tt.unknown)
+			}
+		})
+	}
+
+	_, err := EncodeGpuMetrics(GpuMetrics{CommonHeader: MetricsTableHeader{FormatRevision: 1, ContentRevision: 9}})
+	if !errors.Is(err, ErrUnknownMetricsRevision) {
+		t.Errorf("EncodeGpuMetrics v1.9 error = %v, want ErrUnknownMetricsRevision", err)
+	}
+}
+
+func TestParseMetricsRevision(t *testing.T) {
+	for revision, size := range map[string]uint16{"1.0": 80, "1.1": 96, "1.2": 104, "1.3": 120, "1.4": 288} {
+		header, err := ParseMetricsRevision(revision)
+		if err != nil || header.StructureSize != size {
+			t.Errorf("ParseMetricsRevision(%s) = %+v, %v, want structure size %d", revision, header, err, size)
+		}
+	}
+	if _, err := ParseMetricsRevision("1.9"); !errors.Is(err, ErrUnknownMetricsRevision) {
+		t.Errorf("ParseMetricsRevision(1.9) error = %v, want ErrUnknownMetricsRevision", err)
+	}
+}
+
+// TestDevGpuMetrics 经 sysfs 后端读取样例中采集的度量表并解码
+func TestDevGpuMetrics(t *testing.T) {
+	if err := InitWithBackendName(BackendSysfs + ":" + sysfsFixtureRoot); err != nil {
+		t.Fatalf("InitWithBackendName: %v", err)
+	}
+	t.Cleanup(func() { ShutDown() })
+	tests := []struct {
+		revision       string
+		hotspot        uint16
+		hbm            []uint16
+		gfxActivity    uint16
+		socketPower    uint16
+		energy         uint64
+		gfxActivityAcc uint32
+	}{
+		{"1.3", 5300, []uint16{4800, 4750, 4900, 4850}, 35, 125, 98765432, 1543210},
+		{"1.1", 6000, []uint16{5500, 5500, 5550, 5550}, 75, 220, 12345678, 23456},
+	}
+	for i, w := range tests {
+		m, err := DevGpuMetrics(i)
+		if err != nil {
+			t.Fatalf("device %d: DevGpuMetrics: %v", i, err)
+		}
+		if m.Revision != w.revision || m.TemperatureHotspot != w.hotspot || !reflect.DeepEqual(m.TemperatureHBM, w.hbm) {
+			t.Errorf("device %d: revision %s, hotspot %d, hbm %v, want %s, %d, %v", i,
+				m.Revision, m.TemperatureHotspot, m.TemperatureHBM, w.revision, w.hotspot, w.hbm)
+		}
+		if m.AverageGfxActivity != w.gfxActivity || m.AverageSocketPower != w.socketPower || m.EnergyAccumulator != w.energy ||
+			m.GfxActivityAcc != w.gfxActivityAcc {
+			t.Errorf("device %d: gfx activity %d, socket power %d, energy %d, gfx activity acc %d, want %d, %d, %d, %d", i,
+				m.AverageGfxActivity, m.AverageSocketPower, m.EnergyAccumulator, m.GfxActivityAcc,
+				w.gfxActivity, w.socketPower, w.energy, w.gfxActivityAcc)
+		}
+	}
+}
+
+// TestFakeGpuMetricsTemperature 模拟设备的度量表温度单位与样例及解码一致：v1.0–v1.3 为 0.01℃，v1.4 为 ℃
+func TestFakeGpuMetricsTemperature(t *testing.T) {
+	tests := []struct {
+		revision                string
+		edge, hotspot, mem, hbm uint16
+	}{
+		{"1.1", 4800, 5600, 5200, 5100},
+		{"1.3", 4800, 5600, 5200, 5100},
+		{"1.4", 0, 56, 52, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.revision, func(t *testing.T) {
+			s := DefaultFakeScenario()
+			s.Devices = s.Devices[:1]
+			s.Devices[0].Temperature = FakeValue{Base: 48}
+			s.Devices[0].MetricsRevision = tt.revision
+			if err := InitWithBackend(NewFakeBackend(s)); err != nil {
+				t.Fatalf("InitWithBackend: %v", err)
+			}
+			t.Cleanup(func() { ShutDown() })
+			m, err := DevGpuMetrics(0)
+			if err != nil {
+				t.Fatalf("DevGpuMetrics: %v", err)
+			}
+			if m.TemperatureEdge != tt.edge || m.TemperatureHotspot != tt.hotspot || m.TemperatureMem != tt.mem {
+				t.Errorf("edge/hotspot/mem = %d/%d/%d, want %d/%d/%d", m.TemperatureEdge, m.TemperatureHotspot, m.TemperatureMem,
+					tt.edge, tt.hotspot, tt.mem)
+			}
+			if tt.hbm != 0 && !reflect.DeepEqual(m.TemperatureHBM, []uint16{tt.hbm, tt.hbm, tt.hbm, tt.hbm}) {
+				t.Errorf("hbm = %v, want 4 x %d", m.TemperatureHBM, tt.hbm)
+			}
+		})
+	}
+}
@@ -0,0 +1,551 @@
+package dcgm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// DefaultHealthInterval 健康监控的默认检查间隔
+const DefaultHealthInterval = 5 * time.Second
+
+// HealthResult 健康检查结论
+type HealthResult string
+
+const (
+	HealthPass HealthResult = "PASS"
+	HealthWarn HealthResult = "WARN"
+	HealthFail HealthResult = "FAIL"
+)
+
+var healthResultRank = map[HealthResult]int{HealthPass: 0, HealthWarn: 1, HealthFail: 2}
+
+// worst 返回两个结论中更严重的一个
+func (r HealthResult) worst(other HealthResult) HealthResult {
+	if healthResultRank[other] > healthResultRank[r] {
+		return other
+	}
+	return r
+}
+
+// HealthSystem 健康检查的子系统
+type HealthSystem string
+
+const (
+	// HealthSystemECC 各 GPU 块的 ECC 可纠正/不可纠正错误增量
+	HealthSystemECC HealthSystem = "ecc"
+	// HealthSystemMemory 退役页与待退役页
+	HealthSystemMemory HealthSystem = "memory"
+	// HealthSystemThermal 边缘、结温与显存温度相对 critical/emergency 阈值
+	HealthSystemThermal HealthSystem = "thermal"
+	// HealthSystemPCIe PCIe 重放计数器增长
+	HealthSystemPCIe HealthSystem = "pcie"
+	// HealthSystemXGMI XGMI 错误状态
+	HealthSystemXGMI HealthSystem = "xgmi"
+	// HealthSystemThrottle 温度导致的降频
+	HealthSystemThrottle HealthSystem = "throttle"
+)
+
+// AllHealthSystems 全部健康检查子系统，顺序固定
+var AllHealthSystems = []HealthSystem{
+	HealthSystemECC, HealthSystemMemory, HealthSystemThermal, HealthSystemPCIe, HealthSystemXGMI, HealthSystemThrottle,
+}
+
+// healthThermalSensors 健康检查的温度传感器
+var healthThermalSensors = []struct {
+	sensor int
+	name   string
+}{
+	{SENSOR_EDGE, "edge"},
+	{SENSOR_JUNCTION, "junction"},
+	{SENSOR_MEMORY, "memory"},
+}
+
+// healthThermalThrottle 视为健康问题的节流原因，功率与电流限制属于正常的功耗管理
+var healthThermalThrottle = map[ThrottleReason]bool{
+	ThrottleThermalEdge:    true,
+	ThrottleThermalHotspot: true,
+	ThrottleThermalMemory:  true,
+	ThrottleVRThermal:      true,
+	ThrottleProchot:        true,
+}
+
+// HealthIncident 健康检查发现的问题，监控期间同一问题（如同一传感器超温）只记录一次，Message 为最近一次的描述，
+// Result 为期间最严重的程度，FirstSeen/LastSeen 为首次与最近一次发现的时间
+type HealthIncident struct {
+	//  System 子系统
+	System HealthSystem
+	//  Result 问题的严重程度，WARN 或 FAIL
+	Result HealthResult
+	//  Message 问题描述
+	Message string
+	//  FirstSeen 首次发现的时间
+	FirstSeen time.Time
+	//  LastSeen 最近一次发现的时间
+	LastSeen time.Time
+
+	key string
+}
+
+// SystemHealth 单个子系统的结论，Result 为其中最严重的问题，没有问题时为 PASS
+type SystemHealth struct {
+	//  System 子系统
+	System HealthSystem
+	//  Result 结论
+	Result HealthResult
+	//  Incidents 发现的问题
+	Incidents []HealthIncident
+}
+
+// DeviceHealth 设备的健康状态。监控中的设备给出监控开始以来的结论，Since 为监控开始时间；
+// 未监控的设备为一次性检查，计数器按驱动加载以来的累计值评估。设备不支持的子系统不出现在 Systems 中
+type DeviceHealth struct {
+	//  DeviceID 设备索引号
+	DeviceID int
+	//  Result 所有子系统中最严重的结论
+	Result HealthResult
+	//  Watched 是否来自健康监控
+	Watched bool
+	//  Since 监控开始时间，一次性检查为零值
+	Since time.Time
+	//  CheckedAt 最近一次检查的时间
+	CheckedAt time.Time
+	//  Systems 各子系统的结论
+	Systems []SystemHealth
+}
+
+// ParseHealthSystems 解析逗号分隔的子系统列表，all 或空字符串表示全部子系统
+func ParseHealthSystems(s string) (systems []HealthSystem, err error) {
+	if strings.TrimSpace(s) == "" || strings.TrimSpace(s) == "all" {
+		return append([]HealthSystem{}, AllHealthSystems...), nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+		found := false
+		for _, system := range AllHealthSystems {
+			if string(system) == item {
+				systems = append(systems, system)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Error ParseHealthSystems:unknown health system %q", item)
+		}
+	}
+	return systems, nil
+}
+
+// healthBaseline 计数器类检查的基线，一次性检查的基线为零
+type healthBaseline struct {
+	ecc     map[string]BlocksInfo
+	retired int
+	replay  int64
+	// unread 读取基线失败的子系统，其计数器按驱动加载以来的累计值评估
+	unread map[HealthSystem]bool
+}
+
+// readHealthBaseline 读取监控开始时的计数器
+func readHealthBaseline(dvInd int, systems []HealthSystem) *healthBaseline {
+	base := &healthBaseline{ecc: map[string]BlocksInfo{}, unread: map[HealthSystem]bool{}}
+	for _, system := range systems {
+		var err error
+		switch system {
+		case HealthSystemECC:
+			var blocks []BlocksInfo
+			if blocks, err = EccBlocksInfo(dvInd); err == nil {
+				for _, block := range blocks {
+					base.ecc[block.Block] = block
+				}
+			}
+		case HealthSystemMemory:
+			var records []RSMIRetiredPageRecord
+			if _, records, err = rsmiDevMemoryReservedPagesGet(dvInd); err == nil {
+				for _, rec := range records {
+					if rec.Status == RSMI_MEM_PAGE_STATUS_RESERVED {
+						base.retired++
+					}
+				}
+			}
+		case HealthSystemPCIe:
+			base.replay, err = rsmiDevPciReplayCounterGet(dvInd)
+		}
+		if err != nil {
+			glog.V(2).Infof("health baseline %s on device %d: %v, counting since driver load", system, dvInd, err)
+			base.unread[system] = true
+		}
+	}
+	return base
+}
+
+// checkHealth 检查设备的一组子系统，返回当前发现的问题以及成功检查的子系统；读取失败的子系统视为不支持。
+// since 描述计数器增量的起点，读取基线失败的子系统改为按驱动加载以来的累计值描述
+func checkHealth(dvInd int, systems []HealthSystem, base *healthBaseline, since string) (incidents []HealthIncident, checked []HealthSystem) {
+	now := time.Now()
+	add := func(system HealthSystem, result HealthResult, key, format string, args ...any) {
+		incidents = append(incidents, HealthIncident{
+			System:    system,
+			Result:    result,
+			Message:   fmt.Sprintf(format, args...),
+			FirstSeen: now,
+			LastSeen:  now,
+			key:       string(system) + ":" + key,
+		})
+	}
+	for _, system := range systems {
+		systemSince := since
+		if base.unread[system] {
+			systemSince = "since driver load"
+		}
+		var err error
+		switch system {
+		case HealthSystemECC:
+			err = checkEccHealth(dvInd, base, systemSince, add)
+		case HealthSystemMemory:
+			err = checkMemoryHealth(dvInd, base, systemSince, add)
+		case HealthSystemThermal:
+			err = checkThermalHealth(dvInd, add)
+		case HealthSystemPCIe:
+			err = checkPcieHealth(dvInd, base, systemSince, add)
+		case HealthSystemXGMI:
+			err = checkXgmiHealth(dvInd, add)
+		case HealthSystemThrottle:
+			err = checkThrottleHealth(dvInd, add)
+		}
+		if err != nil {
+			glog.V(2).Infof("health check %s on device %d: %v", system, dvInd, err)
+			continue
+		}
+		checked = append(checked, system)
+	}
+	return incidents, checked
+}
+
+type healthAdder func(system HealthSystem, result HealthResult, key, format string, args ...any)
+
+func checkEccHealth(dvInd int, base *healthBaseline, since string, add healthAdder) error {
+	blocks, err := EccBlocksInfo(dvInd)
+	if err != nil {
+		return err
+	}
+	if len(blocks) == 0 {
+		return fmt.Errorf("no ECC enabled block")
+	}
+	for _, block := range blocks {
+		prev := base.ecc[block.Block]
+		// 计数器变小说明驱动重载或设备复位，按复位后的累计值计算
+		if block.UE < prev.UE || block.CE < prev.CE {
+			prev = BlocksInfo{}
+		}
+		if ue := block.UE - prev.UE; ue > 0 {
+			add(HealthSystemECC, HealthFail, block.Block+":ue", "%d uncorrectable ECC errors in block %s %s", ue, block.Block, since)
+		}
+		if ce := block.CE - prev.CE; ce > 0 {
+			add(HealthSystemECC, HealthWarn, block.Block+":ce", "%d correctable ECC errors in block %s %s", ce, block.Block, since)
+		}
+	}
+	return nil
+}
+
+func checkMemoryHealth(dvInd int, base *healthBaseline, since string, add healthAdder) error {
+	_, records, err := rsmiDevMemoryReservedPagesGet(dvInd)
+	if err != nil {
+		return err
+	}
+	var reserved, pending, unreservable int
+	for _, rec := range records {
+		switch rec.Status {
+		case RSMI_MEM_PAGE_STATUS_RESERVED:
+			reserved++
+		case RSMI_MEM_PAGE_STATUS_PENDING:
+			pending++
+		case RSMI_MEM_PAGE_STATUS_UNRESERVABLE:
+			unreservable++
+		}
+	}
+	if unreservable > 0 {
+		add(HealthSystemMemory, HealthFail, "unreservable", "%d bad pages could not be retired", unreservable)
+	}
+	if pending > 0 {
+		add(HealthSystemMemory, HealthWarn, "pending", "%d pages pending retirement, a device reset is required", pending)
+	}
+	if retired := reserved - base.retired; retired > 0 {
+		add(HealthSystemMemory, HealthWarn, "retired", "%d pages retired %s", retired, since)
+	}
+	return nil
+}
+
+func checkThermalHealth(dvInd int, add healthAdder) error {
+	checked := 0
+	for _, s := range healthThermalSensors {
+		current, err := rsmiDevTempMetricGet(dvInd, s.sensor, RSMI_TEMP_CURRENT)
+		if err != nil {
+			continue
+		}
+		checked++
+		if emergency, err := rsmiDevTempMetricGet(dvInd, s.sensor, RSMI_TEMP_EMERGENCY); err == nil && emergency > 0 && current >= emergency {
+			add(HealthSystemThermal, HealthFail, s.name, "%s temperature %.1f°C reached the emergency threshold %.1f°C",
+				s.name, float64(current)/1000, float64(emergency)/1000)
+			continue
+		}
+		if critical, err := rsmiDevTempMetricGet(dvInd, s.sensor, RSMI_TEMP_CRITICAL); err == nil && critical > 0 && current >= critical {
+			add(HealthSystemThermal, HealthWarn, s.name, "%s temperature %.1f°C reached the critical threshold %.1f°C",
+				s.name, float64(current)/1000, float64(critical)/1000)
+		}
+	}
+	if checked == 0 {
+		return fmt.Errorf("no temperature sensor")
+	}
+	return nil
+}
+
+func checkPcieHealth(dvInd int, base *healthBaseline, since string, add healthAdder) error {
+	counter, err := rsmiDevPciReplayCounterGet(dvInd)
+	if err != nil {
+		return err
+	}
+	replays := counter - base.replay
+	if replays < 0 {
+		replays = counter
+	}
+	if replays > 0 {
+		add(HealthSystemPCIe, HealthWarn, "replay", "%d PCIe replays %s", replays, since)
+	}
+	return nil
+}
+
+func checkXgmiHealth(dvInd int, add healthAdder) error {
+	status, err := rsmiDevXGMIErrorStatus(dvInd)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case RSMIXGMIStatusError:
+		add(HealthSystemXGMI, HealthWarn, "error", "XGMI error detected")
+	case RSMIXGMIStatusMultipleErrors:
+		add(HealthSystemXGMI, HealthFail, "errors", "multiple XGMI errors detected")
+	}
+	return nil
+}
+
+func checkThrottleHealth(dvInd int, add healthAdder) error {
+	info, err := DeviceThrottleInfo(dvInd)
+	if err != nil {
+		return err
+	}
+	for _, reason := range info.Reasons {
+		if healthThermalThrottle[reason] {
+			add(HealthSystemThrottle, HealthWarn, string(reason), "clocks throttled by %s", reason)
+		}
+	}
+	return nil
+}
+
+// buildDeviceHealth 按子系统汇总问题
+func buildDeviceHealth(dvInd int, checked []HealthSystem, incidents []HealthIncident) DeviceHealth {
+	health := DeviceHealth{DeviceID: dvInd, Result: HealthPass, Systems: []SystemHealth{}}
+	for _, system := range AllHealthSystems {
+		if !containsHealthSystem(checked, system) {
+			continue
+		}
+		systemHealth := SystemHealth{System: system, Result: HealthPass, Incidents: []HealthIncident{}}
+		for _, incident := range incidents {
+			if incident.System == system {
+				systemHealth.Incidents = append(systemHealth.Incidents, incident)
+				systemHealth.Result = systemHealth.Result.worst(incident.Result)
+			}
+		}
+		sort.Slice(systemHealth.Incidents, func(i, j int) bool {
+			a, b := systemHealth.Incidents[i], systemHealth.Incidents[j]
+			if !a.FirstSeen.Equal(b.FirstSeen) {
+				return a.FirstSeen.Before(b.FirstSeen)
+			}
+			return a.key < b.key
+		})
+		health.Result = health.Result.worst(systemHealth.Result)
+		health.Systems = append(health.Systems, systemHealth)
+	}
+	return health
+}
+
+func containsHealthSystem(list []HealthSystem, v HealthSystem) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// healthTrack 单个设备的监控状态，incidents 按问题累积，已消失的问题也保留到监控重新启动
+type healthTrack struct {
+	base      *healthBaseline
+	checked   []HealthSystem
+	checkedAt time.Time
+	incidents map[string]*HealthIncident
+}
+
+// healthWatcher 后台周期性检查设备健康状态
+type healthWatcher struct {
+	mu      sync.Mutex
+	since   time.Time
+	devices []int
+	systems []HealthSystem
+	tracks  map[int]*healthTrack
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+var defaultHealth = &healthWatcher{}
+
+// StartHealthWatch 启动健康监控，每隔 interval 检查 devices 的 systems 子系统，devices 为空时监控所有设备，
+// systems 为空时检查全部子系统，interval 为零时取默认值。计数器类检查以启动时的读数为基线。
+// 已经启动时先停止并丢弃已有结果
+func StartHealthWatch(devices []int, systems []HealthSystem, interval time.Duration) error {
+	if interval == 0 {
+		interval = DefaultHealthInterval
+	}
+	if interval < 0 {
+		return fmt.Errorf("Error StartHealthWatch:invalid interval %v", interval)
+	}
+	if len(systems) == 0 {
+		systems = AllHealthSystems
+	}
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return fmt.Errorf("Error StartHealthWatch:%w", err)
+	}
+	if len(devices) == 0 {
+		for i := 0; i < numDevices; i++ {
+			devices = append(devices, i)
+		}
+	}
+	for _, dvInd := range devices {
+		if dvInd < 0 || dvInd >= numDevices {
+			return fmt.Errorf("Error StartHealthWatch:device %d out of range [0, %d)", dvInd, numDevices)
+		}
+	}
+	StopHealthWatch()
+	tracks := map[int]*healthTrack{}
+	for _, dvInd := range devices {
+		tracks[dvInd] = &healthTrack{base: readHealthBaseline(dvInd, systems), incidents: map[string]*HealthIncident{}}
+	}
+	w := defaultHealth
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.since = time.Now()
+	w.devices = append([]int{}, devices...)
+	w.systems = append([]HealthSystem{}, systems...)
+	w.tracks = tracks
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	go w.run(interval, w.stop, w.done)
+	return nil
+}
+
+// StopHealthWatch 停止健康监控并丢弃结果，未启动时不做任何事
+func StopHealthWatch() {
+	w := defaultHealth
+	w.mu.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.tracks = nil
+	w.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// HealthWatchDevices 返回健康监控的设备，未启动时返回空
+func HealthWatchDevices() []int {
+	w := defaultHealth
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.tracks == nil {
+		return nil
+	}
+	devices := append([]int{}, w.devices...)
+	sort.Ints(devices)
+	return devices
+}
+
+// HealthCheck 返回设备的健康状态：设备在健康监控中时返回监控开始以来的结论，
+// 否则立即检查全部子系统，ECC、退役页与 PCIe 重放按驱动加载以来的累计值评估
+func HealthCheck(dvInd int) (health DeviceHealth, err error) {
+	w := defaultHealth
+	w.mu.Lock()
+	if t, ok := w.tracks[dvInd]; ok && !t.checkedAt.IsZero() {
+		incidents := make([]HealthIncident, 0, len(t.incidents))
+		for _, incident := range t.incidents {
+			incidents = append(incidents, *incident)
+		}
+		health = buildDeviceHealth(dvInd, t.checked, incidents)
+		health.Watched, health.Since, health.CheckedAt = true, w.since, t.checkedAt
+		w.mu.Unlock()
+		return health, nil
+	}
+	w.mu.Unlock()
+
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return health, fmt.Errorf("Error HealthCheck:%w", err)
+	}
+	if dvInd < 0 || dvInd >= numDevices {
+		return health, fmt.Errorf("Error HealthCheck:device %d out of range [0, %d)", dvInd, numDevices)
+	}
+	incidents, checked := checkHealth(dvInd, AllHealthSystems, &healthBaseline{}, "since driver load")
+	health = buildDeviceHealth(dvInd, checked, incidents)
+	health.CheckedAt = time.Now()
+	return health, nil
+}
+
+func (w *healthWatcher) run(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w.sample()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *healthWatcher) sample() {
+	w.mu.Lock()
+	devices, systems, since := w.devices, w.systems, w.since
+	tracks := w.tracks
+	w.mu.Unlock()
+	if tracks == nil {
+		return
+	}
+	sinceText := "since " + since.Format(time.RFC3339)
+	for _, dvInd := range devices {
+		t := tracks[dvInd]
+		incidents, checked := checkHealth(dvInd, systems, t.base, sinceText)
+		w.mu.Lock()
+		t.checked, t.checkedAt = checked, time.Now()
+		for _, incident := range incidents {
+			prev, ok := t.incidents[incident.key]
+			if !ok {
+				incident := incident
+				t.incidents[incident.key] = &incident
+				glog.Warningf("device %d health %s %s: %s", dvInd, incident.System, incident.Result, incident.Message)
+				continue
+			}
+			prev.Message, prev.LastSeen = incident.Message, incident.LastSeen
+			prev.Result = prev.Result.worst(incident.Result)
+		}
+		w.mu.Unlock()
+	}
+}
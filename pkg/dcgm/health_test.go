@@ -0,0 +1,214 @@
+package dcgm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// systemResults 返回各子系统的结论
+func systemResults(health DeviceHealth) map[HealthSystem]HealthResult {
+	results := map[HealthSystem]HealthResult{}
+	for _, system := range health.Systems {
+		results[system.System] = system.Result
+	}
+	return results
+}
+
+// systemIncidents 返回子系统中的问题
+func systemIncidents(health DeviceHealth, system HealthSystem) []HealthIncident {
+	for _, s := range health.Systems {
+		if s.System == system {
+			return s.Incidents
+		}
+	}
+	return nil
+}
+
+func TestHealthCheckVerdicts(t *testing.T) {
+	// 模拟设备的临界温度为 95℃、紧急温度为 105℃，结温比边缘温度高 8℃，显存温度高 4℃
+	tests := []struct {
+		name   string
+		device FakeDeviceSpec
+		want   map[HealthSystem]HealthResult
+		result HealthResult
+	}{
+		{"healthy", FakeDeviceSpec{Temperature: ConstFakeValue(50)}, nil, HealthPass},
+		{"junction critical", FakeDeviceSpec{Temperature: ConstFakeValue(90)},
+			map[HealthSystem]HealthResult{HealthSystemThermal: HealthWarn}, HealthWarn},
+		{"junction emergency", FakeDeviceSpec{Temperature: ConstFakeValue(98)},
+			map[HealthSystem]HealthResult{HealthSystemThermal: HealthFail}, HealthFail},
+		{"correctable ECC", FakeDeviceSpec{Temperature: ConstFakeValue(50), Ecc: []FakeEccSpec{{Block: "UMC", CE: 3}}},
+			map[HealthSystem]HealthResult{HealthSystemECC: HealthWarn}, HealthWarn},
+		{"uncorrectable ECC", FakeDeviceSpec{Temperature: ConstFakeValue(50), Ecc: []FakeEccSpec{{Block: "UMC", CE: 3}, {Block: "SDMA", UE: 1}}},
+			map[HealthSystem]HealthResult{HealthSystemECC: HealthFail}, HealthFail},
+		{"pending page", FakeDeviceSpec{Temperature: ConstFakeValue(50), RetiredPages: []FakeRetiredPageSpec{{Address: 0x1000, Status: "pending"}}},
+			map[HealthSystem]HealthResult{HealthSystemMemory: HealthWarn}, HealthWarn},
+		{"unreservable page", FakeDeviceSpec{Temperature: ConstFakeValue(50), RetiredPages: []FakeRetiredPageSpec{{Address: 0x1000, Status: "unreservable"}}},
+			map[HealthSystem]HealthResult{HealthSystemMemory: HealthFail}, HealthFail},
+		// 度量表 v1.3 中位 6 为 TEMP_GPU，位 0 为 PPT0
+		{"thermal throttle", FakeDeviceSpec{Temperature: ConstFakeValue(50), ThrottleStatus: 1 << 6},
+			map[HealthSystem]HealthResult{HealthSystemThrottle: HealthWarn}, HealthWarn},
+		{"power throttle", FakeDeviceSpec{Temperature: ConstFakeValue(50), ThrottleStatus: 1 << 0}, nil, HealthPass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.device.Ecc == nil {
+				tt.device.Ecc = []FakeEccSpec{{Block: "UMC"}}
+			}
+			initFakeDevices(t, tt.device)
+			health, err := HealthCheck(0)
+			if err != nil {
+				t.Fatalf("HealthCheck: %v", err)
+			}
+			want := map[HealthSystem]HealthResult{}
+			for _, system := range AllHealthSystems {
+				want[system] = HealthPass
+			}
+			for system, result := range tt.want {
+				want[system] = result
+			}
+			if got := systemResults(health); !reflect.DeepEqual(got, want) {
+				t.Errorf("systems = %v, want %v", got, want)
+			}
+			if health.Result != tt.result || health.Watched {
+				t.Errorf("result = %s, watched %v, want %s from a one-shot check", health.Result, health.Watched, tt.result)
+			}
+		})
+	}
+
+	t.Run("thermal incidents", func(t *testing.T) {
+		initFakeDevices(t, FakeDeviceSpec{Temperature: ConstFakeValue(98)})
+		health, err := HealthCheck(0)
+		if err != nil {
+			t.Fatalf("HealthCheck: %v", err)
+		}
+		var got []string
+		for _, incident := range systemIncidents(health, HealthSystemThermal) {
+			got = append(got, fmt.Sprintf("%s %s", incident.Result, incident.Message))
+		}
+		want := []string{
+			"WARN edge temperature 98.0°C reached the critical threshold 95.0°C",
+			"FAIL junction temperature 106.0°C reached the emergency threshold 105.0°C",
+			"WARN memory temperature 102.0°C reached the critical threshold 95.0°C",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("thermal incidents = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestHealthWatch(t *testing.T) {
+	advance := initFakeDevices(t, FakeDeviceSpec{
+		Temperature: ConstFakeValue(50),
+		PcieReplay:  ConstFakeValue(0.5),
+		Ecc: []FakeEccSpec{{Block: "UMC", CE: 5, Inject: []FakeEccInjection{
+			{At: 10, CE: 2},
+			{At: 30, UE: 1},
+		}}},
+		RetiredPages: []FakeRetiredPageSpec{{At: 1, Address: 0x1000}, {At: 25, Address: 0x2000}},
+		XgmiErrors:   []FakeXgmiErrorSpec{{At: 6, Count: 1}, {At: 20, Count: 3}},
+	})
+	// 监控开始前已有 5 个 CE、1 个退役页与 2 次重放
+	advance(4 * time.Second)
+	if err := StartHealthWatch([]int{0}, nil, time.Hour); err != nil {
+		t.Fatalf("StartHealthWatch: %v", err)
+	}
+	var health DeviceHealth
+	for deadline := time.Now().Add(5 * time.Second); !health.Watched; time.Sleep(10 * time.Millisecond) {
+		var err error
+		if health, err = HealthCheck(0); err != nil {
+			t.Fatalf("HealthCheck: %v", err)
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("health watch did not check the device")
+		}
+	}
+	if health.Result != HealthPass {
+		t.Fatalf("first check = %s %+v, want PASS against the baseline", health.Result, health.Systems)
+	}
+	since := "since " + health.Since.Format(time.RFC3339)
+
+	steps := []struct {
+		at      time.Duration
+		results map[HealthSystem]HealthResult
+		// messages 每个子系统按首次发现排序的问题描述
+		messages map[HealthSystem][]string
+	}{
+		{8 * time.Second,
+			map[HealthSystem]HealthResult{HealthSystemPCIe: HealthWarn, HealthSystemXGMI: HealthWarn},
+			map[HealthSystem][]string{
+				HealthSystemPCIe: {"2 PCIe replays " + since},
+				HealthSystemXGMI: {"XGMI error detected"},
+			}},
+		// XGMI 错误读取后清除，问题仍然保留
+		{12 * time.Second,
+			map[HealthSystem]HealthResult{HealthSystemECC: HealthWarn, HealthSystemPCIe: HealthWarn, HealthSystemXGMI: HealthWarn},
+			map[HealthSystem][]string{
+				HealthSystemECC:  {"2 correctable ECC errors in block UMC " + since},
+				HealthSystemPCIe: {"4 PCIe replays " + since},
+				HealthSystemXGMI: {"XGMI error detected"},
+			}},
+		{31 * time.Second,
+			map[HealthSystem]HealthResult{HealthSystemECC: HealthFail, HealthSystemMemory: HealthWarn, HealthSystemPCIe: HealthWarn, HealthSystemXGMI: HealthFail},
+			map[HealthSystem][]string{
+				HealthSystemECC:    {"2 correctable ECC errors in block UMC " + since, "1 uncorrectable ECC errors in block UMC " + since},
+				HealthSystemMemory: {"1 pages retired " + since},
+				HealthSystemPCIe:   {"13 PCIe replays " + since},
+				HealthSystemXGMI:   {"XGMI error detected", "multiple XGMI errors detected"},
+			}},
+	}
+	elapsed := 4 * time.Second
+	var firstReplay HealthIncident
+	for _, step := range steps {
+		advance(step.at - elapsed)
+		elapsed = step.at
+		defaultHealth.sample()
+		health, err := HealthCheck(0)
+		if err != nil {
+			t.Fatalf("HealthCheck at %v: %v", step.at, err)
+		}
+		want := map[HealthSystem]HealthResult{}
+		for _, system := range AllHealthSystems {
+			want[system] = HealthPass
+		}
+		for system, result := range step.results {
+			want[system] = result
+		}
+		if got := systemResults(health); !reflect.DeepEqual(got, want) {
+			t.Errorf("at %v: systems = %v, want %v", step.at, got, want)
+		}
+		for _, system := range AllHealthSystems {
+			var got []string
+			for _, incident := range systemIncidents(health, system) {
+				got = append(got, incident.Message)
+			}
+			if !reflect.DeepEqual(got, step.messages[system]) {
+				t.Errorf("at %v: %s incidents = %q, want %q", step.at, system, got, step.messages[system])
+			}
+		}
+
+		// 同一问题只记录一次，FirstSeen 保持不变，LastSeen 随检查更新
+		replay := systemIncidents(health, HealthSystemPCIe)[0]
+		if firstReplay.FirstSeen.IsZero() {
+			firstReplay = replay
+		} else if !replay.FirstSeen.Equal(firstReplay.FirstSeen) || replay.LastSeen.Before(firstReplay.LastSeen) {
+			t.Errorf("at %v: replay incident seen %v–%v, want first seen at %v", step.at, replay.FirstSeen, replay.LastSeen, firstReplay.FirstSeen)
+		}
+	}
+}
+
+func TestHealthBaselineUnread(t *testing.T) {
+	initFakeDevices(t, FakeDeviceSpec{Temperature: ConstFakeValue(50), PcieReplay: ConstFakeValue(1), Ecc: []FakeEccSpec{{Block: "UMC", CE: 3}}})
+	// 读取基线失败时计数器从零开始，问题描述不能写成监控开始以来
+	base := &healthBaseline{ecc: map[string]BlocksInfo{}, unread: map[HealthSystem]bool{HealthSystemECC: true}}
+	incidents, checked := checkHealth(0, []HealthSystem{HealthSystemECC, HealthSystemPCIe}, base, "since 2024-01-01T00:00:00Z")
+	if !reflect.DeepEqual(checked, []HealthSystem{HealthSystemECC, HealthSystemPCIe}) || len(incidents) != 1 {
+		t.Fatalf("checked %v with incidents %+v, want ECC and PCIe with one incident", checked, incidents)
+	}
+	if msg := incidents[0].Message; !strings.HasSuffix(msg, "since driver load") {
+		t.Errorf("message = %q, want the count since driver load", msg)
+	}
+}
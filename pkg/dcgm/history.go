@@ -0,0 +1,447 @@
+package dcgm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// ErrNotRecorded 查询未被历史存储记录的设备字段时返回的错误
+var ErrNotRecorded = errors.New("field not recorded in history")
+
+// 默认的历史存储配置
+const (
+	DefaultHistoryInterval        = 10 * time.Second
+	DefaultHistoryRawRetention    = time.Hour
+	DefaultHistoryMinuteRetention = 24 * time.Hour
+	DefaultHistoryHourRetention   = 30 * 24 * time.Hour
+)
+
+// DefaultHistoryFields 未指定字段时记录的设备字段
+var DefaultHistoryFields = []FieldID{
+	FieldGpuTemp, FieldJunctionTemp, FieldMemoryTemp,
+	FieldPowerUsage, FieldSmClock, FieldMemClock,
+	FieldGpuUtil, FieldMemCopyUtil, FieldFbUsed,
+	FieldPcieTx, FieldPcieRx,
+}
+
+// HistoryConfig 历史存储配置：按 Interval 采样，原始数据保留 RawRetention，
+// 降采样后的 1 分钟数据保留 MinuteRetention，1 小时数据保留 HourRetention
+type HistoryConfig struct {
+	Devices         []int
+	Fields          []FieldID
+	Interval        time.Duration
+	RawRetention    time.Duration
+	MinuteRetention time.Duration
+	HourRetention   time.Duration
+}
+
+// HistoryResolution 历史数据的精度
+type HistoryResolution int
+
+const (
+	HistoryAuto   HistoryResolution = iota // 按查询窗口自动选择能覆盖窗口的最细精度
+	HistoryRaw                             // 原始采样
+	HistoryMinute                          // 1 分钟降采样
+	HistoryHour                            // 1 小时降采样
+)
+
+var historyResolutionNames = []string{"auto", "raw", "1m", "1h"}
+
+func (r HistoryResolution) String() string {
+	if int(r) >= 0 && int(r) < len(historyResolutionNames) {
+		return historyResolutionNames[r]
+	}
+	return "HistoryResolution(" + strconv.Itoa(int(r)) + ")"
+}
+
+// MarshalText 以名称输出精度，便于 JSON 序列化
+func (r HistoryResolution) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// ParseHistoryResolution 解析精度名称 auto、raw、1m、1h，空字符串表示 auto
+func ParseHistoryResolution(s string) (HistoryResolution, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return HistoryAuto, nil
+	}
+	for i, name := range historyResolutionNames {
+		if s == name {
+			return HistoryResolution(i), nil
+		}
+	}
+	return HistoryAuto, fmt.Errorf("Error ParseHistoryResolution:unknown resolution %q", s)
+}
+
+// HistoryPoint 一个数据点，原始数据的 Count 为 1，降采样数据为所在时间段内的采样数量与统计值
+type HistoryPoint struct {
+	Timestamp time.Time
+	Count     int
+	Min       float64
+	Max       float64
+	Avg       float64
+}
+
+// HistoryStats 时间窗口内的聚合结果；Percentiles 的键为 "p50"、"p99.9" 形式，
+// 使用降采样数据时百分位按各时间段的平均值（以采样数量加权）近似计算
+type HistoryStats struct {
+	Device      int
+	FieldID     FieldID
+	Start       time.Time
+	End         time.Time
+	Resolution  HistoryResolution
+	Count       int
+	Min         float64
+	Max         float64
+	Avg         float64
+	Percentiles map[string]float64 `json:",omitempty"`
+}
+
+// historyBucket 一个时间段内的统计值，原始数据每个采样一个 bucket
+type historyBucket struct {
+	start time.Time
+	count int
+	min   float64
+	max   float64
+	sum   float64
+}
+
+func (b historyBucket) point() HistoryPoint {
+	return HistoryPoint{Timestamp: b.start, Count: b.count, Min: b.min, Max: b.max, Avg: b.sum / float64(b.count)}
+}
+
+// historyTier 一种精度的数据，step 为 0 表示原始数据
+type historyTier struct {
+	step      time.Duration
+	retention time.Duration
+	buckets   []historyBucket
+}
+
+func (t *historyTier) add(ts time.Time, v float64) {
+	start := ts
+	if t.step > 0 {
+		start = ts.Truncate(t.step)
+		if n := len(t.buckets); n > 0 && t.buckets[n-1].start.Equal(start) {
+			b := &t.buckets[n-1]
+			b.count++
+			b.sum += v
+			b.min = math.Min(b.min, v)
+			b.max = math.Max(b.max, v)
+			return
+		}
+	}
+	t.buckets = append(t.buckets, historyBucket{start: start, count: 1, min: v, max: v, sum: v})
+}
+
+// prune 丢弃超出保留时间的数据，降采样数据以时间段结束时间为准
+func (t *historyTier) prune(now time.Time) {
+	oldest := now.Add(-t.retention)
+	i := 0
+	for i < len(t.buckets) && t.buckets[i].start.Add(t.step).Before(oldest) {
+		i++
+	}
+	t.buckets = t.buckets[i:]
+}
+
+// between 返回与 [start, end] 有交集的数据
+func (t *historyTier) between(start, end time.Time) []historyBucket {
+	var buckets []historyBucket
+	for _, b := range t.buckets {
+		if b.start.Add(t.step).Before(start) || b.start.After(end) {
+			continue
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets
+}
+
+// historySeries 单个设备字段的三种精度数据
+type historySeries struct {
+	tiers [3]historyTier
+}
+
+func newHistorySeries(cfg HistoryConfig) *historySeries {
+	return &historySeries{tiers: [3]historyTier{
+		{step: 0, retention: cfg.RawRetention},
+		{step: time.Minute, retention: cfg.MinuteRetention},
+		{step: time.Hour, retention: cfg.HourRetention},
+	}}
+}
+
+// historyStore 后台采样并降采样的历史存储
+type historyStore struct {
+	mu     sync.Mutex
+	cfg    HistoryConfig
+	series map[watchKey]*historySeries
+	stop   chan struct{}
+	done   chan struct{}
+	// activity 平均活动字段的游标，与其他调用方的读取互不影响
+	activity *ActivityCursor
+}
+
+var defaultHistory = &historyStore{activity: NewActivityCursor()}
+
+// DefaultHistoryConfig 返回默认配置：所有设备、DefaultHistoryFields，10 秒采样，
+// 原始数据保留 1 小时，1 分钟数据保留 1 天，1 小时数据保留 30 天
+func DefaultHistoryConfig() HistoryConfig {
+	return HistoryConfig{
+		Fields:          append([]FieldID{}, DefaultHistoryFields...),
+		Interval:        DefaultHistoryInterval,
+		RawRetention:    DefaultHistoryRawRetention,
+		MinuteRetention: DefaultHistoryMinuteRetention,
+		HourRetention:   DefaultHistoryHourRetention,
+	}
+}
+
+// StartHistory 启动历史存储，后台按 cfg.Interval 采样设备字段；配置中为零的项取默认值，
+// Devices 为空时记录所有设备。已经启动时先停止并丢弃已有数据。
+func StartHistory(cfg HistoryConfig) error {
+	def := DefaultHistoryConfig()
+	if len(cfg.Fields) == 0 {
+		cfg.Fields = def.Fields
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = def.Interval
+	}
+	if cfg.RawRetention == 0 {
+		cfg.RawRetention = def.RawRetention
+	}
+	if cfg.MinuteRetention == 0 {
+		cfg.MinuteRetention = def.MinuteRetention
+	}
+	if cfg.HourRetention == 0 {
+		cfg.HourRetention = def.HourRetention
+	}
+	if cfg.Interval < 0 || cfg.RawRetention < cfg.Interval {
+		return fmt.Errorf("Error StartHistory:interval %v must be positive and not longer than raw retention %v", cfg.Interval, cfg.RawRetention)
+	}
+	if cfg.MinuteRetention < time.Minute || cfg.HourRetention < time.Hour {
+		return fmt.Errorf("Error StartHistory:minute retention must be at least 1m and hour retention at least 1h")
+	}
+	for _, field := range cfg.Fields {
+		info, ok := lookupField(field)
+		if !ok {
+			return fmt.Errorf("Error StartHistory:unknown field %d", field)
+		}
+		if info.Scope != FieldScopeDevice || info.Type == FieldTypeString {
+			return fmt.Errorf("Error StartHistory:field %v is not a numeric device field", field)
+		}
+	}
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return fmt.Errorf("Error StartHistory:%w", err)
+	}
+	if len(cfg.Devices) == 0 {
+		for i := 0; i < numDevices; i++ {
+			cfg.Devices = append(cfg.Devices, i)
+		}
+	}
+	for _, dvInd := range cfg.Devices {
+		if dvInd < 0 || dvInd >= numDevices {
+			return fmt.Errorf("Error StartHistory:device %d out of range [0, %d)", dvInd, numDevices)
+		}
+	}
+	cfg.Devices = append([]int{}, cfg.Devices...)
+	cfg.Fields = append([]FieldID{}, cfg.Fields...)
+
+	StopHistory()
+	h := defaultHistory
+	h.mu.Lock()
+	h.cfg = cfg
+	h.series = map[watchKey]*historySeries{}
+	for _, dvInd := range cfg.Devices {
+		for _, field := range cfg.Fields {
+			h.series[watchKey{dvInd, field}] = newHistorySeries(cfg)
+		}
+	}
+	h.stop = make(chan struct{})
+	h.done = make(chan struct{})
+	go h.run(cfg, h.stop, h.done)
+	h.mu.Unlock()
+	return nil
+}
+
+// StopHistory 停止历史存储并丢弃所有数据，未启动时不做任何事
+func StopHistory() {
+	h := defaultHistory
+	h.mu.Lock()
+	stop, done := h.stop, h.done
+	h.stop, h.done = nil, nil
+	h.series = nil
+	h.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// HistoryRunning 返回历史存储当前的配置，未启动时第二个返回值为 false
+func HistoryRunning() (HistoryConfig, bool) {
+	h := defaultHistory
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stop == nil {
+		return HistoryConfig{}, false
+	}
+	cfg := h.cfg
+	cfg.Devices = append([]int{}, cfg.Devices...)
+	cfg.Fields = append([]FieldID{}, cfg.Fields...)
+	return cfg, true
+}
+
+// HistorySamples 返回设备字段在 [start, end] 内的数据点及实际使用的精度，按时间从旧到新排列；
+// start 为零值时取 end 前 1 小时，end 为零值时取当前时间
+func HistorySamples(dvInd int, field FieldID, start, end time.Time, resolution HistoryResolution) ([]HistoryPoint, HistoryResolution, error) {
+	buckets, resolution, err := defaultHistory.query(dvInd, field, &start, &end, resolution)
+	if err != nil {
+		return nil, resolution, fmt.Errorf("Error HistorySamples:%w", err)
+	}
+	points := make([]HistoryPoint, len(buckets))
+	for i, b := range buckets {
+		points[i] = b.point()
+	}
+	return points, resolution, nil
+}
+
+// QueryHistory 计算设备字段在 [start, end] 内的最小值、最大值、平均值与百分位（0-100），
+// 例如最近 1 小时的峰值功耗：QueryHistory(3, FieldPowerUsage, time.Now().Add(-time.Hour), time.Time{}, HistoryAuto)
+func QueryHistory(dvInd int, field FieldID, start, end time.Time, resolution HistoryResolution, percentiles ...float64) (HistoryStats, error) {
+	for _, p := range percentiles {
+		if p < 0 || p > 100 || math.IsNaN(p) {
+			return HistoryStats{}, fmt.Errorf("Error QueryHistory:percentile %v out of range [0, 100]", p)
+		}
+	}
+	buckets, resolution, err := defaultHistory.query(dvInd, field, &start, &end, resolution)
+	if err != nil {
+		return HistoryStats{}, fmt.Errorf("Error QueryHistory:%w", err)
+	}
+	stats := HistoryStats{Device: dvInd, FieldID: field, Start: start, End: end, Resolution: resolution}
+	if len(buckets) == 0 {
+		return stats, fmt.Errorf("Error QueryHistory:device %d field %v:%w", dvInd, field, ErrNoData)
+	}
+	var sum float64
+	stats.Min, stats.Max = buckets[0].min, buckets[0].max
+	for _, b := range buckets {
+		stats.Count += b.count
+		sum += b.sum
+		stats.Min = math.Min(stats.Min, b.min)
+		stats.Max = math.Max(stats.Max, b.max)
+	}
+	stats.Avg = sum / float64(stats.Count)
+	if len(percentiles) > 0 {
+		stats.Percentiles = map[string]float64{}
+		sorted := append([]historyBucket{}, buckets...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].sum/float64(sorted[i].count) < sorted[j].sum/float64(sorted[j].count)
+		})
+		for _, p := range percentiles {
+			key := "p" + strconv.FormatFloat(p, 'f', -1, 64)
+			stats.Percentiles[key] = weightedPercentile(sorted, stats.Count, p)
+		}
+	}
+	return stats, nil
+}
+
+// weightedPercentile 按最近秩法计算百分位，每个 bucket 的平均值以采样数量为权重；buckets 需按平均值升序排列
+func weightedPercentile(buckets []historyBucket, total int, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(total)))
+	if rank < 1 {
+		rank = 1
+	}
+	seen := 0
+	for _, b := range buckets {
+		seen += b.count
+		if seen >= rank {
+			return b.sum / float64(b.count)
+		}
+	}
+	last := buckets[len(buckets)-1]
+	return last.sum / float64(last.count)
+}
+
+// query 选择精度并返回 [start, end] 内的数据，会把 start、end 的零值替换为实际使用的时间
+func (h *historyStore) query(dvInd int, field FieldID, start, end *time.Time, resolution HistoryResolution) ([]historyBucket, HistoryResolution, error) {
+	now := time.Now()
+	if end.IsZero() {
+		*end = now
+	}
+	if start.IsZero() {
+		*start = end.Add(-time.Hour)
+	}
+	if end.Before(*start) {
+		return nil, resolution, fmt.Errorf("end %v before start %v", *end, *start)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	series, ok := h.series[watchKey{dvInd, field}]
+	if !ok {
+		return nil, resolution, fmt.Errorf("device %d field %v:%w", dvInd, field, ErrNotRecorded)
+	}
+	switch resolution {
+	case HistoryAuto:
+		// 选择保留时间能覆盖 start 的最细精度，都不能覆盖时使用 1 小时精度。过期数据在每次采样时才清理，
+		// 留出一个采样间隔的余量，调用方在查询前算出的 start（如 time.Now().Add(-time.Hour)）仍能使用原始数据
+		resolution = HistoryHour
+		for i, tier := range series.tiers {
+			if !start.Before(now.Add(-tier.retention - h.cfg.Interval)) {
+				resolution = HistoryResolution(i + 1)
+				break
+			}
+		}
+	case HistoryRaw, HistoryMinute, HistoryHour:
+	default:
+		return nil, resolution, fmt.Errorf("unknown resolution %v", resolution)
+	}
+	return series.tiers[resolution-1].between(*start, *end), resolution, nil
+}
+
+// run 后台采样循环，启动后立即采样一次
+func (h *historyStore) run(cfg HistoryConfig, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		h.sample(cfg)
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample 采样所有记录的设备字段，写入原始数据并累加到 1 分钟与 1 小时数据中
+func (h *historyStore) sample(cfg HistoryConfig) {
+	values, err := h.activity.GetFieldValues(cfg.Devices, cfg.Fields)
+	if err != nil {
+		glog.Errorf("Error sample history:%s", err)
+		return
+	}
+	now := time.Now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, value := range values {
+		series, ok := h.series[watchKey{value.EntityID, value.FieldID}]
+		if !ok {
+			continue
+		}
+		if value.Err == "" {
+			for i := range series.tiers {
+				series.tiers[i].add(value.Timestamp, value.Value)
+			}
+		} else {
+			glog.V(2).Infof("history device %d field %v: %s", value.EntityID, value.FieldID, value.Err)
+		}
+		for i := range series.tiers {
+			series.tiers[i].prune(now)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package dcgm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHistoryTierDownsample(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := []struct {
+		at time.Duration
+		v  float64
+	}{
+		{0, 1}, {10 * time.Second, 3}, {59 * time.Second, 5}, {time.Minute, 7}, {61 * time.Minute, 9},
+	}
+	cfg := HistoryConfig{RawRetention: time.Hour, MinuteRetention: 24 * time.Hour, HourRetention: 30 * 24 * time.Hour}
+	series := newHistorySeries(cfg)
+	for _, s := range samples {
+		for i := range series.tiers {
+			series.tiers[i].add(t0.Add(s.at), s.v)
+		}
+	}
+	tests := []struct {
+		name string
+		tier historyTier
+		want []historyBucket
+	}{
+		{"raw", series.tiers[0], []historyBucket{
+			{t0, 1, 1, 1, 1},
+			{t0.Add(10 * time.Second), 1, 3, 3, 3},
+			{t0.Add(59 * time.Second), 1, 5, 5, 5},
+			{t0.Add(time.Minute), 1, 7, 7, 7},
+			{t0.Add(61 * time.Minute), 1, 9, 9, 9},
+		}},
+		{"1m", series.tiers[1], []historyBucket{
+			{t0, 3, 1, 5, 9},
+			{t0.Add(time.Minute), 1, 7, 7, 7},
+			{t0.Add(61 * time.Minute), 1, 9, 9, 9},
+		}},
+		{"1h", series.tiers[2], []historyBucket{
+			{t0, 4, 1, 7, 16},
+			{t0.Add(time.Hour), 1, 9, 9, 9},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.tier.buckets, tt.want) {
+				t.Errorf("buckets = %+v, want %+v", tt.tier.buckets, tt.want)
+			}
+		})
+	}
+	if p := series.tiers[1].buckets[0].point(); p.Count != 3 || p.Avg != 3 || p.Min != 1 || p.Max != 5 {
+		t.Errorf("1m point = %+v, want 3 samples from 1 to 5 averaging 3", p)
+	}
+
+	// 降采样数据在时间段结束后超出保留时间才丢弃
+	minute := historyTier{step: time.Minute, retention: time.Minute, buckets: append([]historyBucket{}, series.tiers[1].buckets...)}
+	minute.prune(t0.Add(2*time.Minute + time.Second))
+	if len(minute.buckets) != 2 || !minute.buckets[0].start.Equal(t0.Add(time.Minute)) {
+		t.Errorf("1m buckets after prune = %+v, want the buckets from 00:01", minute.buckets)
+	}
+	raw := historyTier{retention: 50 * time.Second, buckets: append([]historyBucket{}, series.tiers[0].buckets...)}
+	raw.prune(t0.Add(time.Minute))
+	if len(raw.buckets) != 4 || !raw.buckets[0].start.Equal(t0.Add(10*time.Second)) {
+		t.Errorf("raw buckets after prune = %+v, want the samples from 00:00:10", raw.buckets)
+	}
+}
+
+func TestWeightedPercentile(t *testing.T) {
+	// 平均值 10、20、100 的时间段分别有 1、8、1 个采样
+	buckets := []historyBucket{
+		{count: 1, sum: 10},
+		{count: 8, sum: 160},
+		{count: 1, sum: 100},
+	}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 10}, {10, 10}, {10.1, 20}, {50, 20}, {90, 20}, {90.1, 100}, {100, 100},
+	}
+	for _, tt := range tests {
+		if got := weightedPercentile(buckets, 10, tt.p); got != tt.want {
+			t.Errorf("weightedPercentile(p%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+// setHistorySeries 直接设置历史存储中的数据，不启动采样循环
+func setHistorySeries(t *testing.T, cfg HistoryConfig, series map[watchKey]*historySeries) {
+	t.Helper()
+	h := defaultHistory
+	h.mu.Lock()
+	h.cfg, h.series = cfg, series
+	h.mu.Unlock()
+	t.Cleanup(StopHistory)
+}
+
+func TestQueryHistory(t *testing.T) {
+	cfg := DefaultHistoryConfig()
+	now := time.Now().Truncate(time.Minute)
+	series := newHistorySeries(cfg)
+	// 过去 3 分钟每分钟 2 个采样，最后 1 分钟的值偏高
+	for i, v := range []float64{10, 10, 20, 20, 100, 100} {
+		ts := now.Add(-3 * time.Minute).Add(time.Duration(i) * 30 * time.Second)
+		for j := range series.tiers {
+			series.tiers[j].add(ts, v)
+		}
+	}
+	setHistorySeries(t, cfg, map[watchKey]*historySeries{{0, FieldPowerUsage}: series})
+
+	stats, err := QueryHistory(0, FieldPowerUsage, now.Add(-5*time.Minute), now, HistoryMinute, 50, 66.7, 100)
+	if err != nil {
+		t.Fatalf("QueryHistory: %v", err)
+	}
+	want := map[string]float64{"p50": 20, "p66.7": 100, "p100": 100}
+	if stats.Resolution != HistoryMinute || stats.Count != 6 || stats.Min != 10 || stats.Max != 100 || stats.Avg != 260.0/6 ||
+		!reflect.DeepEqual(stats.Percentiles, want) {
+		t.Errorf("QueryHistory = %+v, want 6 samples from 10 to 100 with percentiles %v", stats, want)
+	}
+
+	if _, err := QueryHistory(0, FieldPowerUsage, now.Add(-5*time.Minute), now, HistoryRaw, 101); err == nil {
+		t.Error("QueryHistory accepted percentile 101")
+	}
+	if _, err := QueryHistory(1, FieldPowerUsage, time.Time{}, time.Time{}, HistoryAuto); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("QueryHistory of an unrecorded device = %v, want ErrNotRecorded", err)
+	}
+	if _, err := QueryHistory(0, FieldPowerUsage, now.Add(-time.Hour), now.Add(-50*time.Minute), HistoryRaw); !errors.Is(err, ErrNoData) {
+		t.Errorf("QueryHistory of an empty window = %v, want ErrNoData", err)
+	}
+}
+
+func TestHistoryAutoResolution(t *testing.T) {
+	cfg := DefaultHistoryConfig()
+	setHistorySeries(t, cfg, map[watchKey]*historySeries{{0, FieldGpuTemp}: newHistorySeries(cfg)})
+	tests := []struct {
+		name   string
+		window time.Duration
+		res    HistoryResolution
+		want   HistoryResolution
+	}{
+		// start 在查询之前计算，略早于查询时刻的 now-1h
+		{"last hour", time.Hour, HistoryAuto, HistoryRaw},
+		{"within one interval past the raw retention", time.Hour + cfg.Interval/2, HistoryAuto, HistoryRaw},
+		{"past the raw retention", 2 * time.Hour, HistoryAuto, HistoryMinute},
+		{"last day", 24 * time.Hour, HistoryAuto, HistoryMinute},
+		{"past the minute retention", 48 * time.Hour, HistoryAuto, HistoryHour},
+		{"past every retention", 60 * 24 * time.Hour, HistoryAuto, HistoryHour},
+		{"explicit resolution", 2 * time.Hour, HistoryRaw, HistoryRaw},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := time.Now().Add(-tt.window), time.Time{}
+			_, got, err := defaultHistory.query(0, FieldGpuTemp, &start, &end, tt.res)
+			if err != nil {
+				t.Fatalf("query: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolution = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	start, end := time.Time{}, time.Time{}
+	if _, _, err := defaultHistory.query(0, FieldGpuTemp, &start, &end, HistoryResolution(9)); err == nil {
+		t.Error("query accepted an unknown resolution")
+	}
+	// 零值替换为最近 1 小时
+	if end.Sub(start) != time.Hour {
+		t.Errorf("default window = %v, want 1h", end.Sub(start))
+	}
+}
@@ -0,0 +1,608 @@
+package dcgm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+var (
+	// ErrJobNotFound 任务不存在时返回的错误
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobExists 启动已存在的任务时返回的错误
+	ErrJobExists = errors.New("job already exists")
+	// ErrJobNotRunning 停止已结束的任务时返回的错误
+	ErrJobNotRunning = errors.New("job not running")
+)
+
+// DefaultJobInterval 任务统计的默认采样间隔
+const DefaultJobInterval = time.Second
+
+// DefaultJobKeep 默认保留的已结束任务数量，超出时删除结束最早的任务
+const DefaultJobKeep = 1000
+
+// jobSaveInterval 运行中的任务定期写入持久化文件，服务重启后从最近一次保存的统计继续
+const jobSaveInterval = time.Minute
+
+// JobState 任务状态
+type JobState string
+
+const (
+	JobRunning JobState = "running"
+	JobStopped JobState = "stopped"
+)
+
+// JobDeviceStats 任务期间单个设备的统计：能耗为焦耳，利用率为百分比，显存为 MiB，温度为摄氏度，功耗为瓦；
+// ECC 与退役页为任务期间的增量，在任务结束时计算
+type JobDeviceStats struct {
+	Device           int
+	Samples          int
+	EnergyJoules     float64
+	UtilizationAvg   float64
+	UtilizationMax   float64
+	MemoryUsedAvg    float64
+	MemoryUsedMax    float64
+	TemperatureMax   float64
+	PowerMin         float64
+	PowerAvg         float64
+	PowerMax         float64
+	EccCorrectable   int64
+	EccUncorrectable int64
+	RetiredPages     int64
+}
+
+// JobProcess 任务期间在任务设备上出现过的 KFD 进程
+type JobProcess struct {
+	PID          int
+	Name         string
+	Devices      []int
+	FirstSeen    time.Time
+	LastSeen     time.Time
+	MaxVramBytes uint64
+}
+
+// Job 任务记录，运行中的任务 Duration 为启动至今的时长
+type Job struct {
+	ID        string
+	Devices   []int
+	State     JobState
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+	Stats     []JobDeviceStats
+	Processes []JobProcess
+}
+
+// jobDevice 单个设备的累加值与基线，随任务记录一起持久化
+type jobDevice struct {
+	Stats      JobDeviceStats
+	UtilSum    float64
+	UtilN      int
+	MemSum     float64
+	MemN       int
+	PowerSum   float64
+	PowerN     int
+	EccCE      int64
+	EccUE      int64
+	Retired    int64
+	EnergyRaw  uint64
+	EnergyRes  float32
+	EnergyTime time.Time
+	EnergyOK   bool
+}
+
+// jobRecord 持久化文件中的任务记录
+type jobRecord struct {
+	ID        string
+	Devices   []int
+	State     JobState
+	StartTime time.Time
+	EndTime   time.Time
+	Stats     []*jobDevice
+	Processes []JobProcess
+}
+
+func (r *jobRecord) job(now time.Time) Job {
+	job := Job{
+		ID:        r.ID,
+		Devices:   append([]int{}, r.Devices...),
+		State:     r.State,
+		StartTime: r.StartTime,
+		EndTime:   r.EndTime,
+		Processes: append([]JobProcess{}, r.Processes...),
+	}
+	if r.State == JobRunning {
+		job.Duration = now.Sub(r.StartTime)
+	} else {
+		job.Duration = r.EndTime.Sub(r.StartTime)
+	}
+	for _, d := range r.Stats {
+		job.Stats = append(job.Stats, d.Stats)
+	}
+	return job
+}
+
+// jobSample 一轮采样中单个设备的读数
+type jobSample struct {
+	values    map[FieldID]FieldValue
+	energyRaw uint64
+	energyRes float32
+	energyErr error
+	time      time.Time
+}
+
+// jobManager 任务统计，所有运行中的任务共享一个采样循环
+type jobManager struct {
+	mu       sync.Mutex
+	path     string
+	interval time.Duration
+	keep     int
+	jobs     map[string]*jobRecord
+	lastSave time.Time
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+var defaultJobs = &jobManager{jobs: map[string]*jobRecord{}, interval: DefaultJobInterval, keep: DefaultJobKeep}
+
+// jobFields 每轮采样读取的字段
+var jobFields = []FieldID{FieldGpuUtil, FieldFbUsed, FieldGpuTemp, FieldPowerUsage}
+
+// ConfigureJobs 设置任务记录的持久化文件、采样间隔与保留的已结束任务数量，path 为空时只保存在内存中；
+// 文件已存在时加载其中的任务记录，未结束的任务继续统计。keep 为零时取 DefaultJobKeep，运行中的任务不计入
+func ConfigureJobs(path string, interval time.Duration, keep int) error {
+	if interval <= 0 {
+		interval = DefaultJobInterval
+	}
+	if keep == 0 {
+		keep = DefaultJobKeep
+	}
+	if keep < 0 {
+		return fmt.Errorf("Error ConfigureJobs:invalid keep %d", keep)
+	}
+	jobs := map[string]*jobRecord{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Error ConfigureJobs:%w", err)
+		}
+		if len(data) > 0 {
+			var records []*jobRecord
+			if err := json.Unmarshal(data, &records); err != nil {
+				return fmt.Errorf("Error ConfigureJobs:parse %s:%w", path, err)
+			}
+			for _, r := range records {
+				jobs[r.ID] = r
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("Error ConfigureJobs:%w", err)
+		}
+	}
+	m := defaultJobs
+	m.stopLoop()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.path = path
+	m.interval = interval
+	m.keep = keep
+	m.jobs = jobs
+	if m.pruneLocked() {
+		m.saveLocked()
+	}
+	m.startLoopLocked()
+	return nil
+}
+
+// StartJob 在一组设备上启动任务统计，devices 为空时使用所有设备；任务 ID 不能与已有任务重复
+func StartJob(id string, devices []int) (Job, error) {
+	if id == "" {
+		return Job{}, fmt.Errorf("Error StartJob:empty job id")
+	}
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return Job{}, fmt.Errorf("Error StartJob:%w", err)
+	}
+	if len(devices) == 0 {
+		for i := 0; i < numDevices; i++ {
+			devices = append(devices, i)
+		}
+	}
+	for _, dvInd := range devices {
+		if dvInd < 0 || dvInd >= numDevices {
+			return Job{}, fmt.Errorf("Error StartJob:device %d out of range [0, %d)", dvInd, numDevices)
+		}
+	}
+	r := &jobRecord{ID: id, Devices: append([]int{}, devices...), State: JobRunning, StartTime: time.Now()}
+	for _, dvInd := range devices {
+		d := &jobDevice{Stats: JobDeviceStats{Device: dvInd}}
+		d.EccCE, d.EccUE, d.Retired = readJobCounters(dvInd)
+		if raw, res, _, err := rsmiDevEnergyCountGet(dvInd); err == nil {
+			d.EnergyRaw, d.EnergyRes, d.EnergyTime, d.EnergyOK = raw, res, time.Now(), true
+		} else {
+			glog.V(2).Infof("job %s device %d energy:%v", id, dvInd, err)
+		}
+		r.Stats = append(r.Stats, d)
+	}
+
+	m := defaultJobs
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.jobs[id]; ok {
+		return Job{}, fmt.Errorf("Error StartJob:%s:%w", id, ErrJobExists)
+	}
+	m.jobs[id] = r
+	m.startLoopLocked()
+	m.saveLocked()
+	return r.job(time.Now()), nil
+}
+
+// StopJob 结束任务统计，最后采样一次并计算 ECC 与退役页增量
+func StopJob(id string) (Job, error) {
+	m := defaultJobs
+	m.mu.Lock()
+	r, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return Job{}, fmt.Errorf("Error StopJob:%s:%w", id, ErrJobNotFound)
+	}
+	if r.State != JobRunning {
+		m.mu.Unlock()
+		return Job{}, fmt.Errorf("Error StopJob:%s:%w", id, ErrJobNotRunning)
+	}
+	devices := append([]int{}, r.Devices...)
+	m.mu.Unlock()
+
+	samples, procs := sampleJobDevices(devices)
+	counters := map[int][3]int64{}
+	for _, dvInd := range devices {
+		ce, ue, retired := readJobCounters(dvInd)
+		counters[dvInd] = [3]int64{ce, ue, retired}
+	}
+
+	m.mu.Lock()
+	// 采样期间任务可能已被删除或停止
+	if r, ok = m.jobs[id]; !ok || r.State != JobRunning {
+		m.mu.Unlock()
+		return Job{}, fmt.Errorf("Error StopJob:%s:%w", id, ErrJobNotRunning)
+	}
+	r.update(samples, procs)
+	for _, d := range r.Stats {
+		c := counters[d.Stats.Device]
+		d.Stats.EccCorrectable = c[0] - d.EccCE
+		d.Stats.EccUncorrectable = c[1] - d.EccUE
+		d.Stats.RetiredPages = c[2] - d.Retired
+	}
+	r.State = JobStopped
+	r.EndTime = time.Now()
+	m.pruneLocked()
+	m.saveLocked()
+	idle := !m.hasRunningLocked()
+	job := r.job(time.Now())
+	m.mu.Unlock()
+	if idle {
+		m.stopLoop()
+	}
+	return job, nil
+}
+
+// GetJob 返回任务记录
+func GetJob(id string) (Job, error) {
+	m := defaultJobs
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("Error GetJob:%s:%w", id, ErrJobNotFound)
+	}
+	return r.job(time.Now()), nil
+}
+
+// Jobs 返回所有任务记录，按启动时间排序
+func Jobs() []Job {
+	m := defaultJobs
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, r := range m.jobs {
+		jobs = append(jobs, r.job(now))
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].StartTime.Before(jobs[j].StartTime) })
+	return jobs
+}
+
+// RemoveJob 删除任务记录，运行中的任务同时停止统计
+func RemoveJob(id string) error {
+	m := defaultJobs
+	m.mu.Lock()
+	if _, ok := m.jobs[id]; !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("Error RemoveJob:%s:%w", id, ErrJobNotFound)
+	}
+	delete(m.jobs, id)
+	m.saveLocked()
+	idle := !m.hasRunningLocked()
+	m.mu.Unlock()
+	if idle {
+		m.stopLoop()
+	}
+	return nil
+}
+
+// closeJobs 停止采样循环并保存任务记录，未结束的任务在下次 ConfigureJobs 时继续统计，在 ShutDown 时调用
+func closeJobs() {
+	m := defaultJobs
+	m.stopLoop()
+	m.mu.Lock()
+	m.saveLocked()
+	m.mu.Unlock()
+}
+
+// readJobCounters 读取设备的可纠正、不可纠正 ECC 错误总数与退役页数量，不支持时为 0
+func readJobCounters(dvInd int) (ce, ue, retired int64) {
+	if info, ok := lookupField(FieldEccSbeVolTotal); ok {
+		if v, err := info.get(dvInd); err == nil {
+			ce = int64(v.Value)
+		}
+	}
+	if info, ok := lookupField(FieldEccDbeVolTotal); ok {
+		if v, err := info.get(dvInd); err == nil {
+			ue = int64(v.Value)
+		}
+	}
+	if _, records, err := rsmiDevMemoryReservedPagesGet(dvInd); err == nil {
+		for _, rec := range records {
+			if rec.Status == RSMI_MEM_PAGE_STATUS_RESERVED {
+				retired++
+			}
+		}
+	}
+	return ce, ue, retired
+}
+
+// sampleJobDevices 读取一组设备的字段与能量累加器，以及使用这些设备的 KFD 进程
+func sampleJobDevices(devices []int) (map[int]*jobSample, []JobProcess) {
+	samples := map[int]*jobSample{}
+	for _, dvInd := range devices {
+		samples[dvInd] = &jobSample{values: map[FieldID]FieldValue{}}
+	}
+	values, err := GetFieldValues(devices, jobFields)
+	if err != nil {
+		glog.Errorf("Error sample job devices:%s", err)
+	}
+	for _, v := range values {
+		if v.Err == "" {
+			samples[v.EntityID].values[v.FieldID] = v
+		}
+	}
+	for _, dvInd := range devices {
+		s := samples[dvInd]
+		s.energyRaw, s.energyRes, _, s.energyErr = rsmiDevEnergyCountGet(dvInd)
+		s.time = time.Now()
+	}
+
+	var procs []JobProcess
+	infos, _, err := rsmiComputeProcessInfoGet()
+	if err != nil {
+		glog.V(2).Infof("job process info:%v", err)
+		return samples, nil
+	}
+	now := time.Now()
+	for _, info := range infos {
+		pid := int(info.ProcessID)
+		dvIndices, err := rsmiComputeProcessGpusGet(pid)
+		if err != nil {
+			continue
+		}
+		procs = append(procs, JobProcess{PID: pid, Devices: dvIndices, FirstSeen: now, LastSeen: now, MaxVramBytes: info.VramUsage})
+	}
+	return samples, procs
+}
+
+// update 将一轮采样累加到任务统计中，进程只记录使用了任务设备的部分，调用方需持有 jobManager.mu
+func (r *jobRecord) update(samples map[int]*jobSample, procs []JobProcess) {
+	for _, d := range r.Stats {
+		s, ok := samples[d.Stats.Device]
+		if !ok {
+			continue
+		}
+		d.Stats.Samples++
+		if v, ok := s.values[FieldGpuUtil]; ok {
+			d.UtilN++
+			d.UtilSum += v.Value
+			d.Stats.UtilizationAvg = d.UtilSum / float64(d.UtilN)
+			if d.UtilN == 1 || v.Value > d.Stats.UtilizationMax {
+				d.Stats.UtilizationMax = v.Value
+			}
+		}
+		if v, ok := s.values[FieldFbUsed]; ok {
+			d.MemN++
+			d.MemSum += v.Value
+			d.Stats.MemoryUsedAvg = d.MemSum / float64(d.MemN)
+			if d.MemN == 1 || v.Value > d.Stats.MemoryUsedMax {
+				d.Stats.MemoryUsedMax = v.Value
+			}
+		}
+		if v, ok := s.values[FieldGpuTemp]; ok && (d.Stats.Samples == 1 || v.Value > d.Stats.TemperatureMax) {
+			d.Stats.TemperatureMax = v.Value
+		}
+		if v, ok := s.values[FieldPowerUsage]; ok {
+			d.PowerN++
+			d.PowerSum += v.Value
+			d.Stats.PowerAvg = d.PowerSum / float64(d.PowerN)
+			if d.PowerN == 1 || v.Value < d.Stats.PowerMin {
+				d.Stats.PowerMin = v.Value
+			}
+			if d.PowerN == 1 || v.Value > d.Stats.PowerMax {
+				d.Stats.PowerMax = v.Value
+			}
+		}
+		if s.energyErr == nil {
+			if d.EnergyOK {
+				// 与能耗统计相同的回绕与重置处理
+				track := &energyTrack{raw: d.EnergyRaw, res: d.EnergyRes, cur: energyPoint{t: d.EnergyTime, joules: d.Stats.EnergyJoules}}
+				track.accumulate(s.energyRaw, s.energyRes, s.time)
+				d.Stats.EnergyJoules = track.cur.joules
+			}
+			d.EnergyRaw, d.EnergyRes, d.EnergyTime, d.EnergyOK = s.energyRaw, s.energyRes, s.time, true
+		}
+	}
+
+	onJob := map[int]bool{}
+	for _, dvInd := range r.Devices {
+		onJob[dvInd] = true
+	}
+	for _, p := range procs {
+		used := false
+		for _, dvInd := range p.Devices {
+			used = used || onJob[dvInd]
+		}
+		if !used {
+			continue
+		}
+		found := false
+		for i := range r.Processes {
+			q := &r.Processes[i]
+			if q.PID != p.PID {
+				continue
+			}
+			found = true
+			q.LastSeen = p.LastSeen
+			q.Devices = p.Devices
+			if p.MaxVramBytes > q.MaxVramBytes {
+				q.MaxVramBytes = p.MaxVramBytes
+			}
+		}
+		if !found {
+			p.Name = ProcessName(p.PID)
+			r.Processes = append(r.Processes, p)
+		}
+	}
+}
+
+func (m *jobManager) hasRunningLocked() bool {
+	for _, r := range m.jobs {
+		if r.State == JobRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// startLoopLocked 有运行中的任务且采样循环未启动时启动采样循环，调用方需持有 m.mu
+func (m *jobManager) startLoopLocked() {
+	if m.stop != nil || !m.hasRunningLocked() {
+		return
+	}
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	go m.run(m.interval, m.stop, m.done)
+}
+
+func (m *jobManager) stopLoop() {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop, m.done = nil, nil
+	m.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// run 采样循环，每轮读取所有运行中任务的设备，同一设备被多个任务使用时只读取一次
+func (m *jobManager) run(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		m.mu.Lock()
+		seen := map[int]bool{}
+		var devices []int
+		for _, r := range m.jobs {
+			if r.State != JobRunning {
+				continue
+			}
+			for _, dvInd := range r.Devices {
+				if !seen[dvInd] {
+					seen[dvInd] = true
+					devices = append(devices, dvInd)
+				}
+			}
+		}
+		m.mu.Unlock()
+		if len(devices) == 0 {
+			continue
+		}
+		sort.Ints(devices)
+		samples, procs := sampleJobDevices(devices)
+		m.mu.Lock()
+		for _, r := range m.jobs {
+			if r.State == JobRunning {
+				r.update(samples, procs)
+			}
+		}
+		if time.Since(m.lastSave) >= jobSaveInterval {
+			m.saveLocked()
+		}
+		m.mu.Unlock()
+	}
+}
+
+// pruneLocked 已结束的任务超过 keep 个时删除结束最早的任务，有任务被删除时返回 true，调用方需持有 m.mu
+func (m *jobManager) pruneLocked() bool {
+	var stopped []*jobRecord
+	for _, r := range m.jobs {
+		if r.State != JobRunning {
+			stopped = append(stopped, r)
+		}
+	}
+	over := len(stopped) - m.keep
+	if over <= 0 {
+		return false
+	}
+	sort.Slice(stopped, func(i, j int) bool { return stopped[i].EndTime.Before(stopped[j].EndTime) })
+	for _, r := range stopped[:over] {
+		delete(m.jobs, r.ID)
+	}
+	return true
+}
+
+// saveLocked 将所有任务记录写入持久化文件，先写临时文件再重命名，调用方需持有 m.mu
+func (m *jobManager) saveLocked() {
+	m.lastSave = time.Now()
+	if m.path == "" {
+		return
+	}
+	records := make([]*jobRecord, 0, len(m.jobs))
+	for _, r := range m.jobs {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].StartTime.Before(records[j].StartTime) })
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		glog.Errorf("Error save jobs:%s", err)
+		return
+	}
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		glog.Errorf("Error save jobs:%s", err)
+		return
+	}
+	if err := os.Rename(tmp, m.path); err != nil {
+		glog.Errorf("Error save jobs:%s", err)
+	}
+}
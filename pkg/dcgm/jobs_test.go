@@ -0,0 +1,117 @@
+package dcgm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// initJobScenario 以示例场景初始化模拟后端并以 path 配置任务统计，最多保留 2 个已结束的任务
+func initJobScenario(t *testing.T, path string) {
+	t.Helper()
+	if err := InitWithBackendName(BackendFake + ":" + fakeScenarioPath); err != nil {
+		t.Fatalf("InitWithBackendName: %v", err)
+	}
+	if err := ConfigureJobs(path, 10*time.Millisecond, 2); err != nil {
+		t.Fatalf("ConfigureJobs: %v", err)
+	}
+}
+
+// waitJobSamples 等待任务在设备上的采样数达到 n
+func waitJobSamples(t *testing.T, id string, n int) Job {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		job, err := GetJob(id)
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		if job.Stats[0].Samples >= n {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s has %d samples, want %d", id, job.Stats[0].Samples, n)
+		}
+	}
+}
+
+// jobIDs 返回任务的 ID，按字母排序
+func jobIDs(jobs []Job) []string {
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestJobsSaveReloadResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	initJobScenario(t, path)
+	t.Cleanup(func() {
+		ShutDown()
+		ConfigureJobs("", 0, 0)
+	})
+
+	if _, err := StartJob("train", []int{0}); err != nil {
+		t.Fatalf("StartJob: %v", err)
+	}
+	// 超出保留数量时删除结束最早的任务
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := StartJob(id, []int{1}); err != nil {
+			t.Fatalf("StartJob %s: %v", id, err)
+		}
+		if _, err := StopJob(id); err != nil {
+			t.Fatalf("StopJob %s: %v", id, err)
+		}
+	}
+	if got, want := jobIDs(Jobs()), []string{"b", "c", "train"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("jobs = %v, want %v", got, want)
+	}
+	before := waitJobSamples(t, "train", 2)
+
+	// 服务重启：保存后重新加载，运行中的任务继续统计
+	ShutDown()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var records []jobRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		t.Fatalf("parse %s: %v", path, err)
+	}
+	var saved []string
+	for _, r := range records {
+		saved = append(saved, r.ID)
+	}
+	sort.Strings(saved)
+	if want := []string{"b", "c", "train"}; !reflect.DeepEqual(saved, want) {
+		t.Errorf("saved jobs = %v, want %v", saved, want)
+	}
+
+	initJobScenario(t, path)
+	reloaded, err := GetJob("train")
+	if err != nil {
+		t.Fatalf("GetJob after reload: %v", err)
+	}
+	if reloaded.State != JobRunning || !reloaded.StartTime.Equal(before.StartTime) || reloaded.Stats[0].Samples < before.Stats[0].Samples {
+		t.Errorf("reloaded job = %s from %v with %d samples, want running from %v with at least %d samples",
+			reloaded.State, reloaded.StartTime, reloaded.Stats[0].Samples, before.StartTime, before.Stats[0].Samples)
+	}
+	waitJobSamples(t, "train", reloaded.Stats[0].Samples+2)
+	job, err := StopJob("train")
+	if err != nil {
+		t.Fatalf("StopJob after reload: %v", err)
+	}
+	// 场景时间随真实时间推进，设备 0 的功耗与温度从 210 W、48 ℃ 缓慢上升
+	if stats := job.Stats[0]; job.State != JobStopped || stats.PowerMax < 210 || stats.PowerMax > 220 || stats.TemperatureMax < 48 || stats.TemperatureMax > 50 {
+		t.Errorf("stopped job = %s, max power %v, max temperature %v", job.State, job.Stats[0].PowerMax, job.Stats[0].TemperatureMax)
+	}
+	// 结束后同样按保留数量删除最早结束的任务
+	if got, want := jobIDs(Jobs()), []string{"c", "train"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("jobs after stopping train = %v, want %v", got, want)
+	}
+}
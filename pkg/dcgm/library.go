@@ -0,0 +1,100 @@
+package dcgm
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// LibraryRsmi librocm_smi64 的名称
+	LibraryRsmi = "librocm_smi64"
+	// LibraryDmi libhydmi 的名称
+	LibraryDmi = "libhydmi"
+
+	// 指定库路径的环境变量，优先级低于 SetLibraryPaths
+	envRsmiLib = "DCU_DCGM_RSMI_LIB"
+	envDmiLib  = "DCU_DCGM_DMI_LIB"
+)
+
+// ErrLibraryNotLoaded 动态库无法加载时返回的错误，可用 errors.Is 判断
+var ErrLibraryNotLoaded = errors.New("library not loaded")
+
+// defaultLibraryCandidates 未指定路径时依次尝试的库文件名，由 dlopen 按 LD_LIBRARY_PATH 等规则查找
+var defaultLibraryCandidates = map[string][]string{
+	LibraryRsmi: {"librocm_smi64.so.2", "librocm_smi64.so"},
+	LibraryDmi:  {"libhydmi.so.1", "libhydmi.so"},
+}
+
+// LibraryInfo 动态库的加载情况
+type LibraryInfo struct {
+	Name    string // 库名称，librocm_smi64 或 libhydmi
+	Path    string // 实际加载的文件路径
+	Version string // 库版本，rsmi 取 rsmi_version_get，其余取自文件名后缀
+	Loaded  bool   // 是否加载成功
+	Error   string // 加载失败的原因
+	Found   int    // 已解析的符号数量
+	Missing int    // 缺失的符号数量
+}
+
+// SymbolInfo 绑定使用的函数符号及其解析结果
+type SymbolInfo struct {
+	Name    string // 符号名称
+	Library string // 所属库
+	Found   bool   // 是否在已加载的库中找到
+}
+
+var (
+	libraryMu    sync.Mutex
+	libraryPaths = map[string]string{}
+	libraryState = map[string]*LibraryInfo{}
+)
+
+// SetLibraryPaths 指定 librocm_smi64 与 libhydmi 的路径，应在 Init 之前调用；
+// 参数为空时使用环境变量 DCU_DCGM_RSMI_LIB/DCU_DCGM_DMI_LIB，再回退到默认库名。
+// 已加载的库会在下一次 LoadLibraries 时按新路径重新加载。
+func SetLibraryPaths(rsmiPath, dmiPath string) {
+	libraryMu.Lock()
+	defer libraryMu.Unlock()
+	libraryPaths[LibraryRsmi] = rsmiPath
+	libraryPaths[LibraryDmi] = dmiPath
+	libraryState = map[string]*LibraryInfo{}
+}
+
+// libraryCandidates 返回库的候选路径，调用方需持有 libraryMu
+func libraryCandidates(name string) []string {
+	if path := libraryPaths[name]; path != "" {
+		return []string{path}
+	}
+	env := envRsmiLib
+	if name == LibraryDmi {
+		env = envDmiLib
+	}
+	if path := os.Getenv(env); path != "" {
+		return []string{path}
+	}
+	return defaultLibraryCandidates[name]
+}
+
+// libraryFileVersion 从库文件真实路径的后缀解析版本，例如 libhydmi.so.1.5 -> 1.5
+func libraryFileVersion(path string) string {
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+	}
+	if _, version, ok := strings.Cut(filepath.Base(path), ".so."); ok {
+		return version
+	}
+	return ""
+}
+
+// HasSymbol 判断符号是否已在加载的库中找到
+func HasSymbol(name string) bool {
+	for _, sym := range Symbols() {
+		if sym.Name == name {
+			return sym.Found
+		}
+	}
+	return false
+}
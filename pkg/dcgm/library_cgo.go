@@ -0,0 +1,171 @@
+package dcgm
+
+/*
+#cgo CFLAGS: -Wall -I./include
+#cgo LDFLAGS: -ldl
+#include <stdlib.h>
+#include "dlwrap.h"
+*/
+import "C"
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/golang/glog"
+)
+
+// libraryIndex 库名称与 dlwrap.h 中库编号的对应关系
+var libraryIndex = map[string]C.int{
+	LibraryRsmi: C.DCGM_DL_LIB_RSMI,
+	LibraryDmi:  C.DCGM_DL_LIB_DMI,
+}
+
+// LoadLibraries 加载 librocm_smi64 与 libhydmi 并解析绑定使用的全部符号，已加载时直接返回。
+// librocm_smi64 加载失败时返回 ErrLibraryNotLoaded；libhydmi 为可选，加载失败只记录日志，
+// 其 dmi* 调用返回 NotSupportedError。
+func LoadLibraries() error {
+	libraryMu.Lock()
+	defer libraryMu.Unlock()
+	var rsmiErr error
+	for _, name := range []string{LibraryRsmi, LibraryDmi} {
+		if info, ok := libraryState[name]; ok && info.Loaded {
+			continue
+		}
+		info := loadLibrary(name, libraryCandidates(name))
+		libraryState[name] = info
+		if !info.Loaded {
+			err := fmt.Errorf("Error load %s:%w: %s", name, ErrLibraryNotLoaded, info.Error)
+			if name == LibraryRsmi {
+				rsmiErr = err
+			} else {
+				glog.Warningf("%v", err)
+			}
+			continue
+		}
+		glog.Infof("loaded %s %s from %s, %d symbols found, %d missing", name, info.Version, info.Path, info.Found, info.Missing)
+	}
+	return rsmiErr
+}
+
+// loadLibrary 依次尝试候选路径，返回第一个加载成功的结果
+func loadLibrary(name string, candidates []string) *LibraryInfo {
+	info := &LibraryInfo{Name: name}
+	lib := libraryIndex[name]
+	var errs []string
+	for _, candidate := range candidates {
+		cPath := C.CString(candidate)
+		var cErr [512]C.char
+		ret := C.dcgm_dl_open(lib, cPath, &cErr[0], C.size_t(len(cErr)))
+		C.free(unsafe.Pointer(cPath))
+		if ret != 0 {
+			errs = append(errs, C.GoString(&cErr[0]))
+			continue
+		}
+		info.Loaded = true
+		info.Path = candidate
+		if p := C.dcgm_dl_path(lib); p != nil {
+			info.Path = C.GoString(p)
+		}
+		break
+	}
+	if !info.Loaded {
+		info.Error = strings.Join(errs, "; ")
+		return info
+	}
+	for _, sym := range symbolsOf(name) {
+		if sym.Found {
+			info.Found++
+		} else {
+			info.Missing++
+			glog.Warningf("%s: symbol %s not found, calls will return not supported", name, sym.Name)
+		}
+	}
+	info.Version = libraryFileVersion(info.Path)
+	if name == LibraryRsmi {
+		if v, err := rsmiLibraryVersion(); err == nil {
+			info.Version = v
+		}
+	}
+	return info
+}
+
+// rsmiLibraryVersion 通过 rsmi_version_get 获取库版本，不需要先初始化 rsmi
+func rsmiLibraryVersion() (string, error) {
+	version, err := newCgoBackend().RsmiVersionGet()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch), nil
+}
+
+// Libraries 返回 librocm_smi64 与 libhydmi 的加载情况，尚未加载的库 Loaded 为 false
+func Libraries() []LibraryInfo {
+	libraryMu.Lock()
+	defer libraryMu.Unlock()
+	infos := make([]LibraryInfo, 0, len(libraryIndex))
+	for _, name := range []string{LibraryRsmi, LibraryDmi} {
+		if info, ok := libraryState[name]; ok {
+			infos = append(infos, *info)
+		} else {
+			infos = append(infos, LibraryInfo{Name: name})
+		}
+	}
+	return infos
+}
+
+// Symbols 返回绑定使用的全部符号及其解析结果
+func Symbols() []SymbolInfo {
+	return symbolsOf("")
+}
+
+// symbolsOf 返回指定库的符号，library 为空时返回全部
+func symbolsOf(library string) []SymbolInfo {
+	count := int(C.dcgm_dl_sym_count())
+	symbols := make([]SymbolInfo, 0, count)
+	for i := 0; i < count; i++ {
+		sym := C.dcgm_dl_sym_at(C.int(i))
+		info := SymbolInfo{
+			Name:    C.GoString(sym.name),
+			Library: libraryName(sym.lib),
+			Found:   C.dcgm_dl_sym_found(C.int(i)) != 0,
+		}
+		if library == "" || info.Library == library {
+			symbols = append(symbols, info)
+		}
+	}
+	return symbols
+}
+
+func libraryName(lib C.int) string {
+	for name, index := range libraryIndex {
+		if index == lib {
+			return name
+		}
+	}
+	return ""
+}
+
+// missingSymbolError 把包装函数返回的缺失符号状态码转换为 NotSupportedError，
+// 不是缺失符号状态码时返回 nil
+func missingSymbolError(status int64) error {
+	index := status - C.DCGM_DL_STATUS_MISSING
+	if index < 0 || index >= int64(C.dcgm_dl_sym_count()) {
+		return nil
+	}
+	sym := C.dcgm_dl_sym_at(C.int(index))
+	return &NotSupportedError{
+		Symbol:  C.GoString(sym.name),
+		Library: libraryName(sym.lib),
+	}
+}
+
+// backendLoadsLibraries 当前后端是否通过动态库访问设备，录制后端按其包装的后端判断
+func backendLoadsLibraries() bool {
+	b := getBackend()
+	if r, ok := b.(*RecordingBackend); ok {
+		b = r.backend
+	}
+	_, ok := b.(*cgoBackend)
+	return ok
+}
@@ -0,0 +1,33 @@
+//go:build !cgo
+
+package dcgm
+
+import "fmt"
+
+// LoadLibraries 不启用 cgo 构建时无法加载动态库，始终返回 ErrLibraryNotLoaded 与 ErrNoBackend
+func LoadLibraries() error {
+	return fmt.Errorf("Error load %s:%w: %w", LibraryRsmi, ErrLibraryNotLoaded, ErrNoBackend)
+}
+
+// Libraries 不启用 cgo 构建时返回未加载的库信息
+func Libraries() []LibraryInfo {
+	return []LibraryInfo{
+		{Name: LibraryRsmi, Error: ErrNoBackend.Error()},
+		{Name: LibraryDmi, Error: ErrNoBackend.Error()},
+	}
+}
+
+// Symbols 不启用 cgo 构建时没有绑定任何符号
+func Symbols() []SymbolInfo {
+	return nil
+}
+
+// backendLoadsLibraries 不启用 cgo 构建时默认后端同样无法使用动态库，此时库的检查应报告加载失败
+func backendLoadsLibraries() bool {
+	b := getBackend()
+	if r, ok := b.(*RecordingBackend); ok {
+		b = r.backend
+	}
+	_, ok := b.(noCgoBackend)
+	return ok
+}
@@ -0,0 +1,600 @@
+package dcgm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrPolicyNotFound 策略不存在时返回的错误
+var ErrPolicyNotFound = errors.New("policy not found")
+
+// DefaultPolicyInterval 策略检查的默认间隔
+const DefaultPolicyInterval = time.Second
+
+// maxPolicyViolations 保留的违规记录数量
+const maxPolicyViolations = 1000
+
+// PolicyCondition 策略监测的条件
+type PolicyCondition string
+
+const (
+	// PolicyMaxTemperature 边缘温度超过 Threshold（℃）
+	PolicyMaxTemperature PolicyCondition = "max-temperature"
+	// PolicyPowerOverCap 平均功耗超过功率上限的 Threshold%（默认 100）
+	PolicyPowerOverCap PolicyCondition = "power-over-cap"
+	// PolicyEccUncorrectable 出现新的不可纠正 ECC 错误
+	PolicyEccUncorrectable PolicyCondition = "ecc-uncorrectable"
+	// PolicyRetiredPage 出现新的退役页（含待退役与无法退役的页）
+	PolicyRetiredPage PolicyCondition = "retired-page"
+	// PolicyPcieReplay 两次检查之间 PCIe 重放计数增长达到 Threshold（默认 1）
+	PolicyPcieReplay PolicyCondition = "pcie-replay"
+	// PolicyThermalThrottle 出现温度导致的降频
+	PolicyThermalThrottle PolicyCondition = "thermal-throttle"
+)
+
+// AllPolicyConditions 全部策略条件
+var AllPolicyConditions = []PolicyCondition{
+	PolicyMaxTemperature, PolicyPowerOverCap, PolicyEccUncorrectable, PolicyRetiredPage, PolicyPcieReplay, PolicyThermalThrottle,
+}
+
+// policyLevelConditions 按当前状态判断的条件，状态持续 Seconds 后违规一次，恢复后重新计时；
+// 其余条件为计数器，每次增长都违规
+var policyLevelConditions = map[PolicyCondition]bool{
+	PolicyMaxTemperature:  true,
+	PolicyPowerOverCap:    true,
+	PolicyThermalThrottle: true,
+}
+
+// PolicyAction 违规时对设备执行的操作
+type PolicyAction string
+
+const (
+	// PolicyActionNone 不执行操作
+	PolicyActionNone PolicyAction = "none"
+	// PolicyActionPerfLevelLow 将性能等级设置为 low
+	PolicyActionPerfLevelLow PolicyAction = "perf-level-low"
+	// PolicyActionResetClocks 重置超速与性能等级，同 ResetClocks
+	PolicyActionResetClocks PolicyAction = "reset-clocks"
+)
+
+// Policy 一条策略：在一组设备上监测一个条件，违规时执行可选操作、调用注册的回调并记录违规
+type Policy struct {
+	// Name 策略名称，唯一
+	Name string `json:"name" yaml:"name"`
+	// Devices 策略作用的设备组，为空时为所有设备
+	Devices []int `json:"devices,omitempty" yaml:"devices"`
+	// Condition 监测的条件
+	Condition PolicyCondition `json:"condition" yaml:"condition"`
+	// Threshold 条件的阈值，含义见各条件的说明
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold"`
+	// Seconds 温度、功耗与降频条件需要持续的秒数，0 表示检查到即违规
+	Seconds float64 `json:"seconds,omitempty" yaml:"seconds"`
+	// Action 违规时执行的操作，默认 none
+	Action PolicyAction `json:"action,omitempty" yaml:"action"`
+}
+
+// PolicyFile 策略配置文件，可以是 YAML 或 JSON（以 .json 结尾）
+type PolicyFile struct {
+	Policies []Policy `json:"policies" yaml:"policies"`
+}
+
+// PolicyViolation 一次策略违规
+type PolicyViolation struct {
+	//  Policy 策略名称
+	Policy string
+	//  Condition 违规的条件
+	Condition PolicyCondition
+	//  DeviceID 设备索引号
+	DeviceID int
+	//  Time 发现违规的时间
+	Time time.Time
+	//  Value 观测值：温度（℃）、功耗（W）、新增错误数、新增退役页数、重放增长数或降频原因数
+	Value float64
+	//  Limit 比较的阈值
+	Limit float64
+	//  Message 违规描述
+	Message string
+	//  Action 执行的操作
+	Action PolicyAction
+	//  ActionError 操作失败的原因
+	ActionError string `json:",omitempty"`
+}
+
+// normalize 校验策略并填充默认值
+func (p *Policy) normalize() error {
+	if p.Name == "" {
+		return fmt.Errorf("empty policy name")
+	}
+	found := false
+	for _, cond := range AllPolicyConditions {
+		if p.Condition == cond {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("policy %s: unknown condition %q", p.Name, p.Condition)
+	}
+	switch p.Condition {
+	case PolicyMaxTemperature:
+		if p.Threshold <= 0 {
+			return fmt.Errorf("policy %s: %s requires a positive threshold", p.Name, p.Condition)
+		}
+	case PolicyPowerOverCap:
+		if p.Threshold == 0 {
+			p.Threshold = 100
+		}
+	case PolicyPcieReplay:
+		if p.Threshold == 0 {
+			p.Threshold = 1
+		}
+	}
+	if p.Threshold < 0 || p.Seconds < 0 {
+		return fmt.Errorf("policy %s: negative threshold or seconds", p.Name)
+	}
+	switch p.Action {
+	case "":
+		p.Action = PolicyActionNone
+	case PolicyActionNone, PolicyActionPerfLevelLow, PolicyActionResetClocks:
+	default:
+		return fmt.Errorf("policy %s: unknown action %q", p.Name, p.Action)
+	}
+	p.Devices = append([]int{}, p.Devices...)
+	sort.Ints(p.Devices)
+	return nil
+}
+
+// policyDeviceState 策略在单个设备上的状态
+type policyDeviceState struct {
+	since    time.Time // 条件开始成立的时间，不成立时为零值
+	violated bool      // 本次条件成立期间是否已违规
+	counter  float64   // 计数器条件的基线
+	hasBase  bool
+}
+
+type policyState struct {
+	policy  Policy
+	devices map[int]*policyDeviceState
+}
+
+type policyCallback struct {
+	policy string
+	fn     func(PolicyViolation)
+}
+
+type policyEngine struct {
+	mu           sync.Mutex
+	policies     map[string]*policyState
+	callbacks    map[int]policyCallback
+	nextCallback int
+	violations   []PolicyViolation
+	interval     time.Duration
+	stop         chan struct{}
+	done         chan struct{}
+}
+
+var defaultPolicies = &policyEngine{
+	policies:  map[string]*policyState{},
+	callbacks: map[int]policyCallback{},
+	interval:  DefaultPolicyInterval,
+}
+
+// LoadPolicyFile 读取策略配置文件并校验其中的策略
+func LoadPolicyFile(path string) ([]Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error LoadPolicyFile:%w", err)
+	}
+	var file PolicyFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error LoadPolicyFile:parse %s:%w", path, err)
+	}
+	names := map[string]bool{}
+	for i := range file.Policies {
+		if err = file.Policies[i].normalize(); err != nil {
+			return nil, fmt.Errorf("Error LoadPolicyFile:%s:%w", path, err)
+		}
+		if names[file.Policies[i].Name] {
+			return nil, fmt.Errorf("Error LoadPolicyFile:%s:duplicate policy %s", path, file.Policies[i].Name)
+		}
+		names[file.Policies[i].Name] = true
+	}
+	return file.Policies, nil
+}
+
+// ConfigurePolicies 设置策略检查间隔，path 不为空时用配置文件中的策略替换当前全部策略；
+// 之后通过 SetPolicy/RemovePolicy 所做的修改只保存在内存中
+func ConfigurePolicies(path string, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultPolicyInterval
+	}
+	var policies []Policy
+	if path != "" {
+		var err error
+		if policies, err = LoadPolicyFile(path); err != nil {
+			return err
+		}
+		for _, p := range policies {
+			if err = validatePolicyDevices(p); err != nil {
+				return fmt.Errorf("Error ConfigurePolicies:%w", err)
+			}
+		}
+	}
+	e := defaultPolicies
+	e.stopLoop()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.interval = interval
+	if path != "" {
+		e.policies = map[string]*policyState{}
+		for _, p := range policies {
+			e.policies[p.Name] = &policyState{policy: p, devices: map[int]*policyDeviceState{}}
+		}
+		glog.Infof("loaded %d policies from %s", len(policies), path)
+	}
+	e.startLoopLocked()
+	return nil
+}
+
+// validatePolicyDevices 检查策略的设备是否存在
+func validatePolicyDevices(p Policy) error {
+	if len(p.Devices) == 0 {
+		return nil
+	}
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return err
+	}
+	for _, dvInd := range p.Devices {
+		if dvInd < 0 || dvInd >= numDevices {
+			return fmt.Errorf("policy %s: device %d out of range [0, %d)", p.Name, dvInd, numDevices)
+		}
+	}
+	return nil
+}
+
+// SetPolicy 添加策略，同名策略被替换并重新开始计时与计数
+func SetPolicy(p Policy) (Policy, error) {
+	if err := p.normalize(); err != nil {
+		return Policy{}, fmt.Errorf("Error SetPolicy:%w", err)
+	}
+	if err := validatePolicyDevices(p); err != nil {
+		return Policy{}, fmt.Errorf("Error SetPolicy:%w", err)
+	}
+	e := defaultPolicies
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.policies[p.Name] = &policyState{policy: p, devices: map[int]*policyDeviceState{}}
+	e.startLoopLocked()
+	return p, nil
+}
+
+// RemovePolicy 删除策略，为该策略注册的回调保留，重新添加同名策略后继续生效
+func RemovePolicy(name string) error {
+	e := defaultPolicies
+	e.mu.Lock()
+	if _, ok := e.policies[name]; !ok {
+		e.mu.Unlock()
+		return fmt.Errorf("Error RemovePolicy:%s:%w", name, ErrPolicyNotFound)
+	}
+	delete(e.policies, name)
+	e.mu.Unlock()
+	return nil
+}
+
+// GetPolicy 返回指定名称的策略
+func GetPolicy(name string) (Policy, error) {
+	e := defaultPolicies
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	state, ok := e.policies[name]
+	if !ok {
+		return Policy{}, fmt.Errorf("Error GetPolicy:%s:%w", name, ErrPolicyNotFound)
+	}
+	return state.policy, nil
+}
+
+// Policies 返回全部策略，按名称排序
+func Policies() []Policy {
+	e := defaultPolicies
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	policies := make([]Policy, 0, len(e.policies))
+	for _, state := range e.policies {
+		policies = append(policies, state.policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies
+}
+
+// RegisterPolicyCallback 注册违规回调，policy 为空时接收所有策略的违规；回调在检查循环中同步调用，
+// 不应长时间阻塞，也不应调用 ConfigurePolicies。返回的函数用于取消注册
+func RegisterPolicyCallback(policy string, fn func(PolicyViolation)) (unregister func()) {
+	e := defaultPolicies
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.nextCallback++
+	id := e.nextCallback
+	e.callbacks[id] = policyCallback{policy: policy, fn: fn}
+	return func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		delete(e.callbacks, id)
+	}
+}
+
+// PolicyViolations 返回 since 之后的违规记录，按时间排序，最多保留最近 1000 条
+func PolicyViolations(since time.Time) []PolicyViolation {
+	e := defaultPolicies
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	violations := []PolicyViolation{}
+	for _, v := range e.violations {
+		if v.Time.After(since) {
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// closePolicies 停止检查循环，策略保留，在 ShutDown 时调用
+func closePolicies() {
+	defaultPolicies.stopLoop()
+}
+
+// startLoopLocked 有策略且检查循环未启动时启动检查循环，调用方需持有 e.mu
+func (e *policyEngine) startLoopLocked() {
+	if e.stop != nil || len(e.policies) == 0 {
+		return
+	}
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+	go e.run(e.interval, e.stop, e.done)
+}
+
+func (e *policyEngine) stopLoop() {
+	e.mu.Lock()
+	stop, done := e.stop, e.done
+	e.stop, e.done = nil, nil
+	e.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+func (e *policyEngine) run(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			e.check(time.Now())
+		}
+	}
+}
+
+// policyReading 一次检查中设备某个条件的读数，同一设备同一条件在一轮检查中只读取一次
+type policyReading struct {
+	value   float64 // 观测值，降频条件为温度降频原因的数量
+	limit   float64 // 功率上限（W）
+	reasons []ThrottleReason
+	err     error
+}
+
+type policyReadingKey struct {
+	dvInd     int
+	condition PolicyCondition
+}
+
+func readPolicyCondition(dvInd int, condition PolicyCondition) (r policyReading) {
+	var v FieldValue
+	switch condition {
+	case PolicyMaxTemperature:
+		v, r.err = tempGetter(SENSOR_EDGE)(dvInd)
+	case PolicyPowerOverCap:
+		if v, r.err = getPowerUsage(dvInd); r.err == nil {
+			var limit FieldValue
+			limit, r.err = getPowerLimit(dvInd)
+			r.limit = limit.Value
+		}
+	case PolicyEccUncorrectable:
+		v, r.err = eccGetter(true)(dvInd)
+	case PolicyRetiredPage:
+		var records []RSMIRetiredPageRecord
+		_, records, r.err = rsmiDevMemoryReservedPagesGet(dvInd)
+		v.Value = float64(len(records))
+	case PolicyPcieReplay:
+		v, r.err = getPcieReplay(dvInd)
+	case PolicyThermalThrottle:
+		var info ThrottleInfo
+		if info, r.err = DeviceThrottleInfo(dvInd); r.err == nil {
+			for _, reason := range info.Reasons {
+				if healthThermalThrottle[reason] {
+					r.reasons = append(r.reasons, reason)
+				}
+			}
+			v.Value = float64(len(r.reasons))
+		}
+	}
+	r.value = v.Value
+	return r
+}
+
+// check 以 now 为检查时间检查所有策略，违规时执行操作并调用回调
+func (e *policyEngine) check(now time.Time) {
+	e.mu.Lock()
+	states := make([]*policyState, 0, len(e.policies))
+	for _, state := range e.policies {
+		states = append(states, state)
+	}
+	e.mu.Unlock()
+	if len(states) == 0 {
+		return
+	}
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		glog.Errorf("Error check policies:%v", err)
+		return
+	}
+	readings := map[policyReadingKey]policyReading{}
+	var violations []PolicyViolation
+	for _, state := range states {
+		p := state.policy
+		devices := p.Devices
+		if len(devices) == 0 {
+			devices = make([]int, numDevices)
+			for i := range devices {
+				devices[i] = i
+			}
+		}
+		for _, dvInd := range devices {
+			key := policyReadingKey{dvInd, p.Condition}
+			r, ok := readings[key]
+			if !ok {
+				r = readPolicyCondition(dvInd, p.Condition)
+				readings[key] = r
+			}
+			if r.err != nil {
+				glog.V(2).Infof("policy %s device %d: %v", p.Name, dvInd, r.err)
+				continue
+			}
+			e.mu.Lock()
+			ds := state.devices[dvInd]
+			if ds == nil {
+				ds = &policyDeviceState{}
+				state.devices[dvInd] = ds
+			}
+			v, violated := evaluatePolicy(p, ds, r, now)
+			e.mu.Unlock()
+			if violated {
+				v.DeviceID = dvInd
+				violations = append(violations, v)
+			}
+		}
+	}
+	for _, v := range violations {
+		e.fire(v)
+	}
+}
+
+// evaluatePolicy 根据读数更新设备状态，返回是否违规，调用方需持有 e.mu
+func evaluatePolicy(p Policy, ds *policyDeviceState, r policyReading, now time.Time) (PolicyViolation, bool) {
+	v := PolicyViolation{Policy: p.Name, Condition: p.Condition, Time: now, Action: p.Action, Value: r.value, Limit: p.Threshold}
+	if policyLevelConditions[p.Condition] {
+		var holding bool
+		switch p.Condition {
+		case PolicyMaxTemperature:
+			holding = r.value > p.Threshold
+			v.Message = fmt.Sprintf("edge temperature %.1f°C above %.1f°C", r.value, p.Threshold)
+		case PolicyPowerOverCap:
+			v.Limit = r.limit * p.Threshold / 100
+			holding = r.limit > 0 && r.value > v.Limit
+			v.Message = fmt.Sprintf("power %.1fW above %.0f%% of the %.1fW cap", r.value, p.Threshold, r.limit)
+		case PolicyThermalThrottle:
+			v.Limit = 0
+			holding = len(r.reasons) > 0
+			v.Message = fmt.Sprintf("clocks throttled by %s", joinThrottleReasons(r.reasons))
+		}
+		if !holding {
+			ds.since, ds.violated = time.Time{}, false
+			return v, false
+		}
+		if ds.since.IsZero() {
+			ds.since = now
+		}
+		if ds.violated || now.Sub(ds.since) < time.Duration(p.Seconds*float64(time.Second)) {
+			return v, false
+		}
+		ds.violated = true
+		if p.Seconds > 0 {
+			v.Message += fmt.Sprintf(" for %v", now.Sub(ds.since).Round(time.Second))
+		}
+		return v, true
+	}
+	// 计数器条件：首次读取作为基线，计数器变小（驱动重载或复位）时重新取基线
+	if !ds.hasBase || r.value < ds.counter {
+		ds.counter, ds.hasBase = r.value, true
+		return v, false
+	}
+	delta := r.value - ds.counter
+	ds.counter = r.value
+	threshold := p.Threshold
+	if threshold <= 0 {
+		threshold = 1
+	}
+	if delta < threshold {
+		return v, false
+	}
+	v.Value, v.Limit = delta, threshold
+	switch p.Condition {
+	case PolicyEccUncorrectable:
+		v.Message = fmt.Sprintf("%.0f new uncorrectable ECC errors", delta)
+	case PolicyRetiredPage:
+		v.Message = fmt.Sprintf("%.0f new retired pages", delta)
+	case PolicyPcieReplay:
+		v.Message = fmt.Sprintf("%.0f PCIe replays since the last check", delta)
+	}
+	return v, true
+}
+
+func joinThrottleReasons(reasons []ThrottleReason) string {
+	names := make([]string, len(reasons))
+	for i, reason := range reasons {
+		names[i] = string(reason)
+	}
+	return strings.Join(names, ",")
+}
+
+// fire 执行违规操作，记录违规并调用回调
+func (e *policyEngine) fire(v PolicyViolation) {
+	var failed []FailedMessage
+	switch v.Action {
+	case PolicyActionPerfLevelLow:
+		failed = SetPerformanceLevel([]int{v.DeviceID}, "low")
+	case PolicyActionResetClocks:
+		failed = ResetClocks([]int{v.DeviceID})
+	}
+	if len(failed) > 0 {
+		v.ActionError = failed[0].ErrorMsg
+	}
+	glog.Warningf("policy %s violated on device %d: %s, action %s %s", v.Policy, v.DeviceID, v.Message, v.Action, v.ActionError)
+	e.mu.Lock()
+	e.violations = append(e.violations, v)
+	if len(e.violations) > maxPolicyViolations {
+		e.violations = append([]PolicyViolation{}, e.violations[len(e.violations)-maxPolicyViolations:]...)
+	}
+	var fns []func(PolicyViolation)
+	ids := make([]int, 0, len(e.callbacks))
+	for id := range e.callbacks {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if cb := e.callbacks[id]; cb.policy == "" || cb.policy == v.Policy {
+			fns = append(fns, cb.fn)
+		}
+	}
+	e.mu.Unlock()
+	for _, fn := range fns {
+		fn(v)
+	}
+}
@@ -0,0 +1,204 @@
+package dcgm
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// setPolicies 添加策略，检查循环的间隔设为 1 小时，由测试直接调用 check；结束时删除策略并恢复默认间隔
+func setPolicies(t *testing.T, policies ...Policy) {
+	t.Helper()
+	if err := ConfigurePolicies("", time.Hour); err != nil {
+		t.Fatalf("ConfigurePolicies: %v", err)
+	}
+	for _, p := range policies {
+		if _, err := SetPolicy(p); err != nil {
+			t.Fatalf("SetPolicy %s: %v", p.Name, err)
+		}
+	}
+	t.Cleanup(func() {
+		for _, p := range policies {
+			RemovePolicy(p.Name)
+		}
+		ConfigurePolicies("", 0)
+	})
+}
+
+// violationRecorder 记录回调收到的违规
+type violationRecorder struct {
+	mu   sync.Mutex
+	seen []string
+}
+
+func (r *violationRecorder) record(v PolicyViolation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seen = append(r.seen, fmt.Sprintf("%s device %d: %s", v.Policy, v.DeviceID, v.Message))
+}
+
+// take 返回并清空收到的违规，按字母排序
+func (r *violationRecorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	seen := r.seen
+	r.seen = nil
+	sort.Strings(seen)
+	return seen
+}
+
+func TestPolicyCheck(t *testing.T) {
+	advance := initFakeDevices(t,
+		// 边缘温度以 40 秒为周期在 70–90℃ 之间变化，第 3.3–16.7 秒高于 85℃
+		FakeDeviceSpec{Temperature: FakeValue{Base: 80, Amplitude: 10, Period: 40}, Ecc: []FakeEccSpec{{Block: "UMC"}}},
+		// 驱动加载以来已有 2 个 UE，每秒 1 次 PCIe 重放
+		FakeDeviceSpec{Temperature: ConstFakeValue(90), PcieReplay: ConstFakeValue(1),
+			Ecc: []FakeEccSpec{{Block: "UMC", UE: 2, Inject: []FakeEccInjection{{At: 10, UE: 1}, {At: 20, UE: 3}}}}},
+	)
+	start := advance(0)
+	setPolicies(t,
+		Policy{Name: "hot", Devices: []int{0}, Condition: PolicyMaxTemperature, Threshold: 85, Seconds: 3, Action: PolicyActionPerfLevelLow},
+		Policy{Name: "ecc", Condition: PolicyEccUncorrectable},
+		Policy{Name: "replay", Devices: []int{1}, Condition: PolicyPcieReplay, Threshold: 5},
+	)
+	var all, hot, other violationRecorder
+	unregisterAll := RegisterPolicyCallback("", all.record)
+	defer RegisterPolicyCallback("hot", hot.record)()
+	defer RegisterPolicyCallback("no-such-policy", other.record)()
+
+	steps := []struct {
+		at   time.Duration
+		want []string
+	}{
+		// 温度开始高于阈值；计数器条件取得基线
+		{4 * time.Second, nil},
+		{6 * time.Second, nil},
+		{7 * time.Second, []string{"hot device 0: edge temperature 88.9°C above 85.0°C for 3s"}},
+		// 条件持续成立期间只违规一次；重放增长 3 次低于阈值 5
+		{10 * time.Second, []string{"ecc device 1: 1 new uncorrectable ECC errors"}},
+		// 温度恢复后重新计时
+		{18 * time.Second, []string{"replay device 1: 8 PCIe replays since the last check"}},
+		{44 * time.Second, []string{"ecc device 1: 3 new uncorrectable ECC errors", "replay device 1: 26 PCIe replays since the last check"}},
+		{46 * time.Second, nil},
+		{47 * time.Second, []string{"hot device 0: edge temperature 88.9°C above 85.0°C for 3s"}},
+	}
+	var elapsed time.Duration
+	var fired []string
+	for _, step := range steps {
+		now := advance(step.at - elapsed)
+		elapsed = step.at
+		defaultPolicies.check(now)
+		got := all.take()
+		if step.at > 18*time.Second {
+			// 取消注册后不再收到违规
+			if len(got) != 0 {
+				t.Errorf("at %v: unregistered callback got %q", step.at, got)
+			}
+		} else if !reflect.DeepEqual(got, step.want) {
+			t.Errorf("at %v: violations = %q, want %q", step.at, got, step.want)
+		}
+		if step.at == 18*time.Second {
+			unregisterAll()
+		}
+		fired = append(fired, step.want...)
+	}
+
+	// 违规记录包含全部违规，操作在违规的设备上执行
+	var recorded []string
+	for _, v := range PolicyViolations(start) {
+		recorded = append(recorded, fmt.Sprintf("%s device %d: %s", v.Policy, v.DeviceID, v.Message))
+		if v.Policy == "hot" && (v.Action != PolicyActionPerfLevelLow || v.ActionError != "") {
+			t.Errorf("hot violation action = %s %q, want %s without error", v.Action, v.ActionError, PolicyActionPerfLevelLow)
+		}
+	}
+	sort.Strings(recorded)
+	sort.Strings(fired)
+	if !reflect.DeepEqual(recorded, fired) {
+		t.Errorf("recorded violations = %q, want %q", recorded, fired)
+	}
+	for dvInd, want := range []RSMIDevPerfLevel{RSMI_DEV_PERF_LEVEL_LOW, RSMI_DEV_PERF_LEVEL_AUTO} {
+		if level, err := rsmiDevPerfLevelGet(dvInd); err != nil || level != want {
+			t.Errorf("device %d perf level = %v, %v, want %v", dvInd, level, err, want)
+		}
+	}
+
+	// 按策略注册的回调只收到该策略的违规
+	wantHot := []string{
+		"hot device 0: edge temperature 88.9°C above 85.0°C for 3s",
+		"hot device 0: edge temperature 88.9°C above 85.0°C for 3s",
+	}
+	if got := hot.take(); !reflect.DeepEqual(got, wantHot) {
+		t.Errorf("hot callback got %q, want %q", got, wantHot)
+	}
+	if got := other.take(); len(got) != 0 {
+		t.Errorf("callback of an unknown policy got %q", got)
+	}
+}
+
+func TestEvaluatePolicyLevel(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 每个读数的时刻（秒）与是否违规
+	type reading struct {
+		at       float64
+		value    float64
+		violated bool
+	}
+	tests := []struct {
+		name     string
+		policy   Policy
+		limit    float64
+		reasons  []ThrottleReason
+		readings []reading
+	}{
+		{"temperature immediately", Policy{Name: "t", Condition: PolicyMaxTemperature, Threshold: 85},
+			0, nil, []reading{{0, 85, false}, {1, 86, true}, {2, 90, false}, {3, 80, false}, {4, 86, true}}},
+		{"temperature held", Policy{Name: "t", Condition: PolicyMaxTemperature, Threshold: 85, Seconds: 5},
+			0, nil, []reading{{0, 86, false}, {4, 86, false}, {4.5, 84, false}, {5, 86, false}, {9.9, 86, false}, {10, 86, true}, {20, 86, false}}},
+		// 功率上限 300W 的 90%
+		{"power over cap", Policy{Name: "p", Condition: PolicyPowerOverCap, Threshold: 90, Seconds: 2},
+			300, nil, []reading{{0, 270, false}, {1, 271, false}, {2, 280, false}, {3, 300, true}}},
+		{"power without a cap", Policy{Name: "p", Condition: PolicyPowerOverCap, Threshold: 90},
+			0, nil, []reading{{0, 300, false}}},
+		{"thermal throttle", Policy{Name: "th", Condition: PolicyThermalThrottle, Seconds: 1},
+			0, []ThrottleReason{ThrottleThermalHotspot}, []reading{{0, 1, false}, {1, 1, true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.normalize(); err != nil {
+				t.Fatalf("normalize: %v", err)
+			}
+			ds := &policyDeviceState{}
+			for _, r := range tt.readings {
+				now := t0.Add(time.Duration(r.at * float64(time.Second)))
+				if _, violated := evaluatePolicy(tt.policy, ds, policyReading{value: r.value, limit: tt.limit, reasons: tt.reasons}, now); violated != r.violated {
+					t.Errorf("reading %v at %vs: violated = %v, want %v", r.value, r.at, violated, r.violated)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluatePolicyCounter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Policy{Name: "replay", Condition: PolicyPcieReplay, Threshold: 3}
+	if err := p.normalize(); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	// 首次读数为基线，之后与上一次读数比较，计数器变小时重新取基线
+	tests := []struct {
+		value     float64
+		wantDelta float64
+	}{
+		{100, 0}, {101, 0}, {104, 3}, {105, 0}, {2, 0}, {10, 8},
+	}
+	ds := &policyDeviceState{}
+	for _, tt := range tests {
+		v, violated := evaluatePolicy(p, ds, policyReading{value: tt.value}, now)
+		if violated != (tt.wantDelta > 0) || (violated && (v.Value != tt.wantDelta || v.Limit != 3)) {
+			t.Errorf("reading %v: violated %v with %v over %v, want delta %v", tt.value, violated, v.Value, v.Limit, tt.wantDelta)
+		}
+	}
+}
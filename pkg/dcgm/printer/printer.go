@@ -0,0 +1,376 @@
+// Package printer 将 dcgm Show* 系列函数返回的结构化结果格式化为表格文本，供命令行与示例程序使用；
+// dcgm 包本身只返回数据，不向标准输出打印。
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
+
+func newTabWriter(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+}
+
+// ConciseHw 输出设备简要硬件信息表
+func ConciseHw(w io.Writer, infos []dcgm.ConciseHwInfo) {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "GPU\tDID\tGFX RAS\tSDMA RAS\tUMC RAS\tVBIOS\tBUS")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "GPU%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			info.DeviceID, info.DID, info.GfxRas, info.SdmaRas, info.UmcRas, info.VBIOS, info.Bus)
+	}
+	tw.Flush()
+}
+
+// Clocks 输出每个设备各时钟支持的频率档位，当前档位以 * 标记
+func Clocks(w io.Writer, infos []dcgm.DeviceClockInfo) {
+	for _, info := range infos {
+		for _, clock := range info.Clocks {
+			fmt.Fprintf(w, "Supported %s frequencies on GPU%d\n", clock.Type, info.DeviceID)
+			for i, freq := range clock.Frequencies {
+				var fr string
+				if clock.Lanes != nil {
+					fr = fmt.Sprintf("%.1fGT/s x%d", float64(freq)/1000000000, clock.Lanes[i])
+				} else {
+					fr = fmt.Sprintf("%dMhz", freq/1000000)
+				}
+				if i == clock.Current {
+					fr += " *"
+				}
+				fmt.Fprintf(w, "  %d: %s\n", i, fr)
+			}
+		}
+	}
+}
+
+// Fans 输出设备风扇级别、转速百分比与 RPM
+func Fans(w io.Writer, infos []dcgm.FanInfo) {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "GPU\tFAN LEVEL\tFAN SPEED (%)\tFAN RPM")
+	for _, info := range infos {
+		if info.Level == 0 || info.SpeedPercent == 0 {
+			fmt.Fprintf(tw, "GPU%d\tN/A\tN/A\tN/A (GPU might be cooled with a non-PWM fan)\n", info.DeviceID)
+			continue
+		}
+		fmt.Fprintf(tw, "GPU%d\t%d\t%.0f\t%d\n", info.DeviceID, info.Level, info.SpeedPercent, info.RPM)
+	}
+	tw.Flush()
+}
+
+// Energy 输出设备能量累加器与累计能耗
+func Energy(w io.Writer, infos []dcgm.DeviceEnergyInfo) {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "GPU\tENERGY COUNTER\tACCUMULATED ENERGY (uJ)")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "GPU%d\t%d\t%.2f\n", info.DeviceID, info.Counter, info.Energy)
+	}
+	tw.Flush()
+}
+
+// MemInfo 输出设备各类型内存的使用量与总量（字节）
+func MemInfo(w io.Writer, infos []dcgm.DeviceMemInfo) {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "GPU\tTYPE\tUSED (B)\tTOTAL (B)")
+	for _, info := range infos {
+		for _, mem := range info.Memory {
+			fmt.Fprintf(tw, "GPU%d\t%s\t%d\t%d\n", info.DeviceID, mem.Type, mem.Used, mem.Total)
+		}
+	}
+	tw.Flush()
+}
+
+// MemUse 输出设备内存繁忙百分比与内存活动计数
+func MemUse(w io.Writer, infos []dcgm.DeviceMemUseInfo) {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "GPU\tMEMORY USE (%)\tMEMORY ACTIVITY")
+	for _, info := range infos {
+		activity := "N/A"
+		if value, ok := info.Utilization["Memory Activity"]; ok {
+			activity = strconv.FormatUint(value, 10)
+		}
+		fmt.Fprintf(tw, "GPU%d\t%d\t%s\n", info.DeviceID, info.MemoryBusyPercent, activity)
+	}
+	tw.Flush()
+}
+
+// Range 输出设备的有效频率或电压范围
+func Range(w io.Writer, infos []dcgm.DeviceRangeInfo) {
+	for _, info := range infos {
+		switch info.RangeType {
+		case "voltage":
+			for i, r := range info.Ranges {
+				fmt.Fprintf(w, "GPU%d: Region %d: Valid voltage range: %dmV - %dmV\n", info.DeviceID, i, r.Lower, r.Upper)
+			}
+		default:
+			for _, r := range info.Ranges {
+				fmt.Fprintf(w, "GPU%d: Valid %s range: %dMhz - %dMhz\n", info.DeviceID, info.RangeType, r.Lower, r.Upper)
+			}
+		}
+	}
+}
+
+// RetiredPages 输出设备的退役页，没有退役页的设备不输出
+func RetiredPages(w io.Writer, infos []dcgm.DeviceRetiredPagesInfo) {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "GPU\tPAGE ADDRESS\tPAGE SIZE\tSTATUS")
+	for _, info := range infos {
+		for _, page := range info.Pages {
+			fmt.Fprintf(tw, "GPU%d\t0x%X\t0x%X\t%s\n", info.DeviceID, page.Address, page.Size, page.Status)
+		}
+	}
+	tw.Flush()
+}
+
+// VoltageCurve 输出设备的电压曲线点
+func VoltageCurve(w io.Writer, infos []dcgm.DeviceVoltageCurveInfo) {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "GPU\tPOINT\tFREQUENCY (MHz)\tVOLTAGE (mV)")
+	for _, info := range infos {
+		for i, point := range info.Points {
+			fmt.Fprintf(tw, "GPU%d\t%d\t%d\t%d\n", info.DeviceID, i, point.Frequency, point.Voltage)
+		}
+	}
+	tw.Flush()
+}
+
+// XgmiErr 输出设备的 XGMI 错误状态
+func XgmiErr(w io.Writer, infos []dcgm.XgmiErrorInfo) {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "GPU\tXGMI ERROR\tDESCRIPTION")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "GPU%d\t%d\t%s\n", info.DeviceID, info.Status, info.Description)
+	}
+	tw.Flush()
+}
+
+// GpuMetrics 输出每个设备的 gpu metrics，只列出当前度量表版本存在的字段
+func GpuMetrics(w io.Writer, infos []dcgm.DeviceGpuMetrics) {
+	for _, info := range infos {
+		m := info.Metrics
+		fmt.Fprintf(w, "GPU%d gpu metrics v%s\n", info.DeviceID, m.Revision)
+		tw := newTabWriter(w)
+		if m.CommonHeader.ContentRevision < 4 {
+			fmt.Fprintf(tw, "  Temperature edge/hotspot/mem\t%d / %d / %d\n", m.TemperatureEdge, m.TemperatureHotspot, m.TemperatureMem)
+			fmt.Fprintf(tw, "  Temperature VR gfx/soc/mem\t%d / %d / %d\n", m.TemperatureVRGfx, m.TemperatureVRSoc, m.TemperatureVRMem)
+			fmt.Fprintf(tw, "  Activity gfx/umc/mm (%%)\t%d / %d / %d\n", m.AverageGfxActivity, m.AverageUmcActivity, m.AverageMmActivity)
+			fmt.Fprintf(tw, "  Average socket power (W)\t%d\n", m.AverageSocketPower)
+			fmt.Fprintf(tw, "  Gfxclk/Socclk/Uclk (MHz)\t%d / %d / %d\n", m.CurrentGfxclk, m.CurrentSocclk, m.CurrentUclk)
+			fmt.Fprintf(tw, "  Fan speed\t%d\n", m.CurrentFanSpeed)
+		} else {
+			fmt.Fprintf(tw, "  Temperature hotspot/mem/VR soc\t%d / %d / %d\n", m.TemperatureHotspot, m.TemperatureMem, m.TemperatureVRSoc)
+			fmt.Fprintf(tw, "  Activity gfx/umc (%%)\t%d / %d\n", m.AverageGfxActivity, m.AverageUmcActivity)
+			fmt.Fprintf(tw, "  VCN activity (%%)\t%v\n", m.VcnActivity)
+			fmt.Fprintf(tw, "  Socket power (W)\t%d\n", m.AverageSocketPower)
+			fmt.Fprintf(tw, "  Gfxclk/Socclk/Uclk (MHz)\t%d / %d / %d\n", m.CurrentGfxclk, m.CurrentSocclk, m.CurrentUclk)
+			fmt.Fprintf(tw, "  XGMI link width/speed\t%d / %d Gbps\n", m.XgmiLinkWidth, m.XgmiLinkSpeed)
+			fmt.Fprintf(tw, "  XGMI read/write (KB)\t%v / %v\n", m.XgmiReadDataAcc, m.XgmiWriteDataAcc)
+			fmt.Fprintf(tw, "  PCIe bandwidth acc/inst (GB/s)\t%d / %d\n", m.PcieBandwidthAcc, m.PcieBandwidthInst)
+			fmt.Fprintf(tw, "  PCIe replay count\t%d\n", m.PcieReplayCountAcc)
+		}
+		if len(m.TemperatureHBM) > 0 {
+			fmt.Fprintf(tw, "  Temperature HBM\t%v\n", m.TemperatureHBM)
+		}
+		if m.CommonHeader.ContentRevision == 3 {
+			fmt.Fprintf(tw, "  Voltage soc/gfx/mem (mV)\t%d / %d / %d\n", m.VoltageSoc, m.VoltageGfx, m.VoltageMem)
+		}
+		fmt.Fprintf(tw, "  Energy accumulator\t%d\n", m.EnergyAccumulator)
+		if m.CommonHeader.ContentRevision >= 1 {
+			fmt.Fprintf(tw, "  Activity acc gfx/mem\t%d / %d\n", m.GfxActivityAcc, m.MemActivityAcc)
+		}
+		fmt.Fprintf(tw, "  Throttle status\t0x%08X\n", m.ThrottleStatus)
+		fmt.Fprintf(tw, "  PCIe link\tx%d %.1fGT/s\n", m.PcieLinkWidth, float64(m.PcieLinkSpeed)/10)
+		tw.Flush()
+	}
+}
+
+// Topology 以矩阵形式输出设备间的权重或跳数，title 为表头说明
+func Topology(w io.Writer, title string, matrix dcgm.TopologyMatrix) {
+	cells := make([][]string, len(matrix.Values))
+	for i, row := range matrix.Values {
+		cells[i] = make([]string, len(row))
+		for j, value := range row {
+			if value < 0 {
+				cells[i][j] = "N/A"
+			} else {
+				cells[i][j] = strconv.FormatInt(value, 10)
+			}
+		}
+	}
+	printMatrix(w, title, matrix.Devices, cells)
+}
+
+// LinkTypes 以矩阵形式输出设备间的链接类型
+func LinkTypes(w io.Writer, matrix dcgm.LinkTypeMatrix) {
+	cells := make([][]string, len(matrix.Types))
+	for i, row := range matrix.Types {
+		cells[i] = make([]string, len(row))
+		for j, linkType := range row {
+			switch {
+			case i == j:
+				cells[i][j] = "0"
+			case linkType == "":
+				cells[i][j] = "N/A"
+			default:
+				cells[i][j] = linkType
+			}
+		}
+	}
+	printMatrix(w, "Link Type between two GPUs", matrix.Devices, cells)
+}
+
+// HwTopology 输出完整的硬件拓扑：权重、跳数、链接类型与 NUMA 信息
+func HwTopology(w io.Writer, info dcgm.HwTopologyInfo) {
+	Topology(w, "Weight between two GPUs", info.Weight)
+	fmt.Fprintln(w)
+	Topology(w, "Hops between two GPUs", info.Hops)
+	fmt.Fprintln(w)
+	LinkTypes(w, info.LinkType)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Numa Nodes")
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "GPU\tNUMA NODE\tNUMA AFFINITY")
+	for _, numa := range info.Numa {
+		fmt.Fprintf(tw, "GPU%d\t%d\t%d\n", numa.DeviceID, numa.NumaNode, numa.NumaAffinity)
+	}
+	tw.Flush()
+}
+
+// NodeTopology 按 nvidia-smi topo -m 的格式输出设备间的连接矩阵与图例，有 XGMI 带宽时另外输出带宽矩阵
+func NodeTopology(w io.Writer, topology dcgm.NodeTopology) {
+	tw := newTabWriter(w)
+	for _, device := range topology.Devices {
+		fmt.Fprintf(tw, "\tGPU%d", device.DeviceID)
+	}
+	fmt.Fprintln(tw, "\tNUMA Affinity\tPCI Bus ID")
+	hasBandwidth := false
+	for i, src := range topology.Devices {
+		fmt.Fprintf(tw, "GPU%d", src.DeviceID)
+		for j, dst := range topology.Devices {
+			link := topology.Links[i][j]
+			hasBandwidth = hasBandwidth || link.MaxBandwidth > 0
+			fmt.Fprintf(tw, "\t%s", topologyConnection(i == j, src, dst, link))
+		}
+		numa := "N/A"
+		if src.NumaAffinity >= 0 {
+			numa = strconv.Itoa(src.NumaAffinity)
+		}
+		fmt.Fprintf(tw, "\t%s\t%s\n", numa, src.PciBusID)
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Legend:")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  X       = Self")
+	fmt.Fprintln(w, "  SYS     = Connection traversing PCIe as well as the SMP interconnect between NUMA nodes")
+	fmt.Fprintln(w, "  NODE    = Connection traversing PCIe within a single NUMA node")
+	fmt.Fprintln(w, "  PCIE    = Connection traversing PCIe, NUMA nodes unknown")
+	fmt.Fprintln(w, "  XGMI    = Connection traversing a direct XGMI link")
+	fmt.Fprintln(w, "  XGMI#   = Connection traversing # XGMI hops")
+	fmt.Fprintln(w, "  N/A     = Link information unavailable")
+
+	if !hasBandwidth {
+		return
+	}
+	devices := make([]int, len(topology.Devices))
+	cells := make([][]string, len(topology.Devices))
+	for i, device := range topology.Devices {
+		devices[i] = device.DeviceID
+		cells[i] = make([]string, len(topology.Devices))
+		for j, link := range topology.Links[i] {
+			switch {
+			case i == j:
+				cells[i][j] = "X"
+			case link.MaxBandwidth > 0:
+				cells[i][j] = fmt.Sprintf("%d/%d", link.MinBandwidth, link.MaxBandwidth)
+			default:
+				cells[i][j] = "N/A"
+			}
+		}
+	}
+	fmt.Fprintln(w)
+	printMatrix(w, "XGMI bandwidth between two GPUs (min/max MB/s)", devices, cells)
+}
+
+// topologyConnection 返回 topo -m 矩阵中的连接名称
+func topologyConnection(self bool, src, dst dcgm.TopologyDevice, link dcgm.TopologyLink) string {
+	switch {
+	case self:
+		return "X"
+	case link.LinkType == dcgm.LinkTypeXGMI && link.Hops > 1:
+		return fmt.Sprintf("XGMI%d", link.Hops)
+	case link.LinkType == dcgm.LinkTypeXGMI:
+		return "XGMI"
+	case link.LinkType == dcgm.LinkTypePCIE && src.NumaNode >= 0 && dst.NumaNode >= 0:
+		if src.NumaNode == dst.NumaNode {
+			return "NODE"
+		}
+		return "SYS"
+	case link.LinkType == dcgm.LinkTypePCIE:
+		return "PCIE"
+	}
+	return "N/A"
+}
+
+// Health 输出设备的健康结论与各子系统发现的问题
+func Health(w io.Writer, healths []dcgm.DeviceHealth) {
+	for i, health := range healths {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		scope := "one-shot check"
+		if health.Watched {
+			scope = "watched since " + health.Since.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "GPU%d: %s (%s)\n", health.DeviceID, health.Result, scope)
+		tw := newTabWriter(w)
+		for _, system := range health.Systems {
+			fmt.Fprintf(tw, "  %s\t%s\n", system.System, system.Result)
+			for _, incident := range system.Incidents {
+				fmt.Fprintf(tw, "    %s\t%s\n", incident.Result, incident.Message)
+			}
+		}
+		tw.Flush()
+	}
+}
+
+// Diag 输出软件诊断报告，每个诊断项一行，明细缩进列在其后
+func Diag(w io.Writer, report dcgm.DiagReport) {
+	tw := newTabWriter(w)
+	for _, check := range report.Checks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", check.Name, check.Result, check.Message)
+		for _, detail := range check.Details {
+			fmt.Fprintf(tw, "\t\t  %s\n", detail)
+		}
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "Result: %s (%d checks in %v)\n", report.Result, len(report.Checks), report.Duration.Round(time.Millisecond))
+}
+
+// Event 输出一条事件通知，列宽固定以便持续输出时对齐
+func Event(w io.Writer, event dcgm.Event) {
+	fmt.Fprintf(w, "%-8s%-21s%-18s%s\n", fmt.Sprintf("GPU%d", event.DeviceID), event.Time.Format(time.DateTime), event.Type, event.Message)
+}
+
+// printMatrix 输出带行列设备表头的矩阵
+func printMatrix(w io.Writer, title string, devices []int, cells [][]string) {
+	fmt.Fprintln(w, title)
+	tw := newTabWriter(w)
+	for _, device := range devices {
+		fmt.Fprintf(tw, "\tGPU%d", device)
+	}
+	fmt.Fprintln(tw)
+	for i, device := range devices {
+		fmt.Fprintf(tw, "GPU%d", device)
+		for _, cell := range cells[i] {
+			fmt.Fprintf(tw, "\t%s", cell)
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,230 @@
+package dcgm
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// 默认的进程统计配置
+const (
+	DefaultProcessInterval = 5 * time.Second
+	DefaultProcessKeep     = 1000
+)
+
+// ProcessRecord 一个 KFD 进程的使用记录：StartTime、EndTime 为首次与最后一次采样到该进程的时间，
+// 显存为字节，SDMA 使用时间为微秒，CU 占用为计算单元数量
+type ProcessRecord struct {
+	PID            int
+	Name           string
+	Devices        []int
+	Running        bool
+	StartTime      time.Time
+	EndTime        time.Time
+	Samples        int
+	VramPeak       uint64
+	VramAvg        float64
+	SdmaPeak       uint64
+	SdmaAvg        float64
+	CuOccupancyMax uint32
+	CuOccupancyAvg float64
+}
+
+// usesDevice 进程是否使用过该设备
+func (r *ProcessRecord) usesDevice(dvInd int) bool {
+	for _, d := range r.Devices {
+		if d == dvInd {
+			return true
+		}
+	}
+	return false
+}
+
+// add 累加一次采样
+func (r *ProcessRecord) add(info RSMIProcessInfo, devices []int, now time.Time) {
+	r.Samples++
+	n := float64(r.Samples)
+	r.VramAvg += (float64(info.VramUsage) - r.VramAvg) / n
+	r.SdmaAvg += (float64(info.SdmaUsage) - r.SdmaAvg) / n
+	r.CuOccupancyAvg += (float64(info.CuOccupancy) - r.CuOccupancyAvg) / n
+	r.VramPeak = max(r.VramPeak, info.VramUsage)
+	r.SdmaPeak = max(r.SdmaPeak, info.SdmaUsage)
+	r.CuOccupancyMax = max(r.CuOccupancyMax, info.CuOccupancy)
+	for _, dvInd := range devices {
+		if !r.usesDevice(dvInd) {
+			r.Devices = append(r.Devices, dvInd)
+		}
+	}
+	sort.Ints(r.Devices)
+	r.EndTime = now
+}
+
+// processAccountant 后台采样 KFD 进程，进程消失后移入已退出列表，只保留最近 keep 个
+type processAccountant struct {
+	mu      sync.Mutex
+	running map[int]*ProcessRecord
+	exited  []ProcessRecord
+	keep    int
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+var defaultProcesses = &processAccountant{}
+
+// StartProcessAccounting 启动进程统计，每隔 interval 采样 rsmiComputeProcessInfoGet 与 rsmiComputeProcessGpusGet，
+// 保留最近 keep 个已退出进程的记录；参数为零时取默认值。已经启动时先停止并丢弃已有记录。
+func StartProcessAccounting(interval time.Duration, keep int) error {
+	if interval == 0 {
+		interval = DefaultProcessInterval
+	}
+	if keep == 0 {
+		keep = DefaultProcessKeep
+	}
+	if interval < 0 || keep < 0 {
+		return fmt.Errorf("Error StartProcessAccounting:invalid interval %v or keep %d", interval, keep)
+	}
+	StopProcessAccounting()
+	p := defaultProcesses
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.running = map[int]*ProcessRecord{}
+	p.exited = nil
+	p.keep = keep
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.run(interval, p.stop, p.done)
+	return nil
+}
+
+// StopProcessAccounting 停止进程统计并丢弃所有记录，未启动时不做任何事
+func StopProcessAccounting() {
+	p := defaultProcesses
+	p.mu.Lock()
+	stop, done := p.stop, p.done
+	p.stop, p.done = nil, nil
+	p.running, p.exited = nil, nil
+	p.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// ProcessHistory 返回进程记录，运行中的进程在前（按 PID 排序），已退出的进程按退出时间从新到旧；
+// dvInd 不小于 0 时只返回使用过该设备的进程
+func ProcessHistory(dvInd int) []ProcessRecord {
+	p := defaultProcesses
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var records []ProcessRecord
+	for _, r := range p.running {
+		if dvInd < 0 || r.usesDevice(dvInd) {
+			records = append(records, *r)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].PID < records[j].PID })
+	for i := len(p.exited) - 1; i >= 0; i-- {
+		if dvInd < 0 || p.exited[i].usesDevice(dvInd) {
+			records = append(records, p.exited[i])
+		}
+	}
+	for i := range records {
+		records[i].Devices = append([]int{}, records[i].Devices...)
+	}
+	return records
+}
+
+// ProcessHistoryRunning 进程统计是否已启动
+func ProcessHistoryRunning() bool {
+	p := defaultProcesses
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stop != nil
+}
+
+func (p *processAccountant) run(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.sample()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample 采样一次 KFD 进程列表，新进程在首次出现时读取进程名称，本次未出现的进程视为已退出
+func (p *processAccountant) sample() {
+	infos, _, err := rsmiComputeProcessInfoGet()
+	if err != nil {
+		glog.V(2).Infof("process accounting:%v", err)
+		return
+	}
+	now := time.Now()
+	devices := make(map[int][]int, len(infos))
+	for _, info := range infos {
+		pid := int(info.ProcessID)
+		dvIndices, err := rsmiComputeProcessGpusGet(pid)
+		if err != nil {
+			glog.V(2).Infof("process accounting pid %d:%v", pid, err)
+		}
+		devices[pid] = dvIndices
+	}
+	// 进程名称在进程退出后无法读取，新进程在首次出现时读取，读取期间不持有锁
+	p.mu.Lock()
+	known := make(map[int]bool, len(p.running))
+	for pid := range p.running {
+		known[pid] = true
+	}
+	p.mu.Unlock()
+	names := map[int]string{}
+	for _, info := range infos {
+		if pid := int(info.ProcessID); !known[pid] {
+			names[pid] = ProcessName(pid)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// 采样期间统计可能已被停止
+	if p.running == nil {
+		return
+	}
+	seen := map[int]bool{}
+	for _, info := range infos {
+		pid := int(info.ProcessID)
+		seen[pid] = true
+		r, ok := p.running[pid]
+		if !ok {
+			name, ok := names[pid]
+			if !ok {
+				name = ProcessName(pid)
+			}
+			r = &ProcessRecord{PID: pid, Name: name, Running: true, StartTime: now}
+			p.running[pid] = r
+		}
+		r.add(info, devices[pid], now)
+	}
+	var gone []int
+	for pid := range p.running {
+		if !seen[pid] {
+			gone = append(gone, pid)
+		}
+	}
+	sort.Ints(gone)
+	for _, pid := range gone {
+		r := p.running[pid]
+		delete(p.running, pid)
+		r.Running = false
+		p.exited = append(p.exited, *r)
+	}
+	if over := len(p.exited) - p.keep; over > 0 {
+		p.exited = append([]ProcessRecord{}, p.exited[over:]...)
+	}
+}
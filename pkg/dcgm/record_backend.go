@@ -0,0 +1,750 @@
+package dcgm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// RecordedCall 录制文件中的一条记录，对应一次 rsmi/dmi 原语调用。
+// 录制文件为 JSON Lines 格式，每行一条记录，按调用顺序排列。
+type RecordedCall struct {
+	// Seq 调用序号，从 1 开始
+	Seq int `json:"seq"`
+	// Time 调用返回的时间
+	Time time.Time `json:"time"`
+	// Func 后端方法名，例如 RsmiDevTempMetricGet
+	Func string `json:"func"`
+	// Args 调用参数，不透明的句柄记录为 null
+	Args []json.RawMessage `json:"args"`
+	// Results 除 error 外的返回值
+	Results []json.RawMessage `json:"results"`
+	// Err 返回的错误信息，成功时为空
+	Err string `json:"err,omitempty"`
+	// NotSupported 返回的错误是否为 ErrNotSupported
+	NotSupported bool `json:"notSupported,omitempty"`
+}
+
+// RecordingBackend 包装另一个后端，把每次调用的参数、返回状态和输出结构体写入录制文件，
+// 录制结果可以交给 ReplayBackend 在没有硬件的机器上重放
+type RecordingBackend struct {
+	backend Backend
+	mu      sync.Mutex
+	closer  io.Closer
+	enc     *json.Encoder
+	seq     int
+}
+
+// NewRecordingBackend 创建录制后端，调用转发给 backend，记录写入 w
+func NewRecordingBackend(backend Backend, w io.Writer) *RecordingBackend {
+	return &RecordingBackend{backend: backend, enc: json.NewEncoder(w)}
+}
+
+// NewRecordingBackendToFile 创建录制后端，记录写入 path，文件已存在时会被覆盖
+func NewRecordingBackendToFile(backend Backend, path string) (*RecordingBackend, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error create recording file:%s", err)
+	}
+	r := NewRecordingBackend(backend, f)
+	r.closer = f
+	return r, nil
+}
+
+// InitWithRecording 按 spec 创建后端并包装为录制后端后初始化 DCGM，录制写入 path。
+// 录制文件逐条写入，进程异常退出时已完成的调用也会保留。
+func InitWithRecording(spec, path string) (*RecordingBackend, error) {
+	b, err := NewBackend(spec)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewRecordingBackendToFile(b, path)
+	if err != nil {
+		return nil, err
+	}
+	glog.Infof("recording dcgm calls of %s to %s", spec, path)
+	return r, InitWithBackend(r)
+}
+
+// Backend 返回被录制的后端
+func (r *RecordingBackend) Backend() Backend {
+	return r.backend
+}
+
+// Close 关闭录制文件
+func (r *RecordingBackend) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closer == nil {
+		return nil
+	}
+	err := r.closer.Close()
+	r.closer = nil
+	return err
+}
+
+func marshalValues(values []any) []json.RawMessage {
+	raws := make([]json.RawMessage, len(values))
+	for i, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			data, _ = json.Marshal(err.Error())
+		}
+		raws[i] = data
+	}
+	return raws
+}
+
+func (r *RecordingBackend) record(fn string, args, results []any, err error) {
+	call := RecordedCall{
+		Time:    time.Now(),
+		Func:    fn,
+		Args:    marshalValues(args),
+		Results: marshalValues(results),
+	}
+	if err != nil {
+		call.Err = err.Error()
+		call.NotSupported = errors.Is(err, ErrNotSupported)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	call.Seq = r.seq
+	if err := r.enc.Encode(&call); err != nil {
+		glog.Errorf("write recording failed: %v", err)
+	}
+}
+
+func (r *RecordingBackend) ProbeDeviceCount() int {
+	v := r.backend.ProbeDeviceCount()
+	r.record("ProbeDeviceCount", []any{}, []any{v}, nil)
+	return v
+}
+
+/****************************************** 初始化与关闭 *********************************************/
+
+func (r *RecordingBackend) RsmiInit() (err error) {
+	err = r.backend.RsmiInit()
+	r.record("RsmiInit", []any{}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiShutdown() (err error) {
+	err = r.backend.RsmiShutdown()
+	r.record("RsmiShutdown", []any{}, []any{}, err)
+	return
+}
+
+/****************************************** 设备信息、PCIe、功耗与内存 *********************************************/
+
+func (r *RecordingBackend) RsmiNumMonitorDevices() (gpuNum int, err error) {
+	gpuNum, err = r.backend.RsmiNumMonitorDevices()
+	r.record("RsmiNumMonitorDevices", []any{}, []any{gpuNum}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevSkuGet(dvInd int) (sku int, err error) {
+	sku, err = r.backend.RsmiDevSkuGet(dvInd)
+	r.record("RsmiDevSkuGet", []any{dvInd}, []any{sku}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevVendorIdGet(dvInd int) uint {
+	v := r.backend.RsmiDevVendorIdGet(dvInd)
+	r.record("RsmiDevVendorIdGet", []any{dvInd}, []any{v}, nil)
+	return v
+}
+
+func (r *RecordingBackend) RsmiDevIdGet(dvInd int) (id int, err error) {
+	id, err = r.backend.RsmiDevIdGet(dvInd)
+	r.record("RsmiDevIdGet", []any{dvInd}, []any{id}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevNameGet(dvInd int) (nameStr string, err error) {
+	nameStr, err = r.backend.RsmiDevNameGet(dvInd)
+	r.record("RsmiDevNameGet", []any{dvInd}, []any{nameStr}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevBrandGet(dvInd int) (brand string, err error) {
+	brand, err = r.backend.RsmiDevBrandGet(dvInd)
+	r.record("RsmiDevBrandGet", []any{dvInd}, []any{brand}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevVendorNameGet(dvInd int) (bname string, err error) {
+	bname, err = r.backend.RsmiDevVendorNameGet(dvInd)
+	r.record("RsmiDevVendorNameGet", []any{dvInd}, []any{bname}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevVramVendorGet(dvInd int) (result string, err error) {
+	result, err = r.backend.RsmiDevVramVendorGet(dvInd)
+	r.record("RsmiDevVramVendorGet", []any{dvInd}, []any{result}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevSerialNumberGet(dvInd int) (serialNumber string, err error) {
+	serialNumber, err = r.backend.RsmiDevSerialNumberGet(dvInd)
+	r.record("RsmiDevSerialNumberGet", []any{dvInd}, []any{serialNumber}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevSubsystemIdGet(dvInd int) int {
+	v := r.backend.RsmiDevSubsystemIdGet(dvInd)
+	r.record("RsmiDevSubsystemIdGet", []any{dvInd}, []any{v}, nil)
+	return v
+}
+
+func (r *RecordingBackend) RsmiDevSubsystemNameGet(dvInd int) (subSystemName string, err error) {
+	subSystemName, err = r.backend.RsmiDevSubsystemNameGet(dvInd)
+	r.record("RsmiDevSubsystemNameGet", []any{dvInd}, []any{subSystemName}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevDrmRenderMinorGet(dvInd int) int {
+	v := r.backend.RsmiDevDrmRenderMinorGet(dvInd)
+	r.record("RsmiDevDrmRenderMinorGet", []any{dvInd}, []any{v}, nil)
+	return v
+}
+
+func (r *RecordingBackend) RsmiDevUniqueIdGet(dvInd int) (uniqueId int64, err error) {
+	uniqueId, err = r.backend.RsmiDevUniqueIdGet(dvInd)
+	r.record("RsmiDevUniqueIdGet", []any{dvInd}, []any{uniqueId}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevSubsystemVendorIdGet(dvInd int) int {
+	v := r.backend.RsmiDevSubsystemVendorIdGet(dvInd)
+	r.record("RsmiDevSubsystemVendorIdGet", []any{dvInd}, []any{v}, nil)
+	return v
+}
+
+func (r *RecordingBackend) RsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err error) {
+	rsmiPcieBandwidth, err = r.backend.RsmiDevPciBandwidthGet(dvInd)
+	r.record("RsmiDevPciBandwidthGet", []any{dvInd}, []any{rsmiPcieBandwidth}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPciIdGet(dvInd int) (bdfid int64, err error) {
+	bdfid, err = r.backend.RsmiDevPciIdGet(dvInd)
+	r.record("RsmiDevPciIdGet", []any{dvInd}, []any{bdfid}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiTopoNumaAffinityGet(dvInd int) (namaNode int, err error) {
+	namaNode, err = r.backend.RsmiTopoNumaAffinityGet(dvInd)
+	r.record("RsmiTopoNumaAffinityGet", []any{dvInd}, []any{namaNode}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz int64, err error) {
+	sent, received, maxPktSz, err = r.backend.RsmiDevPciThroughputGet(dvInd)
+	r.record("RsmiDevPciThroughputGet", []any{dvInd}, []any{sent, received, maxPktSz}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPciReplayCounterGet(dvInd int) (counter int64, err error) {
+	counter, err = r.backend.RsmiDevPciReplayCounterGet(dvInd)
+	r.record("RsmiDevPciReplayCounterGet", []any{dvInd}, []any{counter}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPciBandwidthSet(dvInd int, bwBitmask int64) (err error) {
+	err = r.backend.RsmiDevPciBandwidthSet(dvInd, bwBitmask)
+	r.record("RsmiDevPciBandwidthSet", []any{dvInd, bwBitmask}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPowerAveGet(dvInd int, senserId int) (power int64, err error) {
+	power, err = r.backend.RsmiDevPowerAveGet(dvInd, senserId)
+	r.record("RsmiDevPowerAveGet", []any{dvInd, senserId}, []any{power}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32, timestamp uint64, err error) {
+	power, counterResolution, timestamp, err = r.backend.RsmiDevEnergyCountGet(dvInd)
+	r.record("RsmiDevEnergyCountGet", []any{dvInd}, []any{power, counterResolution, timestamp}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPowerCapGet(dvInd int, senserId int) (power int64, err error) {
+	power, err = r.backend.RsmiDevPowerCapGet(dvInd, senserId)
+	r.record("RsmiDevPowerCapGet", []any{dvInd, senserId}, []any{power}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error) {
+	max, min, err = r.backend.RsmiDevPowerCapRangeGet(dvInd, senserId)
+	r.record("RsmiDevPowerCapRangeGet", []any{dvInd, senserId}, []any{max, min}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevMemoryTotalGet(dvInd int, memoryType RSMIMemoryType) (total int64, err error) {
+	total, err = r.backend.RsmiDevMemoryTotalGet(dvInd, memoryType)
+	r.record("RsmiDevMemoryTotalGet", []any{dvInd, memoryType}, []any{total}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevMemoryUsageGet(dvInd int, memoryType RSMIMemoryType) (used int64, err error) {
+	used, err = r.backend.RsmiDevMemoryUsageGet(dvInd, memoryType)
+	r.record("RsmiDevMemoryUsageGet", []any{dvInd, memoryType}, []any{used}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevMemoryBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	busyPercent, err = r.backend.RsmiDevMemoryBusyPercentGet(dvInd)
+	r.record("RsmiDevMemoryBusyPercentGet", []any{dvInd}, []any{busyPercent}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetiredPageRecord, err error) {
+	numPages, records, err = r.backend.RsmiDevMemoryReservedPagesGet(dvInd)
+	r.record("RsmiDevMemoryReservedPagesGet", []any{dvInd}, []any{numPages, records}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevFanRpmsGet(dvInd, sensorInd int) (speed int64, err error) {
+	speed, err = r.backend.RsmiDevFanRpmsGet(dvInd, sensorInd)
+	r.record("RsmiDevFanRpmsGet", []any{dvInd, sensorInd}, []any{speed}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevFanSpeedGet(dvInd, sensorInd int) (speed int64, err error) {
+	speed, err = r.backend.RsmiDevFanSpeedGet(dvInd, sensorInd)
+	r.record("RsmiDevFanSpeedGet", []any{dvInd, sensorInd}, []any{speed}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevFanSpeedMaxGet(dvInd, sensorInd int) (maxSpeed int64, err error) {
+	maxSpeed, err = r.backend.RsmiDevFanSpeedMaxGet(dvInd, sensorInd)
+	r.record("RsmiDevFanSpeedMaxGet", []any{dvInd, sensorInd}, []any{maxSpeed}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreqVoltRegion, err error) {
+	numRegions, regions, err = r.backend.RsmiDevOdVoltCurveRegionsGet(dvInd)
+	r.record("RsmiDevOdVoltCurveRegionsGet", []any{dvInd}, []any{numRegions, regions}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSMPowerProfileStatus, err error) {
+	powerProfileStatus, err = r.backend.RsmiDevPowerProfilePresetsGet(dvInd, sensorInd)
+	r.record("RsmiDevPowerProfilePresetsGet", []any{dvInd, sensorInd}, []any{powerProfileStatus}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiVersionGet() (version RSMIVersion, err error) {
+	version, err = r.backend.RsmiVersionGet()
+	r.record("RsmiVersionGet", []any{}, []any{version}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiVersionStrGet(component RSMISwComponent, len int) (varStr string, err error) {
+	varStr, err = r.backend.RsmiVersionStrGet(component, len)
+	r.record("RsmiVersionStrGet", []any{component, len}, []any{varStr}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevVbiosVersionGet(dvInd, len int) (vbios string, err error) {
+	vbios, err = r.backend.RsmiDevVbiosVersionGet(dvInd, len)
+	r.record("RsmiDevVbiosVersionGet", []any{dvInd, len}, []any{vbios}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64, err error) {
+	fwVersion, err = r.backend.RsmiDevFirmwareVersionGet(dvInd, fwBlock)
+	r.record("RsmiDevFirmwareVersionGet", []any{dvInd, fwBlock}, []any{fwVersion}, err)
+	return
+}
+
+/****************************************** vDCU (libhydmi) *********************************************/
+
+func (r *RecordingBackend) DmiGetDeviceCount() (count int, err error) {
+	count, err = r.backend.DmiGetDeviceCount()
+	r.record("DmiGetDeviceCount", []any{}, []any{count}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error) {
+	deviceInfo, err = r.backend.DmiGetDeviceInfo(dvInd)
+	r.record("DmiGetDeviceInfo", []any{dvInd}, []any{deviceInfo}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiGetMaxVDeviceCount() (count int, err error) {
+	count, err = r.backend.DmiGetMaxVDeviceCount()
+	r.record("DmiGetMaxVDeviceCount", []any{}, []any{count}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiGetVDeviceCount() (count int, err error) {
+	count, err = r.backend.DmiGetVDeviceCount()
+	r.record("DmiGetVDeviceCount", []any{}, []any{count}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
+	vDeviceInfo, err = r.backend.DmiGetVDeviceInfo(vDvInd)
+	r.record("DmiGetVDeviceInfo", []any{vDvInd}, []any{vDeviceInfo}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error) {
+	cus, memories, err = r.backend.DmiGetDeviceRemainingInfo(dvInd)
+	r.record("DmiGetDeviceRemainingInfo", []any{dvInd}, []any{cus, memories}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []int) (vdevIDs []int, err error) {
+	vdevIDs, err = r.backend.DmiCreateVDevices(dvInd, vDevCount, vDevCUs, vDevMemSize)
+	r.record("DmiCreateVDevices", []any{dvInd, vDevCount, vDevCUs, vDevMemSize}, []any{vdevIDs}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiDestroyVDevices(dvInd int) (err error) {
+	err = r.backend.DmiDestroyVDevices(dvInd)
+	r.record("DmiDestroyVDevices", []any{dvInd}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiDestroySingleVDevice(vDvInd int) (err error) {
+	err = r.backend.DmiDestroySingleVDevice(vDvInd)
+	r.record("DmiDestroySingleVDevice", []any{vDvInd}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiUpdateSingleVDevice(vDvInd int, vDevCUs int, vDevMemSize int) (err error) {
+	err = r.backend.DmiUpdateSingleVDevice(vDvInd, vDevCUs, vDevMemSize)
+	r.record("DmiUpdateSingleVDevice", []any{vDvInd, vDevCUs, vDevMemSize}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiStartVDevice(vDvInd int) (err error) {
+	err = r.backend.DmiStartVDevice(vDvInd)
+	r.record("DmiStartVDevice", []any{vDvInd}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiStopVDevice(vDvInd int) (err error) {
+	err = r.backend.DmiStopVDevice(vDvInd)
+	r.record("DmiStopVDevice", []any{vDvInd}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiGetDevBusyPercent(dvInd int) (percent int, err error) {
+	percent, err = r.backend.DmiGetDevBusyPercent(dvInd)
+	r.record("DmiGetDevBusyPercent", []any{dvInd}, []any{percent}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiGetVDevBusyPercent(vDvInd int) (percent int, err error) {
+	percent, err = r.backend.DmiGetVDevBusyPercent(vDvInd)
+	r.record("DmiGetVDevBusyPercent", []any{vDvInd}, []any{percent}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiSetEncryptionVMStatus(status bool) (err error) {
+	err = r.backend.DmiSetEncryptionVMStatus(status)
+	r.record("DmiSetEncryptionVMStatus", []any{status}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) DmiGetEncryptionVMStatus() (status bool, err error) {
+	status, err = r.backend.DmiGetEncryptionVMStatus()
+	r.record("DmiGetEncryptionVMStatus", []any{}, []any{status}, err)
+	return
+}
+
+/****************************************** 设备状态 *********************************************/
+
+func (r *RecordingBackend) RsmiDevTempMetricGet(dvInd int, sensorType int, metric RSMITemperatureMetric) (temp int64, err error) {
+	temp, err = r.backend.RsmiDevTempMetricGet(dvInd, sensorType, metric)
+	r.record("RsmiDevTempMetricGet", []any{dvInd, sensorType, metric}, []any{temp}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevVoltMetricGet(dvInd int, voltageType RSMIVoltageType, metric RSMIVoltageMetric) int64 {
+	v := r.backend.RsmiDevVoltMetricGet(dvInd, voltageType, metric)
+	r.record("RsmiDevVoltMetricGet", []any{dvInd, voltageType, metric}, []any{v}, nil)
+	return v
+}
+
+func (r *RecordingBackend) RsmiDevFanSpeedSet(dvInd, sensorInd int, speed int64) (err error) {
+	err = r.backend.RsmiDevFanSpeedSet(dvInd, sensorInd, speed)
+	r.record("RsmiDevFanSpeedSet", []any{dvInd, sensorInd, speed}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	busyPercent, err = r.backend.RsmiDevBusyPercentGet(dvInd)
+	r.record("RsmiDevBusyPercentGet", []any{dvInd}, []any{busyPercent}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCounter, count int) (timestamp int64, err error) {
+	args := []any{dvInd, append([]RSMIUtilizationCounter(nil), utilizationCounters...), count}
+	timestamp, err = r.backend.RsmiUtilizationCountGet(dvInd, utilizationCounters, count)
+	// utilizationCounters 既是输入也是输出，调用后的值作为结果记录
+	r.record("RsmiUtilizationCountGet", args, []any{timestamp, utilizationCounters}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPerfLevelGet(dvInd int) (perf RSMIDevPerfLevel, err error) {
+	perf, err = r.backend.RsmiDevPerfLevelGet(dvInd)
+	r.record("RsmiDevPerfLevelGet", []any{dvInd}, []any{perf}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiPerfDeterminismModeSet(dvInd int, clkValue int64) (err error) {
+	err = r.backend.RsmiPerfDeterminismModeSet(dvInd, clkValue)
+	r.record("RsmiPerfDeterminismModeSet", []any{dvInd, clkValue}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevOverdriveLevelGet(dvInd int) (od int, err error) {
+	od, err = r.backend.RsmiDevOverdriveLevelGet(dvInd)
+	r.record("RsmiDevOverdriveLevelGet", []any{dvInd}, []any{od}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequencies, err error) {
+	frequencies, err = r.backend.RsmiDevGpuClkFreqGet(dvInd, clkType)
+	r.record("RsmiDevGpuClkFreqGet", []any{dvInd, clkType}, []any{frequencies}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error) {
+	odv, err = r.backend.RsmiDevOdVoltInfoGet(dvInd)
+	r.record("RsmiDevOdVoltInfoGet", []any{dvInd}, []any{odv}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error) {
+	gpuMetrics, err = r.backend.RsmiDevGpuMetricsInfoGet(dvInd)
+	r.record("RsmiDevGpuMetricsInfoGet", []any{dvInd}, []any{gpuMetrics}, err)
+	return
+}
+
+func (r *RecordingBackend) GpuMetricsTableGet(dvInd int) (table []byte, err error) {
+	table, err = r.backend.GpuMetricsTableGet(dvInd)
+	r.record("GpuMetricsTableGet", []any{dvInd}, []any{table}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevEccStatusGet(dvInd int, block RSMIGpuBlock) (state RSMIRasErrState, err error) {
+	state, err = r.backend.RsmiDevEccStatusGet(dvInd, block)
+	r.record("RsmiDevEccStatusGet", []any{dvInd, block}, []any{state}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorCount, err error) {
+	errorCount, err = r.backend.RsmiDevEccCountGet(dvInd, gpuBlock)
+	r.record("RsmiDevEccCountGet", []any{dvInd, gpuBlock}, []any{errorCount}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevEccEnabledGet(dvInd int) (enabledBlocks int64, err error) {
+	enabledBlocks, err = r.backend.RsmiDevEccEnabledGet(dvInd)
+	r.record("RsmiDevEccEnabledGet", []any{dvInd}, []any{enabledBlocks}, err)
+	return
+}
+
+/****************************************** 控制与计数器 *********************************************/
+
+func (r *RecordingBackend) RsmiDevPerfLevelSet(dvInd int, devPerfLevel RSMIDevPerfLevel) (err error) {
+	err = r.backend.RsmiDevPerfLevelSet(dvInd, devPerfLevel)
+	r.record("RsmiDevPerfLevelSet", []any{dvInd, devPerfLevel}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevClkRangeSet(dvInd int, minClkValue, maxClkValue int64, clkType RSMIClkType) (err error) {
+	err = r.backend.RsmiDevClkRangeSet(dvInd, minClkValue, maxClkValue, clkType)
+	r.record("RsmiDevClkRangeSet", []any{dvInd, minClkValue, maxClkValue, clkType}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevOdVoltInfoSet(dvInd, vPoint, clkValue, voltValue int) (err error) {
+	err = r.backend.RsmiDevOdVoltInfoSet(dvInd, vPoint, clkValue, voltValue)
+	r.record("RsmiDevOdVoltInfoSet", []any{dvInd, vPoint, clkValue, voltValue}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevOverdriveLevelSet(dvInd, od int) (err error) {
+	err = r.backend.RsmiDevOverdriveLevelSet(dvInd, od)
+	r.record("RsmiDevOverdriveLevelSet", []any{dvInd, od}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevGpuClkFreqSet(dvInd int, clkType RSMIClkType, freqBitmask int64) (err error) {
+	err = r.backend.RsmiDevGpuClkFreqSet(dvInd, clkType, freqBitmask)
+	r.record("RsmiDevGpuClkFreqSet", []any{dvInd, clkType, freqBitmask}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevCounterGroupSupported(dvInd int, group RSMIEventGroup) (err error) {
+	err = r.backend.RsmiDevCounterGroupSupported(dvInd, group)
+	r.record("RsmiDevCounterGroupSupported", []any{dvInd, group}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevCounterCreate(dvInd int, eventType RSMIEventType) (eventHandle EventHandle, err error) {
+	eventHandle, err = r.backend.RsmiDevCounterCreate(dvInd, eventType)
+	r.record("RsmiDevCounterCreate", []any{dvInd, eventType}, []any{eventHandle}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevCounterDestroy(handle EventHandle) (err error) {
+	err = r.backend.RsmiDevCounterDestroy(handle)
+	r.record("RsmiDevCounterDestroy", []any{handle}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiCounterControl(evtHandle EventHandle, cmd RSMICounterCommand) (err error) {
+	err = r.backend.RsmiCounterControl(evtHandle, cmd)
+	r.record("RsmiCounterControl", []any{evtHandle, cmd}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiCounterRead(handle EventHandle) (counterValue RSMICounterValue, err error) {
+	counterValue, err = r.backend.RsmiCounterRead(handle)
+	r.record("RsmiCounterRead", []any{handle}, []any{counterValue}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiCounterAvailableCountersGet(dvInd int, group RSMIEventGroup) (availAble int, err error) {
+	availAble, err = r.backend.RsmiCounterAvailableCountersGet(dvInd, group)
+	r.record("RsmiCounterAvailableCountersGet", []any{dvInd, group}, []any{availAble}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevFanReset(dvInd, sensorInd int) (err error) {
+	err = r.backend.RsmiDevFanReset(dvInd, sensorInd)
+	r.record("RsmiDevFanReset", []any{dvInd, sensorInd}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevPowerProfileSet(dvInd int, reserved int, profile RSNIPowerProfilePresetMasks) (err error) {
+	err = r.backend.RsmiDevPowerProfileSet(dvInd, reserved, profile)
+	r.record("RsmiDevPowerProfileSet", []any{dvInd, reserved, profile}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevXgmiErrorReset(dvInd int) (err error) {
+	err = r.backend.RsmiDevXgmiErrorReset(dvInd)
+	r.record("RsmiDevXgmiErrorReset", []any{dvInd}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevXGMIErrorStatus(dvInd int) (status RSMIXGMIStatus, err error) {
+	status, err = r.backend.RsmiDevXGMIErrorStatus(dvInd)
+	r.record("RsmiDevXGMIErrorStatus", []any{dvInd}, []any{status}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevXgmiHiveIdGet(dvInd int) (hiveId int64, err error) {
+	hiveId, err = r.backend.RsmiDevXgmiHiveIdGet(dvInd)
+	r.record("RsmiDevXgmiHiveIdGet", []any{dvInd}, []any{hiveId}, err)
+	return
+}
+
+/****************************************** 进程与事件通知 *********************************************/
+
+func (r *RecordingBackend) RsmiComputeProcessInfoGet() (processInfo []RSMIProcessInfo, numItems int, err error) {
+	processInfo, numItems, err = r.backend.RsmiComputeProcessInfoGet()
+	r.record("RsmiComputeProcessInfoGet", []any{}, []any{processInfo, numItems}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiComputeProcessInfoByPidGet(pid int) (proc RSMIProcessInfo, err error) {
+	proc, err = r.backend.RsmiComputeProcessInfoByPidGet(pid)
+	r.record("RsmiComputeProcessInfoByPidGet", []any{pid}, []any{proc}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiComputeProcessGpusGet(pid int) (dvIndices []int, err error) {
+	dvIndices, err = r.backend.RsmiComputeProcessGpusGet(pid)
+	r.record("RsmiComputeProcessGpusGet", []any{pid}, []any{dvIndices}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevSupportedFuncIteratorOpen(dvInd int) (iterHandle RSMIFuncIDIterHandle, err error) {
+	iterHandle, err = r.backend.RsmiDevSupportedFuncIteratorOpen(dvInd)
+	r.record("RsmiDevSupportedFuncIteratorOpen", []any{dvInd}, []any{nil}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevSupportedVariantIteratorOpen(iterHandle RSMIFuncIDIterHandle) (handle RSMIFuncIDIterHandle, err error) {
+	handle, err = r.backend.RsmiDevSupportedVariantIteratorOpen(iterHandle)
+	r.record("RsmiDevSupportedVariantIteratorOpen", []any{nil}, []any{nil}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiFuncIterNext(handle RSMIFuncIDIterHandle) (err error) {
+	err = r.backend.RsmiFuncIterNext(handle)
+	r.record("RsmiFuncIterNext", []any{nil}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiDevSupportedFuncIteratorClose(handle RSMIFuncIDIterHandle) (err error) {
+	err = r.backend.RsmiDevSupportedFuncIteratorClose(handle)
+	r.record("RsmiDevSupportedFuncIteratorClose", []any{nil}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiEventNotificationInit(deInd int) (err error) {
+	err = r.backend.RsmiEventNotificationInit(deInd)
+	r.record("RsmiEventNotificationInit", []any{deInd}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiEventNotificationMaskSet(dvInd int, mask int64) (err error) {
+	err = r.backend.RsmiEventNotificationMaskSet(dvInd, mask)
+	r.record("RsmiEventNotificationMaskSet", []any{dvInd, mask}, []any{}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiEventNotificationGet(timeoutMs int) (numElem int, datas []RSMIEEvtNotificationData, err error) {
+	numElem, datas, err = r.backend.RsmiEventNotificationGet(timeoutMs)
+	r.record("RsmiEventNotificationGet", []any{timeoutMs}, []any{numElem, datas}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiEventNotificationStop(dvInd int) (err error) {
+	err = r.backend.RsmiEventNotificationStop(dvInd)
+	r.record("RsmiEventNotificationStop", []any{dvInd}, []any{}, err)
+	return
+}
+
+/****************************************** 拓扑 *********************************************/
+
+func (r *RecordingBackend) RsmiTopoGetLinkWeight(dvIndSrc, dvIndDst int) (weight int64, err error) {
+	weight, err = r.backend.RsmiTopoGetLinkWeight(dvIndSrc, dvIndDst)
+	r.record("RsmiTopoGetLinkWeight", []any{dvIndSrc, dvIndDst}, []any{weight}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiTopoGetLinkType(dvIndSrc, dvIndDst int) (hops int64, linkType RSMIIOLinkType, err error) {
+	hops, linkType, err = r.backend.RsmiTopoGetLinkType(dvIndSrc, dvIndDst)
+	r.record("RsmiTopoGetLinkType", []any{dvIndSrc, dvIndDst}, []any{hops, linkType}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiTopoGetNumaBodeBumber(dvInd int) (numaNode int, err error) {
+	numaNode, err = r.backend.RsmiTopoGetNumaBodeBumber(dvInd)
+	r.record("RsmiTopoGetNumaBodeBumber", []any{dvInd}, []any{numaNode}, err)
+	return
+}
+
+func (r *RecordingBackend) RsmiMinmaxBandwidthGet(dvIndSrc, dvIndDst int) (minBandwidth, maxBandwidth int64, err error) {
+	minBandwidth, maxBandwidth, err = r.backend.RsmiMinmaxBandwidthGet(dvIndSrc, dvIndDst)
+	r.record("RsmiMinmaxBandwidthGet", []any{dvIndSrc, dvIndDst}, []any{minBandwidth, maxBandwidth}, err)
+	return
+}
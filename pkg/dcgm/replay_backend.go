@@ -0,0 +1,679 @@
+package dcgm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// BackendReplay 重放录制文件的后端，配置为录制文件路径
+	BackendReplay = "replay"
+)
+
+// ErrNoRecording 录制文件中没有与本次调用参数相同的记录
+var ErrNoRecording = errors.New("no recorded call")
+
+// ErrEndOfRecording 与本次调用参数相同的记录已全部重放
+var ErrEndOfRecording = errors.New("end of recording")
+
+func init() {
+	RegisterBackend(BackendReplay, func(config string) (Backend, error) {
+		return NewReplayBackendFromFile(config)
+	})
+}
+
+// replayError 重放录制的错误，保留原错误信息以及是否为 ErrNotSupported
+type replayError struct {
+	msg          string
+	notSupported bool
+}
+
+func (e *replayError) Error() string {
+	return e.msg
+}
+
+func (e *replayError) Is(target error) bool {
+	return e.notSupported && target == ErrNotSupported
+}
+
+// ReplayBackend 按录制文件返回结果的后端。调用按方法名和参数匹配记录，
+// 相同调用的多条记录按录制顺序各返回一次，用完后返回 ErrEndOfRecording；
+// 事件通知用完或没有录制时等待 timeoutMs 并返回空结果，与没有新事件时一致。
+type ReplayBackend struct {
+	mu      sync.Mutex
+	calls   map[string][]*RecordedCall
+	cursors map[string]int
+}
+
+// NewReplayBackend 从 JSON Lines 格式的录制内容创建重放后端
+func NewReplayBackend(rd io.Reader) (*ReplayBackend, error) {
+	r := &ReplayBackend{calls: map[string][]*RecordedCall{}, cursors: map[string]int{}}
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		call := &RecordedCall{}
+		if err := json.Unmarshal(data, call); err != nil {
+			return nil, fmt.Errorf("Error parse recording line %d:%s", line, err)
+		}
+		key := replayKey(call.Func, call.Args)
+		r.calls[key] = append(r.calls[key], call)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Error read recording:%s", err)
+	}
+	return r, nil
+}
+
+// NewReplayBackendFromFile 从录制文件创建重放后端
+func NewReplayBackendFromFile(path string) (*ReplayBackend, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error open recording file:%s", err)
+	}
+	defer f.Close()
+	return NewReplayBackend(f)
+}
+
+// Reset 把所有调用的重放位置恢复到第一条记录
+func (r *ReplayBackend) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cursors = map[string]int{}
+}
+
+// replayKey 方法名加上紧凑化后的参数，作为匹配记录的键
+func replayKey(fn string, args []json.RawMessage) string {
+	var buf bytes.Buffer
+	buf.WriteString(fn)
+	for _, arg := range args {
+		buf.WriteByte('|')
+		if err := json.Compact(&buf, arg); err != nil {
+			buf.Write(arg)
+		}
+	}
+	return buf.String()
+}
+
+// replay 查找与本次调用匹配的记录，把返回值解码到 outs 中并返回录制的错误
+func (r *ReplayBackend) replay(fn string, args []any, outs ...any) error {
+	key := replayKey(fn, marshalValues(args))
+	r.mu.Lock()
+	calls := r.calls[key]
+	if len(calls) == 0 {
+		r.mu.Unlock()
+		return fmt.Errorf("Error %s:%w for args %s", fn, ErrNoRecording, key[len(fn):])
+	}
+	cursor := r.cursors[key]
+	if cursor >= len(calls) {
+		r.mu.Unlock()
+		return fmt.Errorf("Error %s:%w", fn, ErrEndOfRecording)
+	}
+	r.cursors[key] = cursor + 1
+	call := calls[cursor]
+	r.mu.Unlock()
+
+	for i, out := range outs {
+		if i >= len(call.Results) {
+			break
+		}
+		if err := json.Unmarshal(call.Results[i], out); err != nil {
+			return fmt.Errorf("Error %s:decode recorded result %d:%s", fn, i, err)
+		}
+	}
+	if call.Err != "" {
+		return &replayError{msg: call.Err, notSupported: call.NotSupported}
+	}
+	return nil
+}
+
+func (r *ReplayBackend) ProbeDeviceCount() int {
+	var v int
+	r.replay("ProbeDeviceCount", []any{}, &v)
+	return v
+}
+
+/****************************************** 初始化与关闭 *********************************************/
+
+func (r *ReplayBackend) RsmiInit() (err error) {
+	err = r.replay("RsmiInit", []any{})
+	return
+}
+
+func (r *ReplayBackend) RsmiShutdown() (err error) {
+	err = r.replay("RsmiShutdown", []any{})
+	return
+}
+
+/****************************************** 设备信息、PCIe、功耗与内存 *********************************************/
+
+func (r *ReplayBackend) RsmiNumMonitorDevices() (gpuNum int, err error) {
+	err = r.replay("RsmiNumMonitorDevices", []any{}, &gpuNum)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevSkuGet(dvInd int) (sku int, err error) {
+	err = r.replay("RsmiDevSkuGet", []any{dvInd}, &sku)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevVendorIdGet(dvInd int) uint {
+	var v uint
+	r.replay("RsmiDevVendorIdGet", []any{dvInd}, &v)
+	return v
+}
+
+func (r *ReplayBackend) RsmiDevIdGet(dvInd int) (id int, err error) {
+	err = r.replay("RsmiDevIdGet", []any{dvInd}, &id)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevNameGet(dvInd int) (nameStr string, err error) {
+	err = r.replay("RsmiDevNameGet", []any{dvInd}, &nameStr)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevBrandGet(dvInd int) (brand string, err error) {
+	err = r.replay("RsmiDevBrandGet", []any{dvInd}, &brand)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevVendorNameGet(dvInd int) (bname string, err error) {
+	err = r.replay("RsmiDevVendorNameGet", []any{dvInd}, &bname)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevVramVendorGet(dvInd int) (result string, err error) {
+	err = r.replay("RsmiDevVramVendorGet", []any{dvInd}, &result)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevSerialNumberGet(dvInd int) (serialNumber string, err error) {
+	err = r.replay("RsmiDevSerialNumberGet", []any{dvInd}, &serialNumber)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevSubsystemIdGet(dvInd int) int {
+	var v int
+	r.replay("RsmiDevSubsystemIdGet", []any{dvInd}, &v)
+	return v
+}
+
+func (r *ReplayBackend) RsmiDevSubsystemNameGet(dvInd int) (subSystemName string, err error) {
+	err = r.replay("RsmiDevSubsystemNameGet", []any{dvInd}, &subSystemName)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevDrmRenderMinorGet(dvInd int) int {
+	var v int
+	r.replay("RsmiDevDrmRenderMinorGet", []any{dvInd}, &v)
+	return v
+}
+
+func (r *ReplayBackend) RsmiDevUniqueIdGet(dvInd int) (uniqueId int64, err error) {
+	err = r.replay("RsmiDevUniqueIdGet", []any{dvInd}, &uniqueId)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevSubsystemVendorIdGet(dvInd int) int {
+	var v int
+	r.replay("RsmiDevSubsystemVendorIdGet", []any{dvInd}, &v)
+	return v
+}
+
+func (r *ReplayBackend) RsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err error) {
+	err = r.replay("RsmiDevPciBandwidthGet", []any{dvInd}, &rsmiPcieBandwidth)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPciIdGet(dvInd int) (bdfid int64, err error) {
+	err = r.replay("RsmiDevPciIdGet", []any{dvInd}, &bdfid)
+	return
+}
+
+func (r *ReplayBackend) RsmiTopoNumaAffinityGet(dvInd int) (namaNode int, err error) {
+	err = r.replay("RsmiTopoNumaAffinityGet", []any{dvInd}, &namaNode)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz int64, err error) {
+	err = r.replay("RsmiDevPciThroughputGet", []any{dvInd}, &sent, &received, &maxPktSz)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPciReplayCounterGet(dvInd int) (counter int64, err error) {
+	err = r.replay("RsmiDevPciReplayCounterGet", []any{dvInd}, &counter)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPciBandwidthSet(dvInd int, bwBitmask int64) (err error) {
+	err = r.replay("RsmiDevPciBandwidthSet", []any{dvInd, bwBitmask})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPowerAveGet(dvInd int, senserId int) (power int64, err error) {
+	err = r.replay("RsmiDevPowerAveGet", []any{dvInd, senserId}, &power)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32, timestamp uint64, err error) {
+	err = r.replay("RsmiDevEnergyCountGet", []any{dvInd}, &power, &counterResolution, &timestamp)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPowerCapGet(dvInd int, senserId int) (power int64, err error) {
+	err = r.replay("RsmiDevPowerCapGet", []any{dvInd, senserId}, &power)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error) {
+	err = r.replay("RsmiDevPowerCapRangeGet", []any{dvInd, senserId}, &max, &min)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevMemoryTotalGet(dvInd int, memoryType RSMIMemoryType) (total int64, err error) {
+	err = r.replay("RsmiDevMemoryTotalGet", []any{dvInd, memoryType}, &total)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevMemoryUsageGet(dvInd int, memoryType RSMIMemoryType) (used int64, err error) {
+	err = r.replay("RsmiDevMemoryUsageGet", []any{dvInd, memoryType}, &used)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevMemoryBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	err = r.replay("RsmiDevMemoryBusyPercentGet", []any{dvInd}, &busyPercent)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetiredPageRecord, err error) {
+	err = r.replay("RsmiDevMemoryReservedPagesGet", []any{dvInd}, &numPages, &records)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevFanRpmsGet(dvInd, sensorInd int) (speed int64, err error) {
+	err = r.replay("RsmiDevFanRpmsGet", []any{dvInd, sensorInd}, &speed)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevFanSpeedGet(dvInd, sensorInd int) (speed int64, err error) {
+	err = r.replay("RsmiDevFanSpeedGet", []any{dvInd, sensorInd}, &speed)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevFanSpeedMaxGet(dvInd, sensorInd int) (maxSpeed int64, err error) {
+	err = r.replay("RsmiDevFanSpeedMaxGet", []any{dvInd, sensorInd}, &maxSpeed)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreqVoltRegion, err error) {
+	err = r.replay("RsmiDevOdVoltCurveRegionsGet", []any{dvInd}, &numRegions, &regions)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSMPowerProfileStatus, err error) {
+	err = r.replay("RsmiDevPowerProfilePresetsGet", []any{dvInd, sensorInd}, &powerProfileStatus)
+	return
+}
+
+func (r *ReplayBackend) RsmiVersionGet() (version RSMIVersion, err error) {
+	err = r.replay("RsmiVersionGet", []any{}, &version)
+	return
+}
+
+func (r *ReplayBackend) RsmiVersionStrGet(component RSMISwComponent, len int) (varStr string, err error) {
+	err = r.replay("RsmiVersionStrGet", []any{component, len}, &varStr)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevVbiosVersionGet(dvInd, len int) (vbios string, err error) {
+	err = r.replay("RsmiDevVbiosVersionGet", []any{dvInd, len}, &vbios)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64, err error) {
+	err = r.replay("RsmiDevFirmwareVersionGet", []any{dvInd, fwBlock}, &fwVersion)
+	return
+}
+
+/****************************************** vDCU (libhydmi) *********************************************/
+
+func (r *ReplayBackend) DmiGetDeviceCount() (count int, err error) {
+	err = r.replay("DmiGetDeviceCount", []any{}, &count)
+	return
+}
+
+func (r *ReplayBackend) DmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error) {
+	err = r.replay("DmiGetDeviceInfo", []any{dvInd}, &deviceInfo)
+	return
+}
+
+func (r *ReplayBackend) DmiGetMaxVDeviceCount() (count int, err error) {
+	err = r.replay("DmiGetMaxVDeviceCount", []any{}, &count)
+	return
+}
+
+func (r *ReplayBackend) DmiGetVDeviceCount() (count int, err error) {
+	err = r.replay("DmiGetVDeviceCount", []any{}, &count)
+	return
+}
+
+func (r *ReplayBackend) DmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
+	err = r.replay("DmiGetVDeviceInfo", []any{vDvInd}, &vDeviceInfo)
+	return
+}
+
+func (r *ReplayBackend) DmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error) {
+	err = r.replay("DmiGetDeviceRemainingInfo", []any{dvInd}, &cus, &memories)
+	return
+}
+
+func (r *ReplayBackend) DmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []int) (vdevIDs []int, err error) {
+	err = r.replay("DmiCreateVDevices", []any{dvInd, vDevCount, vDevCUs, vDevMemSize}, &vdevIDs)
+	return
+}
+
+func (r *ReplayBackend) DmiDestroyVDevices(dvInd int) (err error) {
+	err = r.replay("DmiDestroyVDevices", []any{dvInd})
+	return
+}
+
+func (r *ReplayBackend) DmiDestroySingleVDevice(vDvInd int) (err error) {
+	err = r.replay("DmiDestroySingleVDevice", []any{vDvInd})
+	return
+}
+
+func (r *ReplayBackend) DmiUpdateSingleVDevice(vDvInd int, vDevCUs int, vDevMemSize int) (err error) {
+	err = r.replay("DmiUpdateSingleVDevice", []any{vDvInd, vDevCUs, vDevMemSize})
+	return
+}
+
+func (r *ReplayBackend) DmiStartVDevice(vDvInd int) (err error) {
+	err = r.replay("DmiStartVDevice", []any{vDvInd})
+	return
+}
+
+func (r *ReplayBackend) DmiStopVDevice(vDvInd int) (err error) {
+	err = r.replay("DmiStopVDevice", []any{vDvInd})
+	return
+}
+
+func (r *ReplayBackend) DmiGetDevBusyPercent(dvInd int) (percent int, err error) {
+	err = r.replay("DmiGetDevBusyPercent", []any{dvInd}, &percent)
+	return
+}
+
+func (r *ReplayBackend) DmiGetVDevBusyPercent(vDvInd int) (percent int, err error) {
+	err = r.replay("DmiGetVDevBusyPercent", []any{vDvInd}, &percent)
+	return
+}
+
+func (r *ReplayBackend) DmiSetEncryptionVMStatus(status bool) (err error) {
+	err = r.replay("DmiSetEncryptionVMStatus", []any{status})
+	return
+}
+
+func (r *ReplayBackend) DmiGetEncryptionVMStatus() (status bool, err error) {
+	err = r.replay("DmiGetEncryptionVMStatus", []any{}, &status)
+	return
+}
+
+/****************************************** 设备状态 *********************************************/
+
+func (r *ReplayBackend) RsmiDevTempMetricGet(dvInd int, sensorType int, metric RSMITemperatureMetric) (temp int64, err error) {
+	err = r.replay("RsmiDevTempMetricGet", []any{dvInd, sensorType, metric}, &temp)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevVoltMetricGet(dvInd int, voltageType RSMIVoltageType, metric RSMIVoltageMetric) int64 {
+	var v int64
+	r.replay("RsmiDevVoltMetricGet", []any{dvInd, voltageType, metric}, &v)
+	return v
+}
+
+func (r *ReplayBackend) RsmiDevFanSpeedSet(dvInd, sensorInd int, speed int64) (err error) {
+	err = r.replay("RsmiDevFanSpeedSet", []any{dvInd, sensorInd, speed})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	err = r.replay("RsmiDevBusyPercentGet", []any{dvInd}, &busyPercent)
+	return
+}
+
+func (r *ReplayBackend) RsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCounter, count int) (timestamp int64, err error) {
+	var counters []RSMIUtilizationCounter
+	err = r.replay("RsmiUtilizationCountGet", []any{dvInd, utilizationCounters, count}, &timestamp, &counters)
+	copy(utilizationCounters, counters)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPerfLevelGet(dvInd int) (perf RSMIDevPerfLevel, err error) {
+	err = r.replay("RsmiDevPerfLevelGet", []any{dvInd}, &perf)
+	return
+}
+
+func (r *ReplayBackend) RsmiPerfDeterminismModeSet(dvInd int, clkValue int64) (err error) {
+	err = r.replay("RsmiPerfDeterminismModeSet", []any{dvInd, clkValue})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevOverdriveLevelGet(dvInd int) (od int, err error) {
+	err = r.replay("RsmiDevOverdriveLevelGet", []any{dvInd}, &od)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequencies, err error) {
+	err = r.replay("RsmiDevGpuClkFreqGet", []any{dvInd, clkType}, &frequencies)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error) {
+	err = r.replay("RsmiDevOdVoltInfoGet", []any{dvInd}, &odv)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error) {
+	err = r.replay("RsmiDevGpuMetricsInfoGet", []any{dvInd}, &gpuMetrics)
+	return
+}
+
+func (r *ReplayBackend) GpuMetricsTableGet(dvInd int) (table []byte, err error) {
+	err = r.replay("GpuMetricsTableGet", []any{dvInd}, &table)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevEccStatusGet(dvInd int, block RSMIGpuBlock) (state RSMIRasErrState, err error) {
+	err = r.replay("RsmiDevEccStatusGet", []any{dvInd, block}, &state)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorCount, err error) {
+	err = r.replay("RsmiDevEccCountGet", []any{dvInd, gpuBlock}, &errorCount)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevEccEnabledGet(dvInd int) (enabledBlocks int64, err error) {
+	err = r.replay("RsmiDevEccEnabledGet", []any{dvInd}, &enabledBlocks)
+	return
+}
+
+/****************************************** 控制与计数器 *********************************************/
+
+func (r *ReplayBackend) RsmiDevPerfLevelSet(dvInd int, devPerfLevel RSMIDevPerfLevel) (err error) {
+	err = r.replay("RsmiDevPerfLevelSet", []any{dvInd, devPerfLevel})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevClkRangeSet(dvInd int, minClkValue, maxClkValue int64, clkType RSMIClkType) (err error) {
+	err = r.replay("RsmiDevClkRangeSet", []any{dvInd, minClkValue, maxClkValue, clkType})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevOdVoltInfoSet(dvInd, vPoint, clkValue, voltValue int) (err error) {
+	err = r.replay("RsmiDevOdVoltInfoSet", []any{dvInd, vPoint, clkValue, voltValue})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevOverdriveLevelSet(dvInd, od int) (err error) {
+	err = r.replay("RsmiDevOverdriveLevelSet", []any{dvInd, od})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevGpuClkFreqSet(dvInd int, clkType RSMIClkType, freqBitmask int64) (err error) {
+	err = r.replay("RsmiDevGpuClkFreqSet", []any{dvInd, clkType, freqBitmask})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevCounterGroupSupported(dvInd int, group RSMIEventGroup) (err error) {
+	err = r.replay("RsmiDevCounterGroupSupported", []any{dvInd, group})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevCounterCreate(dvInd int, eventType RSMIEventType) (eventHandle EventHandle, err error) {
+	err = r.replay("RsmiDevCounterCreate", []any{dvInd, eventType}, &eventHandle)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevCounterDestroy(handle EventHandle) (err error) {
+	err = r.replay("RsmiDevCounterDestroy", []any{handle})
+	return
+}
+
+func (r *ReplayBackend) RsmiCounterControl(evtHandle EventHandle, cmd RSMICounterCommand) (err error) {
+	err = r.replay("RsmiCounterControl", []any{evtHandle, cmd})
+	return
+}
+
+func (r *ReplayBackend) RsmiCounterRead(handle EventHandle) (counterValue RSMICounterValue, err error) {
+	err = r.replay("RsmiCounterRead", []any{handle}, &counterValue)
+	return
+}
+
+func (r *ReplayBackend) RsmiCounterAvailableCountersGet(dvInd int, group RSMIEventGroup) (availAble int, err error) {
+	err = r.replay("RsmiCounterAvailableCountersGet", []any{dvInd, group}, &availAble)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevFanReset(dvInd, sensorInd int) (err error) {
+	err = r.replay("RsmiDevFanReset", []any{dvInd, sensorInd})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevPowerProfileSet(dvInd int, reserved int, profile RSNIPowerProfilePresetMasks) (err error) {
+	err = r.replay("RsmiDevPowerProfileSet", []any{dvInd, reserved, profile})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevXgmiErrorReset(dvInd int) (err error) {
+	err = r.replay("RsmiDevXgmiErrorReset", []any{dvInd})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevXGMIErrorStatus(dvInd int) (status RSMIXGMIStatus, err error) {
+	err = r.replay("RsmiDevXGMIErrorStatus", []any{dvInd}, &status)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevXgmiHiveIdGet(dvInd int) (hiveId int64, err error) {
+	err = r.replay("RsmiDevXgmiHiveIdGet", []any{dvInd}, &hiveId)
+	return
+}
+
+/****************************************** 进程与事件通知 *********************************************/
+
+func (r *ReplayBackend) RsmiComputeProcessInfoGet() (processInfo []RSMIProcessInfo, numItems int, err error) {
+	err = r.replay("RsmiComputeProcessInfoGet", []any{}, &processInfo, &numItems)
+	return
+}
+
+func (r *ReplayBackend) RsmiComputeProcessInfoByPidGet(pid int) (proc RSMIProcessInfo, err error) {
+	err = r.replay("RsmiComputeProcessInfoByPidGet", []any{pid}, &proc)
+	return
+}
+
+func (r *ReplayBackend) RsmiComputeProcessGpusGet(pid int) (dvIndices []int, err error) {
+	err = r.replay("RsmiComputeProcessGpusGet", []any{pid}, &dvIndices)
+	return
+}
+
+func (r *ReplayBackend) RsmiDevSupportedFuncIteratorOpen(dvInd int) (iterHandle RSMIFuncIDIterHandle, err error) {
+	err = r.replay("RsmiDevSupportedFuncIteratorOpen", []any{dvInd})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevSupportedVariantIteratorOpen(iterHandle RSMIFuncIDIterHandle) (handle RSMIFuncIDIterHandle, err error) {
+	err = r.replay("RsmiDevSupportedVariantIteratorOpen", []any{nil})
+	return
+}
+
+func (r *ReplayBackend) RsmiFuncIterNext(handle RSMIFuncIDIterHandle) (err error) {
+	err = r.replay("RsmiFuncIterNext", []any{nil})
+	return
+}
+
+func (r *ReplayBackend) RsmiDevSupportedFuncIteratorClose(handle RSMIFuncIDIterHandle) (err error) {
+	err = r.replay("RsmiDevSupportedFuncIteratorClose", []any{nil})
+	return
+}
+
+func (r *ReplayBackend) RsmiEventNotificationInit(deInd int) (err error) {
+	err = r.replay("RsmiEventNotificationInit", []any{deInd})
+	return
+}
+
+func (r *ReplayBackend) RsmiEventNotificationMaskSet(dvInd int, mask int64) (err error) {
+	err = r.replay("RsmiEventNotificationMaskSet", []any{dvInd, mask})
+	return
+}
+
+func (r *ReplayBackend) RsmiEventNotificationGet(timeoutMs int) (numElem int, datas []RSMIEEvtNotificationData, err error) {
+	err = r.replay("RsmiEventNotificationGet", []any{timeoutMs}, &numElem, &datas)
+	if errors.Is(err, ErrEndOfRecording) || errors.Is(err, ErrNoRecording) {
+		// 没有可返回的录制事件，按超时等待后报告没有新事件，避免收集循环空转
+		if timeoutMs > 0 {
+			time.Sleep(time.Duration(timeoutMs) * time.Millisecond)
+		}
+		return 0, nil, nil
+	}
+	return
+}
+
+func (r *ReplayBackend) RsmiEventNotificationStop(dvInd int) (err error) {
+	err = r.replay("RsmiEventNotificationStop", []any{dvInd})
+	return
+}
+
+/****************************************** 拓扑 *********************************************/
+
+func (r *ReplayBackend) RsmiTopoGetLinkWeight(dvIndSrc, dvIndDst int) (weight int64, err error) {
+	err = r.replay("RsmiTopoGetLinkWeight", []any{dvIndSrc, dvIndDst}, &weight)
+	return
+}
+
+func (r *ReplayBackend) RsmiTopoGetLinkType(dvIndSrc, dvIndDst int) (hops int64, linkType RSMIIOLinkType, err error) {
+	err = r.replay("RsmiTopoGetLinkType", []any{dvIndSrc, dvIndDst}, &hops, &linkType)
+	return
+}
+
+func (r *ReplayBackend) RsmiTopoGetNumaBodeBumber(dvInd int) (numaNode int, err error) {
+	err = r.replay("RsmiTopoGetNumaBodeBumber", []any{dvInd}, &numaNode)
+	return
+}
+
+func (r *ReplayBackend) RsmiMinmaxBandwidthGet(dvIndSrc, dvIndDst int) (minBandwidth, maxBandwidth int64, err error) {
+	err = r.replay("RsmiMinmaxBandwidthGet", []any{dvIndSrc, dvIndDst}, &minBandwidth, &maxBandwidth)
+	return
+}
@@ -0,0 +1,89 @@
+package dcgm
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// recordSession 在示例场景上采集一次指标并触发一批事件，返回录制内容与采集结果
+func recordSession(t *testing.T) ([]byte, []MonitorInfo, []RSMIEEvtNotificationData) {
+	t.Helper()
+	fake, err := NewFakeBackendFromFile(fakeScenarioPath)
+	if err != nil {
+		t.Fatalf("NewFakeBackendFromFile: %v", err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake.SetClock(func() time.Time { return now })
+	var buf bytes.Buffer
+	if err := InitWithBackend(NewRecordingBackend(fake, &buf)); err != nil {
+		t.Fatalf("InitWithBackend: %v", err)
+	}
+	defer ShutDown()
+	infos, err := CollectDeviceMetrics()
+	if err != nil {
+		t.Fatalf("CollectDeviceMetrics: %v", err)
+	}
+	// 场景第 15 秒设备 0 温度降频
+	if err := rsmiEventNotificationInit(0); err != nil {
+		t.Fatalf("rsmiEventNotificationInit: %v", err)
+	}
+	if err := rsmiEventNotificationMaskSet(0, eventMask(AllEventTypes)); err != nil {
+		t.Fatalf("rsmiEventNotificationMaskSet: %v", err)
+	}
+	now = now.Add(16 * time.Second)
+	_, events, err := rsmiEventNotificationGet(100)
+	if err != nil || len(events) == 0 {
+		t.Fatalf("rsmiEventNotificationGet = %v, %v, want the scenario event", events, err)
+	}
+	return buf.Bytes(), infos, events
+}
+
+func TestReplayRecordedSession(t *testing.T) {
+	recording, wantInfos, wantEvents := recordSession(t)
+
+	replay, err := NewReplayBackend(bytes.NewReader(recording))
+	if err != nil {
+		t.Fatalf("NewReplayBackend: %v", err)
+	}
+	if err := InitWithBackend(replay); err != nil {
+		t.Fatalf("InitWithBackend: %v", err)
+	}
+	t.Cleanup(func() { ShutDown() })
+	infos, err := CollectDeviceMetrics()
+	if err != nil {
+		t.Fatalf("replayed CollectDeviceMetrics: %v", err)
+	}
+	if !reflect.DeepEqual(infos, wantInfos) {
+		t.Errorf("replayed CollectDeviceMetrics =\n%+v\nwant\n%+v", infos, wantInfos)
+	}
+	rsmiEventNotificationInit(0)
+	rsmiEventNotificationMaskSet(0, eventMask(AllEventTypes))
+	_, events, err := rsmiEventNotificationGet(100)
+	if err != nil || !reflect.DeepEqual(events, wantEvents) {
+		t.Errorf("replayed rsmiEventNotificationGet = %v, %v, want %v", events, err, wantEvents)
+	}
+
+	// 每条记录只返回一次
+	if _, err := rsmiDevSerialNumberGet(0); !errors.Is(err, ErrEndOfRecording) {
+		t.Errorf("rsmiDevSerialNumberGet after the recording = %v, want ErrEndOfRecording", err)
+	}
+	if _, err := rsmiDevSerialNumberGet(7); !errors.Is(err, ErrNoRecording) {
+		t.Errorf("rsmiDevSerialNumberGet(7) = %v, want ErrNoRecording", err)
+	}
+	start := time.Now()
+	n, events, err := rsmiEventNotificationGet(100)
+	if err != nil || n != 0 || len(events) != 0 {
+		t.Errorf("rsmiEventNotificationGet after the recording = %d, %v, %v, want no events", n, events, err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("rsmiEventNotificationGet after the recording returned after %v, want the 100ms timeout", elapsed)
+	}
+
+	replay.Reset()
+	if serial, err := rsmiDevSerialNumberGet(0); err != nil || serial != wantInfos[0].DeviceId {
+		t.Errorf("rsmiDevSerialNumberGet after Reset = %q, %v, want %q", serial, err, wantInfos[0].DeviceId)
+	}
+}
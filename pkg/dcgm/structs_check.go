@@ -0,0 +1,244 @@
+package dcgm
+
+/*
+#cgo CFLAGS: -Wall -I./include
+#include <stdint.h>
+#include <kfd_ioctl.h>
+#include <rocm_smi64Config.h>
+#include <rocm_smi.h>
+#include <dmi_virtual.h>
+#include <dmi_error.h>
+#include <dmi.h>
+#include <dmi_mig.h>
+*/
+import "C"
+
+// 编译期校验 structs.go 中的常量与 C 头文件一致：两者不相等时数组长度不为 0（或常量溢出），编译失败
+var _ = [...][0]struct{}{
+	[int64(RSMI_PWR_PROF_PRST_CUSTOM_MASK) - int64(C.RSMI_PWR_PROF_PRST_CUSTOM_MASK)]struct{}{},
+	[int64(RSMI_PWR_PROF_PRST_VIDEO_MASK) - int64(C.RSMI_PWR_PROF_PRST_VIDEO_MASK)]struct{}{},
+	[int64(RSMI_PWR_PROF_PRST_POWER_SAVING_MASK) - int64(C.RSMI_PWR_PROF_PRST_POWER_SAVING_MASK)]struct{}{},
+	[int64(RSMI_PWR_PROF_PRST_COMPUTE_MASK) - int64(C.RSMI_PWR_PROF_PRST_COMPUTE_MASK)]struct{}{},
+	[int64(RSMI_PWR_PROF_PRST_VR_MASK) - int64(C.RSMI_PWR_PROF_PRST_VR_MASK)]struct{}{},
+	[int64(RSMI_PWR_PROF_PRST_3D_FULL_SCR_MASK) - int64(C.RSMI_PWR_PROF_PRST_3D_FULL_SCR_MASK)]struct{}{},
+	[int64(RSMI_PWR_PROF_PRST_BOOTUP_DEFAULT) - int64(C.RSMI_PWR_PROF_PRST_BOOTUP_DEFAULT)]struct{}{},
+	[int64(RSMI_PWR_PROF_PRST_INVALID) - int64(C.RSMI_PWR_PROF_PRST_INVALID)]struct{}{},
+	[int64(RSMI_MEM_PAGE_STATUS_RESERVED) - int64(C.RSMI_MEM_PAGE_STATUS_RESERVED)]struct{}{},
+	[int64(RSMI_MEM_PAGE_STATUS_PENDING) - int64(C.RSMI_MEM_PAGE_STATUS_PENDING)]struct{}{},
+	[int64(RSMI_MEM_PAGE_STATUS_UNRESERVABLE) - int64(C.RSMI_MEM_PAGE_STATUS_UNRESERVABLE)]struct{}{},
+	[int64(RSMI_TEMP_CURRENT) - int64(C.RSMI_TEMP_CURRENT)]struct{}{},
+	[int64(RSMI_TEMP_FIRST) - int64(C.RSMI_TEMP_FIRST)]struct{}{},
+	[int64(RSMI_TEMP_MAX) - int64(C.RSMI_TEMP_MAX)]struct{}{},
+	[int64(RSMI_TEMP_MIN) - int64(C.RSMI_TEMP_MIN)]struct{}{},
+	[int64(RSMI_TEMP_MAX_HYST) - int64(C.RSMI_TEMP_MAX_HYST)]struct{}{},
+	[int64(RSMI_TEMP_MIN_HYST) - int64(C.RSMI_TEMP_MIN_HYST)]struct{}{},
+	[int64(RSMI_TEMP_CRITICAL) - int64(C.RSMI_TEMP_CRITICAL)]struct{}{},
+	[int64(RSMI_TEMP_CRITICAL_HYST) - int64(C.RSMI_TEMP_CRITICAL_HYST)]struct{}{},
+	[int64(RSMI_TEMP_EMERGENCY) - int64(C.RSMI_TEMP_EMERGENCY)]struct{}{},
+	[int64(RSMI_TEMP_EMERGENCY_HYST) - int64(C.RSMI_TEMP_EMERGENCY_HYST)]struct{}{},
+	[int64(RSMI_TEMP_CRIT_MIN) - int64(C.RSMI_TEMP_CRIT_MIN)]struct{}{},
+	[int64(RSMI_TEMP_CRIT_MIN_HYST) - int64(C.RSMI_TEMP_CRIT_MIN_HYST)]struct{}{},
+	[int64(RSMI_TEMP_OFFSET) - int64(C.RSMI_TEMP_OFFSET)]struct{}{},
+	[int64(RSMI_TEMP_LOWEST) - int64(C.RSMI_TEMP_LOWEST)]struct{}{},
+	[int64(RSMI_TEMP_HIGHEST) - int64(C.RSMI_TEMP_HIGHEST)]struct{}{},
+	[int64(RSMI_TEMP_LAST) - int64(C.RSMI_TEMP_LAST)]struct{}{},
+	[int64(RSMI_VOLT_TYPE_FIRST) - int64(C.RSMI_VOLT_TYPE_FIRST)]struct{}{},
+	[int64(RSMI_VOLT_TYPE_VDDGFX) - int64(C.RSMI_VOLT_TYPE_VDDGFX)]struct{}{},
+	[int64(RSMI_VOLT_TYPE_LAST) - int64(C.RSMI_VOLT_TYPE_LAST)]struct{}{},
+	[int64(RSMI_VOLT_TYPE_INVALID) - int64(C.RSMI_VOLT_TYPE_INVALID)]struct{}{},
+	[int64(RSMI_VOLT_CURRENT) - int64(C.RSMI_VOLT_CURRENT)]struct{}{},
+	[int64(RSMI_VOLT_FIRST) - int64(C.RSMI_VOLT_FIRST)]struct{}{},
+	[int64(RSMI_VOLT_MAX) - int64(C.RSMI_VOLT_MAX)]struct{}{},
+	[int64(RSMI_VOLT_MIN_CRIT) - int64(C.RSMI_VOLT_MIN_CRIT)]struct{}{},
+	[int64(RSMI_VOLT_MIN) - int64(C.RSMI_VOLT_MIN)]struct{}{},
+	[int64(RSMI_VOLT_MAX_CRIT) - int64(C.RSMI_VOLT_MAX_CRIT)]struct{}{},
+	[int64(RSMI_VOLT_AVERAGE) - int64(C.RSMI_VOLT_AVERAGE)]struct{}{},
+	[int64(RSMI_VOLT_LOWEST) - int64(C.RSMI_VOLT_LOWEST)]struct{}{},
+	[int64(RSMI_VOLT_HIGHEST) - int64(C.RSMI_VOLT_HIGHEST)]struct{}{},
+	[int64(RSMI_UTILIZATION_COUNTER_FIRST) - int64(C.RSMI_UTILIZATION_COUNTER_FIRST)]struct{}{},
+	[int64(RSMI_COARSE_GRAIN_GFX_ACTIVITY) - int64(C.RSMI_COARSE_GRAIN_GFX_ACTIVITY)]struct{}{},
+	[int64(RSMI_COARSE_GRAIN_MEM_ACTIVITY) - int64(C.RSMI_COARSE_GRAIN_MEM_ACTIVITY)]struct{}{},
+	[int64(RSMI_UTILIZATION_COUNTER_LAST) - int64(C.RSMI_UTILIZATION_COUNTER_LAST)]struct{}{},
+	[int64(RSMI_CLK_TYPE_SYS) - int64(C.RSMI_CLK_TYPE_SYS)]struct{}{},
+	[int64(RSMI_CLK_TYPE_DF) - int64(C.RSMI_CLK_TYPE_DF)]struct{}{},
+	[int64(RSMI_CLK_TYPE_DCEF) - int64(C.RSMI_CLK_TYPE_DCEF)]struct{}{},
+	[int64(RSMI_CLK_TYPE_SOC) - int64(C.RSMI_CLK_TYPE_SOC)]struct{}{},
+	[int64(RSMI_CLK_TYPE_MEM) - int64(C.RSMI_CLK_TYPE_MEM)]struct{}{},
+	[int64(RSMI_CLK_TYPE_PCIE) - int64(C.RSMI_CLK_TYPE_PCIE)]struct{}{},
+	[int64(RSMI_CLK_INVALID) - int64(C.RSMI_CLK_INVALID)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_AUTO) - int64(C.RSMI_DEV_PERF_LEVEL_AUTO)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_FIRST) - int64(C.RSMI_DEV_PERF_LEVEL_FIRST)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_LOW) - int64(C.RSMI_DEV_PERF_LEVEL_LOW)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_HIGH) - int64(C.RSMI_DEV_PERF_LEVEL_HIGH)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_MANUAL) - int64(C.RSMI_DEV_PERF_LEVEL_MANUAL)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_STABLE_STD) - int64(C.RSMI_DEV_PERF_LEVEL_STABLE_STD)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_STABLE_PEAK) - int64(C.RSMI_DEV_PERF_LEVEL_STABLE_PEAK)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_STABLE_MIN_MCLK) - int64(C.RSMI_DEV_PERF_LEVEL_STABLE_MIN_MCLK)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_STABLE_MIN_SCLK) - int64(C.RSMI_DEV_PERF_LEVEL_STABLE_MIN_SCLK)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_DETERMINISM) - int64(C.RSMI_DEV_PERF_LEVEL_DETERMINISM)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_LAST) - int64(C.RSMI_DEV_PERF_LEVEL_LAST)]struct{}{},
+	[int64(RSMI_DEV_PERF_LEVEL_UNKNOWN) - int64(C.RSMI_DEV_PERF_LEVEL_UNKNOWN)]struct{}{},
+	[int64(RSMIPowerProfPrstCustomMask) - int64(C.RSMI_PWR_PROF_PRST_CUSTOM_MASK)]struct{}{},
+	[int64(RSMIPowerProfPrstVideoMask) - int64(C.RSMI_PWR_PROF_PRST_VIDEO_MASK)]struct{}{},
+	[int64(RSMIPowerProfPrstPowerSavingMask) - int64(C.RSMI_PWR_PROF_PRST_POWER_SAVING_MASK)]struct{}{},
+	[int64(RSMIPowerProfPrstComputeMask) - int64(C.RSMI_PWR_PROF_PRST_COMPUTE_MASK)]struct{}{},
+	[int64(RSMIPowerProfPrstVRMask) - int64(C.RSMI_PWR_PROF_PRST_VR_MASK)]struct{}{},
+	[int64(RSMIPowerProfPrst3DFullScrMask) - int64(C.RSMI_PWR_PROF_PRST_3D_FULL_SCR_MASK)]struct{}{},
+	[int64(RSMIPowerProfPrstBootupDefault) - int64(C.RSMI_PWR_PROF_PRST_BOOTUP_DEFAULT)]struct{}{},
+	[int64(RSMIPowerProfPrstLast) - int64(C.RSMI_PWR_PROF_PRST_LAST)]struct{}{},
+	[int64(RSMIPowerProfPrstInvalid) - int64(C.RSMI_PWR_PROF_PRST_INVALID)]struct{}{},
+	[int64(RSMISwCompFirst) - int64(C.RSMI_SW_COMP_FIRST)]struct{}{},
+	[int64(RSMISwCompDriver) - int64(C.RSMI_SW_COMP_DRIVER)]struct{}{},
+	[int64(RSMISwCompLast) - int64(C.RSMI_SW_COMP_LAST)]struct{}{},
+	[int64(RSMIFwBlockFirst) - int64(C.RSMI_FW_BLOCK_FIRST)]struct{}{},
+	[int64(RSMIFwBlockASD) - int64(C.RSMI_FW_BLOCK_ASD)]struct{}{},
+	[int64(RSMIFwBlockCE) - int64(C.RSMI_FW_BLOCK_CE)]struct{}{},
+	[int64(RSMIFwBlockDMCU) - int64(C.RSMI_FW_BLOCK_DMCU)]struct{}{},
+	[int64(RSMIFwBlockMC) - int64(C.RSMI_FW_BLOCK_MC)]struct{}{},
+	[int64(RSMIFwBlockME) - int64(C.RSMI_FW_BLOCK_ME)]struct{}{},
+	[int64(RSMIFwBlockMEC) - int64(C.RSMI_FW_BLOCK_MEC)]struct{}{},
+	[int64(RSMIFwBlockMEC2) - int64(C.RSMI_FW_BLOCK_MEC2)]struct{}{},
+	[int64(RSMIFwBlockPFP) - int64(C.RSMI_FW_BLOCK_PFP)]struct{}{},
+	[int64(RSMIFwBlockRLC) - int64(C.RSMI_FW_BLOCK_RLC)]struct{}{},
+	[int64(RSMIFwBlockRLC_SRLC) - int64(C.RSMI_FW_BLOCK_RLC_SRLC)]struct{}{},
+	[int64(RSMIFwBlockRLC_SRLG) - int64(C.RSMI_FW_BLOCK_RLC_SRLG)]struct{}{},
+	[int64(RSMIFwBlockRLC_SRLS) - int64(C.RSMI_FW_BLOCK_RLC_SRLS)]struct{}{},
+	[int64(RSMIFwBlockSDMA) - int64(C.RSMI_FW_BLOCK_SDMA)]struct{}{},
+	[int64(RSMIFwBlockSDMA2) - int64(C.RSMI_FW_BLOCK_SDMA2)]struct{}{},
+	[int64(RSMIFwBlockSMC) - int64(C.RSMI_FW_BLOCK_SMC)]struct{}{},
+	[int64(RSMIFwBlockSOS) - int64(C.RSMI_FW_BLOCK_SOS)]struct{}{},
+	[int64(RSMIFwBlockTA_RAS) - int64(C.RSMI_FW_BLOCK_TA_RAS)]struct{}{},
+	[int64(RSMIFwBlockTA_XGMI) - int64(C.RSMI_FW_BLOCK_TA_XGMI)]struct{}{},
+	[int64(RSMIFwBlockUVD) - int64(C.RSMI_FW_BLOCK_UVD)]struct{}{},
+	[int64(RSMIFwBlockVCE) - int64(C.RSMI_FW_BLOCK_VCE)]struct{}{},
+	[int64(RSMIFwBlockVCN) - int64(C.RSMI_FW_BLOCK_VCN)]struct{}{},
+	[int64(RSMIFwBlockLast) - int64(C.RSMI_FW_BLOCK_LAST)]struct{}{},
+	[int64(RSMIGpuBlockInvalid) - int64(C.RSMI_GPU_BLOCK_INVALID)]struct{}{},
+	[int64(RSMIGpuBlockFirst) - int64(C.RSMI_GPU_BLOCK_FIRST)]struct{}{},
+	[int64(RSMIGpuBlockUMC) - int64(C.RSMI_GPU_BLOCK_UMC)]struct{}{},
+	[int64(RSMIGpuBlockSDMA) - int64(C.RSMI_GPU_BLOCK_SDMA)]struct{}{},
+	[int64(RSMIGpuBlockGFX) - int64(C.RSMI_GPU_BLOCK_GFX)]struct{}{},
+	[int64(RSMIGpuBlockMMHUB) - int64(C.RSMI_GPU_BLOCK_MMHUB)]struct{}{},
+	[int64(RSMIGpuBlockATHUB) - int64(C.RSMI_GPU_BLOCK_ATHUB)]struct{}{},
+	[int64(RSMIGpuBlockPCIEBIF) - int64(C.RSMI_GPU_BLOCK_PCIE_BIF)]struct{}{},
+	[int64(RSMIGpuBlockHDP) - int64(C.RSMI_GPU_BLOCK_HDP)]struct{}{},
+	[int64(RSMIGpuBlockXGMIWAFL) - int64(C.RSMI_GPU_BLOCK_XGMI_WAFL)]struct{}{},
+	[int64(RSMIGpuBlockDF) - int64(C.RSMI_GPU_BLOCK_DF)]struct{}{},
+	[int64(RSMIGpuBlockSMN) - int64(C.RSMI_GPU_BLOCK_SMN)]struct{}{},
+	[int64(RSMIGpuBlockSEM) - int64(C.RSMI_GPU_BLOCK_SEM)]struct{}{},
+	[int64(RSMIGpuBlockMP0) - int64(C.RSMI_GPU_BLOCK_MP0)]struct{}{},
+	[int64(RSMIGpuBlockMP1) - int64(C.RSMI_GPU_BLOCK_MP1)]struct{}{},
+	[int64(RSMIGpuBlockFuse) - int64(C.RSMI_GPU_BLOCK_FUSE)]struct{}{},
+	[int64(RSMIGpuBlockMCA) - int64(C.RSMI_GPU_BLOCK_MCA)]struct{}{},
+	[int64(RSMIGpuBlockLast) - int64(C.RSMI_GPU_BLOCK_LAST)]struct{}{},
+	[int64(RSMIGpuBlockReserved) - int64(C.RSMI_GPU_BLOCK_RESERVED)]struct{}{},
+	[int64(RSMIRasErrStateNone) - int64(C.RSMI_RAS_ERR_STATE_NONE)]struct{}{},
+	[int64(RSMIRasErrStateDisabled) - int64(C.RSMI_RAS_ERR_STATE_DISABLED)]struct{}{},
+	[int64(RSMIRasErrStateParity) - int64(C.RSMI_RAS_ERR_STATE_PARITY)]struct{}{},
+	[int64(RSMIRasErrStateSingC) - int64(C.RSMI_RAS_ERR_STATE_SING_C)]struct{}{},
+	[int64(RSMIRasErrStateMultUC) - int64(C.RSMI_RAS_ERR_STATE_MULT_UC)]struct{}{},
+	[int64(RSMIRasErrStatePoison) - int64(C.RSMI_RAS_ERR_STATE_POISON)]struct{}{},
+	[int64(RSMIRasErrStateEnabled) - int64(C.RSMI_RAS_ERR_STATE_ENABLED)]struct{}{},
+	[int64(RSMIRasErrStateLast) - int64(C.RSMI_RAS_ERR_STATE_LAST)]struct{}{},
+	[int64(RSMIRasErrStateInvalid) - int64(C.RSMI_RAS_ERR_STATE_INVALID)]struct{}{},
+	[int64(RSMI_EVNT_GRP_XGMI) - int64(C.RSMI_EVNT_GRP_XGMI)]struct{}{},
+	[int64(RSMI_EVNT_GRP_XGMI_DATA_OUT) - int64(C.RSMI_EVNT_GRP_XGMI_DATA_OUT)]struct{}{},
+	[int64(RSMI_EVNT_GRP_INVALID) - int64(C.RSMI_EVNT_GRP_INVALID)]struct{}{},
+	[int64(RSMIEventFirst) - int64(C.RSMI_EVNT_FIRST)]struct{}{},
+	[int64(RSMIEventXGmiFirst) - int64(C.RSMI_EVNT_XGMI_FIRST)]struct{}{},
+	[int64(RSMIEventXGmi0NopTx) - int64(C.RSMI_EVNT_XGMI_0_NOP_TX)]struct{}{},
+	[int64(RSMIEventXGmi0RequestTx) - int64(C.RSMI_EVNT_XGMI_0_REQUEST_TX)]struct{}{},
+	[int64(RSMIEventXGmi0ResponseTx) - int64(C.RSMI_EVNT_XGMI_0_RESPONSE_TX)]struct{}{},
+	[int64(RSMIEventXGmi0BeatsTx) - int64(C.RSMI_EVNT_XGMI_0_BEATS_TX)]struct{}{},
+	[int64(RSMIEventXGmi1NopTx) - int64(C.RSMI_EVNT_XGMI_1_NOP_TX)]struct{}{},
+	[int64(RSMIEventXGmi1RequestTx) - int64(C.RSMI_EVNT_XGMI_1_REQUEST_TX)]struct{}{},
+	[int64(RSMIEventXGmi1ResponseTx) - int64(C.RSMI_EVNT_XGMI_1_RESPONSE_TX)]struct{}{},
+	[int64(RSMIEventXGmi1BeatsTx) - int64(C.RSMI_EVNT_XGMI_1_BEATS_TX)]struct{}{},
+	[int64(RSMIEventXGmiLast) - int64(C.RSMI_EVNT_XGMI_LAST)]struct{}{},
+	[int64(RSMIEventXGmiDataOutFirst) - int64(C.RSMI_EVNT_XGMI_DATA_OUT_FIRST)]struct{}{},
+	[int64(RSMIEventXGmiDataOut0) - int64(C.RSMI_EVNT_XGMI_DATA_OUT_0)]struct{}{},
+	[int64(RSMIEventXGmiDataOut1) - int64(C.RSMI_EVNT_XGMI_DATA_OUT_1)]struct{}{},
+	[int64(RSMIEventXGmiDataOut2) - int64(C.RSMI_EVNT_XGMI_DATA_OUT_2)]struct{}{},
+	[int64(RSMIEventXGmiDataOut3) - int64(C.RSMI_EVNT_XGMI_DATA_OUT_3)]struct{}{},
+	[int64(RSMIEventXGmiDataOut4) - int64(C.RSMI_EVNT_XGMI_DATA_OUT_4)]struct{}{},
+	[int64(RSMIEventXGmiDataOut5) - int64(C.RSMI_EVNT_XGMI_DATA_OUT_5)]struct{}{},
+	[int64(RSMIEventXGmiDataOutLast) - int64(C.RSMI_EVNT_XGMI_DATA_OUT_LAST)]struct{}{},
+	[int64(RSMIEventLast) - int64(C.RSMI_EVNT_LAST)]struct{}{},
+	[int64(RSMI_CNTR_CMD_START) - int64(C.RSMI_CNTR_CMD_START)]struct{}{},
+	[int64(RSMI_CNTR_CMD_STOP) - int64(C.RSMI_CNTR_CMD_STOP)]struct{}{},
+	[int64(RSMIXGMIStatusNoErrors) - int64(C.RSMI_XGMI_STATUS_NO_ERRORS)]struct{}{},
+	[int64(RSMIXGMIStatusError) - int64(C.RSMI_XGMI_STATUS_ERROR)]struct{}{},
+	[int64(RSMIXGMIStatusMultipleErrors) - int64(C.RSMI_XGMI_STATUS_MULTIPLE_ERRORS)]struct{}{},
+	[int64(RSMIIOLinkTypeUndefined) - int64(C.RSMI_IOLINK_TYPE_UNDEFINED)]struct{}{},
+	[int64(RSMIIOLinkTypePCIExpress) - int64(C.RSMI_IOLINK_TYPE_PCIEXPRESS)]struct{}{},
+	[int64(RSMIIOLinkTypeXGMI) - int64(C.RSMI_IOLINK_TYPE_XGMI)]struct{}{},
+	[int64(RSMIIOLinkTypeNumIOLinkTypes) - int64(C.RSMI_IOLINK_TYPE_NUMIOLINKTYPES)]struct{}{},
+	[int64(RSMIIOLinkTypeSize) - int64(C.RSMI_IOLINK_TYPE_SIZE)]struct{}{},
+	[int64(RSMI_MEM_TYPE_FIRST) - int64(C.RSMI_MEM_TYPE_FIRST)]struct{}{},
+	[int64(RSMI_MEM_TYPE_VRAM) - int64(C.RSMI_MEM_TYPE_VRAM)]struct{}{},
+	[int64(RSMI_MEM_TYPE_VIS_VRAM) - int64(C.RSMI_MEM_TYPE_VIS_VRAM)]struct{}{},
+	[int64(RSMI_MEM_TYPE_GTT) - int64(C.RSMI_MEM_TYPE_GTT)]struct{}{},
+	[int64(RSMI_MEM_TYPE_LAST) - int64(C.RSMI_MEM_TYPE_LAST)]struct{}{},
+	[int64(RSMI_EVT_NOTIF_VMFAULT) - int64(C.RSMI_EVT_NOTIF_VMFAULT)]struct{}{},
+	[int64(RSMI_EVT_NOTIF_FIRST) - int64(C.RSMI_EVT_NOTIF_FIRST)]struct{}{},
+	[int64(RSMI_EVT_NOTIF_THERMAL_THROTTLE) - int64(C.RSMI_EVT_NOTIF_THERMAL_THROTTLE)]struct{}{},
+	[int64(RSMI_EVT_NOTIF_GPU_PRE_RESET) - int64(C.RSMI_EVT_NOTIF_GPU_PRE_RESET)]struct{}{},
+	[int64(RSMI_EVT_NOTIF_GPU_POST_RESET) - int64(C.RSMI_EVT_NOTIF_GPU_POST_RESET)]struct{}{},
+	[int64(RSMI_EVT_NOTIF_LAST) - int64(C.RSMI_EVT_NOTIF_LAST)]struct{}{},
+	[int64(RSMI_STATUS_SUCCESS) - int64(C.RSMI_STATUS_SUCCESS)]struct{}{},
+	[int64(RSMI_STATUS_INVALID_ARGS) - int64(C.RSMI_STATUS_INVALID_ARGS)]struct{}{},
+	[int64(RSMI_STATUS_NOT_SUPPORTED) - int64(C.RSMI_STATUS_NOT_SUPPORTED)]struct{}{},
+	[int64(RSMI_STATUS_FILE_ERROR) - int64(C.RSMI_STATUS_FILE_ERROR)]struct{}{},
+	[int64(RSMI_STATUS_PERMISSION) - int64(C.RSMI_STATUS_PERMISSION)]struct{}{},
+	[int64(RSMI_STATUS_OUT_OF_RESOURCES) - int64(C.RSMI_STATUS_OUT_OF_RESOURCES)]struct{}{},
+	[int64(RSMI_STATUS_INTERNAL_EXCEPTION) - int64(C.RSMI_STATUS_INTERNAL_EXCEPTION)]struct{}{},
+	[int64(RSMI_STATUS_INPUT_OUT_OF_BOUNDS) - int64(C.RSMI_STATUS_INPUT_OUT_OF_BOUNDS)]struct{}{},
+	[int64(RSMI_STATUS_INIT_ERROR) - int64(C.RSMI_STATUS_INIT_ERROR)]struct{}{},
+	[int64(RSMI_INITIALIZATION_ERROR) - int64(C.RSMI_INITIALIZATION_ERROR)]struct{}{},
+	[int64(RSMI_STATUS_NOT_YET_IMPLEMENTED) - int64(C.RSMI_STATUS_NOT_YET_IMPLEMENTED)]struct{}{},
+	[int64(RSMI_STATUS_NOT_FOUND) - int64(C.RSMI_STATUS_NOT_FOUND)]struct{}{},
+	[int64(RSMI_STATUS_INSUFFICIENT_SIZE) - int64(C.RSMI_STATUS_INSUFFICIENT_SIZE)]struct{}{},
+	[int64(RSMI_STATUS_INTERRUPT) - int64(C.RSMI_STATUS_INTERRUPT)]struct{}{},
+	[int64(RSMI_STATUS_UNEXPECTED_SIZE) - int64(C.RSMI_STATUS_UNEXPECTED_SIZE)]struct{}{},
+	[int64(RSMI_STATUS_NO_DATA) - int64(C.RSMI_STATUS_NO_DATA)]struct{}{},
+	[int64(RSMI_STATUS_UNEXPECTED_DATA) - int64(C.RSMI_STATUS_UNEXPECTED_DATA)]struct{}{},
+	[int64(RSMI_STATUS_BUSY) - int64(C.RSMI_STATUS_BUSY)]struct{}{},
+	[int64(RSMI_STATUS_REFCOUNT_OVERFLOW) - int64(C.RSMI_STATUS_REFCOUNT_OVERFLOW)]struct{}{},
+	[int64(RSMI_STATUS_SETTING_UNAVAILABLE) - int64(C.RSMI_STATUS_SETTING_UNAVAILABLE)]struct{}{},
+	[int64(RSMI_STATUS_AMDGPU_RESTART_ERR) - int64(C.RSMI_STATUS_AMDGPU_RESTART_ERR)]struct{}{},
+	[int64(RSMI_STATUS_UNKNOWN_ERROR) - int64(C.RSMI_STATUS_UNKNOWN_ERROR)]struct{}{},
+	[int64(DMI_STATUS_SUCCESS) - int64(C.DMI_STATUS_SUCCESS)]struct{}{},
+	[int64(DMI_STATUS_ERROR) - int64(C.DMI_STATUS_ERROR)]struct{}{},
+	[int64(DMI_STATUS_NO_MEMORY) - int64(C.DMI_STATUS_NO_MEMORY)]struct{}{},
+	[int64(DMI_STATUS_OPEN_MKFD_FAILED) - int64(C.DMI_STATUS_OPEN_MKFD_FAILED)]struct{}{},
+	[int64(DMI_STATUS_MKFD_ALREADY_OPENED) - int64(C.DMI_STATUS_MKFD_ALREADY_OPENED)]struct{}{},
+	[int64(DMI_STATUS_SYS_NODE_NOT_EXIST) - int64(C.DMI_STATUS_SYS_NODE_NOT_EXIST)]struct{}{},
+	[int64(DMI_STATUS_NOT_SUPPORTED) - int64(C.DMI_STATUS_NOT_SUPPORTED)]struct{}{},
+	[int64(DMI_STATUS_MKFD_NOT_OPENED) - int64(C.DMI_STATUS_MKFD_NOT_OPENED)]struct{}{},
+	[int64(DMI_STATUS_CREATE_VDEV_FAILED) - int64(C.DMI_STATUS_CREATE_VDEV_FAILED)]struct{}{},
+	[int64(DMI_STATUS_DESTROY_VDEV_FAILED) - int64(C.DMI_STATUS_DESTROY_VDEV_FAILED)]struct{}{},
+	[int64(DMI_STATUS_INVALID_ARGUMENTS) - int64(C.DMI_STATUS_INVALID_ARGUMENTS)]struct{}{},
+	[int64(DMI_STATUS_OUT_OF_RESOURCES) - int64(C.DMI_STATUS_OUT_OF_RESOURCES)]struct{}{},
+	[int64(DMI_STATUS_QUERY_VDEV_INFO_FAILED) - int64(C.DMI_STATUS_QUERY_VDEV_INFO_FAILED)]struct{}{},
+	[int64(DMI_STATUS_ERROR_NOT_INITIALIZED) - int64(C.DMI_STATUS_ERROR_NOT_INITIALIZED)]struct{}{},
+	[int64(DMI_STATUS_DEVICE_NOT_SUPPORT) - int64(C.DMI_STATUS_DEVICE_NOT_SUPPORT)]struct{}{},
+	[int64(DMI_STATUS_VDEV_NOT_EXIST) - int64(C.DMI_STATUS_VDEV_NOT_EXIST)]struct{}{},
+	[int64(DMI_STATUS_INIT_DEVICE_FAILED) - int64(C.DMI_STATUS_INIT_DEVICE_FAILED)]struct{}{},
+	[int64(DMI_STATUS_DEVICE_BUSY) - int64(C.DMI_STATUS_DEVICE_BUSY)]struct{}{},
+	[int64(DMI_STATUS_FILE_ERROR) - int64(C.DMI_STATUS_FILE_ERROR)]struct{}{},
+	[int64(DMI_STATUS_PERMISSION) - int64(C.DMI_STATUS_PERMISSION)]struct{}{},
+	[int64(DMI_STATUS_INTERNAL_EXCEPTION) - int64(C.DMI_STATUS_INTERNAL_EXCEPTION)]struct{}{},
+	[int64(DMI_STATUS_INPUT_OUT_OF_BOUNDS) - int64(C.DMI_STATUS_INPUT_OUT_OF_BOUNDS)]struct{}{},
+	[int64(DMI_STATUS_SMI_INIT_ERROR) - int64(C.DMI_STATUS_SMI_INIT_ERROR)]struct{}{},
+	[int64(DMI_STATUS_NOT_FOUND) - int64(C.DMI_STATUS_NOT_FOUND)]struct{}{},
+	[int64(DMI_STATUS_INSUFFICIENT_SIZE) - int64(C.DMI_STATUS_INSUFFICIENT_SIZE)]struct{}{},
+	[int64(DMI_STATUS_INTERRUPT) - int64(C.DMI_STATUS_INTERRUPT)]struct{}{},
+	[int64(DMI_STATUS_UNEXPECTED_SIZE) - int64(C.DMI_STATUS_UNEXPECTED_SIZE)]struct{}{},
+	[int64(DMI_STATUS_NO_DATA) - int64(C.DMI_STATUS_NO_DATA)]struct{}{},
+	[int64(DMI_STATUS_UNEXPECTED_DATA) - int64(C.DMI_STATUS_UNEXPECTED_DATA)]struct{}{},
+	[int64(DMI_STATUS_SMI_BUSY) - int64(C.DMI_STATUS_SMI_BUSY)]struct{}{},
+	[int64(DMI_STATUS_REFCOUNT_OVERFLOW) - int64(C.DMI_STATUS_REFCOUNT_OVERFLOW)]struct{}{},
+	[int64(DMI_STATUS_NOT_YET_IMPLEMENTED) - int64(C.DMI_STATUS_NOT_YET_IMPLEMENTED)]struct{}{},
+	[int64(DMI_STATUS_UNKNOWN_ERROR) - int64(C.DMI_STATUS_UNKNOWN_ERROR)]struct{}{},
+	[int64(RSMI_VOLT_LAST) - int64(C.RSMI_VOLT_LAST)]struct{}{},
+}
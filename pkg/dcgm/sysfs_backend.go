@@ -0,0 +1,1073 @@
+package dcgm
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// BackendSysfs 直接读取 amdgpu 风格 sysfs 的后端，配置为 sysfs 根目录，默认 /sys
+	BackendSysfs = "sysfs"
+	// BackendAuto 优先使用 cgo 后端，rsmi 初始化失败时回退到 sysfs 后端
+	BackendAuto = "auto"
+
+	defaultSysfsRoot = "/sys"
+)
+
+func init() {
+	RegisterBackend(BackendSysfs, func(config string) (Backend, error) {
+		return NewSysfsBackend(config), nil
+	})
+	RegisterBackend(BackendAuto, func(config string) (Backend, error) {
+		cgo := newCgoBackend()
+		if err := cgo.RsmiInit(); err != nil {
+			glog.Warningf("rsmi unavailable, falling back to sysfs backend: %v", err)
+			return NewSysfsBackend(config), nil
+		}
+		cgo.RsmiShutdown()
+		return cgo, nil
+	})
+}
+
+var (
+	sysfsCardPattern = regexp.MustCompile(`^card[0-9]+$`)
+	sysfsDpmPattern  = regexp.MustCompile(`^\s*(\d+):\s*(\d+)\s*[Mm][Hh]z\s*(\*)?`)
+)
+
+// sysfsTempLabels rsmi 温度传感器类型与 hwmon temp*_label 的对应关系
+var sysfsTempLabels = map[int]string{
+	SENSOR_EDGE:     "edge",
+	SENSOR_JUNCTION: "junction",
+	SENSOR_MEMORY:   "mem",
+}
+
+// sysfsTempMetricSuffix rsmi 温度指标与 hwmon 文件后缀的对应关系
+var sysfsTempMetricSuffix = map[RSMITemperatureMetric]string{
+	RSMI_TEMP_CURRENT:        "input",
+	RSMI_TEMP_MAX:            "max",
+	RSMI_TEMP_MIN:            "min",
+	RSMI_TEMP_CRITICAL:       "crit",
+	RSMI_TEMP_CRITICAL_HYST:  "crit_hyst",
+	RSMI_TEMP_EMERGENCY:      "emergency",
+	RSMI_TEMP_EMERGENCY_HYST: "emergency_hyst",
+	RSMI_TEMP_OFFSET:         "offset",
+	RSMI_TEMP_LOWEST:         "lowest",
+	RSMI_TEMP_HIGHEST:        "highest",
+}
+
+// sysfsClkFiles rsmi 时钟类型与 pp_dpm_* 文件的对应关系
+var sysfsClkFiles = map[RSMIClkType]string{
+	RSMI_CLK_TYPE_SYS:  "pp_dpm_sclk",
+	RSMI_CLK_TYPE_SOC:  "pp_dpm_socclk",
+	RSMI_CLK_TYPE_MEM:  "pp_dpm_mclk",
+	RSMI_CLK_TYPE_DF:   "pp_dpm_fclk",
+	RSMI_CLK_TYPE_DCEF: "pp_dpm_dcefclk",
+	RSMI_CLK_TYPE_PCIE: "pp_dpm_pcie",
+}
+
+// sysfsMemFiles rsmi 内存类型与 mem_info_* 文件前缀的对应关系
+var sysfsMemFiles = map[RSMIMemoryType]string{
+	RSMI_MEM_TYPE_VRAM:     "mem_info_vram",
+	RSMI_MEM_TYPE_VIS_VRAM: "mem_info_vis_vram",
+	RSMI_MEM_TYPE_GTT:      "mem_info_gtt",
+}
+
+// sysfsRasBlocks GPU 块与 ras/*_err_count 文件名前缀的对应关系
+var sysfsRasBlocks = map[RSMIGpuBlock]string{
+	RSMIGpuBlockUMC:      "umc",
+	RSMIGpuBlockSDMA:     "sdma",
+	RSMIGpuBlockGFX:      "gfx",
+	RSMIGpuBlockMMHUB:    "mmhub",
+	RSMIGpuBlockATHUB:    "athub",
+	RSMIGpuBlockPCIEBIF:  "pcie_bif",
+	RSMIGpuBlockHDP:      "hdp",
+	RSMIGpuBlockXGMIWAFL: "xgmi_wafl",
+	RSMIGpuBlockDF:       "df",
+	RSMIGpuBlockSMN:      "smn",
+	RSMIGpuBlockSEM:      "sem",
+	RSMIGpuBlockMP0:      "mp0",
+	RSMIGpuBlockMP1:      "mp1",
+	RSMIGpuBlockFuse:     "fuse",
+}
+
+// sysfsPerfLevels rsmi 性能级别与 power_dpm_force_performance_level 取值的对应关系
+var sysfsPerfLevels = map[RSMIDevPerfLevel]string{
+	RSMI_DEV_PERF_LEVEL_AUTO:            "auto",
+	RSMI_DEV_PERF_LEVEL_LOW:             "low",
+	RSMI_DEV_PERF_LEVEL_HIGH:            "high",
+	RSMI_DEV_PERF_LEVEL_MANUAL:          "manual",
+	RSMI_DEV_PERF_LEVEL_STABLE_STD:      "profile_standard",
+	RSMI_DEV_PERF_LEVEL_STABLE_PEAK:     "profile_peak",
+	RSMI_DEV_PERF_LEVEL_STABLE_MIN_MCLK: "profile_min_mclk",
+	RSMI_DEV_PERF_LEVEL_STABLE_MIN_SCLK: "profile_min_sclk",
+	RSMI_DEV_PERF_LEVEL_DETERMINISM:     "perf_determinism",
+}
+
+// sysfsDriverModules 读取驱动版本时依次尝试的内核模块
+var sysfsDriverModules = []string{"hydcu", "amdgpu"}
+
+// SysfsBackend 通过 sysfs 读取设备信息的后端，用于 librocm_smi64/libhydmi 缺失或损坏的环境。
+// 只支持 sysfs 能提供的只读指标和少量控制项，其余原语返回 ErrNotSupported。
+type SysfsBackend struct {
+	root    string
+	devices []string
+}
+
+// NewSysfsBackend 创建 sysfs 后端，root 为空时使用 /sys；RsmiInit 时会重新扫描设备
+func NewSysfsBackend(root string) *SysfsBackend {
+	if root == "" {
+		root = defaultSysfsRoot
+	}
+	b := &SysfsBackend{root: root}
+	b.devices = b.scanDevices()
+	return b
+}
+
+// Root 返回 sysfs 根目录
+func (b *SysfsBackend) Root() string {
+	return b.root
+}
+
+// scanDevices 扫描 class/drm/card*/device 下型号在 type2name 中的设备，按 card 编号排序
+func (b *SysfsBackend) scanDevices() []string {
+	drmDir := filepath.Join(b.root, "class", "drm")
+	entries, err := os.ReadDir(drmDir)
+	if err != nil {
+		glog.Warningf("无法读取目录 %s: %v", drmDir, err)
+		return nil
+	}
+	type card struct {
+		num int
+		dir string
+	}
+	var cards []card
+	for _, e := range entries {
+		if !sysfsCardPattern.MatchString(e.Name()) {
+			continue
+		}
+		dir := filepath.Join(drmDir, e.Name(), "device")
+		id, err := readSysfsString(filepath.Join(dir, "device"))
+		if err != nil {
+			continue
+		}
+		if _, ok := type2name[strings.TrimPrefix(strings.ToLower(id), "0x")]; !ok {
+			continue
+		}
+		num, _ := strconv.Atoi(strings.TrimPrefix(e.Name(), "card"))
+		cards = append(cards, card{num: num, dir: dir})
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].num < cards[j].num })
+	devices := make([]string, 0, len(cards))
+	for _, c := range cards {
+		devices = append(devices, c.dir)
+	}
+	return devices
+}
+
+func (b *SysfsBackend) device(fn string, dvInd int) (string, error) {
+	if dvInd < 0 || dvInd >= len(b.devices) {
+		return "", fmt.Errorf("Error %s:RSMI_STATUS_INVALID_ARGS: device index %d out of range", fn, dvInd)
+	}
+	return b.devices[dvInd], nil
+}
+
+func sysfsNotSupported(fn string) error {
+	return fmt.Errorf("Error %s:%w", fn, ErrNotSupported)
+}
+
+func readSysfsString(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// readSysfsInt 读取整数，支持 0x 前缀的十六进制
+func readSysfsInt(path string) (int64, error) {
+	s, err := readSysfsString(path)
+	if err != nil {
+		return 0, err
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		v, err := strconv.ParseUint(s[2:], 16, 64)
+		return int64(v), err
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// readDeviceInt 读取设备目录下的整数文件，失败时返回带函数名的错误
+func (b *SysfsBackend) readDeviceInt(fn string, dvInd int, name string) (int64, error) {
+	dir, err := b.device(fn, dvInd)
+	if err != nil {
+		return 0, err
+	}
+	v, err := readSysfsInt(filepath.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, sysfsNotSupported(fn)
+		}
+		return 0, fmt.Errorf("Error %s:%s", fn, err)
+	}
+	return v, nil
+}
+
+func (b *SysfsBackend) readDeviceString(fn string, dvInd int, name string) (string, error) {
+	dir, err := b.device(fn, dvInd)
+	if err != nil {
+		return "", err
+	}
+	s, err := readSysfsString(filepath.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", sysfsNotSupported(fn)
+		}
+		return "", fmt.Errorf("Error %s:%s", fn, err)
+	}
+	return s, nil
+}
+
+func (b *SysfsBackend) writeDeviceString(fn string, dvInd int, name, value string) error {
+	dir, err := b.device(fn, dvInd)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, name)
+	if _, err = os.Stat(path); os.IsNotExist(err) {
+		return sysfsNotSupported(fn)
+	}
+	if err = os.WriteFile(path, []byte(value), 0644); err != nil {
+		return fmt.Errorf("Error %s:%s", fn, err)
+	}
+	return nil
+}
+
+// hwmonDir 返回设备的第一个 hwmon 目录
+func (b *SysfsBackend) hwmonDir(fn string, dvInd int) (string, error) {
+	dir, err := b.device(fn, dvInd)
+	if err != nil {
+		return "", err
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "hwmon", "hwmon*"))
+	if len(matches) == 0 {
+		return "", sysfsNotSupported(fn)
+	}
+	sort.Strings(matches)
+	return matches[0], nil
+}
+
+func (b *SysfsBackend) readHwmonInt(fn string, dvInd int, name string) (int64, error) {
+	dir, err := b.hwmonDir(fn, dvInd)
+	if err != nil {
+		return 0, err
+	}
+	v, err := readSysfsInt(filepath.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, sysfsNotSupported(fn)
+		}
+		return 0, fmt.Errorf("Error %s:%s", fn, err)
+	}
+	return v, nil
+}
+
+// pciSlotName 返回设备的 PCI 地址，优先取 uevent 中的 PCI_SLOT_NAME，其次取符号链接的目标
+func (b *SysfsBackend) pciSlotName(dir string) (string, error) {
+	if f, err := os.Open(filepath.Join(dir, "uevent")); err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if v, ok := strings.CutPrefix(scanner.Text(), "PCI_SLOT_NAME="); ok {
+				return v, nil
+			}
+		}
+	}
+	target, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(target), nil
+}
+
+func (b *SysfsBackend) ProbeDeviceCount() int {
+	return len(b.devices)
+}
+
+/****************************************** 初始化 *********************************************/
+
+func (b *SysfsBackend) RsmiInit() (err error) {
+	b.devices = b.scanDevices()
+	if len(b.devices) == 0 {
+		return fmt.Errorf("Error sysfs init: no DCU found under %s", b.root)
+	}
+	return nil
+}
+
+func (b *SysfsBackend) RsmiShutdown() (err error) {
+	return nil
+}
+
+/****************************************** 设备信息 *********************************************/
+
+func (b *SysfsBackend) RsmiNumMonitorDevices() (gpuNum int, err error) {
+	return len(b.devices), nil
+}
+
+func (b *SysfsBackend) RsmiDevSkuGet(dvInd int) (sku int, err error) {
+	return 0, sysfsNotSupported("rsmi_dev_sku_get")
+}
+
+func (b *SysfsBackend) RsmiDevVendorIdGet(dvInd int) uint {
+	v, _ := b.readDeviceInt("rsmi_dev_vendor_id_get", dvInd, "vendor")
+	return uint(v)
+}
+
+func (b *SysfsBackend) RsmiDevIdGet(dvInd int) (id int, err error) {
+	v, err := b.readDeviceInt("rsmi_dev_id_get", dvInd, "device")
+	return int(v), err
+}
+
+func (b *SysfsBackend) RsmiDevNameGet(dvInd int) (nameStr string, err error) {
+	if name, err := b.readDeviceString("rsmi_dev_name_get", dvInd, "product_name"); err == nil && name != "" {
+		return name, nil
+	}
+	id, err := b.RsmiDevIdGet(dvInd)
+	if err != nil {
+		return "", err
+	}
+	return type2name[fmt.Sprintf("%x", id)], nil
+}
+
+func (b *SysfsBackend) RsmiDevBrandGet(dvInd int) (brand string, err error) {
+	return b.RsmiDevNameGet(dvInd)
+}
+
+func (b *SysfsBackend) RsmiDevVendorNameGet(dvInd int) (bname string, err error) {
+	return "", sysfsNotSupported("rsmi_dev_vendor_name_get")
+}
+
+func (b *SysfsBackend) RsmiDevVramVendorGet(dvInd int) (result string, err error) {
+	return b.readDeviceString("rsmi_dev_vram_vendor_get", dvInd, "mem_info_vram_vendor")
+}
+
+func (b *SysfsBackend) RsmiDevSerialNumberGet(dvInd int) (serialNumber string, err error) {
+	return b.readDeviceString("rsmi_dev_serial_number_get", dvInd, "serial_number")
+}
+
+func (b *SysfsBackend) RsmiDevSubsystemIdGet(dvInd int) int {
+	v, _ := b.readDeviceInt("rsmi_dev_subsystem_id_get", dvInd, "subsystem_device")
+	return int(v)
+}
+
+func (b *SysfsBackend) RsmiDevSubsystemNameGet(dvInd int) (subSystemName string, err error) {
+	return b.RsmiDevNameGet(dvInd)
+}
+
+func (b *SysfsBackend) RsmiDevDrmRenderMinorGet(dvInd int) int {
+	dir, err := b.device("rsmi_dev_drm_render_minor_get", dvInd)
+	if err != nil {
+		return 0
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "drm", "renderD*"))
+	if len(matches) == 0 {
+		return 0
+	}
+	minor, _ := strconv.Atoi(strings.TrimPrefix(filepath.Base(matches[0]), "renderD"))
+	return minor
+}
+
+func (b *SysfsBackend) RsmiDevUniqueIdGet(dvInd int) (uniqueId int64, err error) {
+	s, err := b.readDeviceString("rsmi_dev_unique_id_get", dvInd, "unique_id")
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Error rsmi_dev_unique_id_get:%s", err)
+	}
+	return int64(v), nil
+}
+
+func (b *SysfsBackend) RsmiDevSubsystemVendorIdGet(dvInd int) int {
+	v, _ := b.readDeviceInt("rsmi_dev_subsystem_vendor_id_get", dvInd, "subsystem_vendor")
+	return int(v)
+}
+
+/****************************************** PCIe *********************************************/
+
+// parseLinkSpeed 解析 "16.0 GT/s PCIe" 格式的链路速率，返回 T/s
+func parseLinkSpeed(s string) (uint64, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid link speed %q", s)
+	}
+	gts, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid link speed %q", s)
+	}
+	return uint64(gts * 1e9), nil
+}
+
+func (b *SysfsBackend) RsmiDevPciBandwidthGet(dvInd int) (rsmiPcieBandwidth RSMIPcieBandwidth, err error) {
+	speedStr, err := b.readDeviceString("rsmi_dev_pci_bandwidth_get", dvInd, "current_link_speed")
+	if err != nil {
+		return
+	}
+	speed, err := parseLinkSpeed(speedStr)
+	if err != nil {
+		return rsmiPcieBandwidth, fmt.Errorf("Error rsmi_dev_pci_bandwidth_get:%s", err)
+	}
+	width, _ := b.readDeviceInt("rsmi_dev_pci_bandwidth_get", dvInd, "current_link_width")
+	rsmiPcieBandwidth.TransferRate.NumSupported = 1
+	rsmiPcieBandwidth.TransferRate.Current = 0
+	rsmiPcieBandwidth.TransferRate.Frequency[0] = speed
+	rsmiPcieBandwidth.Lanes[0] = uint32(width)
+	return rsmiPcieBandwidth, nil
+}
+
+func (b *SysfsBackend) RsmiDevPciIdGet(dvInd int) (bdfid int64, err error) {
+	dir, err := b.device("rsmi_dev_pci_id_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	slot, err := b.pciSlotName(dir)
+	if err != nil {
+		return 0, fmt.Errorf("Error rsmi_dev_pci_id_get:%s", err)
+	}
+	return parsePciBus(slot)
+}
+
+func (b *SysfsBackend) RsmiTopoNumaAffinityGet(dvInd int) (namaNode int, err error) {
+	v, err := b.readDeviceInt("rsmi_topo_numa_affinity_get", dvInd, "numa_node")
+	return int(v), err
+}
+
+func (b *SysfsBackend) RsmiDevPciThroughputGet(dvInd int) (sent int64, received int64, maxPktSz int64, err error) {
+	s, err := b.readDeviceString("rsmi_dev_pci_throughput_get", dvInd, "pcie_bw")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if _, err = fmt.Sscanf(s, "%d %d %d", &sent, &received, &maxPktSz); err != nil {
+		return 0, 0, 0, fmt.Errorf("Error rsmi_dev_pci_throughput_get:%s", err)
+	}
+	return
+}
+
+func (b *SysfsBackend) RsmiDevPciReplayCounterGet(dvInd int) (counter int64, err error) {
+	return b.readDeviceInt("rsmi_dev_pci_replay_counter_get", dvInd, "pcie_replay_count")
+}
+
+func (b *SysfsBackend) RsmiDevPciBandwidthSet(dvInd int, bwBitmask int64) (err error) {
+	return sysfsNotSupported("rsmi_dev_pci_bandwidth_set")
+}
+
+/****************************************** 功耗 *********************************************/
+
+func (b *SysfsBackend) RsmiDevPowerAveGet(dvInd int, senserId int) (power int64, err error) {
+	return b.readHwmonInt("rsmi_dev_power_ave_get", dvInd, fmt.Sprintf("power%d_average", senserId+1))
+}
+
+func (b *SysfsBackend) RsmiDevEnergyCountGet(dvInd int) (power uint64, counterResolution float32, timestamp uint64, err error) {
+	return 0, 0, 0, sysfsNotSupported("rsmi_dev_energy_count_get")
+}
+
+func (b *SysfsBackend) RsmiDevPowerCapGet(dvInd int, senserId int) (power int64, err error) {
+	return b.readHwmonInt("rsmi_dev_power_cap_get", dvInd, fmt.Sprintf("power%d_cap", senserId+1))
+}
+
+func (b *SysfsBackend) RsmiDevPowerCapRangeGet(dvInd int, senserId int) (max, min int64, err error) {
+	max, err = b.readHwmonInt("rsmi_dev_power_cap_range_get", dvInd, fmt.Sprintf("power%d_cap_max", senserId+1))
+	if err != nil {
+		return 0, 0, err
+	}
+	min, err = b.readHwmonInt("rsmi_dev_power_cap_range_get", dvInd, fmt.Sprintf("power%d_cap_min", senserId+1))
+	return max, min, err
+}
+
+/****************************************** 内存 *********************************************/
+
+func (b *SysfsBackend) RsmiDevMemoryTotalGet(dvInd int, memoryType RSMIMemoryType) (total int64, err error) {
+	prefix, ok := sysfsMemFiles[memoryType]
+	if !ok {
+		return 0, fmt.Errorf("Error rsmi_dev_memory_total_get:RSMI_STATUS_INVALID_ARGS")
+	}
+	return b.readDeviceInt("rsmi_dev_memory_total_get", dvInd, prefix+"_total")
+}
+
+func (b *SysfsBackend) RsmiDevMemoryUsageGet(dvInd int, memoryType RSMIMemoryType) (used int64, err error) {
+	prefix, ok := sysfsMemFiles[memoryType]
+	if !ok {
+		return 0, fmt.Errorf("Error rsmi_dev_memory_usage_get:RSMI_STATUS_INVALID_ARGS")
+	}
+	return b.readDeviceInt("rsmi_dev_memory_usage_get", dvInd, prefix+"_used")
+}
+
+func (b *SysfsBackend) RsmiDevMemoryBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	v, err := b.readDeviceInt("rsmi_dev_memory_busy_percent_get", dvInd, "mem_busy_percent")
+	return int(v), err
+}
+
+func (b *SysfsBackend) RsmiDevMemoryReservedPagesGet(dvInd int) (numPages int, records []RSMIRetiredPageRecord, err error) {
+	return 0, nil, sysfsNotSupported("rsmi_dev_memory_reserved_pages_get")
+}
+
+/****************************************** 风扇 *********************************************/
+
+func (b *SysfsBackend) RsmiDevFanRpmsGet(dvInd, sensorInd int) (speed int64, err error) {
+	return b.readHwmonInt("rsmi_dev_fan_rpms_get", dvInd, fmt.Sprintf("fan%d_input", sensorInd+1))
+}
+
+func (b *SysfsBackend) RsmiDevFanSpeedGet(dvInd, sensorInd int) (speed int64, err error) {
+	return b.readHwmonInt("rsmi_dev_fan_speed_get", dvInd, fmt.Sprintf("pwm%d", sensorInd+1))
+}
+
+func (b *SysfsBackend) RsmiDevFanSpeedMaxGet(dvInd, sensorInd int) (maxSpeed int64, err error) {
+	return b.readHwmonInt("rsmi_dev_fan_speed_max_get", dvInd, fmt.Sprintf("pwm%d_max", sensorInd+1))
+}
+
+func (b *SysfsBackend) RsmiDevOdVoltCurveRegionsGet(dvInd int) (numRegions int, regions []RSMIFreqVoltRegion, err error) {
+	return 0, nil, sysfsNotSupported("rsmi_dev_od_volt_curve_regions_get")
+}
+
+func (b *SysfsBackend) RsmiDevPowerProfilePresetsGet(dvInd, sensorInd int) (powerProfileStatus RSMPowerProfileStatus, err error) {
+	return powerProfileStatus, sysfsNotSupported("rsmi_dev_power_profile_presets_get")
+}
+
+/****************************************** 版本 *********************************************/
+
+func (b *SysfsBackend) RsmiVersionGet() (version RSMIVersion, err error) {
+	return version, sysfsNotSupported("rsmi_version_get")
+}
+
+func (b *SysfsBackend) RsmiVersionStrGet(component RSMISwComponent, len int) (varStr string, err error) {
+	if component != RSMISwCompDriver {
+		return "", fmt.Errorf("Error rsmi_version_str_get:RSMI_STATUS_INVALID_ARGS")
+	}
+	for _, module := range sysfsDriverModules {
+		if v, err := readSysfsString(filepath.Join(b.root, "module", module, "version")); err == nil {
+			return v, nil
+		}
+	}
+	return "", sysfsNotSupported("rsmi_version_str_get")
+}
+
+func (b *SysfsBackend) RsmiDevVbiosVersionGet(dvInd, len int) (vbios string, err error) {
+	return b.readDeviceString("rsmi_dev_vbios_version_get", dvInd, "vbios_version")
+}
+
+func (b *SysfsBackend) RsmiDevFirmwareVersionGet(dvInd int, fwBlock RSMIFwBlock) (fwVersion int64, err error) {
+	if int(fwBlock) < 0 || int(fwBlock) >= len(fwBlockNames) {
+		return 0, fmt.Errorf("Error rsmi_dev_firmware_version_get:RSMI_STATUS_INVALID_ARGS")
+	}
+	name := strings.ToLower(fwBlockNames[fwBlock]) + "_fw_version"
+	return b.readDeviceInt("rsmi_dev_firmware_version_get", dvInd, filepath.Join("fw_version", name))
+}
+
+/****************************************** vDCU *********************************************/
+
+func (b *SysfsBackend) DmiGetDeviceCount() (count int, err error) {
+	return len(b.devices), nil
+}
+
+func (b *SysfsBackend) DmiGetDeviceInfo(dvInd int) (deviceInfo DMIDeviceInfo, err error) {
+	return deviceInfo, sysfsNotSupported("dmiGetDeviceInfo")
+}
+
+func (b *SysfsBackend) DmiGetMaxVDeviceCount() (count int, err error) {
+	return 0, sysfsNotSupported("dmiGetMaxVDeviceCount")
+}
+
+func (b *SysfsBackend) DmiGetVDeviceCount() (count int, err error) {
+	return 0, sysfsNotSupported("dmiGetVDeviceCount")
+}
+
+func (b *SysfsBackend) DmiGetVDeviceInfo(vDvInd int) (vDeviceInfo DMIVDeviceInfo, err error) {
+	return vDeviceInfo, sysfsNotSupported("dmiGetVDeviceInfo")
+}
+
+func (b *SysfsBackend) DmiGetDeviceRemainingInfo(dvInd int) (cus, memories uint64, err error) {
+	return 0, 0, sysfsNotSupported("dmiGetDeviceRemainingInfo")
+}
+
+func (b *SysfsBackend) DmiCreateVDevices(dvInd int, vDevCount int, vDevCUs []int, vDevMemSize []int) (vdevIDs []int, err error) {
+	return nil, sysfsNotSupported("dmiCreateVDevices")
+}
+
+func (b *SysfsBackend) DmiDestroyVDevices(dvInd int) (err error) {
+	return sysfsNotSupported("dmiDestroyVDevices")
+}
+
+func (b *SysfsBackend) DmiDestroySingleVDevice(vDvInd int) (err error) {
+	return sysfsNotSupported("dmiDestroySingleVDevice")
+}
+
+func (b *SysfsBackend) DmiUpdateSingleVDevice(vDvInd int, vDevCUs int, vDevMemSize int) (err error) {
+	return sysfsNotSupported("dmiUpdateSingleVDevice")
+}
+
+func (b *SysfsBackend) DmiStartVDevice(vDvInd int) (err error) {
+	return sysfsNotSupported("dmiStartVDevice")
+}
+
+func (b *SysfsBackend) DmiStopVDevice(vDvInd int) (err error) {
+	return sysfsNotSupported("dmiStopVDevice")
+}
+
+func (b *SysfsBackend) DmiGetDevBusyPercent(dvInd int) (percent int, err error) {
+	return b.RsmiDevBusyPercentGet(dvInd)
+}
+
+func (b *SysfsBackend) DmiGetVDevBusyPercent(vDvInd int) (percent int, err error) {
+	return 0, sysfsNotSupported("dmiGetVDevBusyPercent")
+}
+
+func (b *SysfsBackend) DmiSetEncryptionVMStatus(status bool) (err error) {
+	return sysfsNotSupported("dmiSetEncryptionVMStatus")
+}
+
+func (b *SysfsBackend) DmiGetEncryptionVMStatus() (status bool, err error) {
+	return false, sysfsNotSupported("dmiGetEncryptionVMStatus")
+}
+
+/****************************************** 设备状态 *********************************************/
+
+// tempIndex 根据 temp*_label 查找传感器对应的 hwmon 编号，找不到标签时按 sensorType+1 处理
+func (b *SysfsBackend) tempIndex(hwmon string, sensorType int) int {
+	if label, ok := sysfsTempLabels[sensorType]; ok {
+		matches, _ := filepath.Glob(filepath.Join(hwmon, "temp*_label"))
+		for _, m := range matches {
+			if v, err := readSysfsString(m); err == nil && v == label {
+				idx, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(m), "temp"), "_label"))
+				if err == nil {
+					return idx
+				}
+			}
+		}
+	}
+	return sensorType + 1
+}
+
+func (b *SysfsBackend) RsmiDevTempMetricGet(dvInd int, sensorType int, metric RSMITemperatureMetric) (temp int64, err error) {
+	suffix, ok := sysfsTempMetricSuffix[metric]
+	if !ok {
+		return 0, sysfsNotSupported("rsmi_dev_temp_metric_get")
+	}
+	hwmon, err := b.hwmonDir("rsmi_dev_temp_metric_get", dvInd)
+	if err != nil {
+		return 0, err
+	}
+	return b.readHwmonInt("rsmi_dev_temp_metric_get", dvInd, fmt.Sprintf("temp%d_%s", b.tempIndex(hwmon, sensorType), suffix))
+}
+
+func (b *SysfsBackend) RsmiDevVoltMetricGet(dvInd int, voltageType RSMIVoltageType, metric RSMIVoltageMetric) int64 {
+	v, _ := b.readHwmonInt("rsmi_dev_volt_metric_get", dvInd, "in0_input")
+	return v
+}
+
+func (b *SysfsBackend) RsmiDevFanSpeedSet(dvInd, sensorInd int, speed int64) (err error) {
+	hwmon, err := b.hwmonDir("rsmi_dev_fan_speed_set", dvInd)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(hwmon, fmt.Sprintf("pwm%d_enable", sensorInd+1)), []byte("1"), 0644); err != nil {
+		return fmt.Errorf("Error rsmi_dev_fan_speed_set:%s", err)
+	}
+	if err = os.WriteFile(filepath.Join(hwmon, fmt.Sprintf("pwm%d", sensorInd+1)), []byte(strconv.FormatInt(speed, 10)), 0644); err != nil {
+		return fmt.Errorf("Error rsmi_dev_fan_speed_set:%s", err)
+	}
+	return nil
+}
+
+func (b *SysfsBackend) RsmiDevBusyPercentGet(dvInd int) (busyPercent int, err error) {
+	v, err := b.readDeviceInt("rsmi_dev_busy_percent_get", dvInd, "gpu_busy_percent")
+	return int(v), err
+}
+
+func (b *SysfsBackend) RsmiUtilizationCountGet(dvInd int, utilizationCounters []RSMIUtilizationCounter, count int) (timestamp int64, err error) {
+	return 0, sysfsNotSupported("rsmi_utilization_count_get")
+}
+
+func (b *SysfsBackend) RsmiDevPerfLevelGet(dvInd int) (perf RSMIDevPerfLevel, err error) {
+	s, err := b.readDeviceString("rsmi_dev_perf_level_get", dvInd, "power_dpm_force_performance_level")
+	if err != nil {
+		return RSMI_DEV_PERF_LEVEL_UNKNOWN, err
+	}
+	for level, name := range sysfsPerfLevels {
+		if name == s {
+			return level, nil
+		}
+	}
+	return RSMI_DEV_PERF_LEVEL_UNKNOWN, nil
+}
+
+func (b *SysfsBackend) RsmiPerfDeterminismModeSet(dvInd int, clkValue int64) (err error) {
+	return sysfsNotSupported("rsmi_perf_determinism_mode_set")
+}
+
+func (b *SysfsBackend) RsmiDevOverdriveLevelGet(dvInd int) (od int, err error) {
+	v, err := b.readDeviceInt("rsmi_dev_overdrive_level_get", dvInd, "pp_sclk_od")
+	return int(v), err
+}
+
+// parseDpmLevels 解析 pp_dpm_* 文件，每行格式为 "1: 800Mhz *"，星号表示当前档位
+func parseDpmLevels(content string) (frequencies RSMIFrequencies) {
+	for _, line := range strings.Split(content, "\n") {
+		m := sysfsDpmPattern.FindStringSubmatch(line)
+		if m == nil || int(frequencies.NumSupported) >= len(frequencies.Frequency) {
+			continue
+		}
+		mhz, _ := strconv.ParseUint(m[2], 10, 64)
+		frequencies.Frequency[frequencies.NumSupported] = mhz * 1000000
+		if m[3] == "*" {
+			frequencies.Current = frequencies.NumSupported
+		}
+		frequencies.NumSupported++
+	}
+	return
+}
+
+func (b *SysfsBackend) RsmiDevGpuClkFreqGet(dvInd int, clkType RSMIClkType) (frequencies RSMIFrequencies, err error) {
+	name, ok := sysfsClkFiles[clkType]
+	if !ok {
+		return frequencies, fmt.Errorf("Error rsmi_dev_gpu_clk_freq_get:RSMI_STATUS_INVALID_ARGS")
+	}
+	s, err := b.readDeviceString("rsmi_dev_gpu_clk_freq_get", dvInd, name)
+	if err != nil {
+		return frequencies, err
+	}
+	return parseDpmLevels(s), nil
+}
+
+func (b *SysfsBackend) RsmiDevOdVoltInfoGet(dvInd int) (odv RSMIOdVoltFreqData, err error) {
+	return odv, sysfsNotSupported("rsmi_dev_od_volt_info_get")
+}
+
+// RsmiDevGpuMetricsInfoGet 按版本解码 gpu_metrics 文件后转换为 rsmi_gpu_metrics_t 的固定布局
+func (b *SysfsBackend) RsmiDevGpuMetricsInfoGet(dvInd int) (gpuMetrics RSMIGPUMetrics, err error) {
+	table, err := b.GpuMetricsTableGet(dvInd)
+	if err != nil {
+		return gpuMetrics, err
+	}
+	decoded, err := DecodeGpuMetrics(table)
+	if err != nil {
+		return gpuMetrics, fmt.Errorf("Error rsmi_dev_gpu_metrics_info_get:%w", err)
+	}
+	return decoded.rsmiGPUMetrics(), nil
+}
+
+func (b *SysfsBackend) GpuMetricsTableGet(dvInd int) (table []byte, err error) {
+	dir, err := b.device("gpu_metrics", dvInd)
+	if err != nil {
+		return nil, err
+	}
+	table, err = os.ReadFile(filepath.Join(dir, "gpu_metrics"))
+	if err != nil {
+		return nil, fmt.Errorf("Error gpu_metrics:%s", err)
+	}
+	return table, nil
+}
+
+// rasCount 读取 ras/<block>_err_count，格式为 "ue: 0\nce: 0"
+func (b *SysfsBackend) rasCount(fn string, dvInd int, block RSMIGpuBlock) (errorCount RSMIErrorCount, err error) {
+	name, ok := sysfsRasBlocks[block]
+	if !ok {
+		return errorCount, sysfsNotSupported(fn)
+	}
+	s, err := b.readDeviceString(fn, dvInd, filepath.Join("ras", name+"_err_count"))
+	if err != nil {
+		return errorCount, err
+	}
+	for _, line := range strings.Split(s, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		v, _ := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		switch strings.TrimSpace(key) {
+		case "ue":
+			errorCount.UncorrectableErr = v
+		case "ce":
+			errorCount.CorrectableErr = v
+		}
+	}
+	return errorCount, nil
+}
+
+func (b *SysfsBackend) RsmiDevEccStatusGet(dvInd int, block RSMIGpuBlock) (state RSMIRasErrState, err error) {
+	if _, err = b.device("rsmi_dev_ecc_status_get", dvInd); err != nil {
+		return state, err
+	}
+	if _, err = b.rasCount("rsmi_dev_ecc_status_get", dvInd, block); err != nil {
+		return RSMIRasErrStateDisabled, nil
+	}
+	return RSMIRasErrStateEnabled, nil
+}
+
+func (b *SysfsBackend) RsmiDevEccCountGet(dvInd int, gpuBlock RSMIGpuBlock) (errorCount RSMIErrorCount, err error) {
+	return b.rasCount("rsmi_dev_ecc_count_get", dvInd, gpuBlock)
+}
+
+func (b *SysfsBackend) RsmiDevEccEnabledGet(dvInd int) (enabledBlocks int64, err error) {
+	if _, err = b.device("rsmi_dev_ecc_enabled_get", dvInd); err != nil {
+		return 0, err
+	}
+	for block := range sysfsRasBlocks {
+		if _, err := b.rasCount("rsmi_dev_ecc_enabled_get", dvInd, block); err == nil {
+			enabledBlocks |= int64(block)
+		}
+	}
+	return enabledBlocks, nil
+}
+
+/****************************************** 控制 *********************************************/
+
+func (b *SysfsBackend) RsmiDevPerfLevelSet(dvInd int, devPerfLevel RSMIDevPerfLevel) (err error) {
+	level, ok := sysfsPerfLevels[devPerfLevel]
+	if !ok {
+		return fmt.Errorf("Error rsmi_dev_perf_level_set:RSMI_STATUS_INVALID_ARGS")
+	}
+	return b.writeDeviceString("rsmi_dev_perf_level_set", dvInd, "power_dpm_force_performance_level", level)
+}
+
+func (b *SysfsBackend) RsmiDevClkRangeSet(dvInd int, minClkValue, maxClkValue int64, clkType RSMIClkType) (err error) {
+	return sysfsNotSupported("rsmi_dev_clk_range_set")
+}
+
+func (b *SysfsBackend) RsmiDevOdVoltInfoSet(dvInd, vPoint, clkValue, voltValue int) (err error) {
+	return sysfsNotSupported("rsmi_dev_od_volt_info_set")
+}
+
+func (b *SysfsBackend) RsmiDevOverdriveLevelSet(dvInd, od int) (err error) {
+	return b.writeDeviceString("rsmi_dev_overdrive_level_set", dvInd, "pp_sclk_od", strconv.Itoa(od))
+}
+
+func (b *SysfsBackend) RsmiDevGpuClkFreqSet(dvInd int, clkType RSMIClkType, freqBitmask int64) (err error) {
+	name, ok := sysfsClkFiles[clkType]
+	if !ok {
+		return fmt.Errorf("Error rsmi_dev_gpu_clk_freq_set:RSMI_STATUS_INVALID_ARGS")
+	}
+	var levels []string
+	for i := 0; i < 64; i++ {
+		if freqBitmask&(1<<uint(i)) != 0 {
+			levels = append(levels, strconv.Itoa(i))
+		}
+	}
+	return b.writeDeviceString("rsmi_dev_gpu_clk_freq_set", dvInd, name, strings.Join(levels, " "))
+}
+
+func (b *SysfsBackend) RsmiDevCounterGroupSupported(dvInd int, group RSMIEventGroup) (err error) {
+	return sysfsNotSupported("rsmi_dev_counter_group_supported")
+}
+
+func (b *SysfsBackend) RsmiDevCounterCreate(dvInd int, eventType RSMIEventType) (eventHandle EventHandle, err error) {
+	return 0, sysfsNotSupported("rsmi_dev_counter_create")
+}
+
+func (b *SysfsBackend) RsmiDevCounterDestroy(handle EventHandle) (err error) {
+	return sysfsNotSupported("rsmi_dev_counter_destroy")
+}
+
+func (b *SysfsBackend) RsmiCounterControl(evtHandle EventHandle, cmd RSMICounterCommand) (err error) {
+	return sysfsNotSupported("rsmi_counter_control")
+}
+
+func (b *SysfsBackend) RsmiCounterRead(handle EventHandle) (counterValue RSMICounterValue, err error) {
+	return counterValue, sysfsNotSupported("rsmi_counter_read")
+}
+
+func (b *SysfsBackend) RsmiCounterAvailableCountersGet(dvInd int, group RSMIEventGroup) (availAble int, err error) {
+	return 0, sysfsNotSupported("rsmi_counter_available_counters_get")
+}
+
+func (b *SysfsBackend) RsmiDevFanReset(dvInd, sensorInd int) (err error) {
+	hwmon, err := b.hwmonDir("rsmi_dev_fan_reset", dvInd)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(hwmon, fmt.Sprintf("pwm%d_enable", sensorInd+1)), []byte("2"), 0644); err != nil {
+		return fmt.Errorf("Error rsmi_dev_fan_reset:%s", err)
+	}
+	return nil
+}
+
+func (b *SysfsBackend) RsmiDevPowerProfileSet(dvInd int, reserved int, profile RSNIPowerProfilePresetMasks) (err error) {
+	return sysfsNotSupported("rsmi_dev_power_profile_set")
+}
+
+func (b *SysfsBackend) RsmiDevXgmiErrorReset(dvInd int) (err error) {
+	return sysfsNotSupported("rsmi_dev_xgmi_error_reset")
+}
+
+func (b *SysfsBackend) RsmiDevXGMIErrorStatus(dvInd int) (status RSMIXGMIStatus, err error) {
+	return status, sysfsNotSupported("rsmi_dev_xgmi_error_status")
+}
+
+func (b *SysfsBackend) RsmiDevXgmiHiveIdGet(dvInd int) (hiveId int64, err error) {
+	return b.readDeviceInt("rsmi_dev_xgmi_hive_id_get", dvInd, "xgmi_hive_id")
+}
+
+/****************************************** 进程 *********************************************/
+
+// kfdProcess 读取 class/kfd/kfd/proc/<pid> 下的进程信息，vram_<gpuid> 按 GPU 累加
+func (b *SysfsBackend) kfdProcess(pid int) (proc RSMIProcessInfo, gpuIDs []string, err error) {
+	dir := filepath.Join(b.root, "class", "kfd", "kfd", "proc", strconv.Itoa(pid))
+	if _, err = os.Stat(dir); err != nil {
+		return proc, nil, fmt.Errorf("Error rsmiComputeProcessInfoByPidGet:RSMI_STATUS_NOT_FOUND")
+	}
+	proc.ProcessID = uint32(pid)
+	if pasid, err := readSysfsInt(filepath.Join(dir, "pasid")); err == nil {
+		proc.Pasid = uint32(pasid)
+	}
+	vrams, _ := filepath.Glob(filepath.Join(dir, "vram_*"))
+	for _, f := range vrams {
+		v, err := readSysfsInt(f)
+		if err != nil {
+			continue
+		}
+		proc.VramUsage += uint64(v)
+		gpuIDs = append(gpuIDs, strings.TrimPrefix(filepath.Base(f), "vram_"))
+	}
+	sdmas, _ := filepath.Glob(filepath.Join(dir, "sdma_*"))
+	for _, f := range sdmas {
+		if v, err := readSysfsInt(f); err == nil {
+			proc.SdmaUsage += uint64(v)
+		}
+	}
+	cus, _ := filepath.Glob(filepath.Join(dir, "stats_*", "cu_occupancy"))
+	for _, f := range cus {
+		if v, err := readSysfsInt(f); err == nil {
+			proc.CuOccupancy += uint32(v)
+		}
+	}
+	return proc, gpuIDs, nil
+}
+
+func (b *SysfsBackend) RsmiComputeProcessInfoGet() (processInfo []RSMIProcessInfo, numItems int, err error) {
+	entries, err := os.ReadDir(filepath.Join(b.root, "class", "kfd", "kfd", "proc"))
+	if err != nil {
+		return nil, 0, sysfsNotSupported("rsmi_compute_process_info_get")
+	}
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		if proc, _, err := b.kfdProcess(pid); err == nil {
+			processInfo = append(processInfo, proc)
+		}
+	}
+	return processInfo, len(processInfo), nil
+}
+
+func (b *SysfsBackend) RsmiComputeProcessInfoByPidGet(pid int) (proc RSMIProcessInfo, err error) {
+	proc, _, err = b.kfdProcess(pid)
+	return
+}
+
+// kfdGpuIndex 通过 KFD 拓扑节点的 gpu_id 与 location_id 把 gpu_id 映射为设备索引
+func (b *SysfsBackend) kfdGpuIndex() map[string]int {
+	index := map[string]int{}
+	bdfs := map[int64]int{}
+	for i := range b.devices {
+		if bdfid, err := b.RsmiDevPciIdGet(i); err == nil {
+			bdfs[bdfid&0xffff] = i
+		}
+	}
+	nodes, _ := filepath.Glob(filepath.Join(b.root, "class", "kfd", "kfd", "topology", "nodes", "*"))
+	for _, node := range nodes {
+		gpuID, err := readSysfsString(filepath.Join(node, "gpu_id"))
+		if err != nil || gpuID == "0" {
+			continue
+		}
+		props, err := os.ReadFile(filepath.Join(node, "properties"))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(props), "\n") {
+			if v, ok := strings.CutPrefix(line, "location_id "); ok {
+				loc, _ := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+				if dv, ok := bdfs[loc]; ok {
+					index[gpuID] = dv
+				}
+			}
+		}
+	}
+	return index
+}
+
+func (b *SysfsBackend) RsmiComputeProcessGpusGet(pid int) (dvIndices []int, err error) {
+	_, gpuIDs, err := b.kfdProcess(pid)
+	if err != nil {
+		return nil, err
+	}
+	index := b.kfdGpuIndex()
+	for _, id := range gpuIDs {
+		if dv, ok := index[id]; ok {
+			dvIndices = append(dvIndices, dv)
+		}
+	}
+	sort.Ints(dvIndices)
+	return dvIndices, nil
+}
+
+func (b *SysfsBackend) RsmiDevSupportedFuncIteratorOpen(dvInd int) (iterHandle RSMIFuncIDIterHandle, err error) {
+	return iterHandle, sysfsNotSupported("rsmi_dev_supported_func_iterator_open")
+}
+
+func (b *SysfsBackend) RsmiDevSupportedVariantIteratorOpen(iterHandle RSMIFuncIDIterHandle) (handle RSMIFuncIDIterHandle, err error) {
+	return handle, sysfsNotSupported("rsmi_dev_supported_variant_iterator_open")
+}
+
+func (b *SysfsBackend) RsmiFuncIterNext(handle RSMIFuncIDIterHandle) (err error) {
+	return sysfsNotSupported("rsmi_func_iter_next")
+}
+
+func (b *SysfsBackend) RsmiDevSupportedFuncIteratorClose(handle RSMIFuncIDIterHandle) (err error) {
+	return sysfsNotSupported("rsmi_dev_supported_func_iterator_close")
+}
+
+/****************************************** 事件 *********************************************/
+
+func (b *SysfsBackend) RsmiEventNotificationInit(deInd int) (err error) {
+	return sysfsNotSupported("rsmi_event_notification_init")
+}
+
+func (b *SysfsBackend) RsmiEventNotificationMaskSet(dvInd int, mask int64) (err error) {
+	return sysfsNotSupported("rsmi_event_notification_mask_set")
+}
+
+func (b *SysfsBackend) RsmiEventNotificationGet(timeoutMs int) (numElem int, datas []RSMIEEvtNotificationData, err error) {
+	time.Sleep(time.Duration(timeoutMs) * time.Millisecond)
+	return 0, nil, sysfsNotSupported("rsmi_event_notification_get")
+}
+
+func (b *SysfsBackend) RsmiEventNotificationStop(dvInd int) (err error) {
+	return sysfsNotSupported("rsmi_event_notification_stop")
+}
+
+/****************************************** 拓扑 *********************************************/
+
+func (b *SysfsBackend) RsmiTopoGetLinkWeight(dvIndSrc, dvIndDst int) (weight int64, err error) {
+	return 0, sysfsNotSupported("rsmi_topo_get_link_weight")
+}
+
+func (b *SysfsBackend) RsmiTopoGetLinkType(dvIndSrc, dvIndDst int) (hops int64, linkType RSMIIOLinkType, err error) {
+	return 0, linkType, sysfsNotSupported("rsmi_topo_get_link_type")
+}
+
+func (b *SysfsBackend) RsmiTopoGetNumaBodeBumber(dvInd int) (numaNode int, err error) {
+	return b.RsmiTopoNumaAffinityGet(dvInd)
+}
+
+func (b *SysfsBackend) RsmiMinmaxBandwidthGet(dvIndSrc, dvIndDst int) (minBandwidth, maxBandwidth int64, err error) {
+	return 0, 0, sysfsNotSupported("rsmi_minmax_bandwidth_get")
+}
@@ -0,0 +1,94 @@
+package dcgm
+
+import (
+	"testing"
+)
+
+const sysfsFixtureRoot = "../../samples/sysfs/fixture"
+
+func TestSysfsBackendFixture(t *testing.T) {
+	if err := InitWithBackendName(BackendSysfs + ":" + sysfsFixtureRoot); err != nil {
+		t.Fatalf("InitWithBackendName: %v", err)
+	}
+	t.Cleanup(func() { ShutDown() })
+	n, err := NumMonitorDevices()
+	if err != nil || n != 2 {
+		t.Fatalf("NumMonitorDevices = %d, %v, want 2", n, err)
+	}
+
+	tests := []struct {
+		temps     [3]int64 // edge、junction、mem，毫摄氏度
+		power     int64    // 微瓦
+		powerCap  int64    // 微瓦
+		vramTotal int64
+		vramUsed  int64
+		bdfid     int64
+	}{
+		{[3]int64{45000, 53000, 49000}, 125000000, 300000000, 68702699520, 4294967296, 0x4300},
+		{[3]int64{52000, 60000, 56000}, 220000000, 300000000, 68702699520, 21474836480, 0x6300},
+	}
+	for i, w := range tests {
+		for sensor, want := range w.temps {
+			if got, err := rsmiDevTempMetricGet(i, sensor, RSMI_TEMP_CURRENT); err != nil || got != want {
+				t.Errorf("device %d sensor %d: temperature = %d, %v, want %d", i, sensor, got, err, want)
+			}
+		}
+		if got, err := rsmiDevPowerAveGet(i, 0); err != nil || got != w.power {
+			t.Errorf("device %d: power = %d, %v, want %d", i, got, err, w.power)
+		}
+		if got, err := rsmiDevPowerCapGet(i, 0); err != nil || got != w.powerCap {
+			t.Errorf("device %d: power cap = %d, %v, want %d", i, got, err, w.powerCap)
+		}
+		if got, err := rsmiDevMemoryTotalGet(i, RSMI_MEM_TYPE_VRAM); err != nil || got != w.vramTotal {
+			t.Errorf("device %d: vram total = %d, %v, want %d", i, got, err, w.vramTotal)
+		}
+		if got, err := rsmiDevMemoryUsageGet(i, RSMI_MEM_TYPE_VRAM); err != nil || got != w.vramUsed {
+			t.Errorf("device %d: vram used = %d, %v, want %d", i, got, err, w.vramUsed)
+		}
+		if got, err := rsmiDevPciIdGet(i); err != nil || got != w.bdfid {
+			t.Errorf("device %d: bdfid = %#x, %v, want %#x", i, got, err, w.bdfid)
+		}
+	}
+
+	infos, err := CollectDeviceMetrics()
+	if err != nil {
+		t.Fatalf("CollectDeviceMetrics: %v", err)
+	}
+	wantInfos := []struct {
+		pciBus string
+		serial string
+	}{
+		{"0000:43:00.0", "SN2024K100A00"},
+		{"0000:63:00.0", "SN2024K100A01"},
+	}
+	for i, w := range wantInfos {
+		if got := infos[i]; got.PciBusNumber != w.pciBus || got.DeviceId != w.serial || got.SubSystemName != "K100_AI" {
+			t.Errorf("device %d = %s %s %s, want %s %s K100_AI", i, got.PciBusNumber, got.DeviceId, got.SubSystemName, w.pciBus, w.serial)
+		}
+	}
+}
+
+func TestAutoBackendFallsBackToSysfs(t *testing.T) {
+	if err := newCgoBackend().RsmiInit(); err == nil {
+		newCgoBackend().RsmiShutdown()
+		t.Skip("rsmi is available, auto backend does not fall back")
+	}
+	b, err := NewBackend(BackendAuto + ":" + sysfsFixtureRoot)
+	if err != nil {
+		t.Fatalf("NewBackend: %v", err)
+	}
+	sysfs, ok := b.(*SysfsBackend)
+	if !ok {
+		t.Fatalf("backend is %T, want *SysfsBackend", b)
+	}
+	if sysfs.Root() != sysfsFixtureRoot {
+		t.Errorf("root = %q, want %q", sysfs.Root(), sysfsFixtureRoot)
+	}
+	if err := InitWithBackend(b); err != nil {
+		t.Fatalf("InitWithBackend: %v", err)
+	}
+	t.Cleanup(func() { ShutDown() })
+	if n, err := NumMonitorDevices(); err != nil || n != 2 {
+		t.Errorf("NumMonitorDevices = %d, %v, want 2", n, err)
+	}
+}
@@ -0,0 +1,351 @@
+package dcgm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+var (
+	// ErrUnknownMetricsRevision gpu metrics 度量表版本不在已知范围内，无法按版本解码
+	ErrUnknownMetricsRevision = errors.New("unknown gpu metrics table revision")
+	// ErrThrottleNotTracked 查询未启动节流统计的设备时返回的错误
+	ErrThrottleNotTracked = errors.New("throttle accounting not started for device")
+)
+
+// DefaultThrottleInterval 默认的节流状态采样间隔
+const DefaultThrottleInterval = time.Second
+
+// gpuMetricsFormatDGPU 独立显卡 gpu metrics 的格式版本，与 rocm_smi.h 中的 RSMI_GPU_METRICS_API_FORMAT_VER 一致
+const gpuMetricsFormatDGPU = 1
+
+// ThrottleReason 设备降频（节流）原因
+type ThrottleReason string
+
+const (
+	// ThrottlePowerCap 功率超过 PPT 上限
+	ThrottlePowerCap ThrottleReason = "power_cap"
+	// ThrottleCurrentLimit 电流超过 TDC 限制或触发 APCC 峰值电流控制
+	ThrottleCurrentLimit ThrottleReason = "current_limit"
+	// ThrottleThermalEdge 边缘温度过高
+	ThrottleThermalEdge ThrottleReason = "thermal_edge"
+	// ThrottleThermalHotspot 结温（热点）过高
+	ThrottleThermalHotspot ThrottleReason = "thermal_hotspot"
+	// ThrottleThermalMemory 显存（HBM）温度过高
+	ThrottleThermalMemory ThrottleReason = "thermal_memory"
+	// ThrottleVRThermal 供电模块（VR）温度过高
+	ThrottleVRThermal ThrottleReason = "vr_thermal"
+	// ThrottleProchot 外部 VR_HOT/PROCHOT 信号拉低频率
+	ThrottleProchot ThrottleReason = "prochot"
+	// ThrottlePlatformPower 平台功率管理（PPM）限制
+	ThrottlePlatformPower ThrottleReason = "platform_power"
+	// ThrottleReliability 可靠性（FIT）限制
+	ThrottleReliability ThrottleReason = "reliability"
+)
+
+// AllThrottleReasons 全部节流原因，顺序固定，用于导出指标
+var AllThrottleReasons = []ThrottleReason{
+	ThrottlePowerCap, ThrottleCurrentLimit, ThrottleThermalEdge, ThrottleThermalHotspot, ThrottleThermalMemory,
+	ThrottleVRThermal, ThrottleProchot, ThrottlePlatformPower, ThrottleReliability,
+}
+
+// throttleLayout 一种 throttle_status 位定义，throttle_status 为 SMU 固件的原始节流位，不同代固件位含义不同
+type throttleLayout struct {
+	bits map[uint]ThrottleReason
+}
+
+// throttleLayoutSMU11 度量表 v1.0、v1.1（SMU11 固件）的节流位
+var throttleLayoutSMU11 = throttleLayout{
+	bits: map[uint]ThrottleReason{
+		0:  ThrottleThermalEdge,    // TEMP_EDGE
+		1:  ThrottleThermalHotspot, // TEMP_HOTSPOT
+		2:  ThrottleThermalMemory,  // TEMP_MEM
+		3:  ThrottleVRThermal,      // TEMP_VR_GFX
+		4:  ThrottleVRThermal,      // TEMP_VR_MEM
+		5:  ThrottleVRThermal,      // TEMP_VR_SOC
+		6:  ThrottleCurrentLimit,   // TDC_GFX
+		7:  ThrottleCurrentLimit,   // TDC_SOC
+		8:  ThrottlePowerCap,       // PPT0
+		9:  ThrottlePowerCap,       // PPT1
+		10: ThrottlePowerCap,       // PPT2
+		11: ThrottlePowerCap,       // PPT3
+		12: ThrottlePlatformPower,  // PPM
+		13: ThrottleReliability,    // FIT
+		14: ThrottleCurrentLimit,   // APCC
+		15: ThrottleProchot,        // VRHOT0
+		16: ThrottleProchot,        // VRHOT1
+	},
+}
+
+// throttleLayoutSMU13 度量表 v1.2、v1.3（SMU13 固件）的节流位
+var throttleLayoutSMU13 = throttleLayout{
+	bits: map[uint]ThrottleReason{
+		0:  ThrottlePowerCap,       // PPT0
+		1:  ThrottlePowerCap,       // PPT1
+		2:  ThrottleCurrentLimit,   // TDC_GFX
+		3:  ThrottleCurrentLimit,   // TDC_SOC
+		4:  ThrottleCurrentLimit,   // TDC_HBM
+		6:  ThrottleThermalHotspot, // TEMP_GPU
+		7:  ThrottleThermalMemory,  // TEMP_MEM
+		11: ThrottleVRThermal,      // TEMP_VR_GFX
+		12: ThrottleVRThermal,      // TEMP_VR_SOC
+		13: ThrottleVRThermal,      // TEMP_VR_MEM
+		19: ThrottleCurrentLimit,   // APCC
+	},
+}
+
+// throttleLayoutFor 按度量表版本选择节流位定义
+func throttleLayoutFor(header MetricsTableHeader) (*throttleLayout, error) {
+	if header.FormatRevision == gpuMetricsFormatDGPU {
+		switch header.ContentRevision {
+		case 0, 1:
+			return &throttleLayoutSMU11, nil
+		case 2, 3:
+			return &throttleLayoutSMU13, nil
+		}
+	}
+	return nil, fmt.Errorf("%w v%d.%d", ErrUnknownMetricsRevision, header.FormatRevision, header.ContentRevision)
+}
+
+// DecodeThrottleStatus 按度量表版本把 RSMIGPUMetrics.ThrottleStatus 解码为节流原因，多个位对应同一原因时只出现一次；
+// unknownBits 为当前版本未定义的置位，版本未知时返回 ErrUnknownMetricsRevision
+func DecodeThrottleStatus(header MetricsTableHeader, status uint32) (reasons []ThrottleReason, unknownBits uint32, err error) {
+	layout, err := throttleLayoutFor(header)
+	if err != nil {
+		return nil, status, err
+	}
+	active := map[ThrottleReason]bool{}
+	for bit := uint(0); bit < 32; bit++ {
+		if status&(1<<bit) == 0 {
+			continue
+		}
+		if reason, ok := layout.bits[bit]; ok {
+			active[reason] = true
+		} else {
+			unknownBits |= 1 << bit
+		}
+	}
+	for _, reason := range AllThrottleReasons {
+		if active[reason] {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons, unknownBits, nil
+}
+
+// ThrottleInfo 设备当前的节流状态
+type ThrottleInfo struct {
+	//  DeviceID 设备索引号
+	DeviceID int
+	//  Revision 度量表版本，如 1.3
+	Revision string
+	//  ThrottleStatus 原始节流状态位
+	ThrottleStatus uint32
+	//  Reasons 当前生效的节流原因
+	Reasons []ThrottleReason
+	//  UnknownBits 当前版本未定义的置位
+	UnknownBits uint32
+}
+
+// DeviceThrottleInfo 读取设备的 gpu metrics 并解码当前的节流原因
+func DeviceThrottleInfo(dvInd int) (throttleInfo ThrottleInfo, err error) {
+	gpuMetrics, err := rsmiDevGpuMetricsInfoGet(dvInd)
+	if err != nil {
+		return throttleInfo, err
+	}
+	throttleInfo = ThrottleInfo{
+		DeviceID:       dvInd,
+		Revision:       fmt.Sprintf("%d.%d", gpuMetrics.CommonHeader.FormatRevision, gpuMetrics.CommonHeader.ContentRevision),
+		ThrottleStatus: gpuMetrics.ThrottleStatus,
+	}
+	throttleInfo.Reasons, throttleInfo.UnknownBits, err = DecodeThrottleStatus(gpuMetrics.CommonHeader, gpuMetrics.ThrottleStatus)
+	if err != nil {
+		return throttleInfo, fmt.Errorf("Error DeviceThrottleInfo:%w", err)
+	}
+	return throttleInfo, nil
+}
+
+// ThrottleStats 节流统计启动以来设备每个节流原因累计的时长
+type ThrottleStats struct {
+	//  DeviceID 设备索引号
+	DeviceID int
+	//  Since 统计开始时间
+	Since time.Time
+	//  Active 最近一次采样时生效的节流原因
+	Active []ThrottleReason
+	//  Seconds 每个节流原因累计的秒数，未出现过的原因为0
+	Seconds map[ThrottleReason]float64
+}
+
+// throttleTrack 单个设备的节流统计
+type throttleTrack struct {
+	last    time.Time
+	active  []ThrottleReason
+	seconds map[ThrottleReason]float64
+}
+
+// throttleAccountant 后台采样 gpu metrics 的节流统计，两次采样之间的时长计入前一次采样时生效的原因
+type throttleAccountant struct {
+	mu      sync.Mutex
+	since   time.Time
+	devices []int
+	tracks  map[int]*throttleTrack
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+var defaultThrottle = &throttleAccountant{}
+
+// StartThrottleAccounting 启动节流统计，每隔 interval 读取 devices 的节流状态，devices 为空时统计所有设备，
+// interval 为零时取默认值。已经启动时先停止并丢弃已有数据。
+func StartThrottleAccounting(devices []int, interval time.Duration) error {
+	if interval == 0 {
+		interval = DefaultThrottleInterval
+	}
+	if interval < 0 {
+		return fmt.Errorf("Error StartThrottleAccounting:invalid interval %v", interval)
+	}
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return fmt.Errorf("Error StartThrottleAccounting:%w", err)
+	}
+	if len(devices) == 0 {
+		for i := 0; i < numDevices; i++ {
+			devices = append(devices, i)
+		}
+	}
+	for _, dvInd := range devices {
+		if dvInd < 0 || dvInd >= numDevices {
+			return fmt.Errorf("Error StartThrottleAccounting:device %d out of range [0, %d)", dvInd, numDevices)
+		}
+	}
+	StopThrottleAccounting()
+	a := defaultThrottle
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.since = time.Now()
+	a.devices = append([]int{}, devices...)
+	a.tracks = map[int]*throttleTrack{}
+	a.stop = make(chan struct{})
+	a.done = make(chan struct{})
+	go a.run(interval, a.stop, a.done)
+	return nil
+}
+
+// StopThrottleAccounting 停止节流统计并丢弃所有数据，未启动时不做任何事
+func StopThrottleAccounting() {
+	a := defaultThrottle
+	a.mu.Lock()
+	stop, done := a.stop, a.done
+	a.stop, a.done = nil, nil
+	a.tracks = nil
+	a.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// ThrottleDurations 返回设备自节流统计启动以来每个节流原因累计的时长，
+// 未启动统计或设备不在统计范围内时返回 ErrThrottleNotTracked
+func ThrottleDurations(dvInd int) (stats ThrottleStats, err error) {
+	a := defaultThrottle
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.tracks == nil || !containsInt(a.devices, dvInd) {
+		return stats, fmt.Errorf("Error ThrottleDurations:device %d:%w", dvInd, ErrThrottleNotTracked)
+	}
+	stats = ThrottleStats{DeviceID: dvInd, Since: a.since, Seconds: map[ThrottleReason]float64{}}
+	for _, reason := range AllThrottleReasons {
+		stats.Seconds[reason] = 0
+	}
+	if t, ok := a.tracks[dvInd]; ok {
+		stats.Active = append([]ThrottleReason{}, t.active...)
+		for reason, seconds := range t.seconds {
+			stats.Seconds[reason] = seconds
+		}
+	}
+	return stats, nil
+}
+
+// ThrottleAccountingDevices 返回节流统计的设备，未启动时返回空
+func ThrottleAccountingDevices() []int {
+	a := defaultThrottle
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.tracks == nil {
+		return nil
+	}
+	devices := append([]int{}, a.devices...)
+	sort.Ints(devices)
+	return devices
+}
+
+func (a *throttleAccountant) run(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		a.sample()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample 读取一次所有设备的节流状态，读取失败的设备清空生效原因，读取失败的时段不计时
+func (a *throttleAccountant) sample() {
+	a.mu.Lock()
+	devices := a.devices
+	a.mu.Unlock()
+	infos := make(map[int]ThrottleInfo, len(devices))
+	for _, dvInd := range devices {
+		info, err := DeviceThrottleInfo(dvInd)
+		if err != nil {
+			glog.V(2).Infof("throttle accounting device %d:%v", dvInd, err)
+			continue
+		}
+		infos[dvInd] = info
+	}
+	now := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// 采样期间统计可能已被停止
+	if a.tracks == nil {
+		return
+	}
+	for _, dvInd := range devices {
+		t, ok := a.tracks[dvInd]
+		if !ok {
+			t = &throttleTrack{seconds: map[ThrottleReason]float64{}}
+			a.tracks[dvInd] = t
+		}
+		t.record(now, infos[dvInd].Reasons)
+	}
+}
+
+// record 把上一次采样到 now 的时长计入上一次采样时生效的原因，并记录本次生效的原因
+func (t *throttleTrack) record(now time.Time, reasons []ThrottleReason) {
+	elapsed := now.Sub(t.last).Seconds()
+	for _, reason := range t.active {
+		t.seconds[reason] += elapsed
+	}
+	t.last = now
+	t.active = reasons
+}
+
+func containsInt(list []int, v int) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
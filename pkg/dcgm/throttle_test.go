@@ -0,0 +1,82 @@
+package dcgm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeThrottleStatus(t *testing.T) {
+	revision := func(format, content uint8) MetricsTableHeader {
+		return MetricsTableHeader{FormatRevision: format, ContentRevision: content}
+	}
+	tests := []struct {
+		name        string
+		header      MetricsTableHeader
+		status      uint32
+		wantReasons []ThrottleReason
+		wantUnknown uint32
+		wantErr     bool
+	}{
+		{"v1.0 none", revision(1, 0), 0, nil, 0, false},
+		// SMU11：TEMP_EDGE、PPT0、PPT1，两个 PPT 位只报告一次 power_cap
+		{"v1.0 edge and ppt", revision(1, 0), 1<<0 | 1<<8 | 1<<9, []ThrottleReason{ThrottlePowerCap, ThrottleThermalEdge}, 0, false},
+		{"v1.1 vr, apcc and vrhot", revision(1, 1), 1<<3 | 1<<14 | 1<<16, []ThrottleReason{ThrottleCurrentLimit, ThrottleVRThermal, ThrottleProchot}, 0, false},
+		{"v1.1 ppm, fit and undefined", revision(1, 1), 1<<12 | 1<<13 | 1<<31, []ThrottleReason{ThrottlePlatformPower, ThrottleReliability}, 1 << 31, false},
+		// SMU13：位 0 为 PPT0，位 6 为 TEMP_GPU，与 SMU11 不同
+		{"v1.2 ppt, gpu temp and apcc", revision(1, 2), 1<<0 | 1<<6 | 1<<19, []ThrottleReason{ThrottlePowerCap, ThrottleCurrentLimit, ThrottleThermalHotspot}, 0, false},
+		{"v1.3 hbm temp and undefined", revision(1, 3), 1<<7 | 1<<5 | 1<<8, []ThrottleReason{ThrottleThermalMemory}, 1<<5 | 1<<8, false},
+		{"v1.3 tdc hbm and vr mem", revision(1, 3), 1<<4 | 1<<13, []ThrottleReason{ThrottleCurrentLimit, ThrottleVRThermal}, 0, false},
+		{"v1.4", revision(1, 4), 1, nil, 1, true},
+		{"apu format", revision(2, 1), 1, nil, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons, unknown, err := DecodeThrottleStatus(tt.header, tt.status)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownMetricsRevision) {
+					t.Errorf("DecodeThrottleStatus error = %v, want ErrUnknownMetricsRevision", err)
+				}
+			} else if err != nil {
+				t.Fatalf("DecodeThrottleStatus: %v", err)
+			}
+			if !reflect.DeepEqual(reasons, tt.wantReasons) || unknown != tt.wantUnknown {
+				t.Errorf("DecodeThrottleStatus = %v, unknown %#x, want %v, unknown %#x", reasons, unknown, tt.wantReasons, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestThrottleTrackRecord(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	power := []ThrottleReason{ThrottlePowerCap}
+	powerThermal := []ThrottleReason{ThrottlePowerCap, ThrottleThermalHotspot}
+	// 每次采样的时刻与生效的原因，读取失败的采样没有原因
+	samples := []struct {
+		at      time.Duration
+		reasons []ThrottleReason
+	}{
+		{0, power},
+		{2 * time.Second, powerThermal},
+		{3 * time.Second, nil},
+		{5 * time.Second, powerThermal},
+		{5500 * time.Millisecond, power},
+		{7 * time.Second, nil},
+	}
+	want := []map[ThrottleReason]float64{
+		{},
+		{ThrottlePowerCap: 2},
+		{ThrottlePowerCap: 3, ThrottleThermalHotspot: 1},
+		{ThrottlePowerCap: 3, ThrottleThermalHotspot: 1},
+		{ThrottlePowerCap: 3.5, ThrottleThermalHotspot: 1.5},
+		{ThrottlePowerCap: 5, ThrottleThermalHotspot: 1.5},
+	}
+	track := &throttleTrack{seconds: map[ThrottleReason]float64{}}
+	for i, s := range samples {
+		track.record(t0.Add(s.at), s.reasons)
+		if !reflect.DeepEqual(track.seconds, want[i]) || !reflect.DeepEqual(track.active, s.reasons) {
+			t.Errorf("after sample %d: seconds %v, active %v, want %v, %v", i, track.seconds, track.active, want[i], s.reasons)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package types 定义 dcgm 对外返回的数据结构，不依赖 cgo 和 rocm_smi.h，
+// 只需要这些类型的调用方（例如调度器、看板）可以直接引用本包，在任何环境下编译。
+package types
+
+// MonitorInfo 设备监控信息
+// swagger:model MonitorInfo
+type MonitorInfo struct {
+	//  MinorNumber 设备索引号
+	MinorNumber int
+	//  PciBusNumber PCI ID
+	PciBusNumber string
+	//  DeviceId 设备序列号
+	DeviceId string
+	//  SubSystemName 型号名称
+	SubSystemName string
+	// Temperature 设备温度
+	Temperature float64
+	//  PowerUsage 设备平均功耗
+	PowerUsage float64
+	//  PowerCap 设备功率上限
+	PowerCap float64
+	//  MemoryCap 设备内存总量
+	MemoryCap float64
+	//  MemoryUsed 设备内存使用量
+	MemoryUsed float64
+	//  UtilizationRate 设备忙碌时间百分比
+	UtilizationRate float64
+	//  GfxActivity 由活动累加器计算的平均图形活动百分比，比 UtilizationRate 的瞬时值稳定
+	GfxActivity float64
+	//  MemActivity 由活动累加器计算的平均内存活动百分比
+	MemActivity float64
+	//  PcieBwMb pcie流量信息
+	PcieBwMb float64
+	// Clk 系统时钟速度
+	Clk float64
+	// SclkFrequency 系统时钟频率列表
+	SclkFrequency []string
+	// Socclk socclk时钟
+	Socclk float64
+	// SocclkFrequency Soc时钟频率列表
+	SocclkFrequency []string
+	// PerfLevel 性能水平
+	PerfLevel string
+	// ThrottleReasons 当前生效的节流原因，如 power_cap、thermal_hotspot，无节流时为空
+	ThrottleReasons []string
+}
+
+// DeviceInfo 设备信息结构体
+type DeviceInfo struct {
+	// DvInd 设备索引
+	DvInd int
+	// DeviceId 设备ID
+	DeviceId string
+	// DevType 设备类型
+	DevType string
+	// DevTypeName 设备类型名称
+	DevTypeName string
+	// PciBusNumber 设备的总线号
+	PciBusNumber string
+	// MemoryTotal 设备的内存总量
+	MemoryTotal float64
+	// MemoryUsed 设备的已使用内存量
+	MemoryUsed float64
+	// ComputeUnit 设备的计算单元数量
+	ComputeUnit float64
+}
+
+// DMIVDeviceInfo 虚拟设备信息
+type DMIVDeviceInfo struct {
+	// Name 虚拟设备的名称
+	Name string
+
+	// ComputeUnitCount 虚拟设备的计算单元数量
+	ComputeUnitCount int
+
+	// GlobalMemSize 虚拟设备的全局内存大小
+	// @swagignore
+	GlobalMemSize uintptr
+
+	// UsageMemSize 虚拟设备的已使用内存大小
+	// @swagignore
+	UsageMemSize uintptr
+
+	// ContainerID 虚拟设备的容器ID
+	ContainerID uint64
+
+	// DeviceID 虚拟设备的设备ID
+	DeviceID int
+
+	// Percent 虚拟设备的使用百分比
+	Percent int
+
+	// VMinorNumber 虚拟设备的索引号
+	VMinorNumber int
+
+	// PciBusNumber 虚拟设备的总线编号
+	PciBusNumber string
+}
+
+// Device 物理设备的详细信息
+type Device struct {
+	// MinorNumber 设备的索引号
+	MinorNumber int
+
+	// PciBusNumber 设备的总线编号
+	PciBusNumber string
+
+	// DeviceId 设备的唯一标识符
+	DeviceId string
+
+	// SubSystemName 设备的子系统名称
+	SubSystemName string
+
+	// Temperature 设备当前的温度
+	Temperature float64
+
+	// PowerUsage 设备当前的功耗
+	PowerUsage float64
+
+	// PowerCap 设备的功耗上限
+	PowerCap float64
+
+	// MemoryCap 设备的内存容量
+	MemoryCap float64
+
+	// MemoryUsed 设备已使用的内存
+	MemoryUsed float64
+
+	// UtilizationRate 设备的利用率
+	UtilizationRate float64
+
+	// PcieBwMb 设备的PCIe带宽 (MB/s)
+	PcieBwMb float64
+
+	// Clk 设备的当前时钟频率
+	Clk float64
+
+	// ComputeUnitCount 设备的计算单元总数
+	ComputeUnitCount float64
+
+	// ComputeUnitRemainingCount 设备剩余可用的计算单元数量
+	ComputeUnitRemainingCount uint64
+
+	// MemoryRemaining 设备剩余可用的内存量
+	MemoryRemaining uint64
+
+	// Percent 物理设备使用百分比
+	Percent int
+
+	// MaxVDeviceCount 物理设备上支持的最大虚拟设备数量
+	MaxVDeviceCount int
+	// VDeviceCount 虚拟设备数量
+	VDeviceCount int
+
+	// BlocksInfo 设备的block信息
+	BlocksInfos []BlocksInfo
+}
+
+// PhysicalDeviceInfo 物理设备信息
+type PhysicalDeviceInfo struct {
+	// Device 物理设备的详细信息
+	Device Device
+	// VirtualDevices 该物理设备上关联的虚拟设备信息列表
+	VirtualDevices []DMIVDeviceInfo
+}
+
+// FailedMessage 重置clock错误信息
+// @Description 包含重置clock操作失败时的设备ID和错误信息
+type FailedMessage struct {
+	// ID 设备ID
+	ID int
+	// ErrorMsg 错误信息
+	ErrorMsg string
+}
+
+// BlocksInfo 设备 RAS 块的 ECC 状态与错误计数
+type BlocksInfo struct {
+	Block string
+	State string
+	CE    int64
+	UE    int64
+}
@@ -1,20 +1,8 @@
 package dcgm
 
-/*
-#cgo CFLAGS: -Wall -I./include
-#cgo LDFLAGS: -L./lib -lrocm_smi64 -lhydmi -Wl,--unresolved-symbols=ignore-in-object-files
-#include <stdint.h>
-#include <kfd_ioctl.h>
-#include <rocm_smi64Config.h>
-#include <rocm_smi.h>
-#include <dmi_virtual.h>
-#include <dmi_error.h>
-#include <dmi.h>
-#include <dmi_mig.h>
-*/
-import "C"
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -22,37 +10,10 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"unsafe"
 
 	"github.com/golang/glog"
 )
 
-func errorString(result C.rsmi_status_t) error {
-	if RSMIStatus(result) == RSMI_STATUS_SUCCESS {
-		return nil
-	}
-	var cStatusString *C.char
-	statusCode := C.rsmi_status_string(result, (**C.char)(unsafe.Pointer(&cStatusString)))
-	if RSMIStatus(statusCode) != RSMI_STATUS_SUCCESS {
-		return fmt.Errorf("error: %s", statusCode)
-	}
-	goStatusString := C.GoString(cStatusString)
-	return fmt.Errorf("%s", goStatusString)
-}
-
-func dmiErrorString(result C.dmiStatus) error {
-	if DMIStatus(result) == DMI_STATUS_SUCCESS {
-		return nil
-	}
-	var cStatusString *C.char
-	statusCode := C.dmiGetStatusString(result, (**C.char)(unsafe.Pointer(&cStatusString)))
-	if DMIStatus(statusCode) != DMI_STATUS_SUCCESS {
-		return fmt.Errorf("error: %s", statusCode)
-	}
-	goStatusString := C.GoString(cStatusString)
-	return fmt.Errorf("%s", goStatusString)
-}
-
 func dataToJson(data any) string {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
@@ -61,16 +22,6 @@ func dataToJson(data any) string {
 	return string(jsonData)
 }
 
-// 获取所提供的RSMI错误状态的描述
-func go_rsmi_status_string(status RSMIStatus) (statusStr string, err error) {
-	var cstatusStr *C.char
-	ret := C.rsmi_status_string(C.rsmi_status_t(status), (**C.char)(unsafe.Pointer(&cstatusStr)))
-	if err = errorString(ret); err != nil {
-		return statusStr, fmt.Errorf("Error go_rsmi_status_string:%s", err)
-	}
-	statusStr = C.GoString(cstatusStr)
-	return
-}
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -267,44 +218,6 @@ func profileEnum(profile string) RSNIPowerProfilePresetMasks {
 	return RSMI_PWR_PROF_PRST_INVALID
 }
 
-func printTableLog(headers []string, data [][]string, device int, title string) {
-	fmt.Printf("Device: %d - %s\n", device, title)
-	fmt.Println(headers)
-	for _, row := range data {
-		fmt.Println(row)
-	}
-	fmt.Println()
-}
-
-func formatMatrixToJSON(deviceList []int, matrix [][]int64, metricName string) {
-	for rowIndx := 0; rowIndx < len(deviceList); rowIndx++ {
-		for colInd := rowIndx + 1; colInd < len(deviceList); colInd++ {
-			valueStr := matrix[deviceList[rowIndx]][deviceList[colInd]]
-			fmt.Printf(metricName+"\n", deviceList[rowIndx], deviceList[colInd])
-			fmt.Println(valueStr)
-		}
-	}
-}
-
-func formatMatrixToStrJSON(deviceList []int, matrix [][]string, metricName string) {
-	for rowIndx := 0; rowIndx < len(deviceList); rowIndx++ {
-		for colInd := rowIndx + 1; colInd < len(deviceList); colInd++ {
-			valueStr := matrix[deviceList[rowIndx]][deviceList[colInd]]
-			fmt.Printf(metricName+"\n", deviceList[rowIndx], deviceList[colInd])
-			fmt.Println(valueStr)
-		}
-	}
-}
-
-func printTableRow(format string, displayString interface{}) {
-	if format != "" {
-		fmt.Printf(format, displayString)
-	} else {
-		fmt.Print(displayString)
-	}
-	fmt.Print(" ")
-}
-
 // 获取指定目录下的文件列表，如果目录不存在或为空，返回空切片
 func getConfigFiles(dir string) ([]os.DirEntry, error) {
 	files, err := os.ReadDir(dir)
@@ -495,3 +408,32 @@ func process0000Dir(dirPath string, foundCounter *int) error {
 
 	return nil
 }
+
+// 打印事件列表方法，delay 已不再使用，事件由后台统一收集
+func printEventList(device int, delay int, eventList []string) {
+	var types []EventType
+	if len(eventList) > 0 {
+		var err error
+		if types, err = ParseNotificationTypes(strings.Join(eventList, ",")); err != nil {
+			glog.Error(device, err)
+			return
+		}
+	}
+	printEvents([]int{device}, types)
+}
+
+// printEvents 订阅并打印事件，直到 ShutDown 关闭订阅；信号处理由调用方负责
+func printEvents(devices []int, types []EventType) {
+	events, err := Subscribe(context.Background(), devices, types)
+	if err != nil {
+		glog.Error(devices, "Unable to subscribe to event notifications: ", err)
+		return
+	}
+	print2DArray([][]string{{"DEVICE", "TIME", "TYPE", "DESCRIPTION"}})
+	for event := range events {
+		print2DArray([][]string{
+			{fmt.Sprintf("GPU[%d]", event.DeviceID), event.Time.Format("2006-01-02 15:04:05"), string(event.Type), event.Message},
+		})
+	}
+	fmt.Println("Exiting...")
+}
@@ -0,0 +1,221 @@
+package dcgm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrActivityCounterReset 两次读数之间活动累加器被重置（驱动重载或设备复位），无法计算平均利用率
+var ErrActivityCounterReset = errors.New("activity accumulator reset between samples")
+
+// DefaultActivityWindow AverageUtilization 默认阻塞采样的时长
+const DefaultActivityWindow = time.Second
+
+// 活动累加器的来源
+const (
+	ActivitySourceGpuMetrics  = "gpu_metrics"  // gpu metrics 中的 gfx_activity_acc/mem_activity_acc
+	ActivitySourceCoarseGrain = "coarse_grain" // rsmi_utilization_count_get 的粗粒度计数器
+)
+
+const (
+	// activityAccTicksPerMs SMU 每毫秒把当前的繁忙百分比累加到活动累加器，满载时累加器每毫秒增长 100
+	activityAccTicksPerMs = 1
+	// activityMinInterval 两次读数的最小间隔，间隔过短时误差大，直接返回上一次的结果
+	activityMinInterval = 100 * time.Millisecond
+	// activityMaxAge 上一次读数超过该时长后不再作为起点
+	activityMaxAge = 5 * time.Minute
+)
+
+// ActivityCounters 设备活动累加器的一次读数
+type ActivityCounters struct {
+	//  DeviceID 设备索引号
+	DeviceID int
+	//  Source 累加器来源，gpu_metrics 或 coarse_grain
+	Source string
+	//  Timestamp 读数时间戳（纳秒），由驱动给出
+	Timestamp uint64
+	//  GfxActivityAcc 图形活动累加器
+	GfxActivityAcc uint32
+	//  MemActivityAcc 内存活动累加器
+	MemActivityAcc uint32
+}
+
+// ActivityUtilization 由两次累加器读数计算的平均利用率
+type ActivityUtilization struct {
+	//  DeviceID 设备索引号
+	DeviceID int
+	//  Source 累加器来源
+	Source string
+	//  Interval 两次读数之间的时长
+	Interval time.Duration
+	//  GfxActivity 平均图形活动百分比
+	GfxActivity float64
+	//  MemActivity 平均内存活动百分比
+	MemActivity float64
+}
+
+// ReadActivityCounters 读取设备的图形与内存活动累加器，优先使用 gpu metrics（v1.1 起才有累加器），
+// 否则使用 rsmi 的粗粒度利用率计数器
+func ReadActivityCounters(dvInd int) (counters ActivityCounters, err error) {
+	gpuMetrics, err := rsmiDevGpuMetricsInfoGet(dvInd)
+	if err == nil && gpuMetrics.CommonHeader.FormatRevision == gpuMetricsFormatDGPU && gpuMetrics.CommonHeader.ContentRevision >= 1 {
+		return ActivityCounters{
+			DeviceID:       dvInd,
+			Source:         ActivitySourceGpuMetrics,
+			Timestamp:      gpuMetrics.SystemClockCounter,
+			GfxActivityAcc: gpuMetrics.GfxActivityAcc,
+			MemActivityAcc: gpuMetrics.MemActivityAcc,
+		}, nil
+	}
+	utilizationCounters := []RSMIUtilizationCounter{
+		{Type: RSMI_COARSE_GRAIN_GFX_ACTIVITY},
+		{Type: RSMI_COARSE_GRAIN_MEM_ACTIVITY},
+	}
+	timestamp, err := rsmiUtilizationCountGet(dvInd, utilizationCounters, len(utilizationCounters))
+	if err != nil {
+		return counters, fmt.Errorf("Error ReadActivityCounters:%w", err)
+	}
+	return ActivityCounters{
+		DeviceID:       dvInd,
+		Source:         ActivitySourceCoarseGrain,
+		Timestamp:      uint64(timestamp),
+		GfxActivityAcc: uint32(utilizationCounters[0].Value),
+		MemActivityAcc: uint32(utilizationCounters[1].Value),
+	}, nil
+}
+
+// UtilizationBetween 由同一设备的两次累加器读数计算期间的平均图形与内存活动百分比。
+// 32 位累加器的一次回绕按差值处理；差值超出满载所能达到的上限时视为累加器被重置，返回 ErrActivityCounterReset
+func UtilizationBetween(prev, cur ActivityCounters) (util ActivityUtilization, err error) {
+	if prev.DeviceID != cur.DeviceID || prev.Source != cur.Source {
+		return util, fmt.Errorf("Error UtilizationBetween:samples from different devices or sources")
+	}
+	if cur.Timestamp <= prev.Timestamp {
+		return util, fmt.Errorf("Error UtilizationBetween:non-increasing timestamp %d -> %d", prev.Timestamp, cur.Timestamp)
+	}
+	elapsed := time.Duration(cur.Timestamp - prev.Timestamp)
+	ticks := float64(elapsed) / float64(time.Millisecond) * activityAccTicksPerMs
+	// 允许 5% 的计时误差
+	limit := math.Ceil(ticks*100*1.05) + 100
+	gfx := float64(cur.GfxActivityAcc - prev.GfxActivityAcc)
+	mem := float64(cur.MemActivityAcc - prev.MemActivityAcc)
+	if gfx > limit || mem > limit {
+		return util, fmt.Errorf("Error UtilizationBetween:device %d:%w", cur.DeviceID, ErrActivityCounterReset)
+	}
+	return ActivityUtilization{
+		DeviceID:    cur.DeviceID,
+		Source:      cur.Source,
+		Interval:    elapsed,
+		GfxActivity: math.Min(gfx/ticks, 100),
+		MemActivity: math.Min(mem/ticks, 100),
+	}, nil
+}
+
+// AverageUtilization 读取两次间隔 interval 的活动累加器，返回期间的平均图形与内存活动百分比，
+// 比 rsmi_dev_busy_percent_get 的瞬时值更稳定。interval 为零时取 DefaultActivityWindow，调用会阻塞 interval
+func AverageUtilization(dvInd int, interval time.Duration) (util ActivityUtilization, err error) {
+	if interval == 0 {
+		interval = DefaultActivityWindow
+	}
+	if interval < activityMinInterval {
+		return util, fmt.Errorf("Error AverageUtilization:interval %v shorter than %v", interval, activityMinInterval)
+	}
+	prev, err := ReadActivityCounters(dvInd)
+	if err != nil {
+		return util, err
+	}
+	time.Sleep(interval)
+	cur, err := ReadActivityCounters(dvInd)
+	if err != nil {
+		return util, err
+	}
+	return UtilizationBetween(prev, cur)
+}
+
+// activityTrack 设备最近一次的累加器读数与计算结果
+type activityTrack struct {
+	counters ActivityCounters
+	util     ActivityUtilization
+}
+
+// ActivityCursor 保存每个设备上一次读取的活动累加器读数，周期性的读取得到的是两次读取之间的平均利用率。
+// 每个周期性读取的调用方（Prometheus 抓取、历史记录、字段监视、REST 接口）使用各自的游标，互不缩短对方的统计区间
+type ActivityCursor struct {
+	mu     sync.Mutex
+	tracks map[int]*activityTrack
+}
+
+// NewActivityCursor 创建活动累加器游标
+func NewActivityCursor() *ActivityCursor {
+	return &ActivityCursor{tracks: map[int]*activityTrack{}}
+}
+
+// defaultActivity 包级函数 CollectDeviceMetrics 与 GetFieldValues 使用的游标
+var defaultActivity = NewActivityCursor()
+
+// Utilization 返回设备自上一次经该游标读取以来的平均利用率，两次读数的间隔由驱动给出的时间戳计算。
+// 没有可用的上一次读数（首次读取、间隔超过 activityMaxAge 或累加器被重置）时不阻塞，返回 rsmi_dev_busy_percent_get
+// 与 rsmi_dev_memory_busy_percent_get 的瞬时值，结果的 Interval 为 0；间隔不足 activityMinInterval 时返回上一次的结果
+func (c *ActivityCursor) Utilization(dvInd int) (util ActivityUtilization, err error) {
+	cur, err := ReadActivityCounters(dvInd)
+	if err != nil {
+		return util, err
+	}
+	c.mu.Lock()
+	last, ok := c.tracks[dvInd]
+	if ok && last.counters.Source == cur.Source && cur.Timestamp >= last.counters.Timestamp {
+		elapsed := time.Duration(cur.Timestamp - last.counters.Timestamp)
+		if elapsed < activityMinInterval {
+			util = last.util
+			c.mu.Unlock()
+			return util, nil
+		}
+		if elapsed <= activityMaxAge {
+			if util, err = UtilizationBetween(last.counters, cur); err == nil {
+				c.tracks[dvInd] = &activityTrack{counters: cur, util: util}
+				c.mu.Unlock()
+				return util, nil
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	if util, err = busyUtilization(cur); err != nil {
+		return util, err
+	}
+	c.mu.Lock()
+	c.tracks[dvInd] = &activityTrack{counters: cur, util: util}
+	c.mu.Unlock()
+	return util, nil
+}
+
+// busyUtilization 没有可用的上一次读数时以瞬时繁忙百分比代替平均值
+func busyUtilization(cur ActivityCounters) (util ActivityUtilization, err error) {
+	gfx, err := rsmiDevBusyPercentGet(cur.DeviceID)
+	if err != nil {
+		return util, fmt.Errorf("Error ActivityCursor.Utilization:%w", err)
+	}
+	mem, err := rsmiDevMemoryBusyPercentGet(cur.DeviceID)
+	if err != nil {
+		return util, fmt.Errorf("Error ActivityCursor.Utilization:%w", err)
+	}
+	return ActivityUtilization{
+		DeviceID:    cur.DeviceID,
+		Source:      cur.Source,
+		GfxActivity: float64(gfx),
+		MemActivity: float64(mem),
+	}, nil
+}
+
+// CollectDeviceMetrics 与包级函数 CollectDeviceMetrics 相同，平均活动百分比由该游标计算
+func (c *ActivityCursor) CollectDeviceMetrics() ([]MonitorInfo, error) {
+	return collectDeviceMetrics(c)
+}
+
+// GetFieldValues 与包级函数 GetFieldValues 相同，平均活动字段由该游标计算
+func (c *ActivityCursor) GetFieldValues(entities []int, fields []FieldID) ([]FieldValue, error) {
+	return getFieldValues(c, entities, fields)
+}
@@ -0,0 +1,98 @@
+package dcgm
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUtilizationBetween(t *testing.T) {
+	counters := func(ts time.Duration, gfx, mem uint32) ActivityCounters {
+		return ActivityCounters{Source: ActivitySourceGpuMetrics, Timestamp: uint64(ts), GfxActivityAcc: gfx, MemActivityAcc: mem}
+	}
+	tests := []struct {
+		name      string
+		prev, cur ActivityCounters
+		wantGfx   float64
+		wantMem   float64
+		wantErr   error
+	}{
+		// 满载时累加器每毫秒增长 100
+		{"average", counters(time.Second, 1000, 0), counters(3*time.Second, 101000, 40000), 50, 20, nil},
+		{"32-bit wrap", counters(time.Second, math.MaxUint32-9999, 0), counters(2*time.Second, 20000, 0), 30, 0, nil},
+		{"clamped to 100 within tolerance", counters(0, 0, 0), counters(time.Second, 102000, 0), 100, 0, nil},
+		{"reset", counters(time.Second, 500000000, 0), counters(2*time.Second, 1000, 0), 0, 0, ErrActivityCounterReset},
+		{"non-increasing timestamp", counters(2*time.Second, 0, 0), counters(2*time.Second, 0, 0), 0, 0, errAny},
+		{"different source", counters(0, 0, 0), ActivityCounters{Source: ActivitySourceCoarseGrain, Timestamp: uint64(time.Second)}, 0, 0, errAny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			util, err := UtilizationBetween(tt.prev, tt.cur)
+			switch {
+			case tt.wantErr == errAny:
+				if err == nil {
+					t.Fatalf("UtilizationBetween = %+v, want an error", util)
+				}
+				return
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("UtilizationBetween error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case err != nil:
+				t.Fatalf("UtilizationBetween: %v", err)
+			}
+			wantInterval := time.Duration(tt.cur.Timestamp - tt.prev.Timestamp)
+			if util.Interval != wantInterval || math.Abs(util.GfxActivity-tt.wantGfx) > 1e-9 || math.Abs(util.MemActivity-tt.wantMem) > 1e-9 {
+				t.Errorf("UtilizationBetween = %v %v%% %v%%, want %v %v%% %v%%",
+					util.Interval, util.GfxActivity, util.MemActivity, wantInterval, tt.wantGfx, tt.wantMem)
+			}
+		})
+	}
+}
+
+// errAny 表示期望返回任意错误
+var errAny = errors.New("any error")
+
+func TestActivityCursor(t *testing.T) {
+	// 设备 0 的繁忙百分比以 70 为中心、周期 30 秒波动，内存繁忙百分比恒为 35
+	advance := initFakeScenario(t)
+	a, b := NewActivityCursor(), NewActivityCursor()
+	check := func(name string, c *ActivityCursor, wantInterval time.Duration, wantGfx, wantMem float64) {
+		t.Helper()
+		start := time.Now()
+		util, err := c.Utilization(0)
+		if err != nil {
+			t.Fatalf("%s: Utilization: %v", name, err)
+		}
+		if elapsed := time.Since(start); elapsed >= DefaultActivityWindow {
+			t.Errorf("%s: Utilization blocked for %v", name, elapsed)
+		}
+		if util.Interval != wantInterval || math.Abs(util.GfxActivity-wantGfx) > 0.01 || math.Abs(util.MemActivity-wantMem) > 0.01 {
+			t.Errorf("%s: Utilization = %v %v%% %v%%, want %v %v%% %v%%", name,
+				util.Interval, util.GfxActivity, util.MemActivity, wantInterval, wantGfx, wantMem)
+		}
+	}
+
+	// 首次读取不阻塞，返回瞬时繁忙百分比
+	check("first read", a, 0, 70, 35)
+	advance(50 * time.Millisecond)
+	check("within the minimum interval", a, 0, 70, 35)
+	advance(30*time.Second - 50*time.Millisecond)
+	check("one period later", a, 30*time.Second, 70, 35)
+
+	// 另一个游标有自己的起点，不缩短 a 的统计区间
+	busy, _ := rsmiDevBusyPercentGet(0)
+	check("second cursor first read", b, 0, float64(busy), 35)
+	advance(30 * time.Second)
+	check("second cursor", b, 30*time.Second, 70, 35)
+	check("first cursor", a, 30*time.Second, 70, 35)
+
+	// 上一次读数过旧时重新以瞬时值开始
+	advance(activityMaxAge + time.Second)
+	busy, _ = rsmiDevBusyPercentGet(0)
+	check("stale previous sample", a, 0, float64(busy), 35)
+	advance(30 * time.Second)
+	check("after the stale sample", a, 30*time.Second, 70, 35)
+}
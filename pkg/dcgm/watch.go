@@ -0,0 +1,423 @@
+package dcgm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+var (
+	// ErrNotWatched 读取未被监视的字段时返回的错误
+	ErrNotWatched = errors.New("field not watched")
+	// ErrNoData 字段已被监视但尚未采样到数据时返回的错误
+	ErrNoData = errors.New("no data sampled yet")
+)
+
+// maxRingSize 单个字段环形缓冲区的最大容量，防止 maxKeepAge 远大于 updateInterval 时占用过多内存
+const maxRingSize = 100000
+
+// Watch 一组字段在一组设备上的监视配置
+type Watch struct {
+	ID             int
+	Devices        []int
+	Fields         []FieldID
+	UpdateInterval time.Duration
+	MaxKeepAge     time.Duration
+}
+
+// watchKey 采样的最小单位：某个设备上的某个字段
+type watchKey struct {
+	dvInd int
+	field FieldID
+}
+
+// watchEntry 单个设备字段的采样计划与历史数据，同一字段被多个监视引用时取最短的间隔与最长的保留时间
+type watchEntry struct {
+	interval time.Duration
+	maxKeep  time.Duration
+	static   bool
+	sampled  bool
+	next     time.Time
+	ring     *fieldRing
+	lastErr  error
+}
+
+// fieldRing 固定容量的环形缓冲区，写满后覆盖最旧的数据
+type fieldRing struct {
+	values []FieldValue
+	start  int
+	size   int
+}
+
+func newFieldRing(capacity int) *fieldRing {
+	return &fieldRing{values: make([]FieldValue, capacity)}
+}
+
+func (r *fieldRing) push(v FieldValue) {
+	if r.size < len(r.values) {
+		r.values[(r.start+r.size)%len(r.values)] = v
+		r.size++
+		return
+	}
+	r.values[r.start] = v
+	r.start = (r.start + 1) % len(r.values)
+}
+
+// all 按时间从旧到新返回所有数据
+func (r *fieldRing) all() []FieldValue {
+	values := make([]FieldValue, 0, r.size)
+	for i := 0; i < r.size; i++ {
+		values = append(values, r.values[(r.start+i)%len(r.values)])
+	}
+	return values
+}
+
+// resize 调整容量，保留最新的数据
+func (r *fieldRing) resize(capacity int) {
+	if capacity == len(r.values) {
+		return
+	}
+	values := r.all()
+	if len(values) > capacity {
+		values = values[len(values)-capacity:]
+	}
+	r.values = make([]FieldValue, capacity)
+	r.start = 0
+	r.size = copy(r.values, values)
+}
+
+// watcher 后台采样器，所有监视共享同一组环形缓冲区
+type watcher struct {
+	mu      sync.Mutex
+	nextID  int
+	watches map[int]*Watch
+	entries map[watchKey]*watchEntry
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	// activity 平均活动字段的游标，与其他调用方的读取互不影响
+	activity *ActivityCursor
+}
+
+var defaultWatcher = &watcher{
+	watches:  map[int]*Watch{},
+	entries:  map[watchKey]*watchEntry{},
+	wake:     make(chan struct{}, 1),
+	activity: NewActivityCursor(),
+}
+
+// WatchFields 在指定设备上监视一组设备字段，后台按 updateInterval 采样并保留最近 maxKeepAge 内的数据；
+// devices 为空时监视所有设备。静态字段（序列号、VBIOS 版本等）只采样一次。
+func WatchFields(devices []int, fields []FieldID, updateInterval, maxKeepAge time.Duration) (*Watch, error) {
+	if updateInterval <= 0 {
+		return nil, fmt.Errorf("Error WatchFields:updateInterval must be positive, got %v", updateInterval)
+	}
+	if maxKeepAge < updateInterval {
+		maxKeepAge = updateInterval
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("Error WatchFields:no fields")
+	}
+	for _, field := range fields {
+		info, ok := lookupField(field)
+		if !ok {
+			return nil, fmt.Errorf("Error WatchFields:unknown field %d", field)
+		}
+		if info.Scope != FieldScopeDevice {
+			return nil, fmt.Errorf("Error WatchFields:field %v is not a device field", field)
+		}
+	}
+	numDevices, err := rsmiNumMonitorDevices()
+	if err != nil {
+		return nil, fmt.Errorf("Error WatchFields:%w", err)
+	}
+	if len(devices) == 0 {
+		for i := 0; i < numDevices; i++ {
+			devices = append(devices, i)
+		}
+	}
+	for _, dvInd := range devices {
+		if dvInd < 0 || dvInd >= numDevices {
+			return nil, fmt.Errorf("Error WatchFields:device %d out of range [0, %d)", dvInd, numDevices)
+		}
+	}
+	w := &Watch{
+		Devices:        append([]int{}, devices...),
+		Fields:         append([]FieldID{}, fields...),
+		UpdateInterval: updateInterval,
+		MaxKeepAge:     maxKeepAge,
+	}
+	defaultWatcher.add(w)
+	return w, nil
+}
+
+// Unwatch 取消监视，不再被任何监视引用的字段停止采样并丢弃历史数据
+func (w *Watch) Unwatch() {
+	defaultWatcher.remove(w.ID)
+}
+
+// Watches 返回当前所有的监视配置
+func Watches() []Watch {
+	defaultWatcher.mu.Lock()
+	defer defaultWatcher.mu.Unlock()
+	watches := make([]Watch, 0, len(defaultWatcher.watches))
+	for _, w := range defaultWatcher.watches {
+		watches = append(watches, *w)
+	}
+	sort.Slice(watches, func(i, j int) bool { return watches[i].ID < watches[j].ID })
+	return watches
+}
+
+// LatestValue 返回字段最近一次的采样值，不访问硬件
+func LatestValue(dvInd int, field FieldID) (FieldValue, error) {
+	defaultWatcher.mu.Lock()
+	defer defaultWatcher.mu.Unlock()
+	entry, ok := defaultWatcher.entries[watchKey{dvInd, field}]
+	if !ok {
+		return FieldValue{}, fmt.Errorf("Error LatestValue:device %d field %v:%w", dvInd, field, ErrNotWatched)
+	}
+	if entry.ring.size == 0 {
+		if entry.lastErr != nil {
+			return FieldValue{}, fmt.Errorf("Error LatestValue:device %d field %v:%w", dvInd, field, entry.lastErr)
+		}
+		return FieldValue{}, fmt.Errorf("Error LatestValue:device %d field %v:%w", dvInd, field, ErrNoData)
+	}
+	return entry.ring.values[(entry.ring.start+entry.ring.size-1)%len(entry.ring.values)], nil
+}
+
+// FieldValues 返回字段在 [start, end] 时间范围内的采样值，按时间从旧到新排列；end 为零值时表示当前时间
+func FieldValues(dvInd int, field FieldID, start, end time.Time) ([]FieldValue, error) {
+	defaultWatcher.mu.Lock()
+	defer defaultWatcher.mu.Unlock()
+	entry, ok := defaultWatcher.entries[watchKey{dvInd, field}]
+	if !ok {
+		return nil, fmt.Errorf("Error FieldValues:device %d field %v:%w", dvInd, field, ErrNotWatched)
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	// 静态字段只有一次采样，始终返回
+	if !entry.static {
+		if oldest := time.Now().Add(-entry.maxKeep); start.Before(oldest) {
+			start = oldest
+		}
+	}
+	var values []FieldValue
+	for _, v := range entry.ring.all() {
+		if entry.static || (!v.Timestamp.Before(start) && !v.Timestamp.After(end)) {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// UpdateAllFields 立即采样所有被监视的字段并等待完成
+func UpdateAllFields() {
+	defaultWatcher.sample(true)
+}
+
+// unwatchAll 取消所有监视，在 ShutDown 时调用
+func unwatchAll() {
+	defaultWatcher.mu.Lock()
+	ids := make([]int, 0, len(defaultWatcher.watches))
+	for id := range defaultWatcher.watches {
+		ids = append(ids, id)
+	}
+	defaultWatcher.mu.Unlock()
+	for _, id := range ids {
+		defaultWatcher.remove(id)
+	}
+}
+
+func (w *watcher) add(watch *Watch) {
+	w.mu.Lock()
+	w.nextID++
+	watch.ID = w.nextID
+	w.watches[watch.ID] = watch
+	w.rebuild()
+	if w.stop == nil {
+		w.stop = make(chan struct{})
+		w.done = make(chan struct{})
+		go w.run(w.stop, w.done)
+	}
+	w.mu.Unlock()
+	w.notify()
+}
+
+func (w *watcher) remove(id int) {
+	w.mu.Lock()
+	if _, ok := w.watches[id]; !ok {
+		w.mu.Unlock()
+		return
+	}
+	delete(w.watches, id)
+	w.rebuild()
+	var done chan struct{}
+	if len(w.watches) == 0 && w.stop != nil {
+		close(w.stop)
+		done = w.done
+		w.stop, w.done = nil, nil
+	}
+	w.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+	w.notify()
+}
+
+// rebuild 根据所有监视重新计算各字段的采样间隔与保留时间，调用方需持有 w.mu
+func (w *watcher) rebuild() {
+	type plan struct{ interval, maxKeep time.Duration }
+	plans := map[watchKey]plan{}
+	for _, watch := range w.watches {
+		for _, dvInd := range watch.Devices {
+			for _, field := range watch.Fields {
+				key := watchKey{dvInd, field}
+				p, ok := plans[key]
+				if !ok || watch.UpdateInterval < p.interval {
+					p.interval = watch.UpdateInterval
+				}
+				if watch.MaxKeepAge > p.maxKeep {
+					p.maxKeep = watch.MaxKeepAge
+				}
+				plans[key] = p
+			}
+		}
+	}
+	now := time.Now()
+	for key := range w.entries {
+		if _, ok := plans[key]; !ok {
+			delete(w.entries, key)
+		}
+	}
+	for key, p := range plans {
+		info, _ := lookupField(key.field)
+		static := info.Static
+		capacity := 1
+		if !static {
+			capacity = int(p.maxKeep/p.interval) + 1
+			if capacity > maxRingSize {
+				capacity = maxRingSize
+			}
+		}
+		entry, ok := w.entries[key]
+		if !ok {
+			w.entries[key] = &watchEntry{
+				interval: p.interval,
+				maxKeep:  p.maxKeep,
+				static:   static,
+				next:     now,
+				ring:     newFieldRing(capacity),
+			}
+			continue
+		}
+		if !static && p.interval < entry.interval {
+			if next := now.Add(p.interval); next.Before(entry.next) {
+				entry.next = next
+			}
+		}
+		entry.interval = p.interval
+		entry.maxKeep = p.maxKeep
+		entry.ring.resize(capacity)
+	}
+}
+
+func (w *watcher) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run 后台采样循环，每次醒来采样到期的字段，再休眠到下一个字段到期
+func (w *watcher) run(stop, done chan struct{}) {
+	defer close(done)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-w.wake:
+		case <-timer.C:
+		}
+		w.sample(false)
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(w.nextDelay())
+	}
+}
+
+// nextDelay 距离下一个字段到期的时间，没有待采样的字段时休眠到被唤醒
+func (w *watcher) nextDelay() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var next time.Time
+	for _, entry := range w.entries {
+		if entry.static && entry.sampled {
+			continue
+		}
+		if next.IsZero() || entry.next.Before(next) {
+			next = entry.next
+		}
+	}
+	if next.IsZero() {
+		return time.Hour
+	}
+	if d := time.Until(next); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// sample 采样到期（all 为 true 时为全部）的字段，同一设备的字段顺序读取，不同设备并发读取
+func (w *watcher) sample(all bool) {
+	now := time.Now()
+	w.mu.Lock()
+	byDevice := map[int][]FieldID{}
+	for key, entry := range w.entries {
+		if !all && (entry.static && entry.sampled || entry.next.After(now)) {
+			continue
+		}
+		entry.next = now.Add(entry.interval)
+		byDevice[key.dvInd] = append(byDevice[key.dvInd], key.field)
+	}
+	w.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for dvInd, fields := range byDevice {
+		wg.Add(1)
+		go func(dvInd int, fields []FieldID) {
+			defer wg.Done()
+			for _, field := range fields {
+				info, _ := lookupField(field)
+				value, err := fieldValue(w.activity, info, dvInd)
+				value.FieldID = field
+				value.EntityID = dvInd
+				value.Timestamp = time.Now()
+				w.mu.Lock()
+				// 采样期间监视可能已被取消
+				if entry, ok := w.entries[watchKey{dvInd, field}]; ok {
+					if err != nil {
+						entry.lastErr = err
+						glog.V(2).Infof("watch device %d field %v: %v", dvInd, field, err)
+					} else {
+						entry.lastErr = nil
+						entry.sampled = true
+						entry.ring.push(value)
+					}
+				}
+				w.mu.Unlock()
+			}
+		}(dvInd, fields)
+	}
+	wg.Wait()
+}
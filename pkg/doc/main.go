@@ -5,7 +5,7 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
-	_ "g.sugon.com/das/dcgm-dcu/pkg/doc/docs"
+	_ "github.com/Project-HAMi/dcu-dcgm/pkg/doc/docs"
 )
 
 //	@title			Swagger Example API
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/golang/glog"
 	swaggerFiles "github.com/swaggo/files"
@@ -12,11 +15,45 @@ import (
 
 	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
 	_ "github.com/Project-HAMi/dcu-dcgm/pkg/service/docs"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/service/metrics"
 	"github.com/Project-HAMi/dcu-dcgm/pkg/service/router"
 )
 
 var (
-	portFlag = flag.Int("port", 16081, "Port number for the DCGM")
+	portFlag    = flag.Int("port", 16081, "Port number for the DCGM")
+	backendFlag = flag.String("backend", "", "DCGM backend spec name[:config], e.g. cgo, auto, sysfs:/sys, fake:scenario.yaml (default cgo, env DCU_DCGM_BACKEND)")
+	recordFlag  = flag.String("record", "", "Record every rsmi/dmi call to this file for replay (env DCU_DCGM_RECORD)")
+	metricsMode = flag.String("metrics-mode", "", "Metric naming of /metrics: dcu or dcgm-exporter (default dcu, env DCU_DCGM_METRICS_MODE)")
+	countersCSV = flag.String("collectors", "", "dcgm-exporter style counters CSV used in dcgm-exporter mode (env DCU_DCGM_COLLECTORS)")
+
+	historyInterval = flag.Duration("history-interval", dcgm.DefaultHistoryInterval, "Sampling interval of the metrics history store, 0 disables history")
+	historyFields   = flag.String("history-fields", "", "Comma separated field IDs or names recorded in history (default temperature, power, clocks, utilization, memory and PCIe fields)")
+	historyRaw      = flag.Duration("history-raw-retention", dcgm.DefaultHistoryRawRetention, "Retention of raw history samples")
+	historyMinute   = flag.Duration("history-minute-retention", dcgm.DefaultHistoryMinuteRetention, "Retention of 1-minute downsampled history")
+	historyHour     = flag.Duration("history-hour-retention", dcgm.DefaultHistoryHourRetention, "Retention of 1-hour downsampled history")
+
+	energyInterval  = flag.Duration("energy-interval", dcgm.DefaultEnergyInterval, "Polling interval of the energy accumulators, must be well below the counter wrap period, 0 disables energy accounting")
+	energyRetention = flag.Duration("energy-retention", dcgm.DefaultEnergyRetention, "Retention of energy totals used by window queries")
+
+	jobStore    = flag.String("job-store", "", "File persisting job statistics across restarts (default /var/lib/dcu-dcgm/jobs.json, env DCU_DCGM_JOB_STORE)")
+	jobInterval = flag.Duration("job-interval", dcgm.DefaultJobInterval, "Sampling interval of running jobs")
+	jobKeep     = flag.Int("job-history", dcgm.DefaultJobKeep, "Number of stopped jobs kept in memory and in the job store")
+
+	processInterval = flag.Duration("process-interval", dcgm.DefaultProcessInterval, "Sampling interval of KFD process accounting, 0 disables it")
+	processKeep     = flag.Int("process-history", dcgm.DefaultProcessKeep, "Number of exited processes kept by process accounting")
+
+	throttleInterval = flag.Duration("throttle-interval", dcgm.DefaultThrottleInterval, "Sampling interval of throttle accounting, 0 disables it")
+
+	healthInterval = flag.Duration("health-interval", dcgm.DefaultHealthInterval, "Interval of the device health watch, 0 disables it")
+	healthSystems  = flag.String("health-systems", "all", "Comma separated health systems watched: ecc, memory, thermal, pcie, xgmi, throttle")
+
+	policyFile     = flag.String("policy-file", "", "YAML or JSON file with the policies enforced at startup (env DCU_DCGM_POLICY_FILE)")
+	policyInterval = flag.Duration("policy-interval", dcgm.DefaultPolicyInterval, "Interval of policy checks")
+
+	eventBuffer = flag.Int("event-buffer", dcgm.DefaultEventStreamBuffer, "Number of recent events kept for clients reconnecting to /events/stream and /events/ws, 0 disables event streaming")
+	wsOrigins   = flag.String("ws-allowed-origins", "", "Comma separated cross-origin Origins allowed by /events/ws besides same-origin pages, * allows any (env DCU_DCGM_WS_ALLOWED_ORIGINS)")
+
+	driverAllowList = flag.String("driver-allowlist", "", "Comma separated driver versions allowed by /diag, wildcards supported, e.g. 6.3.8-* (env DCU_DCGM_DRIVER_ALLOWLIST)")
 )
 
 func main() {
@@ -24,13 +61,143 @@ func main() {
 	flag.Parse()
 	// 确保程序退出时刷新 glog 缓存
 	defer glog.Flush()
-	// 初始化服务
-	err := dcgm.Init()
+	// 初始化服务，后端名称优先取命令行参数，其次取环境变量
+	backend := *backendFlag
+	if backend == "" {
+		backend = os.Getenv("DCU_DCGM_BACKEND")
+	}
+	if backend == "" {
+		backend = dcgm.BackendCgo
+	}
+	// 指定录制文件时，所有原语调用写入该文件，可用 replay:<文件> 后端重放
+	record := *recordFlag
+	if record == "" {
+		record = os.Getenv("DCU_DCGM_RECORD")
+	}
+	var err error
+	if record != "" {
+		var recorder *dcgm.RecordingBackend
+		recorder, err = dcgm.InitWithRecording(backend, record)
+		if recorder != nil {
+			defer recorder.Close()
+		}
+	} else {
+		err = dcgm.InitWithBackendName(backend)
+	}
 	if err != nil {
 		glog.Errorf("DCGM 初始化失败: %v", err)
 		return
 	}
 	defer dcgm.ShutDown()
+	// 设置 /metrics 的指标命名方式，dcgm-exporter 模式可指定计数器文件
+	mode := *metricsMode
+	if mode == "" {
+		mode = os.Getenv("DCU_DCGM_METRICS_MODE")
+	}
+	collectors := *countersCSV
+	if collectors == "" {
+		collectors = os.Getenv("DCU_DCGM_COLLECTORS")
+	}
+	if err = metrics.Configure(mode, collectors); err != nil {
+		glog.Errorf("指标配置失败: %v", err)
+		return
+	}
+	// 启动历史数据存储，供 /history 接口查询
+	if *historyInterval > 0 {
+		cfg := dcgm.HistoryConfig{
+			Interval:        *historyInterval,
+			RawRetention:    *historyRaw,
+			MinuteRetention: *historyMinute,
+			HourRetention:   *historyHour,
+		}
+		if *historyFields != "" {
+			if cfg.Fields, err = dcgm.ParseFieldIDs(*historyFields); err != nil {
+				glog.Errorf("历史字段解析失败: %v", err)
+				return
+			}
+		}
+		if err = dcgm.StartHistory(cfg); err != nil {
+			glog.Errorf("历史数据存储启动失败: %v", err)
+			return
+		}
+	}
+	// 启动能耗统计，供 /energy 接口与指标导出使用
+	if *energyInterval > 0 {
+		if err = dcgm.StartEnergyAccounting(dcgm.EnergyConfig{Interval: *energyInterval, Retention: *energyRetention}); err != nil {
+			// 部分驱动不支持能量累加器，不影响其余功能
+			glog.Warningf("能耗统计启动失败: %v", err)
+		}
+	}
+	// 加载任务记录，未结束的任务继续统计
+	store := *jobStore
+	if store == "" {
+		store = os.Getenv("DCU_DCGM_JOB_STORE")
+	}
+	if store == "" {
+		store = "/var/lib/dcu-dcgm/jobs.json"
+	}
+	if err = dcgm.ConfigureJobs(store, *jobInterval, *jobKeep); err != nil {
+		glog.Warningf("任务记录加载失败，任务只保存在内存中: %v", err)
+		if err = dcgm.ConfigureJobs("", *jobInterval, *jobKeep); err != nil {
+			glog.Errorf("任务统计配置失败: %v", err)
+			return
+		}
+	}
+	// 启动进程统计，记录已退出进程的资源占用
+	if *processInterval > 0 {
+		if err = dcgm.StartProcessAccounting(*processInterval, *processKeep); err != nil {
+			glog.Errorf("进程统计启动失败: %v", err)
+			return
+		}
+	}
+	if *throttleInterval > 0 {
+		if err = dcgm.StartThrottleAccounting(nil, *throttleInterval); err != nil {
+			glog.Errorf("节流统计启动失败: %v", err)
+			return
+		}
+	}
+	// 启动健康监控，/health/{dvInd} 返回监控开始以来的结论
+	if *healthInterval > 0 {
+		systems, err := dcgm.ParseHealthSystems(*healthSystems)
+		if err != nil {
+			glog.Errorf("健康监控参数错误: %v", err)
+			return
+		}
+		if err = dcgm.StartHealthWatch(nil, systems, *healthInterval); err != nil {
+			glog.Errorf("健康监控启动失败: %v", err)
+			return
+		}
+	}
+	// 加载策略文件，之后可通过 /policies 接口修改
+	policies := *policyFile
+	if policies == "" {
+		policies = os.Getenv("DCU_DCGM_POLICY_FILE")
+	}
+	if err = dcgm.ConfigurePolicies(policies, *policyInterval); err != nil {
+		glog.Errorf("策略配置失败: %v", err)
+		return
+	}
+	// 启动事件流，/events/stream 与 /events/ws 推送设备事件与策略违规
+	if *eventBuffer > 0 {
+		if err = dcgm.StartEventStream(*eventBuffer); err != nil {
+			glog.Errorf("事件流启动失败: %v", err)
+			return
+		}
+	}
+	// /events/ws 允许的跨域 Origin
+	origins := *wsOrigins
+	if origins == "" {
+		origins = os.Getenv("DCU_DCGM_WS_ALLOWED_ORIGINS")
+	}
+	if origins != "" {
+		router.ConfigureWebSocketOrigins(strings.Split(origins, ","))
+	}
+	// /diag 的驱动版本允许列表
+	allowList := *driverAllowList
+	if allowList == "" {
+		allowList = os.Getenv("DCU_DCGM_DRIVER_ALLOWLIST")
+	}
+	router.ConfigureDiag(dcgm.DiagOptions{DriverAllowList: dcgm.ParseDriverAllowList(allowList)})
 	log.Println("服务启动中...")
 	// 初始化路由
 	r := router.InitRouter()
@@ -47,6 +214,17 @@ func main() {
 		}
 	}
 
+	// 收到退出信号时关闭 DCGM，保存任务记录等后台状态
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigs
+		glog.Infof("收到信号 %v，服务退出", sig)
+		dcgm.ShutDown()
+		glog.Flush()
+		os.Exit(0)
+	}()
+
 	// 启动服务器，监听指定的端口号
 	err = r.Run(":" + port)
 	if err != nil {
@@ -0,0 +1,257 @@
+// Package metrics 以 Prometheus 文本格式导出 DCU 物理设备与虚拟设备的监控指标
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
+
+const namespace = "dcu"
+
+// deviceLabels 每个物理设备指标都带有的标签：设备索引号、PCI 总线号、序列号、型号名称
+var deviceLabels = []string{"minor_number", "pci_bus_id", "serial", "model_name"}
+
+// vDeviceLabels 虚拟设备指标在物理设备标签的基础上增加虚拟设备索引号与名称
+var vDeviceLabels = append(append([]string{}, deviceLabels...), "vminor_number", "vdevice_name")
+
+// eccLabels ECC 指标在物理设备标签的基础上增加 RAS 块名称
+var eccLabels = append(append([]string{}, deviceLabels...), "block")
+
+// deviceMetric 从 CollectDeviceMetrics 结果中取值的物理设备指标
+type deviceMetric struct {
+	desc  *prometheus.Desc
+	value func(info dcgm.MonitorInfo) float64
+}
+
+// vDeviceMetric 从 VDeviceInfos 结果中取值的虚拟设备指标
+type vDeviceMetric struct {
+	desc  *prometheus.Desc
+	value func(info dcgm.DMIVDeviceInfo) float64
+}
+
+func newDesc(name, help string, labels []string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, nil)
+}
+
+var deviceMetrics = []deviceMetric{
+	{newDesc("temperature_celsius", "Device edge temperature in degrees Celsius.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.Temperature }},
+	{newDesc("power_usage_watts", "Device average power usage in watts.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.PowerUsage }},
+	{newDesc("power_cap_watts", "Device power cap in watts.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.PowerCap }},
+	{newDesc("memory_used_bytes", "Device VRAM used in bytes.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.MemoryUsed }},
+	{newDesc("memory_total_bytes", "Device VRAM total in bytes.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.MemoryCap }},
+	{newDesc("utilization_percent", "Device busy percent.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.UtilizationRate }},
+	{newDesc("gfx_activity_percent", "Average graphics activity since the previous scrape, from the activity accumulator.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.GfxActivity }},
+	{newDesc("mem_activity_percent", "Average memory activity since the previous scrape, from the activity accumulator.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.MemActivity }},
+	{newDesc("sclk_mhz", "Current system clock in MHz.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.Clk }},
+	{newDesc("socclk_mhz", "Current SoC clock in MHz.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.Socclk }},
+	{newDesc("pcie_bandwidth_mb", "PCIe sent plus received traffic in MB over the last sample window.", deviceLabels),
+		func(info dcgm.MonitorInfo) float64 { return info.PcieBwMb }},
+}
+
+// energyDesc 能耗统计启动以来消耗的能量，未启动能耗统计时不导出
+var energyDesc = newDesc("energy_consumption_joules_total", "Energy consumed since energy accounting started in joules.", deviceLabels)
+
+// throttleLabels 节流指标在物理设备标签的基础上增加节流原因
+var throttleLabels = append(append([]string{}, deviceLabels...), "reason")
+
+var (
+	// throttleActiveDesc 每个节流原因当前是否生效
+	throttleActiveDesc = newDesc("throttle_active", "Whether the throttle reason is active (1) or not (0).", throttleLabels)
+	// throttleDurationDesc 节流统计启动以来每个节流原因累计的时长，未启动节流统计时不导出
+	throttleDurationDesc = newDesc("throttle_duration_seconds_total", "Time the throttle reason was active since throttle accounting started in seconds.", throttleLabels)
+)
+
+var (
+	eccCorrectableDesc   = newDesc("ecc_correctable_errors", "Correctable ECC error count per RAS block.", eccLabels)
+	eccUncorrectableDesc = newDesc("ecc_uncorrectable_errors", "Uncorrectable ECC error count per RAS block.", eccLabels)
+)
+
+var vDeviceMetrics = []vDeviceMetric{
+	{newDesc("vdevice_utilization_percent", "Virtual device busy percent.", vDeviceLabels),
+		func(info dcgm.DMIVDeviceInfo) float64 { return float64(info.Percent) }},
+	{newDesc("vdevice_memory_total_bytes", "Virtual device global memory size in bytes.", vDeviceLabels),
+		func(info dcgm.DMIVDeviceInfo) float64 { return float64(info.GlobalMemSize) }},
+	{newDesc("vdevice_memory_used_bytes", "Virtual device memory used in bytes.", vDeviceLabels),
+		func(info dcgm.DMIVDeviceInfo) float64 { return float64(info.UsageMemSize) }},
+	{newDesc("vdevice_compute_units", "Compute units assigned to the virtual device.", vDeviceLabels),
+		func(info dcgm.DMIVDeviceInfo) float64 { return float64(info.ComputeUnitCount) }},
+}
+
+// Collector 实现 prometheus.Collector，每次抓取时通过 pkg/dcgm 实时读取设备指标
+type Collector struct {
+	// activity 平均活动指标的游标，得到的是两次抓取之间的平均值
+	activity *dcgm.ActivityCursor
+}
+
+// NewCollector 创建 DCU 指标采集器
+func NewCollector() *Collector {
+	return &Collector{activity: dcgm.NewActivityCursor()}
+}
+
+// Describe 实现 prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range deviceMetrics {
+		ch <- m.desc
+	}
+	ch <- energyDesc
+	ch <- throttleActiveDesc
+	ch <- throttleDurationDesc
+	ch <- eccCorrectableDesc
+	ch <- eccUncorrectableDesc
+	for _, m := range vDeviceMetrics {
+		ch <- m.desc
+	}
+}
+
+// collectThrottle 导出每个节流原因是否生效，节流统计启动时同时导出每个原因累计的时长
+func collectThrottle(ch chan<- prometheus.Metric, info dcgm.MonitorInfo, labels []string) {
+	active := make(map[string]bool, len(info.ThrottleReasons))
+	for _, reason := range info.ThrottleReasons {
+		active[reason] = true
+	}
+	stats, err := dcgm.ThrottleDurations(info.MinorNumber)
+	if err != nil {
+		glog.V(2).Infof("Error collect throttle durations of device %d:%s", info.MinorNumber, err)
+	}
+	for _, reason := range dcgm.AllThrottleReasons {
+		reasonLabels := append(append([]string{}, labels...), string(reason))
+		value := 0.0
+		if active[string(reason)] {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(throttleActiveDesc, prometheus.GaugeValue, value, reasonLabels...)
+		if err == nil {
+			ch <- prometheus.MustNewConstMetric(throttleDurationDesc, prometheus.CounterValue, stats.Seconds[reason], reasonLabels...)
+		}
+	}
+}
+
+// Collect 实现 prometheus.Collector，单个指标读取失败只记录日志，不影响其余指标
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	monitorInfos, err := c.activity.CollectDeviceMetrics()
+	if err != nil {
+		glog.Errorf("Error collect device metrics:%s", err)
+		return
+	}
+	labelsByMinor := make(map[int][]string, len(monitorInfos))
+	for _, info := range monitorInfos {
+		labels := []string{strconv.Itoa(info.MinorNumber), info.PciBusNumber, info.DeviceId, info.SubSystemName}
+		labelsByMinor[info.MinorNumber] = labels
+		for _, m := range deviceMetrics {
+			ch <- prometheus.MustNewConstMetric(m.desc, prometheus.GaugeValue, m.value(info), labels...)
+		}
+		if energy, err := dcgm.EnergySinceStart(info.MinorNumber); err == nil {
+			ch <- prometheus.MustNewConstMetric(energyDesc, prometheus.CounterValue, energy.Joules, labels...)
+		} else {
+			glog.V(2).Infof("Error collect energy of device %d:%s", info.MinorNumber, err)
+		}
+		collectThrottle(ch, info, labels)
+		blocksInfos, err := dcgm.EccBlocksInfo(info.MinorNumber)
+		if err != nil {
+			glog.Errorf("Error collect ecc blocks info of device %d:%s", info.MinorNumber, err)
+			continue
+		}
+		for _, block := range blocksInfos {
+			blockLabels := append(append([]string{}, labels...), block.Block)
+			ch <- prometheus.MustNewConstMetric(eccCorrectableDesc, prometheus.GaugeValue, float64(block.CE), blockLabels...)
+			ch <- prometheus.MustNewConstMetric(eccUncorrectableDesc, prometheus.GaugeValue, float64(block.UE), blockLabels...)
+		}
+	}
+
+	vDeviceInfos, err := dcgm.VDeviceInfos()
+	if err != nil {
+		glog.Errorf("Error collect virtual device infos:%s", err)
+		return
+	}
+	for _, info := range vDeviceInfos {
+		labels, ok := labelsByMinor[info.DeviceID]
+		if !ok {
+			continue
+		}
+		vLabels := append(append([]string{}, labels...), strconv.Itoa(info.VMinorNumber), info.Name)
+		for _, m := range vDeviceMetrics {
+			ch <- prometheus.MustNewConstMetric(m.desc, prometheus.GaugeValue, m.value(info), vLabels...)
+		}
+	}
+}
+
+const (
+	// ModeDCU 使用 dcu_ 前缀的指标名称（默认）
+	ModeDCU = "dcu"
+	// ModeDCGMExporter 使用与 NVIDIA dcgm-exporter 相同的指标名称与标签
+	ModeDCGMExporter = "dcgm-exporter"
+)
+
+var (
+	handlerMu sync.RWMutex
+	handler   http.Handler
+)
+
+// Configure 设置 /metrics 的导出模式，countersPath 为 dcgm-exporter 模式使用的计数器文件，为空时使用内置默认值
+func Configure(mode, countersPath string) error {
+	var collector prometheus.Collector
+	switch mode {
+	case "", ModeDCU:
+		collector = NewCollector()
+	case ModeDCGMExporter:
+		counters, err := LoadCounters(countersPath)
+		if err != nil {
+			return err
+		}
+		collector = NewDCGMCollector(counters)
+	default:
+		return fmt.Errorf("Error metrics mode:unknown mode %q, expected %s or %s", mode, ModeDCU, ModeDCGMExporter)
+	}
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		return fmt.Errorf("Error register collector:%w", err)
+	}
+	handlerMu.Lock()
+	handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorLog: errorLogger{}})
+	handlerMu.Unlock()
+	return nil
+}
+
+// Handler 返回 /metrics 使用的 HTTP 处理器，只包含 DCU 指标，未调用 Configure 时使用 ModeDCU
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerMu.RLock()
+		h := handler
+		handlerMu.RUnlock()
+		if h == nil {
+			if err := Configure(ModeDCU, ""); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			handlerMu.RLock()
+			h = handler
+			handlerMu.RUnlock()
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// errorLogger 将 promhttp 的错误输出到 glog
+type errorLogger struct{}
+
+func (errorLogger) Println(v ...interface{}) {
+	glog.Errorln(v...)
+}
@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
+
+// defaultCounters 未指定计数器文件时使用的字段列表
+//
+//go:embed default-counters.csv
+var defaultCounters string
+
+// dcgmLabels 与 dcgm-exporter 相同的标签：设备索引号、设备唯一标识、PCI 总线号、型号名称、主机名
+var dcgmLabels = []string{"gpu", "UUID", "pci_bus_id", "modelName", "Hostname"}
+
+// labelFields 生成标签需要读取的字段
+var labelFields = []dcgm.FieldID{dcgm.FieldSerial, dcgm.FieldPciBusID, dcgm.FieldDevName}
+
+// Counter 计数器文件中的一行：字段、指标类型、帮助信息与导出名称
+type Counter struct {
+	Field   string
+	FieldID dcgm.FieldID
+	Type    string
+	Help    string
+	Name    string
+}
+
+// ParseCounters 解析 dcgm-exporter 格式的计数器文件，每行为 "字段, 类型, 帮助信息[, 自定义指标名称]"；
+// 字段按名称在 dcgm 字段注册表中查找，DCGM_FI_ 与 DCU_FI_ 前缀均可，只支持设备字段
+func ParseCounters(r io.Reader) ([]Counter, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Error parse counters:%w", err)
+	}
+	var counters []Counter
+	for _, record := range records {
+		line, _ := reader.FieldPos(0)
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if len(record) < 3 || len(record) > 4 {
+			return nil, fmt.Errorf("Error parse counters:line %d: expected 3 or 4 columns, got %d", line, len(record))
+		}
+		counter := Counter{Field: record[0], Type: record[1], Help: record[2], Name: record[0]}
+		if len(record) == 4 && record[3] != "" {
+			counter.Name = record[3]
+		}
+		meta, ok := dcgm.FieldByName(counter.Field)
+		if !ok || meta.Scope != dcgm.FieldScopeDevice || meta.Type == dcgm.FieldTypeString {
+			return nil, fmt.Errorf("Error parse counters:line %d: unsupported field %s", line, counter.Field)
+		}
+		counter.FieldID = meta.ID
+		if counter.Type != "gauge" && counter.Type != "counter" {
+			return nil, fmt.Errorf("Error parse counters:line %d: unsupported metric type %s", line, counter.Type)
+		}
+		counters = append(counters, counter)
+	}
+	return counters, nil
+}
+
+// LoadCounters 读取计数器文件，路径为空时返回内置的默认计数器
+func LoadCounters(path string) ([]Counter, error) {
+	if path == "" {
+		return ParseCounters(strings.NewReader(defaultCounters))
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error open counters:%w", err)
+	}
+	defer f.Close()
+	return ParseCounters(f)
+}
+
+// DCGMCollector 以 dcgm-exporter 的指标名称与标签导出设备指标，便于与 NVIDIA 集群共用看板和告警规则
+type DCGMCollector struct {
+	counters []Counter
+	descs    []*prometheus.Desc
+	fields   []dcgm.FieldID
+	hostname string
+	// activity 平均活动字段的游标，得到的是两次抓取之间的平均值
+	activity *dcgm.ActivityCursor
+}
+
+// NewDCGMCollector 按计数器列表创建采集器，主机名取环境变量 NODE_NAME，未设置时取 os.Hostname
+func NewDCGMCollector(counters []Counter) *DCGMCollector {
+	hostname := os.Getenv("NODE_NAME")
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+	c := &DCGMCollector{counters: counters, hostname: hostname, activity: dcgm.NewActivityCursor()}
+	c.fields = append(c.fields, labelFields...)
+	seen := map[dcgm.FieldID]bool{}
+	for _, field := range labelFields {
+		seen[field] = true
+	}
+	for _, counter := range counters {
+		c.descs = append(c.descs, prometheus.NewDesc(counter.Name, counter.Help, dcgmLabels, nil))
+		if !seen[counter.FieldID] {
+			seen[counter.FieldID] = true
+			c.fields = append(c.fields, counter.FieldID)
+		}
+	}
+	return c
+}
+
+// Describe 实现 prometheus.Collector
+func (c *DCGMCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+// Collect 实现 prometheus.Collector，通过字段注册表读取所有设备的字段，读取失败的字段不导出
+func (c *DCGMCollector) Collect(ch chan<- prometheus.Metric) {
+	values, err := c.activity.GetFieldValues(nil, c.fields)
+	if err != nil {
+		glog.Errorf("Error collect field values:%s", err)
+		return
+	}
+	byDevice := map[int]map[dcgm.FieldID]dcgm.FieldValue{}
+	var devices []int
+	for _, value := range values {
+		if byDevice[value.EntityID] == nil {
+			byDevice[value.EntityID] = map[dcgm.FieldID]dcgm.FieldValue{}
+			devices = append(devices, value.EntityID)
+		}
+		byDevice[value.EntityID][value.FieldID] = value
+	}
+	for _, dvInd := range devices {
+		fields := byDevice[dvInd]
+		labels := []string{strconv.Itoa(dvInd), fields[dcgm.FieldSerial].Text, fields[dcgm.FieldPciBusID].Text,
+			fields[dcgm.FieldDevName].Text, c.hostname}
+		for i, counter := range c.counters {
+			value := fields[counter.FieldID]
+			if value.Err != "" {
+				glog.V(2).Infof("device %d field %v: %s", dvInd, counter.FieldID, value.Err)
+				continue
+			}
+			valueType := prometheus.GaugeValue
+			if counter.Type == "counter" {
+				valueType = prometheus.CounterValue
+			}
+			ch <- prometheus.MustNewConstMetric(c.descs[i], valueType, value.Value, labels...)
+		}
+	}
+}
@@ -1,13 +1,23 @@
 package router
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang/glog"
+	"golang.org/x/net/websocket"
 
-	"g.sugon.com/das/dcgm-dcu/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/service/metrics"
 )
 
 // @Summary 获取设备名称
@@ -265,6 +275,32 @@ func DevGpuMetricsInfo(c *gin.Context) {
 	c.JSON(http.StatusOK, gpuMetrics)
 }
 
+// DevGpuMetrics 按度量表版本解码设备的 gpu metrics
+// @Summary 获取按版本解码的 GPU 度量信息
+// @Description 读取设备原始的 gpu_metrics 度量表，按格式与内容版本（v1.0–v1.4）解码，
+// @Description 包含 HBM 温度、每个 VCN 的活动、XGMI 与 PCIe 累加器等新版本才有的字段，版本未知时返回错误
+// @Produce json
+// @Param dvInd path int true "设备索引"
+// @Success 200 {object} dcgm.GpuMetrics "GPU 度量信息"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 500 {object} error "读取失败或度量表版本未知"
+// @Router /DevGpuMetrics/{dvInd} [get]
+func DevGpuMetrics(c *gin.Context) {
+	dvInd, err := strconv.Atoi(c.Param("dvInd"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse dvInd:%s", err)))
+		return
+	}
+	gpuMetrics, err := dcgm.DevGpuMetrics(dvInd)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"gpuMetrics": gpuMetrics,
+	}))
+}
+
 // 获取设备监控中的指标
 // @Summary 获取设备监控中的指标
 // @Description 收集所有设备的监控指标信息。
@@ -606,104 +642,1201 @@ func EccStatus(c *gin.Context) {
 
 	eccStatus, err := dcgm.EccStatus(dvInd, blockConverted)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		return
+	}
+	status := map[string]interface{}{
+		"eccStatus": eccStatus,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(status))
+}
+
+// Temperature 获取设备温度
+// @Summary 获取设备温度
+// @Description 返回指定设备的当前温度
+// @Produce json
+// @Param dvInd path int true "设备索引"
+// @Param sensorType query int true "传感器类型:0：Edge GPU temperature; 1:Junction/hotspot temperature;2:VRAM temperature"
+// @Success 200 {float64} float64 "返回温度（摄氏度）"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 500 {object} error "服务器内部错误"
+// @Router /Temperature/{dvInd} [get]
+func Temperature(c *gin.Context) {
+	dvInd, err := strconv.Atoi(c.Param("dvInd"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse("Invalid device ID"))
+		return
+	}
+	sensorType, err := strconv.Atoi(c.Query("sensorType"))
+	if err != nil || sensorType < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse("Invalid sensor type"))
+		return
+	}
+	temp, err := dcgm.Temperature(dvInd, sensorType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		return
+	}
+	devTemp := map[string]interface{}{
+		"temp": temp,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(devTemp))
+}
+
+// VbiosVersion 获取设备的VBIOS版本
+// @Summary 获取设备的VBIOS版本
+// @Description 返回指定设备的VBIOS版本
+// @Produce json
+// @Param dvInd path int true "设备索引"
+// @Success 200 {string} string "返回VBIOS版本"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 500 {object} error "服务器内部错误"
+// @Router /VbiosVersion/{dvInd} [get]
+func VbiosVersion(c *gin.Context) {
+	dvInd, err := strconv.Atoi(c.Param("dvInd"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse("Invalid device ID"))
+		return
+	}
+	vbios, err := dcgm.VbiosVersion(dvInd)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		return
+	}
+	devVbios := map[string]interface{}{
+		"vbios": vbios,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(devVbios))
+}
+
+// Swagger 注解
+// @Summary 设置 GPU 时钟频率
+// @Description 设置 GPU 上指定时钟的允许频率。clkType 设置为默认值，无需传递。
+// @Tags GPU
+// @Accept json
+// @Produce json
+// @Param dvInd query int true "设备索引"
+// @Param freqBitmask query int64 true "频率掩码"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 500 {object} error "服务器内部错误"
+// @Router /DevGpuClkFreqSet [post]
+func DevGpuClkFreqSet(c *gin.Context) {
+	dvInd, err := strconv.Atoi(c.Query("dvInd"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse("无效的 dvInd 参数"))
+		return
+	}
+	freqBitmask, err := strconv.ParseInt(c.Query("freqBitmask"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse("无效的 bwBitmask 参数"))
+		return
+	}
+
+	// 调用 DevGpuClkFreqSet 函数
+	err = dcgm.DevGpuClkFreqSet(dvInd, dcgm.RSMI_CLK_TYPE_SYS, freqBitmask)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(nil))
+}
+
+// Libraries 获取动态库的加载情况
+// @Summary 获取动态库的加载情况
+// @Description 返回 librocm_smi64 与 libhydmi 的路径、版本，以及绑定使用的各符号是否找到
+// @Produce json
+// @Success 200 {object} map[string]interface{} "返回库与符号信息"
+// @Router /Libraries [get]
+func Libraries(c *gin.Context) {
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"libraries": dcgm.Libraries(),
+		"symbols":   dcgm.Symbols(),
+	}))
+}
+
+// metricsHandler Prometheus 指标处理器，导出模式由 metrics.Configure 设置
+var metricsHandler = metrics.Handler()
+
+// Metrics 以 Prometheus 文本格式导出设备指标
+// @Summary 导出 Prometheus 指标
+// @Description 返回每个物理设备的温度、功耗、功率上限、显存、利用率、sclk/socclk、PCIe 带宽与各 RAS 块 ECC 计数，
+// @Description 以及每个虚拟设备的使用百分比、显存与计算单元数量，所有指标带有设备索引号、PCI 总线号、序列号与型号标签；
+// @Description dcgm-exporter 模式下使用 DCGM_FI_* 指标名称与 gpu、UUID、pci_bus_id、modelName、Hostname 标签
+// @Produce plain
+// @Success 200 {string} string "Prometheus 文本格式的指标"
+// @Router /metrics [get]
+func Metrics(c *gin.Context) {
+	metricsHandler.ServeHTTP(c.Writer, c.Request)
+}
+
+// Fields 获取字段注册表
+// @Summary 获取字段注册表
+// @Description 返回所有字段的编号、名称、单位、值类型与所属实体类型（device、vdevice、process）
+// @Produce json
+// @Success 200 {array} dcgm.FieldMeta "字段列表"
+// @Router /fields [get]
+func Fields(c *gin.Context) {
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"fields": dcgm.Fields(),
+	}))
+}
+
+// FieldValues 实时读取一组实体的一组字段
+// @Summary 读取字段值
+// @Description 按字段编号或名称读取一组实体的字段值，实体 ID 的含义由字段所属实体类型决定（设备索引、虚拟设备索引或 PID）
+// @Produce json
+// @Param fields query string true "字段编号或名称，逗号分隔，例如 150,155,252"
+// @Param entities query string false "实体 ID 列表，支持区间，例如 0-3；为空时读取全部实体"
+// @Success 200 {array} dcgm.FieldValue "字段值列表"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 500 {object} error "服务器内部错误"
+// @Router /fields/values [get]
+func FieldValues(c *gin.Context) {
+	fields, err := dcgm.ParseFieldIDs(c.Query("fields"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	entities, err := dcgm.ParseEntityList(c.Query("entities"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	values, err := dcgm.GetFieldValues(entities, fields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"values": values,
+	}))
+}
+
+// historyQuery 历史数据查询参数
+type historyQuery struct {
+	dvInd      int
+	field      dcgm.FieldID
+	start      time.Time
+	end        time.Time
+	resolution dcgm.HistoryResolution
+}
+
+// parseHistoryQuery 解析 dvInd、field、start、end、window 与 resolution 参数；
+// start、end 为 RFC3339 时间，指定 window 时 start 取 end 之前 window 的时间
+func parseHistoryQuery(c *gin.Context) (historyQuery, error) {
+	var q historyQuery
+	var err error
+	if q.dvInd, err = strconv.Atoi(c.Query("dvInd")); err != nil {
+		return q, fmt.Errorf("Error parse dvInd:%s", err)
+	}
+	fields, err := dcgm.ParseFieldIDs(c.Query("field"))
+	if err != nil {
+		return q, fmt.Errorf("Error parse field:%s", err)
+	}
+	if len(fields) != 1 {
+		return q, fmt.Errorf("Error parse field:expected exactly one field")
+	}
+	q.field = fields[0]
+	if s := c.Query("start"); s != "" {
+		if q.start, err = time.Parse(time.RFC3339, s); err != nil {
+			return q, fmt.Errorf("Error parse start:%s", err)
+		}
+	}
+	if s := c.Query("end"); s != "" {
+		if q.end, err = time.Parse(time.RFC3339, s); err != nil {
+			return q, fmt.Errorf("Error parse end:%s", err)
+		}
+	}
+	if s := c.Query("window"); s != "" {
+		window, err := time.ParseDuration(s)
+		if err != nil || window <= 0 {
+			return q, fmt.Errorf("Error parse window:invalid duration %q", s)
+		}
+		end := q.end
+		if end.IsZero() {
+			end = time.Now()
+		}
+		q.start = end.Add(-window)
+	}
+	if q.resolution, err = dcgm.ParseHistoryResolution(c.Query("resolution")); err != nil {
+		return q, err
+	}
+	return q, nil
+}
+
+// historyErrorStatus 未记录的字段与窗口内无数据返回 404，其余返回 500
+func historyErrorStatus(err error) int {
+	if errors.Is(err, dcgm.ErrNotRecorded) || errors.Is(err, dcgm.ErrNoData) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// HistoryStats 查询历史数据的聚合值
+// @Summary 查询历史数据的最小值、最大值、平均值与百分位
+// @Description 在历史存储中计算设备字段在时间窗口内的聚合值，例如最近 1 小时的峰值功耗：
+// @Description /history/stats?dvInd=3&field=155&window=1h&percentiles=50,95,99；
+// @Description 默认按窗口自动选择原始、1 分钟或 1 小时精度，使用降采样数据时百分位为近似值
+// @Produce json
+// @Param dvInd query int true "设备索引"
+// @Param field query string true "字段编号或名称，例如 155 或 DCGM_FI_DEV_POWER_USAGE"
+// @Param start query string false "开始时间（RFC3339），默认为 end 之前 1 小时"
+// @Param end query string false "结束时间（RFC3339），默认为当前时间"
+// @Param window query string false "时间窗口，例如 15m、1h、24h，指定时覆盖 start"
+// @Param resolution query string false "精度：auto、raw、1m、1h，默认 auto"
+// @Param percentiles query string false "百分位列表（0-100），逗号分隔，例如 50,95,99"
+// @Success 200 {object} dcgm.HistoryStats "聚合结果"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 404 {object} error "字段未被记录或窗口内无数据"
+// @Router /history/stats [get]
+func HistoryStats(c *gin.Context) {
+	q, err := parseHistoryQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	var percentiles []float64
+	if s := c.Query("percentiles"); s != "" {
+		for _, part := range strings.Split(s, ",") {
+			p, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse percentiles:%s", err)))
+				return
+			}
+			percentiles = append(percentiles, p)
+		}
+	}
+	stats, err := dcgm.QueryHistory(q.dvInd, q.field, q.start, q.end, q.resolution, percentiles...)
+	if err != nil {
+		c.JSON(historyErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"stats": stats,
+	}))
+}
+
+// HistorySamples 查询历史数据点
+// @Summary 查询历史数据点
+// @Description 返回设备字段在时间窗口内的数据点，原始精度每个采样一个点，1 分钟与 1 小时精度每个时间段一个点，
+// @Description 包含采样数量、最小值、最大值与平均值
+// @Produce json
+// @Param dvInd query int true "设备索引"
+// @Param field query string true "字段编号或名称"
+// @Param start query string false "开始时间（RFC3339），默认为 end 之前 1 小时"
+// @Param end query string false "结束时间（RFC3339），默认为当前时间"
+// @Param window query string false "时间窗口，例如 15m、1h、24h，指定时覆盖 start"
+// @Param resolution query string false "精度：auto、raw、1m、1h，默认 auto"
+// @Success 200 {array} dcgm.HistoryPoint "数据点"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 404 {object} error "字段未被记录"
+// @Router /history/samples [get]
+func HistorySamples(c *gin.Context) {
+	q, err := parseHistoryQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	points, resolution, err := dcgm.HistorySamples(q.dvInd, q.field, q.start, q.end, q.resolution)
+	if err != nil {
+		c.JSON(historyErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"resolution": resolution,
+		"points":     points,
+	}))
+}
+
+// energyDevices 解析可选的 dvInd 参数，未指定时返回所有正在统计能耗的设备
+func energyDevices(c *gin.Context) ([]int, error) {
+	if s := c.Query("dvInd"); s != "" {
+		dvInd, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("Error parse dvInd:%s", err)
+		}
+		return []int{dvInd}, nil
+	}
+	return dcgm.EnergyDevices(), nil
+}
+
+// energyErrorStatus 未统计的设备与不存在的标记返回 404，其余返回 500
+func energyErrorStatus(err error) int {
+	if errors.Is(err, dcgm.ErrEnergyNotTracked) || errors.Is(err, dcgm.ErrEnergyMarkNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// EnergyConsumption 查询设备消耗的能量
+// @Summary 查询设备能耗
+// @Description 返回设备自能耗统计启动以来消耗的能量（焦耳与千瓦时），指定 window 时返回最近 window 内消耗的能量；
+// @Description 能量累加器的回绕与驱动重置已处理，Wraps 与 Resets 为期间检测到的次数
+// @Produce json
+// @Param dvInd query int false "设备索引，为空时返回所有统计中的设备"
+// @Param window query string false "时间窗口，例如 15m、1h"
+// @Success 200 {array} dcgm.EnergyReading "能耗列表"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 404 {object} error "设备未统计能耗"
+// @Router /energy/consumption [get]
+func EnergyConsumption(c *gin.Context) {
+	devices, err := energyDevices(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	var window time.Duration
+	if s := c.Query("window"); s != "" {
+		if window, err = time.ParseDuration(s); err != nil || window <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse window:invalid duration %q", s)))
+			return
+		}
+	}
+	readings := []dcgm.EnergyReading{}
+	for _, dvInd := range devices {
+		var reading dcgm.EnergyReading
+		if window > 0 {
+			reading, err = dcgm.EnergyOverWindow(dvInd, window)
+		} else {
+			reading, err = dcgm.EnergySinceStart(dvInd)
+		}
+		if err != nil {
+			c.JSON(energyErrorStatus(err), ErrorResponse(err.Error()))
+			return
+		}
+		readings = append(readings, reading)
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"energy": readings,
+	}))
+}
+
+// EnergyMarks 列出能耗标记
+// @Summary 列出能耗标记
+// @Description 返回调用方定义的所有能耗标记及打标记的时间
+// @Produce json
+// @Success 200 {array} dcgm.EnergyMark "标记列表"
+// @Router /energy/marks [get]
+func EnergyMarks(c *gin.Context) {
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"marks": dcgm.EnergyMarks(),
+	}))
+}
+
+// SetEnergyMark 设置能耗标记
+// @Summary 设置能耗标记
+// @Description 记录所有统计中的设备此刻的累计能量，之后可通过 /energy/between 查询两个标记之间的能耗，同名标记会被覆盖
+// @Produce json
+// @Param name query string true "标记名称"
+// @Success 200 {object} dcgm.EnergyMark "标记"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 404 {object} error "能耗统计未启动"
+// @Router /energy/marks [post]
+func SetEnergyMark(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse("Error parse name:empty mark name"))
+		return
+	}
+	mark, err := dcgm.SetEnergyMark(name)
+	if err != nil {
+		c.JSON(energyErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"mark": mark,
+	}))
+}
+
+// DeleteEnergyMark 删除能耗标记
+// @Summary 删除能耗标记
+// @Produce json
+// @Param name query string true "标记名称"
+// @Success 200 {string} string "删除成功"
+// @Failure 404 {object} error "标记不存在"
+// @Router /energy/marks [delete]
+func DeleteEnergyMark(c *gin.Context) {
+	if err := dcgm.DeleteEnergyMark(c.Query("name")); err != nil {
+		c.JSON(energyErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(nil))
+}
+
+// EnergyBetweenMarks 查询两个标记之间的能耗
+// @Summary 查询两个能耗标记之间的能耗
+// @Description 返回设备在 from 与 to 两个标记之间消耗的能量，to 为空时表示当前时刻
+// @Produce json
+// @Param dvInd query int false "设备索引，为空时返回所有统计中的设备"
+// @Param from query string true "起始标记名称"
+// @Param to query string false "结束标记名称"
+// @Success 200 {array} dcgm.EnergyReading "能耗列表"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 404 {object} error "设备未统计能耗或标记不存在"
+// @Router /energy/between [get]
+func EnergyBetweenMarks(c *gin.Context) {
+	devices, err := energyDevices(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	from := c.Query("from")
+	if from == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse("Error parse from:empty mark name"))
+		return
+	}
+	readings := []dcgm.EnergyReading{}
+	for _, dvInd := range devices {
+		reading, err := dcgm.EnergyBetweenMarks(dvInd, from, c.Query("to"))
+		if err != nil {
+			c.JSON(energyErrorStatus(err), ErrorResponse(err.Error()))
+			return
+		}
+		readings = append(readings, reading)
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"energy": readings,
+	}))
+}
+
+// ThrottleReasons 查询设备当前的节流原因
+// @Summary 查询设备节流原因
+// @Description 读取 gpu metrics 并按度量表版本将 throttle_status 解码为节流原因（power_cap、thermal_hotspot 等），
+// @Description UnknownBits 为当前版本未定义的置位
+// @Produce json
+// @Param dvInd query int false "设备索引，为空时返回所有设备"
+// @Success 200 {array} dcgm.ThrottleInfo "节流状态列表"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 500 {object} error "服务器内部错误"
+// @Router /throttle/reasons [get]
+func ThrottleReasons(c *gin.Context) {
+	var devices []int
+	if s := c.Query("dvInd"); s != "" {
+		dvInd, err := strconv.Atoi(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse dvInd:%s", err)))
+			return
+		}
+		devices = []int{dvInd}
+	} else {
+		count, err := dcgm.NumMonitorDevices()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+			return
+		}
+		for i := 0; i < count; i++ {
+			devices = append(devices, i)
+		}
+	}
+	throttleInfos := []dcgm.ThrottleInfo{}
+	for _, dvInd := range devices {
+		throttleInfo, err := dcgm.DeviceThrottleInfo(dvInd)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+			return
+		}
+		throttleInfos = append(throttleInfos, throttleInfo)
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"throttleInfos": throttleInfos,
+	}))
+}
+
+// ThrottleDurations 查询每个节流原因累计的时长
+// @Summary 查询节流时长
+// @Description 返回设备自节流统计启动以来每个节流原因累计的秒数，以及最近一次采样时生效的节流原因
+// @Produce json
+// @Param dvInd query int false "设备索引，为空时返回所有统计中的设备"
+// @Success 200 {array} dcgm.ThrottleStats "节流统计列表"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 404 {object} error "设备未统计节流"
+// @Router /throttle/durations [get]
+func ThrottleDurations(c *gin.Context) {
+	devices := dcgm.ThrottleAccountingDevices()
+	if s := c.Query("dvInd"); s != "" {
+		dvInd, err := strconv.Atoi(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse dvInd:%s", err)))
+			return
+		}
+		devices = []int{dvInd}
+	}
+	throttleStats := []dcgm.ThrottleStats{}
+	for _, dvInd := range devices {
+		stats, err := dcgm.ThrottleDurations(dvInd)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, dcgm.ErrThrottleNotTracked) {
+				status = http.StatusNotFound
+			}
+			c.JSON(status, ErrorResponse(err.Error()))
+			return
+		}
+		throttleStats = append(throttleStats, stats)
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"throttleStats": throttleStats,
+	}))
+}
+
+// jobErrorStatus 任务不存在返回 404，任务已存在或已结束返回 409，其余返回 500
+func jobErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dcgm.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dcgm.ErrJobExists), errors.Is(err, dcgm.ErrJobNotRunning):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// StartJob 启动任务统计
+// @Summary 启动任务统计
+// @Description 在一组设备上启动任务统计，任务期间定时采样利用率、显存、温度、功耗与能耗，并记录使用这些设备的 KFD 进程；
+// @Description 任务记录持久化到文件，服务重启后保留
+// @Produce json
+// @Param id query string true "任务 ID"
+// @Param devices query string false "设备索引列表，支持区间，例如 0-3；为空时使用所有设备"
+// @Success 200 {object} dcgm.Job "任务记录"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 409 {object} error "任务已存在"
+// @Router /jobs [post]
+func StartJob(c *gin.Context) {
+	id := c.Query("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse("Error parse id:empty job id"))
+		return
+	}
+	devices, err := dcgm.ParseEntityList(c.Query("devices"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	job, err := dcgm.StartJob(id, devices)
+	if err != nil {
+		c.JSON(jobErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"job": job,
+	}))
+}
+
+// StopJob 结束任务统计
+// @Summary 结束任务统计
+// @Description 结束任务统计并返回任务报告：时长、能耗、平均/最大利用率与显存、最高温度、最小/平均/最大功耗、
+// @Description ECC 与退役页增量以及任务期间出现的 KFD 进程
+// @Produce json
+// @Param id path string true "任务 ID"
+// @Success 200 {object} dcgm.Job "任务记录"
+// @Failure 404 {object} error "任务不存在"
+// @Failure 409 {object} error "任务已结束"
+// @Router /jobs/{id}/stop [post]
+func StopJob(c *gin.Context) {
+	job, err := dcgm.StopJob(c.Param("id"))
+	if err != nil {
+		c.JSON(jobErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"job": job,
+	}))
+}
+
+// GetJob 获取任务记录
+// @Summary 获取任务记录
+// @Description 返回任务的统计报告，运行中的任务返回截至目前的统计
+// @Produce json
+// @Param id path string true "任务 ID"
+// @Success 200 {object} dcgm.Job "任务记录"
+// @Failure 404 {object} error "任务不存在"
+// @Router /jobs/{id} [get]
+func GetJob(c *gin.Context) {
+	job, err := dcgm.GetJob(c.Param("id"))
+	if err != nil {
+		c.JSON(jobErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"job": job,
+	}))
+}
+
+// ListJobs 列出任务记录
+// @Summary 列出任务记录
+// @Description 返回所有任务记录，按启动时间排序
+// @Produce json
+// @Success 200 {array} dcgm.Job "任务列表"
+// @Router /jobs [get]
+func ListJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"jobs": dcgm.Jobs(),
+	}))
+}
+
+// ProcessHistory 查询 KFD 进程的使用记录
+// @Summary 查询进程使用记录
+// @Description 返回后台采样得到的 KFD 进程记录：起止时间、使用的设备、显存/SDMA/CU 占用的峰值与平均值，
+// @Description 运行中的进程在前，已退出的进程按退出时间从新到旧，只保留最近的若干个
+// @Produce json
+// @Param dvInd query int false "设备索引，为空时返回所有设备的进程"
+// @Param state query string false "running 只返回运行中的进程，exited 只返回已退出的进程，默认全部"
+// @Success 200 {array} dcgm.ProcessRecord "进程记录"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 404 {object} error "进程统计未启动"
+// @Router /process/history [get]
+func ProcessHistory(c *gin.Context) {
+	dvInd := -1
+	if s := c.Query("dvInd"); s != "" {
+		var err error
+		if dvInd, err = strconv.Atoi(s); err != nil || dvInd < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse dvInd:invalid device %q", s)))
+			return
+		}
+	}
+	state := c.Query("state")
+	if state != "" && state != "running" && state != "exited" {
+		c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse state:unknown state %q", state)))
+		return
+	}
+	if !dcgm.ProcessHistoryRunning() {
+		c.JSON(http.StatusNotFound, ErrorResponse("Error ProcessHistory:process accounting not started"))
+		return
+	}
+	records := []dcgm.ProcessRecord{}
+	for _, r := range dcgm.ProcessHistory(dvInd) {
+		if state == "" || (state == "running") == r.Running {
+			records = append(records, r)
+		}
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"processes": records,
+	}))
+}
+
+// RemoveJob 删除任务记录
+// @Summary 删除任务记录
+// @Description 删除任务记录，运行中的任务同时停止统计
+// @Produce json
+// @Param id path string true "任务 ID"
+// @Success 200 {string} string "删除成功"
+// @Failure 404 {object} error "任务不存在"
+// @Router /jobs/{id} [delete]
+func RemoveJob(c *gin.Context) {
+	if err := dcgm.RemoveJob(c.Param("id")); err != nil {
+		c.JSON(jobErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(nil))
+}
+
+func counterErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dcgm.ErrCounterSessionNotFound), errors.Is(err, dcgm.ErrCounterSessionClosed):
+		return http.StatusNotFound
+	case errors.Is(err, dcgm.ErrCountersUnavailable):
+		return http.StatusConflict
+	case errors.Is(err, dcgm.ErrNotSupported):
+		return http.StatusNotImplemented
+	}
+	return http.StatusInternalServerError
+}
+
+func counterSessionByParam(c *gin.Context) (*dcgm.CounterSession, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse id:%s", err)))
+		return nil, false
+	}
+	session, err := dcgm.CounterSessionByID(id)
+	if err != nil {
+		c.JSON(counterErrorStatus(err), ErrorResponse(err.Error()))
+		return nil, false
+	}
+	return session, true
+}
+
+func counterSessionInfo(session *dcgm.CounterSession) map[string]interface{} {
+	events := []string{}
+	for _, eventType := range session.EventTypes() {
+		events = append(events, eventType.String())
+	}
+	return map[string]interface{}{
+		"id":      session.ID(),
+		"dvInd":   session.Device(),
+		"events":  events,
+		"running": session.Running(),
+	}
+}
+
+// CreateCounterSession 创建性能计数器会话
+// @Summary 创建性能计数器会话
+// @Description 在设备上为一组 XGMI 事件创建性能计数器，检查事件组是否受支持以及剩余计数器是否足够；
+// @Description 会话使用完毕需调用 DELETE /counters/{id} 释放硬件计数器
+// @Produce json
+// @Param dvInd query int true "设备索引"
+// @Param events query string true "事件列表，逗号分隔，例如 xgmi0_beats_tx,xgmi1_beats_tx 或 xgmi_data_out_0"
+// @Param start query bool false "创建后立即开始计数"
+// @Success 200 {object} map[string]interface{} "会话信息"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 409 {object} error "剩余计数器不足"
+// @Failure 501 {object} error "设备不支持该事件组"
+// @Router /counters [post]
+func CreateCounterSession(c *gin.Context) {
+	dvInd, err := strconv.Atoi(c.Query("dvInd"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse dvInd:%s", err)))
+		return
+	}
+	eventTypes, err := dcgm.ParseEventTypes(c.Query("events"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	session, err := dcgm.NewCounterSession(dvInd, eventTypes)
+	if err != nil {
+		c.JSON(counterErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	if c.Query("start") == "true" {
+		if err = session.Start(); err != nil {
+			session.Close()
+			c.JSON(counterErrorStatus(err), ErrorResponse(err.Error()))
+			return
+		}
+	}
+	c.JSON(http.StatusOK, SuccessResponse(counterSessionInfo(session)))
+}
+
+// StartCounterSession 开始计数
+// @Summary 开始计数
+// @Produce json
+// @Param id path int true "会话 ID"
+// @Success 200 {object} map[string]interface{} "会话信息"
+// @Failure 404 {object} error "会话不存在"
+// @Router /counters/{id}/start [post]
+func StartCounterSession(c *gin.Context) {
+	session, ok := counterSessionByParam(c)
+	if !ok {
+		return
+	}
+	if err := session.Start(); err != nil {
+		c.JSON(counterErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(counterSessionInfo(session)))
+}
+
+// StopCounterSession 停止计数
+// @Summary 停止计数
+// @Description 停止计数，计数值保留，再次开始后继续累加
+// @Produce json
+// @Param id path int true "会话 ID"
+// @Success 200 {object} map[string]interface{} "会话信息"
+// @Failure 404 {object} error "会话不存在"
+// @Router /counters/{id}/stop [post]
+func StopCounterSession(c *gin.Context) {
+	session, ok := counterSessionByParam(c)
+	if !ok {
+		return
+	}
+	if err := session.Stop(); err != nil {
+		c.JSON(counterErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(counterSessionInfo(session)))
+}
+
+// ReadCounterSession 读取计数器
+// @Summary 读取计数器
+// @Description 返回每个计数器的原始值、启用与实际计数时长（纳秒）和按时长折算的值，以及会话中 XGMI 链路事件对应的平均发送带宽
+// @Produce json
+// @Param id path int true "会话 ID"
+// @Success 200 {object} map[string]interface{} "计数器读数与链路带宽"
+// @Failure 404 {object} error "会话不存在"
+// @Router /counters/{id} [get]
+func ReadCounterSession(c *gin.Context) {
+	session, ok := counterSessionByParam(c)
+	if !ok {
+		return
+	}
+	readings, err := session.Read()
+	if err != nil {
+		c.JSON(counterErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	info := counterSessionInfo(session)
+	info["readings"] = readings
+	info["xgmiBandwidth"] = dcgm.XGMIBandwidthFromReadings(readings)
+	c.JSON(http.StatusOK, SuccessResponse(info))
+}
+
+// CloseCounterSession 关闭性能计数器会话
+// @Summary 关闭性能计数器会话
+// @Description 停止计数并释放会话的全部计数器句柄
+// @Produce json
+// @Param id path int true "会话 ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Failure 404 {object} error "会话不存在"
+// @Router /counters/{id} [delete]
+func CloseCounterSession(c *gin.Context) {
+	session, ok := counterSessionByParam(c)
+	if !ok {
+		return
+	}
+	if err := session.Close(); err != nil {
+		c.JSON(counterErrorStatus(err), ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(nil))
+}
+
+// XGMIBandwidth 测量 XGMI 链路带宽
+// @Summary 测量 XGMI 链路带宽
+// @Description 在设备上计数 interval 时长后返回每条 XGMI 链路的平均发送带宽，请求会阻塞 interval
+// @Produce json
+// @Param dvInd path int true "设备索引"
+// @Param interval query string false "计数时长，例如 500ms、2s，默认 1s，最长 1m"
+// @Success 200 {array} dcgm.XGMILinkBandwidth "链路带宽列表"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 409 {object} error "剩余计数器不足"
+// @Failure 501 {object} error "设备不支持 XGMI 计数器"
+// @Router /xgmi/bandwidth/{dvInd} [get]
+func XGMIBandwidth(c *gin.Context) {
+	dvInd, err := strconv.Atoi(c.Param("dvInd"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse dvInd:%s", err)))
+		return
+	}
+	interval := time.Second
+	if s := c.Query("interval"); s != "" {
+		if interval, err = time.ParseDuration(s); err != nil || interval <= 0 || interval > time.Minute {
+			c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse interval:invalid duration %q", s)))
+			return
+		}
+	}
+	links, err := dcgm.MeasureXGMIBandwidth(dvInd, interval)
+	if err != nil {
+		c.JSON(counterErrorStatus(err), ErrorResponse(err.Error()))
 		return
 	}
-	status := map[string]interface{}{
-		"eccStatus": eccStatus,
-	}
-	c.JSON(http.StatusOK, SuccessResponse(status))
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"xgmiBandwidth": links,
+	}))
 }
 
-// Temperature 获取设备温度
-// @Summary 获取设备温度
-// @Description 返回指定设备的当前温度
+// DeviceHealth 查询设备健康状态
+// @Summary 查询设备健康状态
+// @Description 返回设备 ECC、退役页、温度、PCIe 重放、XGMI 错误与温度降频各子系统的 PASS/WARN/FAIL 结论及问题描述。
+// @Description 设备在健康监控中时返回监控开始以来的结论，否则立即检查，计数器按驱动加载以来的累计值评估
 // @Produce json
 // @Param dvInd path int true "设备索引"
-// @Param sensorType query int true "传感器类型:0：Edge GPU temperature; 1:Junction/hotspot temperature;2:VRAM temperature"
-// @Success 200 {float64} float64 "返回温度（摄氏度）"
+// @Success 200 {object} dcgm.DeviceHealth "设备健康状态"
 // @Failure 400 {object} error "请求参数错误"
-// @Failure 500 {object} error "服务器内部错误"
-// @Router /Temperature/{dvInd} [get]
-func Temperature(c *gin.Context) {
+// @Router /health/{dvInd} [get]
+func DeviceHealth(c *gin.Context) {
 	dvInd, err := strconv.Atoi(c.Param("dvInd"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse("Invalid device ID"))
-		return
-	}
-	sensorType, err := strconv.Atoi(c.Query("sensorType"))
-	if err != nil || sensorType < 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse("Invalid sensor type"))
+		c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse dvInd:%s", err)))
 		return
 	}
-	temp, err := dcgm.Temperature(dvInd, sensorType)
+	health, err := dcgm.HealthCheck(dvInd)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
 		return
 	}
-	devTemp := map[string]interface{}{
-		"temp": temp,
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"health": health,
+	}))
+}
+
+// diagOptions /diag 的默认诊断参数，由服务启动参数设置
+var diagOptions dcgm.DiagOptions
+
+// ConfigureDiag 设置 /diag 的默认诊断参数，例如驱动版本允许列表
+func ConfigureDiag(opts dcgm.DiagOptions) {
+	diagOptions = opts
+}
+
+// Diag 运行软件诊断
+// @Summary 运行软件诊断
+// @Description 检查动态库加载与版本兼容、/dev/kfd 与 /dev/dri/renderD* 权限、驱动版本允许列表、设备数量、同型号设备固件一致性
+// @Description 以及 /etc/vdev 是否可读，返回每个诊断项的 PASS/FAIL/SKIP 结论，相当于 dcgmi diag -r 1
+// @Produce json
+// @Param driverAllowList query string false "逗号分隔的驱动版本允许列表，支持通配符，默认取服务启动参数"
+// @Success 200 {object} dcgm.DiagReport "诊断报告"
+// @Router /diag [get]
+func Diag(c *gin.Context) {
+	opts := diagOptions
+	if allowList := c.Query("driverAllowList"); allowList != "" {
+		opts.DriverAllowList = dcgm.ParseDriverAllowList(allowList)
 	}
-	c.JSON(http.StatusOK, SuccessResponse(devTemp))
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"report": dcgm.RunSoftwareDiag(opts),
+	}))
 }
 
-// VbiosVersion 获取设备的VBIOS版本
-// @Summary 获取设备的VBIOS版本
-// @Description 返回指定设备的VBIOS版本
+// ListPolicies 查询全部策略
+// @Summary 查询全部策略
 // @Produce json
-// @Param dvInd path int true "设备索引"
-// @Success 200 {string} string "返回VBIOS版本"
+// @Success 200 {array} dcgm.Policy "策略列表"
+// @Router /policies [get]
+func ListPolicies(c *gin.Context) {
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"policies": dcgm.Policies(),
+	}))
+}
+
+// SetPolicy 添加或替换策略
+// @Summary 添加或替换策略
+// @Description 在一组设备上监测 max-temperature、power-over-cap、ecc-uncorrectable、retired-page、pcie-replay 或 thermal-throttle 条件，
+// @Description 违规时可执行 perf-level-low 或 reset-clocks 操作；同名策略被替换，修改只保存在内存中
+// @Accept json
+// @Produce json
+// @Param policy body dcgm.Policy true "策略"
+// @Success 200 {object} dcgm.Policy "策略"
 // @Failure 400 {object} error "请求参数错误"
-// @Failure 500 {object} error "服务器内部错误"
-// @Router /VbiosVersion/{dvInd} [get]
-func VbiosVersion(c *gin.Context) {
-	dvInd, err := strconv.Atoi(c.Param("dvInd"))
+// @Router /policies [post]
+func SetPolicy(c *gin.Context) {
+	var policy dcgm.Policy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	policy, err := dcgm.SetPolicy(policy)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse("Invalid device ID"))
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
 		return
 	}
-	vbios, err := dcgm.VbiosVersion(dvInd)
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"policy": policy,
+	}))
+}
+
+// GetPolicy 查询策略
+// @Summary 查询策略
+// @Produce json
+// @Param name path string true "策略名称"
+// @Success 200 {object} dcgm.Policy "策略"
+// @Failure 404 {object} error "策略不存在"
+// @Router /policies/{name} [get]
+func GetPolicy(c *gin.Context) {
+	policy, err := dcgm.GetPolicy(c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		c.JSON(http.StatusNotFound, ErrorResponse(err.Error()))
 		return
 	}
-	devVbios := map[string]interface{}{
-		"vbios": vbios,
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"policy": policy,
+	}))
+}
+
+// RemovePolicy 删除策略
+// @Summary 删除策略
+// @Produce json
+// @Param name path string true "策略名称"
+// @Success 200 {object} map[string]interface{} "删除成功"
+// @Failure 404 {object} error "策略不存在"
+// @Router /policies/{name} [delete]
+func RemovePolicy(c *gin.Context) {
+	if err := dcgm.RemovePolicy(c.Param("name")); err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse(err.Error()))
+		return
 	}
-	c.JSON(http.StatusOK, SuccessResponse(devVbios))
+	c.JSON(http.StatusOK, SuccessResponse(nil))
 }
 
-// Swagger 注解
-// @Summary 设置 GPU 时钟频率
-// @Description 设置 GPU 上指定时钟的允许频率。clkType 设置为默认值，无需传递。
-// @Tags GPU
-// @Accept json
+// PolicyViolations 查询策略违规记录
+// @Summary 查询策略违规记录
+// @Description 返回 since 之后的违规记录，包括观测值、阈值与执行的操作，最多保留最近 1000 条
 // @Produce json
-// @Param dvInd query int true "设备索引"
-// @Param freqBitmask query int64 true "频率掩码"
-// @Success 200 {object} map[string]interface{} "成功"
+// @Param since query string false "RFC3339 时间，默认返回全部记录"
+// @Success 200 {array} dcgm.PolicyViolation "违规记录"
 // @Failure 400 {object} error "请求参数错误"
-// @Failure 500 {object} error "服务器内部错误"
-// @Router /DevGpuClkFreqSet [post]
-func DevGpuClkFreqSet(c *gin.Context) {
-	dvInd, err := strconv.Atoi(c.Query("dvInd"))
+// @Router /policies/violations [get]
+func PolicyViolations(c *gin.Context) {
+	var since time.Time
+	if s := c.Query("since"); s != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, s); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse since:%s", err)))
+			return
+		}
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"violations": dcgm.PolicyViolations(since),
+	}))
+}
+
+// eventStreamKeepAlive SSE 连接空闲时发送注释行的间隔，避免被代理断开
+const eventStreamKeepAlive = 15 * time.Second
+
+// eventStreamRetry 建议 SSE 客户端断线后重连的等待时间
+const eventStreamRetry = 3 * time.Second
+
+// eventStreamParams 解析事件流的设备、类型与重连序号参数，序号优先取 Last-Event-ID 请求头，
+// 未指定时从当前最后一条记录之后开始推送
+func eventStreamParams(c *gin.Context) (devices []int, types []dcgm.EventType, after uint64, err error) {
+	if s := c.Query("devices"); s != "" {
+		if devices, err = dcgm.ParseEntityList(s); err != nil {
+			return nil, nil, 0, fmt.Errorf("Error parse devices:%w", err)
+		}
+	}
+	if types, err = dcgm.ParseStreamEventTypes(c.Query("types")); err != nil {
+		return nil, nil, 0, err
+	}
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("lastEventId")
+	}
+	if lastID == "" {
+		return devices, types, dcgm.EventStreamLastID(), nil
+	}
+	if after, err = strconv.ParseUint(lastID, 10, 64); err != nil {
+		return nil, nil, 0, fmt.Errorf("Error parse lastEventId:invalid id %q", lastID)
+	}
+	return devices, types, after, nil
+}
+
+// subscribeEventStream 订阅事件流，失败时返回错误响应
+func subscribeEventStream(ctx context.Context, c *gin.Context, after uint64, devices []int, types []dcgm.EventType) (<-chan dcgm.StreamEvent, bool) {
+	events, err := dcgm.SubscribeEventStream(ctx, after, devices, types)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse("无效的 dvInd 参数"))
-		return
+		status := http.StatusBadRequest
+		if errors.Is(err, dcgm.ErrEventStreamNotStarted) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, ErrorResponse(err.Error()))
+		return nil, false
+	}
+	return events, true
+}
+
+// writeEventStream 以 SSE 格式推送事件流直到通道关闭：先发送起始序号，之后每条记录的 id 为序号，data 为 JSON
+func writeEventStream(c *gin.Context, after uint64, events <-chan dcgm.StreamEvent) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\nid: %d\n\n", eventStreamRetry.Milliseconds(), after)
+	c.Writer.Flush()
+	ticker := time.NewTicker(eventStreamKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				glog.Errorf("marshal event %d: %v", event.ID, err)
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %d\ndata: %s\n\n", event.ID, data)
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": keepalive\n\n")
+		}
+		c.Writer.Flush()
 	}
-	freqBitmask, err := strconv.ParseInt(c.Query("freqBitmask"), 10, 64)
+}
+
+// EventStream 以 Server-Sent Events 推送事件
+// @Summary 以 Server-Sent Events 推送事件
+// @Description 推送设备事件通知（VM_FAULT、THERMAL_THROTTLE、GPU_PRE_RESET、GPU_POST_RESET）与策略违规（POLICY_VIOLATION），
+// @Description 每条记录的 id 为序号，data 为 JSON。断线重连时携带 Last-Event-ID 请求头或 lastEventId 参数，
+// @Description 补发服务保存的之后的记录；未指定时只推送新记录
+// @Produce text/event-stream
+// @Param devices query string false "设备列表，支持区间，如 0-3，默认全部设备"
+// @Param types query string false "逗号分隔的事件类型，支持 GPU_RESET，默认全部类型"
+// @Param lastEventId query int false "收到的最后一条记录的序号"
+// @Success 200 {object} dcgm.StreamEvent "事件流"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 404 {object} error "事件流未启动"
+// @Router /events/stream [get]
+func EventStream(c *gin.Context) {
+	devices, types, after, err := eventStreamParams(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse("无效的 bwBitmask 参数"))
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	events, ok := subscribeEventStream(c.Request.Context(), c, after, devices, types)
+	if !ok {
 		return
 	}
+	writeEventStream(c, after, events)
+}
 
-	// 调用 DevGpuClkFreqSet 函数
-	err = dcgm.DevGpuClkFreqSet(dvInd, dcgm.RSMI_CLK_TYPE_SYS, freqBitmask)
+// wsAllowedOrigins /events/ws 允许的跨域 Origin，由服务启动参数设置
+var wsAllowedOrigins []string
+
+// ConfigureWebSocketOrigins 设置 /events/ws 允许的跨域 Origin（如 https://grafana.example.com），"*" 允许任意 Origin
+func ConfigureWebSocketOrigins(origins []string) {
+	wsAllowedOrigins = origins
+}
+
+// checkWebSocketOrigin 校验 WebSocket 握手的 Origin：没有 Origin 的非浏览器客户端、与服务同源的页面以及
+// 允许列表中的 Origin 可以连接，防止任意网页借用户浏览器订阅事件
+func checkWebSocketOrigin(config *websocket.Config, req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("Error check origin:invalid origin %q", origin)
+	}
+	if strings.EqualFold(u.Host, req.Host) {
+		return nil
+	}
+	for _, allowed := range wsAllowedOrigins {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return nil
+		}
+	}
+	return fmt.Errorf("Error check origin:origin %q not allowed", origin)
+}
+
+// EventWebSocket 以 WebSocket 推送事件
+// @Summary 以 WebSocket 推送事件
+// @Description 与 /events/stream 相同的事件，每条记录为一个 JSON 文本消息。断线重连时以 lastEventId 参数
+// @Description 指定收到的最后一条记录的序号，补发服务保存的之后的记录；lastEventId=0 补发全部保存的记录。
+// @Description 浏览器的 Origin 须与服务同源或在 -ws-allowed-origins 列表中
+// @Param devices query string false "设备列表，支持区间，如 0-3，默认全部设备"
+// @Param types query string false "逗号分隔的事件类型，支持 GPU_RESET，默认全部类型"
+// @Param lastEventId query int false "收到的最后一条记录的序号"
+// @Success 101 {object} dcgm.StreamEvent "事件流"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 403 {object} error "Origin 不允许"
+// @Failure 404 {object} error "事件流未启动"
+// @Router /events/ws [get]
+func EventWebSocket(c *gin.Context) {
+	devices, types, after, err := eventStreamParams(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
 		return
 	}
-	c.JSON(http.StatusOK, SuccessResponse(nil))
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	events, ok := subscribeEventStream(ctx, c, after, devices, types)
+	if !ok {
+		return
+	}
+	websocket.Server{Handshake: checkWebSocketOrigin, Handler: func(ws *websocket.Conn) {
+		// 客户端不发送数据，读取返回说明连接已关闭
+		go func() {
+			io.Copy(io.Discard, ws)
+			cancel()
+		}()
+		for event := range events {
+			if err := websocket.JSON.Send(ws, event); err != nil {
+				return
+			}
+		}
+	}}.ServeHTTP(c.Writer, c.Request)
 }
 
 // Version 获取当前系统的驱动程序版本
@@ -1211,7 +2344,7 @@ func GetBus(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "成功信息"
+// @Success 200 {object} []dcgm.ConciseHwInfo "设备简要硬件信息列表"
 // @Failure 400 {string} string "失败信息"
 // @Router /ShowAllConciseHw [post]
 func ShowAllConciseHw(c *gin.Context) {
@@ -1222,8 +2355,15 @@ func ShowAllConciseHw(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowAllConciseHw(dvIdList)
-	c.JSON(http.StatusOK, SuccessResponse(nil))
+	conciseHwInfos, err := dcgm.ShowAllConciseHw(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"conciseHwInfos": conciseHwInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowClocks 显示时钟信息
@@ -1232,7 +2372,7 @@ func ShowAllConciseHw(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "成功信息"
+// @Success 200 {object} []dcgm.DeviceClockInfo "设备时钟频率信息列表"
 // @Failure 400 {string} string "失败信息"
 // @Router /ShowClocks [post]
 func ShowClocks(c *gin.Context) {
@@ -1243,8 +2383,15 @@ func ShowClocks(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowClocks(dvIdList)
-	c.JSON(http.StatusOK, SuccessResponse(nil))
+	deviceClockInfos, err := dcgm.ShowClocks(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"deviceClockInfos": deviceClockInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowCurrentFans 展示风扇转速和风扇级别
@@ -1253,7 +2400,7 @@ func ShowClocks(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "成功信息"
+// @Success 200 {object} []dcgm.FanInfo "设备风扇信息列表"
 // @Failure 400 {string} string "失败信息"
 // @Router /fans/current [post]
 func ShowCurrentFans(c *gin.Context) {
@@ -1263,8 +2410,15 @@ func ShowCurrentFans(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowCurrentFans(dvIdList, true)
-	c.JSON(http.StatusOK, SuccessResponse(nil))
+	fanInfos, err := dcgm.ShowCurrentFans(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"fanInfos": fanInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowCurrentTemps 显示设备温度传感器数据
@@ -1400,7 +2554,7 @@ func ShowGpuUse(c *gin.Context) {
 // @Summary 展示设备的能量消耗
 // @Description 获取并展示指定设备的能量消耗情况。
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "成功返回设备的能量消耗信息"
+// @Success 200 {object} []dcgm.DeviceEnergyInfo "设备的能量消耗信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /energy [post]
 func ShowEnergy(c *gin.Context) {
@@ -1410,8 +2564,15 @@ func ShowEnergy(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowEnergy(dvIdList)
-	c.JSON(http.StatusOK, SuccessResponse(nil))
+	deviceEnergyInfos, err := dcgm.ShowEnergy(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"deviceEnergyInfos": deviceEnergyInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowMemInfo 展示设备的内存信息
@@ -1419,7 +2580,7 @@ func ShowEnergy(c *gin.Context) {
 // @Description 获取并展示指定设备的内存使用情况，包括不同类型的内存。
 // @Param dvIdList body []int true "设备 ID 列表"
 // @Param memTypes body []string true "内存类型列表，如 'all' 或指定类型"
-// @Success 200 {string} string "成功返回设备的内存信息"
+// @Success 200 {object} []dcgm.DeviceMemInfo "设备的内存信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /memory/info [post]
 func ShowMemInfo(c *gin.Context) {
@@ -1433,15 +2594,22 @@ func ShowMemInfo(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowMemInfo(request.DvIdList, request.MemTypes)
-	c.JSON(http.StatusOK, SuccessResponse(nil))
+	deviceMemInfos, err := dcgm.ShowMemInfo(request.DvIdList, request.MemTypes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"deviceMemInfos": deviceMemInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowMemUse 展示设备的内存使用情况
 // @Summary 展示设备内存使用情况
 // @Description 获取并展示指定设备的当前内存使用百分比和其他相关的利用率数据。
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "成功返回设备的内存使用信息"
+// @Success 200 {object} []dcgm.DeviceMemUseInfo "设备的内存使用信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /memory/use [post]
 func ShowMemUse(c *gin.Context) {
@@ -1451,8 +2619,15 @@ func ShowMemUse(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowMemUse(dvIdList)
-	c.JSON(http.StatusOK, SuccessResponse(nil))
+	deviceMemUseInfos, err := dcgm.ShowMemUse(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"deviceMemUseInfos": deviceMemUseInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowMemVendor 展示设备供应商信息
@@ -1645,7 +2820,7 @@ func GetDeviceProfile(c *gin.Context) {
 // @Description 获取并显示指定设备的有效电流或电压范围
 // @Param dvIdList body []int true "设备ID列表"
 // @Param rangeType body string true "范围类型 (sclk, mclk, voltage)"
-// @Success 200 {string} string "设备的电流或电压范围信息"
+// @Success 200 {object} []dcgm.DeviceRangeInfo "设备的电流或电压范围信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /device/range [post]
 func GetDeviceRange(c *gin.Context) {
@@ -1659,15 +2834,22 @@ func GetDeviceRange(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowRange(request.DvIdList, request.RangeType)
-	c.JSON(http.StatusOK, SuccessResponse(nil))
+	deviceRangeInfos, err := dcgm.ShowRange(request.DvIdList, request.RangeType)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"deviceRangeInfos": deviceRangeInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // @Summary 显示设备的退役页信息
 // @Description 获取并显示指定设备的退役内存页信息
 // @Param dvIdList body []int true "设备ID列表"
 // @Param retiredType body string false "退役类型 (默认为'all')"
-// @Success 200 {string} string "设备的退役页信息"
+// @Success 200 {object} []dcgm.DeviceRetiredPagesInfo "设备的退役页信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /device/retiredpages [post]
 func GetDeviceRetiredPages(c *gin.Context) {
@@ -1680,8 +2862,15 @@ func GetDeviceRetiredPages(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse("Invalid JSON body"))
 		return
 	}
-	dcgm.ShowRetiredPages(request.DvIdList, request.RetiredType)
-	c.JSON(http.StatusOK, SuccessResponse(nil))
+	deviceRetiredPagesInfos, err := dcgm.ShowRetiredPages(request.DvIdList, request.RetiredType)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"deviceRetiredPagesInfos": deviceRetiredPagesInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // @Summary 显示设备的序列号
@@ -1809,7 +2998,7 @@ func ShowVoltage(c *gin.Context) {
 // @Summary 显示设备的电压曲线点
 // @Description 获取并显示指定设备的电压曲线点信息。
 // @Param dvIdList body []int true "设备ID列表"
-// @Success 200 {string} string "设备的电压曲线点信息"
+// @Success 200 {object} []dcgm.DeviceVoltageCurveInfo "设备的电压曲线点信息列表"
 // @Failure 400 {string} string "请求参数错误"
 // @Router /showVoltageCurve [post]
 func ShowVoltageCurve(c *gin.Context) {
@@ -1819,15 +3008,22 @@ func ShowVoltageCurve(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowVoltageCurve(dvIdList)
-	c.String(http.StatusOK, "设备电压曲线点信息已显示")
+	deviceVoltageCurveInfos, err := dcgm.ShowVoltageCurve(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"deviceVoltageCurveInfos": deviceVoltageCurveInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowXgmiErr 显示 XGMI 错误状态
 // @Summary 显示 XGMI 错误状态
 // @Description 显示一组 GPU 设备的 XGMI 错误状态。
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "XGMI 错误状态信息"
+// @Success 200 {object} []dcgm.XgmiErrorInfo "XGMI 错误状态信息列表"
 // @Router /showXgmiErr [post]
 func ShowXgmiErr(c *gin.Context) {
 	var dvIdList []int
@@ -1836,15 +3032,22 @@ func ShowXgmiErr(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowXgmiErr(dvIdList, true)
-	c.String(http.StatusOK, "XGMI 错误状态信息已显示")
+	xgmiErrorInfos, err := dcgm.ShowXgmiErr(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"xgmiErrorInfos": xgmiErrorInfos,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowWeightTopology 显示 GPU 拓扑权重
 // @Summary 显示 GPU 拓扑权重
 // @Description 显示 GPU 设备之间的权重信息。
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "GPU 拓扑权重信息"
+// @Success 200 {object} dcgm.TopologyMatrix "GPU 拓扑权重矩阵"
 // @Router /showWeightTopology [post]
 func ShowWeightTopology(c *gin.Context) {
 	var dvIdList []int
@@ -1852,15 +3055,22 @@ func ShowWeightTopology(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse("Invalid JSON body"))
 		return
 	}
-	dcgm.ShowWeightTopology(dvIdList, true)
-	c.String(http.StatusOK, "GPU 拓扑权重信息已显示")
+	weights, err := dcgm.ShowWeightTopology(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"weights": weights,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowHopsTopology 显示 GPU 拓扑跳数
 // @Summary 显示 GPU 拓扑跳数
 // @Description 显示 GPU 设备之间的跳数信息。
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "GPU 拓扑跳数信息"
+// @Success 200 {object} dcgm.TopologyMatrix "GPU 拓扑跳数矩阵"
 // @Router /showHopsTopology [post]
 func ShowHopsTopology(c *gin.Context) {
 	var dvIdList []int
@@ -1869,15 +3079,22 @@ func ShowHopsTopology(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowHopsTopology(dvIdList, true)
-	c.String(http.StatusOK, "GPU 拓扑跳数信息已显示")
+	hops, err := dcgm.ShowHopsTopology(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"hops": hops,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowTypeTopology 显示 GPU 拓扑中两台设备之间的链接类型。
 // @Summary 显示 GPU 拓扑链接类型
 // @Description 显示 GPU 设备之间的链接类型信息。
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "GPU 拓扑链接类型信息"
+// @Success 200 {object} dcgm.LinkTypeMatrix "GPU 拓扑链接类型矩阵"
 // @Router /showTypeTopology [post]
 func ShowTypeTopology(c *gin.Context) {
 	var dvIdList []int
@@ -1885,8 +3102,15 @@ func ShowTypeTopology(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse("Invalid JSON body"))
 		return
 	}
-	dcgm.ShowTypeTopology(dvIdList, true)
-	c.String(http.StatusOK, " GPU拓扑中两台设备之间的链接类型已展示")
+	linkTypes, err := dcgm.ShowTypeTopology(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"linkTypes": linkTypes,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
 // ShowNumaTopology 显示指定设备的 NUMA 节点信息。
@@ -1913,7 +3137,7 @@ func ShowNumaTopology(c *gin.Context) {
 // @Summary 显示完整的硬件拓扑信息
 // @Description 显示一组 GPU 设备的权重、跳数、链接类型和 NUMA 节点信息。
 // @Param dvIdList body []int true "设备 ID 列表"
-// @Success 200 {string} string "完整的硬件拓扑信息"
+// @Success 200 {object} dcgm.HwTopologyInfo "完整的硬件拓扑信息"
 // @Router /showHwTopology [post]
 func ShowHwTopology(c *gin.Context) {
 	var dvIdList []int
@@ -1922,8 +3146,52 @@ func ShowHwTopology(c *gin.Context) {
 		return
 	}
 
-	dcgm.ShowHwTopology(dvIdList)
-	c.String(http.StatusOK, "指定设备的完整硬件拓扑信息已显示")
+	hwTopologyInfo, err := dcgm.ShowHwTopology(dvIdList)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	response := map[string]interface{}{
+		"hwTopologyInfo": hwTopologyInfo,
+	}
+	c.JSON(http.StatusOK, SuccessResponse(response))
+}
+
+// NodeTopology 显示节点拓扑矩阵
+// @Summary 显示节点拓扑矩阵
+// @Description 返回一组设备两两之间的链接类型、跳数、权重、理论 min/max 带宽（MB/s，仅跳数为1的 XGMI 链接）
+// @Description 以及每个设备的 PCI 总线地址与 NUMA 节点
+// @Produce json
+// @Param devices query string false "设备索引列表，支持区间，例如 0-3；为空时使用所有设备"
+// @Success 200 {object} dcgm.NodeTopology "节点拓扑"
+// @Failure 400 {object} error "请求参数错误"
+// @Router /topology [get]
+func NodeTopology(c *gin.Context) {
+	var devices []int
+	if s := c.Query("devices"); s != "" {
+		var err error
+		if devices, err = dcgm.ParseEntityList(s); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+			return
+		}
+	} else {
+		count, err := dcgm.NumMonitorDevices()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+			return
+		}
+		for i := 0; i < count; i++ {
+			devices = append(devices, i)
+		}
+	}
+	topology, err := dcgm.ShowNodeTopology(devices)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse(map[string]interface{}{
+		"topology": topology,
+	}))
 }
 
 // DeviceCount 返回设备的数量
@@ -2232,31 +3500,34 @@ func EncryptionVMStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, SuccessResponse(response))
 }
 
-// 打印事件列表
-// @Summary 打印设备的事件列表
-// @Description 打印指定设备的事件列表，并设置延迟
+// PrintEventList 以 Server-Sent Events 推送设备的事件
+// @Summary 以 Server-Sent Events 推送设备的事件
+// @Description 兼容旧接口，等同于 /events/stream?devices={device}&types={eventList}，delay 已不再使用
+// @Produce text/event-stream
 // @Param device path int true "设备索引"
-// @Param delay query int true "延迟时间（秒）"
-// @Param eventList query []string true "事件列表"
-// @Success 200 {string} string "操作成功"
-// @Failure 400 {string} string "操作失败"
+// @Param delay query int false "已不再使用"
+// @Param eventList query []string false "事件列表，默认全部类型"
+// @Success 200 {object} dcgm.StreamEvent "事件流"
+// @Failure 400 {object} error "请求参数错误"
+// @Failure 404 {object} error "事件流未启动"
 // @Router /PrintEventList/{device} [get]
 func PrintEventList(c *gin.Context) {
 	device, err := strconv.Atoi(c.Param("device"))
+	if err != nil || device < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("Error parse device:invalid device %q", c.Param("device"))))
+		return
+	}
+	types, err := dcgm.ParseNotificationTypes(strings.Join(c.QueryArray("eventList"), ","))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, "请求参数错误")
+		c.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
 		return
 	}
-
-	var delay int
-	var eventList []string
-	if err := c.BindQuery(&delay); err != nil || c.BindQuery(&eventList) != nil {
-		c.JSON(http.StatusBadRequest, err.Error())
+	after := dcgm.EventStreamLastID()
+	events, ok := subscribeEventStream(c.Request.Context(), c, after, []int{device}, types)
+	if !ok {
 		return
 	}
-
-	dcgm.PrintEventList(device, delay, eventList)
-	c.JSON(http.StatusOK, "操作成功")
+	writeEventStream(c, after, events)
 }
 
 // GetDeviceInfo 获取设备信息
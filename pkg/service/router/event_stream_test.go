@@ -0,0 +1,167 @@
+package router
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
+
+// newEventStreamServer 以示例场景启动事件流，场景在第 1、2、3 秒向设备 0 发出三个事件并已全部保存，
+// 返回服务与第一个事件之前的序号
+func newEventStreamServer(t *testing.T) (*httptest.Server, uint64) {
+	t.Helper()
+	s, err := dcgm.LoadFakeScenario(fakeScenarioPath)
+	if err != nil {
+		t.Fatalf("LoadFakeScenario: %v", err)
+	}
+	s.Events = []dcgm.FakeEventSpec{
+		{At: 1, Device: 0, Type: "THERMAL_THROTTLE", Message: "first"},
+		{At: 2, Device: 0, Type: "VM_FAULT", Message: "second"},
+		{At: 3, Device: 0, Type: "THERMAL_THROTTLE", Message: "third"},
+	}
+	b := dcgm.NewFakeBackend(s)
+	// 场景时间随真实时间推进，offset 用于跳过等待
+	var offset atomic.Int64
+	b.SetClock(func() time.Time { return time.Now().Add(time.Duration(offset.Load())) })
+	if err := dcgm.InitWithBackend(b); err != nil {
+		t.Fatalf("InitWithBackend: %v", err)
+	}
+	t.Cleanup(func() { dcgm.ShutDown() })
+	if err := dcgm.StartEventStream(100); err != nil {
+		t.Fatalf("StartEventStream: %v", err)
+	}
+	start := dcgm.EventStreamLastID()
+	offset.Store(int64(10 * time.Second))
+	for deadline := time.Now().Add(5 * time.Second); dcgm.EventStreamLastID() < start+3; {
+		if time.Now().After(deadline) {
+			t.Fatalf("event stream has %d events, want 3", dcgm.EventStreamLastID()-start)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	gin.SetMode(gin.TestMode)
+	server := httptest.NewServer(InitRouter())
+	t.Cleanup(server.Close)
+	return server, start
+}
+
+// readSSE 读取 SSE 响应中带 data 的记录，返回它们的 id 与 data，读满 n 条为止
+func readSSE(t *testing.T, resp *http.Response, n int) (ids []uint64, data []string) {
+	t.Helper()
+	scanner := bufio.NewScanner(resp.Body)
+	var id uint64
+	for len(ids) < n && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			v, err := strconv.ParseUint(strings.TrimPrefix(line, "id: "), 10, 64)
+			if err != nil {
+				t.Fatalf("bad id line %q", line)
+			}
+			id = v
+		case strings.HasPrefix(line, "data: "):
+			ids = append(ids, id)
+			data = append(data, strings.TrimPrefix(line, "data: "))
+		}
+	}
+	if len(ids) < n {
+		t.Fatalf("got %d events, want %d: %v", len(ids), n, scanner.Err())
+	}
+	return ids, data
+}
+
+func TestEventStreamReplay(t *testing.T) {
+	server, start := newEventStreamServer(t)
+	tests := []struct {
+		name   string
+		header string
+		query  string
+	}{
+		{"Last-Event-ID header", strconv.FormatUint(start+1, 10), ""},
+		{"lastEventId query", "", "?lastEventId=" + strconv.FormatUint(start+1, 10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"/events/stream"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("Last-Event-ID", tt.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("GET /events/stream: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+				t.Fatalf("status %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+			}
+			ids, data := readSSE(t, resp, 2)
+			if ids[0] != start+2 || ids[1] != start+3 {
+				t.Errorf("replayed ids = %v, want %d and %d", ids, start+2, start+3)
+			}
+			if !strings.Contains(data[0], `"second"`) || !strings.Contains(data[1], `"third"`) {
+				t.Errorf("replayed data = %q", data)
+			}
+		})
+	}
+}
+
+func TestEventWebSocketReplay(t *testing.T) {
+	server, start := newEventStreamServer(t)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/events/ws?types=thermal_throttle&lastEventId=" + strconv.FormatUint(start, 10)
+	ws, err := websocket.Dial(wsURL, "", server.URL)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer ws.Close()
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for _, want := range []struct {
+		id      uint64
+		message string
+	}{{start + 1, "first"}, {start + 3, "third"}} {
+		var event dcgm.StreamEvent
+		if err := websocket.JSON.Receive(ws, &event); err != nil {
+			t.Fatalf("Receive: %v", err)
+		}
+		if event.ID != want.id || event.Message != want.message || event.Type != dcgm.EventType("THERMAL_THROTTLE") {
+			t.Errorf("event = %d %s %q, want %d THERMAL_THROTTLE %q", event.ID, event.Type, event.Message, want.id, want.message)
+		}
+	}
+}
+
+func TestEventWebSocketOrigin(t *testing.T) {
+	server, _ := newEventStreamServer(t)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/events/ws"
+	t.Cleanup(func() { ConfigureWebSocketOrigins(nil) })
+	tests := []struct {
+		name    string
+		allowed []string
+		origin  string
+		wantOK  bool
+	}{
+		{"same origin", nil, server.URL, true},
+		{"cross origin", nil, "http://evil.example", false},
+		{"allowed cross origin", []string{"https://grafana.example", " http://evil.example/"}, "http://evil.example", true},
+		{"any origin", []string{"*"}, "http://evil.example", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ConfigureWebSocketOrigins(tt.allowed)
+			ws, err := websocket.Dial(wsURL, "", tt.origin)
+			if err == nil {
+				ws.Close()
+			}
+			if (err == nil) != tt.wantOK {
+				t.Errorf("Dial with origin %s: err = %v, want ok %v", tt.origin, err, tt.wantOK)
+			}
+		})
+	}
+}
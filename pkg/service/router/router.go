@@ -22,6 +22,8 @@ func InitRouter() *gin.Engine {
 	router.GET("/PerfLevel/:dvInd", PerfLevel)
 	router.POST("/DevPerfLevelSet/:dvInd", DevPerfLevelSet)
 	router.GET("/DevGpuMetricsInfo/:dvInd", DevGpuMetricsInfo)
+	// 按度量表版本解码的 GPU 度量信息
+	router.GET("/DevGpuMetrics/:dvInd", DevGpuMetrics)
 	router.GET("/CollectDeviceMetrics", CollectDeviceMetrics)
 	router.GET("/DeviceInfo/:dvInd", GetDeviceByDvInd)
 	// 路由
@@ -54,6 +56,51 @@ func InitRouter() *gin.Engine {
 	router.GET("/VbiosVersion/:dvInd", VbiosVersion)
 	// 路由注册
 	router.GET("/Version", Version)
+	// 动态库与符号的加载情况
+	router.GET("/Libraries", Libraries)
+	// Prometheus 指标
+	router.GET("/metrics", Metrics)
+	// 字段注册表与字段读取
+	router.GET("/fields", Fields)
+	router.GET("/fields/values", FieldValues)
+	// 历史数据查询
+	router.GET("/history/stats", HistoryStats)
+	router.GET("/history/samples", HistorySamples)
+	// 能耗统计
+	router.GET("/energy/consumption", EnergyConsumption)
+	router.GET("/energy/marks", EnergyMarks)
+	router.POST("/energy/marks", SetEnergyMark)
+	router.DELETE("/energy/marks", DeleteEnergyMark)
+	router.GET("/energy/between", EnergyBetweenMarks)
+	// 节流原因与节流时长
+	router.GET("/throttle/reasons", ThrottleReasons)
+	router.GET("/throttle/durations", ThrottleDurations)
+	// 设备健康状态
+	router.GET("/health/:dvInd", DeviceHealth)
+	// 软件诊断
+	router.GET("/diag", Diag)
+	// 策略
+	router.GET("/policies", ListPolicies)
+	router.POST("/policies", SetPolicy)
+	router.GET("/policies/violations", PolicyViolations)
+	router.GET("/policies/:name", GetPolicy)
+	router.DELETE("/policies/:name", RemovePolicy)
+	// 设备事件通知与策略违规的推送，支持断线重连补发
+	router.GET("/events/stream", EventStream)
+	router.GET("/events/ws", EventWebSocket)
+	// 任务统计
+	router.POST("/jobs", StartJob)
+	router.GET("/jobs", ListJobs)
+	router.GET("/jobs/:id", GetJob)
+	router.POST("/jobs/:id/stop", StopJob)
+	router.DELETE("/jobs/:id", RemoveJob)
+	// 性能计数器会话与 XGMI 链路带宽
+	router.POST("/counters", CreateCounterSession)
+	router.GET("/counters/:id", ReadCounterSession)
+	router.POST("/counters/:id/start", StartCounterSession)
+	router.POST("/counters/:id/stop", StopCounterSession)
+	router.DELETE("/counters/:id", CloseCounterSession)
+	router.GET("/xgmi/bandwidth/:dvInd", XGMIBandwidth)
 	// 重置设备时钟(K100 AI不支持)
 	router.POST("/ResetClocks", ResetClocks)
 	router.POST("/ResetFans", ResetFans)
@@ -102,6 +149,8 @@ func InitRouter() *gin.Engine {
 	router.POST("/pcie/bandwidth", ShowPcieBw)
 	router.POST("/pcie/replaycount", ShowPcieReplayCount)
 	router.GET("/process/name/:pid", GetProcessName)
+	// 进程使用记录
+	router.GET("/process/history", ProcessHistory)
 	router.POST("/device/power", GetDevicePower)
 	//（K100_AI卡不支持该操作）
 	router.POST("/device/powerplay", GetDevicePowerPlayTable)
@@ -123,6 +172,8 @@ func InitRouter() *gin.Engine {
 	router.POST("/showTypeTopology", ShowTypeTopology)
 	router.POST("/showNumaTopology", ShowNumaTopology)
 	router.POST("/showHwTopology", ShowHwTopology)
+	// 节点拓扑矩阵
+	router.GET("/topology", NodeTopology)
 	router.GET("/deviceCount", DeviceCount)
 	router.GET("/VDeviceSingleInfo", VDeviceSingleInfo)
 	router.GET("/vDeviceCount", VDeviceCount)
@@ -139,7 +190,7 @@ func InitRouter() *gin.Engine {
 	router.POST("/SetEncryptionVMStatus", SetEncryptionVMStatus)
 	// 获取加密虚拟机状态
 	router.GET("/EncryptionVMStatus", EncryptionVMStatus)
-	// 打印设备的事件列表
+	// 推送设备的事件，兼容旧接口
 	router.GET("/PrintEventList/:device", PrintEventList)
 	router.GET("/device/info/:dvInd", GetDeviceInfo)
 	// 路由
@@ -0,0 +1,142 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
+
+const fakeScenarioPath = "../../../samples/fake/scenario.yaml"
+
+// newFakeRouter 以示例场景的模拟后端初始化 dcgm，场景时间固定在起点
+func newFakeRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	if err := dcgm.InitWithBackendName(dcgm.BackendFake + ":" + fakeScenarioPath); err != nil {
+		t.Fatalf("InitWithBackendName: %v", err)
+	}
+	t.Cleanup(func() { dcgm.ShutDown() })
+	b, ok := dcgm.GetBackend().(*dcgm.FakeBackend)
+	if !ok {
+		t.Fatalf("backend is %T, want *dcgm.FakeBackend", dcgm.GetBackend())
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.SetClock(func() time.Time { return now })
+	gin.SetMode(gin.TestMode)
+	return InitRouter()
+}
+
+// get 发送 GET 请求并将响应解析到 out
+func get(t *testing.T, router *gin.Engine, path string, out interface{}) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+		t.Fatalf("GET %s: decode %q: %v", path, w.Body.String(), err)
+	}
+	return w.Code
+}
+
+func TestResponses(t *testing.T) {
+	router := newFakeRouter(t)
+	tests := []struct {
+		path     string
+		wantCode int
+		wantMsg  string
+		wantData map[string]interface{}
+	}{
+		{"/NumMonitorDevices", http.StatusOK, "成功", map[string]interface{}{"gpuCount": float64(3)}},
+		{"/devicename/0", http.StatusOK, "成功", map[string]interface{}{"deviceName": "K100_AI"}},
+		{"/devicename/abc", http.StatusBadRequest, "失败", nil},
+		{"/devicename/9", http.StatusInternalServerError, "失败", nil},
+		{"/Temperature/0?sensorType=0", http.StatusOK, "成功", map[string]interface{}{"temp": float64(48)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var resp struct {
+				Message string
+				Data    interface{}
+			}
+			if code := get(t, router, tt.path, &resp); code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", code, tt.wantCode)
+			}
+			if resp.Message != tt.wantMsg {
+				t.Errorf("message = %q, want %q", resp.Message, tt.wantMsg)
+			}
+			if tt.wantData == nil {
+				return
+			}
+			data, ok := resp.Data.(map[string]interface{})
+			if !ok {
+				t.Fatalf("data = %v, want an object", resp.Data)
+			}
+			for k, v := range tt.wantData {
+				if data[k] != v {
+					t.Errorf("data[%q] = %v, want %v", k, data[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectDeviceMetrics(t *testing.T) {
+	router := newFakeRouter(t)
+	var infos []dcgm.MonitorInfo
+	if code := get(t, router, "/CollectDeviceMetrics", &infos); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	want := []struct {
+		pciBus      string
+		model       string
+		temperature float64
+		power       float64
+		utilization float64
+	}{
+		{"0000:03:00.0", "K100_AI", 48, 210, 70},
+		{"0000:43:00.0", "K100_AI", 36, 82, 5},
+		{"0000:83:00.0", "Z100", 33, 40, 0},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("got %d devices, want %d", len(infos), len(want))
+	}
+	for i, w := range want {
+		got := infos[i]
+		if got.PciBusNumber != w.pciBus || got.SubSystemName != w.model || got.Temperature != w.temperature ||
+			got.PowerUsage != w.power || got.UtilizationRate != w.utilization {
+			t.Errorf("device %d = %s %s %v°C %vW %v%%, want %s %s %v°C %vW %v%%", i,
+				got.PciBusNumber, got.SubSystemName, got.Temperature, got.PowerUsage, got.UtilizationRate,
+				w.pciBus, w.model, w.temperature, w.power, w.utilization)
+		}
+	}
+}
+
+func TestAllDeviceInfos(t *testing.T) {
+	router := newFakeRouter(t)
+	var list []dcgm.PhysicalDeviceInfo
+	if code := get(t, router, "/AllDeviceInfos", &list); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	// 返回顺序不固定，按设备索引号整理
+	infos := map[int]dcgm.PhysicalDeviceInfo{}
+	for _, info := range list {
+		infos[info.Device.MinorNumber] = info
+	}
+	if len(infos) != 3 {
+		t.Fatalf("got %d devices, want 3", len(infos))
+	}
+	vdevs := infos[1].VirtualDevices
+	if infos[1].Device.VDeviceCount != 2 || len(vdevs) != 2 {
+		t.Fatalf("device 1: vdevice count %v, %d vdevices, want 2", infos[1].Device.VDeviceCount, len(vdevs))
+	}
+	if vdevs[0].ContainerID != 101 || vdevs[0].VMinorNumber != 4 || vdevs[1].ContainerID != 102 || vdevs[1].VMinorNumber != 5 {
+		t.Errorf("device 1 vdevices = %+v, want containers 101 and 102 at indices 4 and 5", vdevs)
+	}
+	if len(infos[0].VirtualDevices) != 0 || len(infos[2].VirtualDevices) != 0 {
+		t.Errorf("devices 0 and 2 have vdevices %+v and %+v, want none", infos[0].VirtualDevices, infos[2].VirtualDevices)
+	}
+}
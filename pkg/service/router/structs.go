@@ -1,18 +1,10 @@
 package router
 
-/*
-#cgo CFLAGS: -Wall -I../../dcgm/include
-#cgo LDFLAGS: -L../../dcgm/lib -lrocm_smi64 -lhydmi -Wl,--unresolved-symbols=ignore-in-object-files
-#include <stdint.h>
-#include <kfd_ioctl.h>
-#include <rocm_smi64Config.h>
-#include <rocm_smi.h>
-#include <dmi_virtual.h>
-#include <dmi_error.h>
-#include <dmi.h>
-#include <dmi_mig.h>
-*/
-import "C"
+import (
+	"unsafe"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
 
 // RSMIPcieBandwidth 表示设备的 PCIe 带宽信息
 // swagger:model RSMIPcieBandwidth
@@ -39,22 +31,22 @@ type RSMIFrequencies struct {
 	Frequency [32]uint64
 }
 
-type RSNIPowerProfilePresetMasks C.rsmi_power_profile_preset_masks_t
+type RSNIPowerProfilePresetMasks int64
 
 const (
-	RSMI_PWR_PROF_PRST_CUSTOM_MASK       RSNIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_CUSTOM_MASK       //!< Custom Power Profile
-	RSMI_PWR_PROF_PRST_VIDEO_MASK        RSNIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_VIDEO_MASK        //!< Video Power Profile
-	RSMI_PWR_PROF_PRST_POWER_SAVING_MASK RSNIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_POWER_SAVING_MASK //!< Power Saving Profile
-	RSMI_PWR_PROF_PRST_COMPUTE_MASK      RSNIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_COMPUTE_MASK      //!< Compute Saving Profile
-	RSMI_PWR_PROF_PRST_VR_MASK           RSNIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_VR_MASK           //!< VR Power Profile
+	RSMI_PWR_PROF_PRST_CUSTOM_MASK       RSNIPowerProfilePresetMasks = 0x1  //!< Custom Power Profile
+	RSMI_PWR_PROF_PRST_VIDEO_MASK        RSNIPowerProfilePresetMasks = 0x2  //!< Video Power Profile
+	RSMI_PWR_PROF_PRST_POWER_SAVING_MASK RSNIPowerProfilePresetMasks = 0x4  //!< Power Saving Profile
+	RSMI_PWR_PROF_PRST_COMPUTE_MASK      RSNIPowerProfilePresetMasks = 0x8  //!< Compute Saving Profile
+	RSMI_PWR_PROF_PRST_VR_MASK           RSNIPowerProfilePresetMasks = 0x10 //!< VR Power Profile
 
 	//!< 3D Full Screen Power Profile
-	RSMI_PWR_PROF_PRST_3D_FULL_SCR_MASK RSNIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_3D_FULL_SCR_MASK
-	RSMI_PWR_PROF_PRST_BOOTUP_DEFAULT   RSNIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_BOOTUP_DEFAULT //!< Default Boot Up Profile
+	RSMI_PWR_PROF_PRST_3D_FULL_SCR_MASK RSNIPowerProfilePresetMasks = 0x20
+	RSMI_PWR_PROF_PRST_BOOTUP_DEFAULT   RSNIPowerProfilePresetMasks = 0x40 //!< Default Boot Up Profile
 	RSMI_PWR_PROF_PRST_LAST             RSNIPowerProfilePresetMasks = RSMI_PWR_PROF_PRST_BOOTUP_DEFAULT
 
 	//!< Invalid power profile
-	RSMI_PWR_PROF_PRST_INVALID RSNIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_INVALID
+	RSMI_PWR_PROF_PRST_INVALID RSNIPowerProfilePresetMasks = -1
 )
 
 type RSMIRetiredPageRecord struct {
@@ -63,12 +55,12 @@ type RSMIRetiredPageRecord struct {
 	Status      RSMIMemoryPageStatus //!< Page "reserved" status
 }
 
-type RSMIMemoryPageStatus C.rsmi_memory_page_status_t
+type RSMIMemoryPageStatus uint32
 
 const (
-	RSMI_MEM_PAGE_STATUS_RESERVED     RSMIMemoryPageStatus = C.RSMI_MEM_PAGE_STATUS_RESERVED
-	RSMI_MEM_PAGE_STATUS_PENDING      RSMIMemoryPageStatus = C.RSMI_MEM_PAGE_STATUS_PENDING
-	RSMI_MEM_PAGE_STATUS_UNRESERVABLE RSMIMemoryPageStatus = C.RSMI_MEM_PAGE_STATUS_UNRESERVABLE
+	RSMI_MEM_PAGE_STATUS_RESERVED     RSMIMemoryPageStatus = 0
+	RSMI_MEM_PAGE_STATUS_PENDING      RSMIMemoryPageStatus = 1
+	RSMI_MEM_PAGE_STATUS_UNRESERVABLE RSMIMemoryPageStatus = 2
 )
 
 type RSMIFreqVoltRegion struct {
@@ -76,58 +68,58 @@ type RSMIFreqVoltRegion struct {
 	VoltRange RSMIRange
 }
 
-type RSMITemperatureMetric C.rsmi_temperature_metric_t
+type RSMITemperatureMetric uint32
 
 const (
-	RSMI_TEMP_CURRENT        RSMITemperatureMetric = C.RSMI_TEMP_CURRENT
-	RSMI_TEMP_FIRST          RSMITemperatureMetric = C.RSMI_TEMP_FIRST
-	RSMI_TEMP_MAX            RSMITemperatureMetric = C.RSMI_TEMP_MAX
-	RSMI_TEMP_MIN            RSMITemperatureMetric = C.RSMI_TEMP_MIN
-	RSMI_TEMP_MAX_HYST       RSMITemperatureMetric = C.RSMI_TEMP_MAX_HYST
-	RSMI_TEMP_MIN_HYST       RSMITemperatureMetric = C.RSMI_TEMP_MIN_HYST
-	RSMI_TEMP_CRITICAL       RSMITemperatureMetric = C.RSMI_TEMP_CRITICAL
-	RSMI_TEMP_CRITICAL_HYST  RSMITemperatureMetric = C.RSMI_TEMP_CRITICAL_HYST
-	RSMI_TEMP_EMERGENCY      RSMITemperatureMetric = C.RSMI_TEMP_EMERGENCY
-	RSMI_TEMP_EMERGENCY_HYST RSMITemperatureMetric = C.RSMI_TEMP_EMERGENCY_HYST
-	RSMI_TEMP_CRIT_MIN       RSMITemperatureMetric = C.RSMI_TEMP_CRIT_MIN
-	RSMI_TEMP_CRIT_MIN_HYST  RSMITemperatureMetric = C.RSMI_TEMP_CRIT_MIN_HYST
-	RSMI_TEMP_OFFSET         RSMITemperatureMetric = C.RSMI_TEMP_OFFSET
-	RSMI_TEMP_LOWEST         RSMITemperatureMetric = C.RSMI_TEMP_LOWEST
-	RSMI_TEMP_HIGHEST        RSMITemperatureMetric = C.RSMI_TEMP_HIGHEST
-	RSMI_TEMP_LAST           RSMITemperatureMetric = C.RSMI_TEMP_LAST
+	RSMI_TEMP_CURRENT        RSMITemperatureMetric = 0
+	RSMI_TEMP_FIRST          RSMITemperatureMetric = 0
+	RSMI_TEMP_MAX            RSMITemperatureMetric = 1
+	RSMI_TEMP_MIN            RSMITemperatureMetric = 2
+	RSMI_TEMP_MAX_HYST       RSMITemperatureMetric = 3
+	RSMI_TEMP_MIN_HYST       RSMITemperatureMetric = 4
+	RSMI_TEMP_CRITICAL       RSMITemperatureMetric = 5
+	RSMI_TEMP_CRITICAL_HYST  RSMITemperatureMetric = 6
+	RSMI_TEMP_EMERGENCY      RSMITemperatureMetric = 7
+	RSMI_TEMP_EMERGENCY_HYST RSMITemperatureMetric = 8
+	RSMI_TEMP_CRIT_MIN       RSMITemperatureMetric = 9
+	RSMI_TEMP_CRIT_MIN_HYST  RSMITemperatureMetric = 10
+	RSMI_TEMP_OFFSET         RSMITemperatureMetric = 11
+	RSMI_TEMP_LOWEST         RSMITemperatureMetric = 12
+	RSMI_TEMP_HIGHEST        RSMITemperatureMetric = 13
+	RSMI_TEMP_LAST           RSMITemperatureMetric = 13
 )
 
-type RSMIVoltageType C.rsmi_voltage_type_t
+type RSMIVoltageType uint32
 
 const (
-	RSMI_VOLT_TYPE_FIRST   RSMIVoltageType = C.RSMI_VOLT_TYPE_FIRST
-	RSMI_VOLT_TYPE_VDDGFX  RSMIVoltageType = C.RSMI_VOLT_TYPE_VDDGFX
-	RSMI_VOLT_TYPE_LAST    RSMIVoltageType = C.RSMI_VOLT_TYPE_LAST
-	RSMI_VOLT_TYPE_INVALID RSMIVoltageType = C.RSMI_VOLT_TYPE_INVALID
+	RSMI_VOLT_TYPE_FIRST   RSMIVoltageType = 0
+	RSMI_VOLT_TYPE_VDDGFX  RSMIVoltageType = 0
+	RSMI_VOLT_TYPE_LAST    RSMIVoltageType = 0
+	RSMI_VOLT_TYPE_INVALID RSMIVoltageType = 4294967295
 )
 
-type RSMIVoltageMetric C.rsmi_voltage_metric_t
+type RSMIVoltageMetric uint32
 
 const (
-	RSMI_VOLT_CURRENT  RSMIVoltageMetric = C.RSMI_VOLT_CURRENT //!< Voltage current value.
-	RSMI_VOLT_FIRST    RSMIVoltageMetric = C.RSMI_VOLT_FIRST
-	RSMI_VOLT_MAX      RSMIVoltageMetric = C.RSMI_VOLT_MAX      //!< Voltage max value.
-	RSMI_VOLT_MIN_CRIT RSMIVoltageMetric = C.RSMI_VOLT_MIN_CRIT //!< Voltage critical min value.
-	RSMI_VOLT_MIN      RSMIVoltageMetric = C.RSMI_VOLT_MIN      //!< Voltage min value.
-	RSMI_VOLT_MAX_CRIT RSMIVoltageMetric = C.RSMI_VOLT_MAX_CRIT //!< Voltage critical max value.
-	RSMI_VOLT_AVERAGE  RSMIVoltageMetric = C.RSMI_VOLT_AVERAGE  //!< Average voltage.
-	RSMI_VOLT_LOWEST   RSMIVoltageMetric = C.RSMI_VOLT_LOWEST   //!< Historical minimum voltage.
-	RSMI_VOLT_HIGHEST  RSMIVoltageMetric = C.RSMI_VOLT_HIGHEST  //!< Historical maximum voltage.
-	RSMI_VOLT_LAST                       = C.RSMI_VOLT_LAST
+	RSMI_VOLT_CURRENT  RSMIVoltageMetric = 0 //!< Voltage current value.
+	RSMI_VOLT_FIRST    RSMIVoltageMetric = 0
+	RSMI_VOLT_MAX      RSMIVoltageMetric = 1 //!< Voltage max value.
+	RSMI_VOLT_MIN_CRIT RSMIVoltageMetric = 2 //!< Voltage critical min value.
+	RSMI_VOLT_MIN      RSMIVoltageMetric = 3 //!< Voltage min value.
+	RSMI_VOLT_MAX_CRIT RSMIVoltageMetric = 4 //!< Voltage critical max value.
+	RSMI_VOLT_AVERAGE  RSMIVoltageMetric = 5 //!< Average voltage.
+	RSMI_VOLT_LOWEST   RSMIVoltageMetric = 6 //!< Historical minimum voltage.
+	RSMI_VOLT_HIGHEST  RSMIVoltageMetric = 7 //!< Historical maximum voltage.
+	RSMI_VOLT_LAST                       = 7
 )
 
 type RSMIUtilizationCounterType uint32
 
 const (
-	RSMI_UTILIZATION_COUNTER_FIRST RSMIUtilizationCounterType = C.RSMI_UTILIZATION_COUNTER_FIRST
-	RSMI_COARSE_GRAIN_GFX_ACTIVITY RSMIUtilizationCounterType = C.RSMI_COARSE_GRAIN_GFX_ACTIVITY
-	RSMI_COARSE_GRAIN_MEM_ACTIVITY RSMIUtilizationCounterType = C.RSMI_COARSE_GRAIN_MEM_ACTIVITY
-	RSMI_UTILIZATION_COUNTER_LAST  RSMIUtilizationCounterType = C.RSMI_UTILIZATION_COUNTER_LAST
+	RSMI_UTILIZATION_COUNTER_FIRST RSMIUtilizationCounterType = 0
+	RSMI_COARSE_GRAIN_GFX_ACTIVITY RSMIUtilizationCounterType = 0
+	RSMI_COARSE_GRAIN_MEM_ACTIVITY RSMIUtilizationCounterType = 1
+	RSMI_UTILIZATION_COUNTER_LAST  RSMIUtilizationCounterType = 1
 )
 
 // @swagignore
@@ -140,16 +132,16 @@ type RSMIUtilizationCounter struct {
 	Value uint64
 }
 
-type RSMIClkType C.rsmi_clk_type_t
+type RSMIClkType uint32
 
 const (
-	RSMI_CLK_TYPE_SYS  RSMIClkType = C.RSMI_CLK_TYPE_SYS
-	RSMI_CLK_TYPE_DF   RSMIClkType = C.RSMI_CLK_TYPE_DF
-	RSMI_CLK_TYPE_DCEF RSMIClkType = C.RSMI_CLK_TYPE_DCEF
-	RSMI_CLK_TYPE_SOC  RSMIClkType = C.RSMI_CLK_TYPE_SOC
-	RSMI_CLK_TYPE_MEM  RSMIClkType = C.RSMI_CLK_TYPE_MEM
-	RSMI_CLK_TYPE_PCIE RSMIClkType = C.RSMI_CLK_TYPE_PCIE
-	RSMI_CLK_INVALID   RSMIClkType = C.RSMI_CLK_INVALID
+	RSMI_CLK_TYPE_SYS  RSMIClkType = 0
+	RSMI_CLK_TYPE_DF   RSMIClkType = 1
+	RSMI_CLK_TYPE_DCEF RSMIClkType = 2
+	RSMI_CLK_TYPE_SOC  RSMIClkType = 3
+	RSMI_CLK_TYPE_MEM  RSMIClkType = 4
+	RSMI_CLK_TYPE_PCIE RSMIClkType = 5
+	RSMI_CLK_INVALID   RSMIClkType = 4294967295
 )
 
 type RSMIOdVoltFreqData struct {
@@ -262,40 +254,40 @@ type RSMIGPUMetrics struct {
 	TempetureHBM [4]uint16
 }
 
-type RSMIDevPerfLevel C.rsmi_dev_perf_level_t
+type RSMIDevPerfLevel uint32
 
 const (
-	RSMI_DEV_PERF_LEVEL_AUTO            RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_AUTO
-	RSMI_DEV_PERF_LEVEL_FIRST           RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_FIRST
-	RSMI_DEV_PERF_LEVEL_LOW             RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_LOW
-	RSMI_DEV_PERF_LEVEL_HIGH            RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_HIGH
-	RSMI_DEV_PERF_LEVEL_MANUAL          RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_MANUAL
-	RSMI_DEV_PERF_LEVEL_STABLE_STD      RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_STABLE_STD
-	RSMI_DEV_PERF_LEVEL_STABLE_PEAK     RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_STABLE_PEAK
-	RSMI_DEV_PERF_LEVEL_STABLE_MIN_MCLK RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_STABLE_MIN_MCLK
-	RSMI_DEV_PERF_LEVEL_STABLE_MIN_SCLK RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_STABLE_MIN_SCLK
-	RSMI_DEV_PERF_LEVEL_DETERMINISM     RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_DETERMINISM
-	RSMI_DEV_PERF_LEVEL_LAST            RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_LAST
-	RSMI_DEV_PERF_LEVEL_UNKNOWN         RSMIDevPerfLevel = C.RSMI_DEV_PERF_LEVEL_UNKNOWN
+	RSMI_DEV_PERF_LEVEL_AUTO            RSMIDevPerfLevel = 0
+	RSMI_DEV_PERF_LEVEL_FIRST           RSMIDevPerfLevel = 0
+	RSMI_DEV_PERF_LEVEL_LOW             RSMIDevPerfLevel = 1
+	RSMI_DEV_PERF_LEVEL_HIGH            RSMIDevPerfLevel = 2
+	RSMI_DEV_PERF_LEVEL_MANUAL          RSMIDevPerfLevel = 3
+	RSMI_DEV_PERF_LEVEL_STABLE_STD      RSMIDevPerfLevel = 4
+	RSMI_DEV_PERF_LEVEL_STABLE_PEAK     RSMIDevPerfLevel = 5
+	RSMI_DEV_PERF_LEVEL_STABLE_MIN_MCLK RSMIDevPerfLevel = 6
+	RSMI_DEV_PERF_LEVEL_STABLE_MIN_SCLK RSMIDevPerfLevel = 7
+	RSMI_DEV_PERF_LEVEL_DETERMINISM     RSMIDevPerfLevel = 8
+	RSMI_DEV_PERF_LEVEL_LAST            RSMIDevPerfLevel = 8
+	RSMI_DEV_PERF_LEVEL_UNKNOWN         RSMIDevPerfLevel = 256
 )
 
 // 系统支持的配置文件
-type RSMIBitField C.rsmi_bit_field_t
+type RSMIBitField uint64
 
 // 当前激活的电源配置文件
-type RSMIPowerProfilePresetMasks C.rsmi_power_profile_preset_masks_t
+type RSMIPowerProfilePresetMasks int64
 
 // 定义 power profile preset masks 的枚举类型
 const (
-	RSMIPowerProfPrstCustomMask      RSMIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_CUSTOM_MASK       // Custom Power Profile
-	RSMIPowerProfPrstVideoMask       RSMIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_VIDEO_MASK        // Video Power Profile
-	RSMIPowerProfPrstPowerSavingMask RSMIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_POWER_SAVING_MASK // Power Saving Profile
-	RSMIPowerProfPrstComputeMask     RSMIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_COMPUTE_MASK      // Compute Saving Profile
-	RSMIPowerProfPrstVRMask          RSMIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_VR_MASK           // VR Power Profile
-	RSMIPowerProfPrst3DFullScrMask   RSMIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_3D_FULL_SCR_MASK  // 3D Full Screen Power Profile
-	RSMIPowerProfPrstBootupDefault   RSMIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_BOOTUP_DEFAULT    // Default Boot Up Profile
-	RSMIPowerProfPrstLast            RSMIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_LAST              // Last Profile (same as Bootup Default)
-	RSMIPowerProfPrstInvalid         RSMIPowerProfilePresetMasks = C.RSMI_PWR_PROF_PRST_INVALID           // Invalid power profile
+	RSMIPowerProfPrstCustomMask      RSMIPowerProfilePresetMasks = 0x1  // Custom Power Profile
+	RSMIPowerProfPrstVideoMask       RSMIPowerProfilePresetMasks = 0x2  // Video Power Profile
+	RSMIPowerProfPrstPowerSavingMask RSMIPowerProfilePresetMasks = 0x4  // Power Saving Profile
+	RSMIPowerProfPrstComputeMask     RSMIPowerProfilePresetMasks = 0x8  // Compute Saving Profile
+	RSMIPowerProfPrstVRMask          RSMIPowerProfilePresetMasks = 0x10 // VR Power Profile
+	RSMIPowerProfPrst3DFullScrMask   RSMIPowerProfilePresetMasks = 0x20 // 3D Full Screen Power Profile
+	RSMIPowerProfPrstBootupDefault   RSMIPowerProfilePresetMasks = 0x40 // Default Boot Up Profile
+	RSMIPowerProfPrstLast            RSMIPowerProfilePresetMasks = 0x40 // Last Profile (same as Bootup Default)
+	RSMIPowerProfPrstInvalid         RSMIPowerProfilePresetMasks = -1   // Invalid power profile
 )
 
 // RSMPowerProfileStatus  电源配置文件状态信息
@@ -315,41 +307,41 @@ type RSMIVersion struct {
 	Build string
 }
 
-type RSMISwComponent C.rsmi_sw_component_t
+type RSMISwComponent uint32
 
 const (
-	RSMISwCompFirst  RSMISwComponent = C.RSMI_SW_COMP_FIRST
-	RSMISwCompDriver RSMISwComponent = C.RSMI_SW_COMP_DRIVER
-	RSMISwCompLast   RSMISwComponent = C.RSMI_SW_COMP_LAST
+	RSMISwCompFirst  RSMISwComponent = 0
+	RSMISwCompDriver RSMISwComponent = 0
+	RSMISwCompLast   RSMISwComponent = 0
 )
 
 // 用于识别各种固
-type RSMIFwBlock C.rsmi_fw_block_t
+type RSMIFwBlock uint32
 
 const (
-	RSMIFwBlockFirst    RSMIFwBlock = C.RSMI_FW_BLOCK_FIRST
-	RSMIFwBlockASD      RSMIFwBlock = C.RSMI_FW_BLOCK_ASD
-	RSMIFwBlockCE       RSMIFwBlock = C.RSMI_FW_BLOCK_CE
-	RSMIFwBlockDMCU     RSMIFwBlock = C.RSMI_FW_BLOCK_DMCU
-	RSMIFwBlockMC       RSMIFwBlock = C.RSMI_FW_BLOCK_MC
-	RSMIFwBlockME       RSMIFwBlock = C.RSMI_FW_BLOCK_ME
-	RSMIFwBlockMEC      RSMIFwBlock = C.RSMI_FW_BLOCK_MEC
-	RSMIFwBlockMEC2     RSMIFwBlock = C.RSMI_FW_BLOCK_MEC2
-	RSMIFwBlockPFP      RSMIFwBlock = C.RSMI_FW_BLOCK_PFP
-	RSMIFwBlockRLC      RSMIFwBlock = C.RSMI_FW_BLOCK_RLC
-	RSMIFwBlockRLC_SRLC RSMIFwBlock = C.RSMI_FW_BLOCK_RLC_SRLC
-	RSMIFwBlockRLC_SRLG RSMIFwBlock = C.RSMI_FW_BLOCK_RLC_SRLG
-	RSMIFwBlockRLC_SRLS RSMIFwBlock = C.RSMI_FW_BLOCK_RLC_SRLS
-	RSMIFwBlockSDMA     RSMIFwBlock = C.RSMI_FW_BLOCK_SDMA
-	RSMIFwBlockSDMA2    RSMIFwBlock = C.RSMI_FW_BLOCK_SDMA2
-	RSMIFwBlockSMC      RSMIFwBlock = C.RSMI_FW_BLOCK_SMC
-	RSMIFwBlockSOS      RSMIFwBlock = C.RSMI_FW_BLOCK_SOS
-	RSMIFwBlockTA_RAS   RSMIFwBlock = C.RSMI_FW_BLOCK_TA_RAS
-	RSMIFwBlockTA_XGMI  RSMIFwBlock = C.RSMI_FW_BLOCK_TA_XGMI
-	RSMIFwBlockUVD      RSMIFwBlock = C.RSMI_FW_BLOCK_UVD
-	RSMIFwBlockVCE      RSMIFwBlock = C.RSMI_FW_BLOCK_VCE
-	RSMIFwBlockVCN      RSMIFwBlock = C.RSMI_FW_BLOCK_VCN
-	RSMIFwBlockLast     RSMIFwBlock = C.RSMI_FW_BLOCK_LAST
+	RSMIFwBlockFirst    RSMIFwBlock = 0
+	RSMIFwBlockASD      RSMIFwBlock = 0
+	RSMIFwBlockCE       RSMIFwBlock = 1
+	RSMIFwBlockDMCU     RSMIFwBlock = 2
+	RSMIFwBlockMC       RSMIFwBlock = 3
+	RSMIFwBlockME       RSMIFwBlock = 4
+	RSMIFwBlockMEC      RSMIFwBlock = 5
+	RSMIFwBlockMEC2     RSMIFwBlock = 6
+	RSMIFwBlockPFP      RSMIFwBlock = 7
+	RSMIFwBlockRLC      RSMIFwBlock = 8
+	RSMIFwBlockRLC_SRLC RSMIFwBlock = 9
+	RSMIFwBlockRLC_SRLG RSMIFwBlock = 10
+	RSMIFwBlockRLC_SRLS RSMIFwBlock = 11
+	RSMIFwBlockSDMA     RSMIFwBlock = 12
+	RSMIFwBlockSDMA2    RSMIFwBlock = 13
+	RSMIFwBlockSMC      RSMIFwBlock = 14
+	RSMIFwBlockSOS      RSMIFwBlock = 15
+	RSMIFwBlockTA_RAS   RSMIFwBlock = 16
+	RSMIFwBlockTA_XGMI  RSMIFwBlock = 17
+	RSMIFwBlockUVD      RSMIFwBlock = 18
+	RSMIFwBlockVCE      RSMIFwBlock = 19
+	RSMIFwBlockVCN      RSMIFwBlock = 20
+	RSMIFwBlockLast     RSMIFwBlock = 20
 )
 
 // 保存错误计
@@ -359,91 +351,91 @@ type RSMIErrorCount struct {
 }
 
 // 用于标识不同的GPU
-type RSMIGpuBlock C.rsmi_gpu_block_t
+type RSMIGpuBlock int64
 
 const (
-	RSMIGpuBlockInvalid  RSMIGpuBlock = C.RSMI_GPU_BLOCK_INVALID
-	RSMIGpuBlockFirst    RSMIGpuBlock = C.RSMI_GPU_BLOCK_FIRST
-	RSMIGpuBlockUMC      RSMIGpuBlock = C.RSMI_GPU_BLOCK_UMC
-	RSMIGpuBlockSDMA     RSMIGpuBlock = C.RSMI_GPU_BLOCK_SDMA
-	RSMIGpuBlockGFX      RSMIGpuBlock = C.RSMI_GPU_BLOCK_GFX
-	RSMIGpuBlockMMHUB    RSMIGpuBlock = C.RSMI_GPU_BLOCK_MMHUB
-	RSMIGpuBlockATHUB    RSMIGpuBlock = C.RSMI_GPU_BLOCK_ATHUB
-	RSMIGpuBlockPCIEBIF  RSMIGpuBlock = C.RSMI_GPU_BLOCK_PCIE_BIF
-	RSMIGpuBlockHDP      RSMIGpuBlock = C.RSMI_GPU_BLOCK_HDP
-	RSMIGpuBlockXGMIWAFL RSMIGpuBlock = C.RSMI_GPU_BLOCK_XGMI_WAFL
-	RSMIGpuBlockDF       RSMIGpuBlock = C.RSMI_GPU_BLOCK_DF
-	RSMIGpuBlockSMN      RSMIGpuBlock = C.RSMI_GPU_BLOCK_SMN
-	RSMIGpuBlockSEM      RSMIGpuBlock = C.RSMI_GPU_BLOCK_SEM
-	RSMIGpuBlockMP0      RSMIGpuBlock = C.RSMI_GPU_BLOCK_MP0
-	RSMIGpuBlockMP1      RSMIGpuBlock = C.RSMI_GPU_BLOCK_MP1
-	RSMIGpuBlockFuse     RSMIGpuBlock = C.RSMI_GPU_BLOCK_FUSE
-	RSMIGpuBlockMCA      RSMIGpuBlock = C.RSMI_GPU_BLOCK_MCA
-	RSMIGpuBlockLast     RSMIGpuBlock = C.RSMI_GPU_BLOCK_LAST
-	RSMIGpuBlockReserved RSMIGpuBlock = C.RSMI_GPU_BLOCK_RESERVED
+	RSMIGpuBlockInvalid  RSMIGpuBlock = 0x0
+	RSMIGpuBlockFirst    RSMIGpuBlock = 0x1
+	RSMIGpuBlockUMC      RSMIGpuBlock = 0x1
+	RSMIGpuBlockSDMA     RSMIGpuBlock = 0x2
+	RSMIGpuBlockGFX      RSMIGpuBlock = 0x4
+	RSMIGpuBlockMMHUB    RSMIGpuBlock = 0x8
+	RSMIGpuBlockATHUB    RSMIGpuBlock = 0x10
+	RSMIGpuBlockPCIEBIF  RSMIGpuBlock = 0x20
+	RSMIGpuBlockHDP      RSMIGpuBlock = 0x40
+	RSMIGpuBlockXGMIWAFL RSMIGpuBlock = 0x80
+	RSMIGpuBlockDF       RSMIGpuBlock = 0x100
+	RSMIGpuBlockSMN      RSMIGpuBlock = 0x200
+	RSMIGpuBlockSEM      RSMIGpuBlock = 0x400
+	RSMIGpuBlockMP0      RSMIGpuBlock = 0x800
+	RSMIGpuBlockMP1      RSMIGpuBlock = 0x1000
+	RSMIGpuBlockFuse     RSMIGpuBlock = 0x2000
+	RSMIGpuBlockMCA      RSMIGpuBlock = 0x4000
+	RSMIGpuBlockLast     RSMIGpuBlock = 0x4000
+	RSMIGpuBlockReserved RSMIGpuBlock = -9223372036854775808
 )
 
 // 当前ECC状态
-type RSMIRasErrState C.rsmi_ras_err_state_t
+type RSMIRasErrState uint32
 
 const (
-	RSMIRasErrStateNone     RSMIRasErrState = C.RSMI_RAS_ERR_STATE_NONE
-	RSMIRasErrStateDisabled RSMIRasErrState = C.RSMI_RAS_ERR_STATE_DISABLED
-	RSMIRasErrStateParity   RSMIRasErrState = C.RSMI_RAS_ERR_STATE_PARITY
-	RSMIRasErrStateSingC    RSMIRasErrState = C.RSMI_RAS_ERR_STATE_SING_C
-	RSMIRasErrStateMultUC   RSMIRasErrState = C.RSMI_RAS_ERR_STATE_MULT_UC
-	RSMIRasErrStatePoison   RSMIRasErrState = C.RSMI_RAS_ERR_STATE_POISON
-	RSMIRasErrStateEnabled  RSMIRasErrState = C.RSMI_RAS_ERR_STATE_ENABLED
-	RSMIRasErrStateLast     RSMIRasErrState = C.RSMI_RAS_ERR_STATE_LAST
-	RSMIRasErrStateInvalid  RSMIRasErrState = C.RSMI_RAS_ERR_STATE_INVALID
+	RSMIRasErrStateNone     RSMIRasErrState = 0
+	RSMIRasErrStateDisabled RSMIRasErrState = 1
+	RSMIRasErrStateParity   RSMIRasErrState = 2
+	RSMIRasErrStateSingC    RSMIRasErrState = 3
+	RSMIRasErrStateMultUC   RSMIRasErrState = 4
+	RSMIRasErrStatePoison   RSMIRasErrState = 5
+	RSMIRasErrStateEnabled  RSMIRasErrState = 6
+	RSMIRasErrStateLast     RSMIRasErrState = 6
+	RSMIRasErrStateInvalid  RSMIRasErrState = 4294967295
 )
 
 // 事件组枚举值
-type RSMIEventGroup C.rsmi_event_group_t
+type RSMIEventGroup uint32
 
 const (
-	RSMI_EVNT_GRP_XGMI          RSMIEventGroup = C.RSMI_EVNT_GRP_XGMI
-	RSMI_EVNT_GRP_XGMI_DATA_OUT RSMIEventGroup = C.RSMI_EVNT_GRP_XGMI_DATA_OUT
-	RSMI_EVNT_GRP_INVALID       RSMIEventGroup = C.RSMI_EVNT_GRP_INVALID
+	RSMI_EVNT_GRP_XGMI          RSMIEventGroup = 0
+	RSMI_EVNT_GRP_XGMI_DATA_OUT RSMIEventGroup = 10
+	RSMI_EVNT_GRP_INVALID       RSMIEventGroup = 4294967295
 )
 
-type RSMIEventType C.rsmi_event_type_t
+type RSMIEventType uint32
 
 const (
-	RSMIEventFirst RSMIEventType = C.RSMI_EVNT_FIRST
-
-	RSMIEventXGmiFirst       RSMIEventType = C.RSMI_EVNT_XGMI_FIRST
-	RSMIEventXGmi0NopTx      RSMIEventType = C.RSMI_EVNT_XGMI_0_NOP_TX
-	RSMIEventXGmi0RequestTx  RSMIEventType = C.RSMI_EVNT_XGMI_0_REQUEST_TX
-	RSMIEventXGmi0ResponseTx RSMIEventType = C.RSMI_EVNT_XGMI_0_RESPONSE_TX
-	RSMIEventXGmi0BeatsTx    RSMIEventType = C.RSMI_EVNT_XGMI_0_BEATS_TX
-	RSMIEventXGmi1NopTx      RSMIEventType = C.RSMI_EVNT_XGMI_1_NOP_TX
-	RSMIEventXGmi1RequestTx  RSMIEventType = C.RSMI_EVNT_XGMI_1_REQUEST_TX
-	RSMIEventXGmi1ResponseTx RSMIEventType = C.RSMI_EVNT_XGMI_1_RESPONSE_TX
-	RSMIEventXGmi1BeatsTx    RSMIEventType = C.RSMI_EVNT_XGMI_1_BEATS_TX
-
-	RSMIEventXGmiLast RSMIEventType = C.RSMI_EVNT_XGMI_LAST
-
-	RSMIEventXGmiDataOutFirst RSMIEventType = C.RSMI_EVNT_XGMI_DATA_OUT_FIRST
-
-	RSMIEventXGmiDataOut0    RSMIEventType = C.RSMI_EVNT_XGMI_DATA_OUT_0
-	RSMIEventXGmiDataOut1    RSMIEventType = C.RSMI_EVNT_XGMI_DATA_OUT_1
-	RSMIEventXGmiDataOut2    RSMIEventType = C.RSMI_EVNT_XGMI_DATA_OUT_2
-	RSMIEventXGmiDataOut3    RSMIEventType = C.RSMI_EVNT_XGMI_DATA_OUT_3
-	RSMIEventXGmiDataOut4    RSMIEventType = C.RSMI_EVNT_XGMI_DATA_OUT_4
-	RSMIEventXGmiDataOut5    RSMIEventType = C.RSMI_EVNT_XGMI_DATA_OUT_5
-	RSMIEventXGmiDataOutLast RSMIEventType = C.RSMI_EVNT_XGMI_DATA_OUT_LAST
-
-	RSMIEventLast RSMIEventType = C.RSMI_EVNT_LAST
+	RSMIEventFirst RSMIEventType = 0
+
+	RSMIEventXGmiFirst       RSMIEventType = 0
+	RSMIEventXGmi0NopTx      RSMIEventType = 0
+	RSMIEventXGmi0RequestTx  RSMIEventType = 1
+	RSMIEventXGmi0ResponseTx RSMIEventType = 2
+	RSMIEventXGmi0BeatsTx    RSMIEventType = 3
+	RSMIEventXGmi1NopTx      RSMIEventType = 4
+	RSMIEventXGmi1RequestTx  RSMIEventType = 5
+	RSMIEventXGmi1ResponseTx RSMIEventType = 6
+	RSMIEventXGmi1BeatsTx    RSMIEventType = 7
+
+	RSMIEventXGmiLast RSMIEventType = 7
+
+	RSMIEventXGmiDataOutFirst RSMIEventType = 10
+
+	RSMIEventXGmiDataOut0    RSMIEventType = 10
+	RSMIEventXGmiDataOut1    RSMIEventType = 11
+	RSMIEventXGmiDataOut2    RSMIEventType = 12
+	RSMIEventXGmiDataOut3    RSMIEventType = 13
+	RSMIEventXGmiDataOut4    RSMIEventType = 14
+	RSMIEventXGmiDataOut5    RSMIEventType = 15
+	RSMIEventXGmiDataOutLast RSMIEventType = 15
+
+	RSMIEventLast RSMIEventType = 15
 )
 
-type EventHandle C.rsmi_event_handle_t
+type EventHandle uint64
 
-type RSMICounterCommand C.rsmi_counter_command_t
+type RSMICounterCommand uint32
 
 const (
-	RSMI_CNTR_CMD_START RSMICounterCommand = C.RSMI_CNTR_CMD_START
-	RSMI_CNTR_CMD_STOP  RSMICounterCommand = C.RSMI_CNTR_CMD_STOP
+	RSMI_CNTR_CMD_START RSMICounterCommand = 0
+	RSMI_CNTR_CMD_STOP  RSMICounterCommand = 1
 )
 
 // 计数器值
@@ -463,38 +455,38 @@ type RSMIProcessInfo struct {
 }
 
 // RSMIXGMIStatus XGMI状态
-type RSMIXGMIStatus C.rsmi_xgmi_status_t
+type RSMIXGMIStatus uint32
 
 const (
 	// RSMIXGMIStatus 0
-	RSMIXGMIStatusNoErrors RSMIXGMIStatus = C.RSMI_XGMI_STATUS_NO_ERRORS
+	RSMIXGMIStatusNoErrors RSMIXGMIStatus = 0
 	// RSMIXGMIStatusError 1
-	RSMIXGMIStatusError RSMIXGMIStatus = C.RSMI_XGMI_STATUS_ERROR
+	RSMIXGMIStatusError RSMIXGMIStatus = 1
 	// RSMIXGMIStatusMultipleErrors 2
-	RSMIXGMIStatusMultipleErrors RSMIXGMIStatus = C.RSMI_XGMI_STATUS_MULTIPLE_ERRORS
+	RSMIXGMIStatusMultipleErrors RSMIXGMIStatus = 2
 )
 
 // IO链路类型
-type RSMIIOLinkType C.RSMI_IO_LINK_TYPE
+type RSMIIOLinkType uint32
 
 const (
-	RSMIIOLinkTypeUndefined      RSMIIOLinkType = C.RSMI_IOLINK_TYPE_UNDEFINED
-	RSMIIOLinkTypePCIExpress     RSMIIOLinkType = C.RSMI_IOLINK_TYPE_PCIEXPRESS
-	RSMIIOLinkTypeXGMI           RSMIIOLinkType = C.RSMI_IOLINK_TYPE_XGMI
-	RSMIIOLinkTypeNumIOLinkTypes RSMIIOLinkType = C.RSMI_IOLINK_TYPE_NUMIOLINKTYPES
-	RSMIIOLinkTypeSize           RSMIIOLinkType = C.RSMI_IOLINK_TYPE_SIZE
+	RSMIIOLinkTypeUndefined      RSMIIOLinkType = 0
+	RSMIIOLinkTypePCIExpress     RSMIIOLinkType = 1
+	RSMIIOLinkTypeXGMI           RSMIIOLinkType = 2
+	RSMIIOLinkTypeNumIOLinkTypes RSMIIOLinkType = 3
+	RSMIIOLinkTypeSize           RSMIIOLinkType = 4294967295
 )
 
-type RSMIFuncIDIterHandle C.rsmi_func_id_iter_handle_t
+type RSMIFuncIDIterHandle unsafe.Pointer
 
-type RSMIMemoryType C.rsmi_memory_type_t
+type RSMIMemoryType uint32
 
 const (
-	RSMI_MEM_TYPE_FIRST    RSMIMemoryType = C.RSMI_MEM_TYPE_FIRST
-	RSMI_MEM_TYPE_VRAM     RSMIMemoryType = C.RSMI_MEM_TYPE_VRAM
-	RSMI_MEM_TYPE_VIS_VRAM RSMIMemoryType = C.RSMI_MEM_TYPE_VIS_VRAM
-	RSMI_MEM_TYPE_GTT      RSMIMemoryType = C.RSMI_MEM_TYPE_GTT
-	RSMI_MEM_TYPE_LAST     RSMIMemoryType = C.RSMI_MEM_TYPE_LAST
+	RSMI_MEM_TYPE_FIRST    RSMIMemoryType = 0
+	RSMI_MEM_TYPE_VRAM     RSMIMemoryType = 0
+	RSMI_MEM_TYPE_VIS_VRAM RSMIMemoryType = 1
+	RSMI_MEM_TYPE_GTT      RSMIMemoryType = 2
+	RSMI_MEM_TYPE_LAST     RSMIMemoryType = 2
 )
 
 type RSMIFuncIDValue struct {
@@ -509,15 +501,15 @@ type RSMIFuncIDValue struct {
 	GpuBlock   RSMIGpuBlock
 }
 
-type RSMIEvtNotificationType C.rsmi_evt_notification_type_t
+type RSMIEvtNotificationType uint32
 
 const (
-	RSMI_EVT_NOTIF_VMFAULT          RSMIEvtNotificationType = C.RSMI_EVT_NOTIF_VMFAULT
-	RSMI_EVT_NOTIF_FIRST            RSMIEvtNotificationType = C.RSMI_EVT_NOTIF_FIRST
-	RSMI_EVT_NOTIF_THERMAL_THROTTLE RSMIEvtNotificationType = C.RSMI_EVT_NOTIF_THERMAL_THROTTLE
-	RSMI_EVT_NOTIF_GPU_PRE_RESET    RSMIEvtNotificationType = C.RSMI_EVT_NOTIF_GPU_PRE_RESET
-	RSMI_EVT_NOTIF_GPU_POST_RESET   RSMIEvtNotificationType = C.RSMI_EVT_NOTIF_GPU_POST_RESET
-	RSMI_EVT_NOTIF_LAST             RSMIEvtNotificationType = C.RSMI_EVT_NOTIF_LAST
+	RSMI_EVT_NOTIF_VMFAULT          RSMIEvtNotificationType = 1
+	RSMI_EVT_NOTIF_FIRST            RSMIEvtNotificationType = 1
+	RSMI_EVT_NOTIF_THERMAL_THROTTLE RSMIEvtNotificationType = 2
+	RSMI_EVT_NOTIF_GPU_PRE_RESET    RSMIEvtNotificationType = 3
+	RSMI_EVT_NOTIF_GPU_POST_RESET   RSMIEvtNotificationType = 4
+	RSMI_EVT_NOTIF_LAST             RSMIEvtNotificationType = 4
 )
 
 type RSMIEEvtNotificationData struct {
@@ -526,31 +518,31 @@ type RSMIEEvtNotificationData struct {
 	Message [64]byte
 }
 
-type RSMIStatus C.rsmi_status_t
+type RSMIStatus uint32
 
 const (
-	RSMI_STATUS_SUCCESS             RSMIStatus = C.RSMI_STATUS_SUCCESS             //!< Operation was successful
-	RSMI_STATUS_INVALID_ARGS        RSMIStatus = C.RSMI_STATUS_INVALID_ARGS        //!< Passed in arguments are not valid
-	RSMI_STATUS_NOT_SUPPORTED       RSMIStatus = C.RSMI_STATUS_NOT_SUPPORTED       //!< The requested information or
-	RSMI_STATUS_FILE_ERROR          RSMIStatus = C.RSMI_STATUS_FILE_ERROR          //!< Problem accessing a file. This
-	RSMI_STATUS_PERMISSION          RSMIStatus = C.RSMI_STATUS_PERMISSION          //!< Permission denied/EACCESS file
-	RSMI_STATUS_OUT_OF_RESOURCES    RSMIStatus = C.RSMI_STATUS_OUT_OF_RESOURCES    //!< Unable to acquire memory or other
-	RSMI_STATUS_INTERNAL_EXCEPTION  RSMIStatus = C.RSMI_STATUS_INTERNAL_EXCEPTION  //!< An internal exception was caught
-	RSMI_STATUS_INPUT_OUT_OF_BOUNDS RSMIStatus = C.RSMI_STATUS_INPUT_OUT_OF_BOUNDS //!< The provided input is out of
-	RSMI_STATUS_INIT_ERROR          RSMIStatus = C.RSMI_STATUS_INIT_ERROR          //!< An error occurred when rsmi
-	RSMI_INITIALIZATION_ERROR       RSMIStatus = C.RSMI_INITIALIZATION_ERROR
-	RSMI_STATUS_NOT_YET_IMPLEMENTED RSMIStatus = C.RSMI_STATUS_NOT_YET_IMPLEMENTED //!< The requested function has not
-	RSMI_STATUS_NOT_FOUND           RSMIStatus = C.RSMI_STATUS_NOT_FOUND           //!< An item was searched for but not
-	RSMI_STATUS_INSUFFICIENT_SIZE   RSMIStatus = C.RSMI_STATUS_INSUFFICIENT_SIZE   //!< Not enough resources were
-	RSMI_STATUS_INTERRUPT           RSMIStatus = C.RSMI_STATUS_INTERRUPT           //!< An interrupt occurred during
-	RSMI_STATUS_UNEXPECTED_SIZE     RSMIStatus = C.RSMI_STATUS_UNEXPECTED_SIZE     //!< An unexpected amount of data
-	RSMI_STATUS_NO_DATA             RSMIStatus = C.RSMI_STATUS_NO_DATA             //!< No data was found for a given
-	RSMI_STATUS_UNEXPECTED_DATA     RSMIStatus = C.RSMI_STATUS_UNEXPECTED_DATA     //!< The data read or provided to
-	RSMI_STATUS_BUSY                RSMIStatus = C.RSMI_STATUS_BUSY
-	RSMI_STATUS_REFCOUNT_OVERFLOW   RSMIStatus = C.RSMI_STATUS_REFCOUNT_OVERFLOW   //!< An internal reference counter
-	RSMI_STATUS_SETTING_UNAVAILABLE RSMIStatus = C.RSMI_STATUS_SETTING_UNAVAILABLE //!< Requested setting is unavailable
-	RSMI_STATUS_AMDGPU_RESTART_ERR  RSMIStatus = C.RSMI_STATUS_AMDGPU_RESTART_ERR  //!< Could not successfully restart
-	RSMI_STATUS_UNKNOWN_ERROR       RSMIStatus = C.RSMI_STATUS_UNKNOWN_ERROR
+	RSMI_STATUS_SUCCESS             RSMIStatus = 0 //!< Operation was successful
+	RSMI_STATUS_INVALID_ARGS        RSMIStatus = 1 //!< Passed in arguments are not valid
+	RSMI_STATUS_NOT_SUPPORTED       RSMIStatus = 2 //!< The requested information or
+	RSMI_STATUS_FILE_ERROR          RSMIStatus = 3 //!< Problem accessing a file. This
+	RSMI_STATUS_PERMISSION          RSMIStatus = 4 //!< Permission denied/EACCESS file
+	RSMI_STATUS_OUT_OF_RESOURCES    RSMIStatus = 5 //!< Unable to acquire memory or other
+	RSMI_STATUS_INTERNAL_EXCEPTION  RSMIStatus = 6 //!< An internal exception was caught
+	RSMI_STATUS_INPUT_OUT_OF_BOUNDS RSMIStatus = 7 //!< The provided input is out of
+	RSMI_STATUS_INIT_ERROR          RSMIStatus = 8 //!< An error occurred when rsmi
+	RSMI_INITIALIZATION_ERROR       RSMIStatus = 8
+	RSMI_STATUS_NOT_YET_IMPLEMENTED RSMIStatus = 9  //!< The requested function has not
+	RSMI_STATUS_NOT_FOUND           RSMIStatus = 10 //!< An item was searched for but not
+	RSMI_STATUS_INSUFFICIENT_SIZE   RSMIStatus = 11 //!< Not enough resources were
+	RSMI_STATUS_INTERRUPT           RSMIStatus = 12 //!< An interrupt occurred during
+	RSMI_STATUS_UNEXPECTED_SIZE     RSMIStatus = 13 //!< An unexpected amount of data
+	RSMI_STATUS_NO_DATA             RSMIStatus = 14 //!< No data was found for a given
+	RSMI_STATUS_UNEXPECTED_DATA     RSMIStatus = 15 //!< The data read or provided to
+	RSMI_STATUS_BUSY                RSMIStatus = 16
+	RSMI_STATUS_REFCOUNT_OVERFLOW   RSMIStatus = 17 //!< An internal reference counter
+	RSMI_STATUS_SETTING_UNAVAILABLE RSMIStatus = 18 //!< Requested setting is unavailable
+	RSMI_STATUS_AMDGPU_RESTART_ERR  RSMIStatus = 19 //!< Could not successfully restart
+	RSMI_STATUS_UNKNOWN_ERROR       RSMIStatus = 4294967295
 )
 
 // MonitorInfo 设备监控信息
@@ -576,10 +568,16 @@ type MonitorInfo struct {
 	MemoryUsed float64
 	//  UtilizationRate 设备忙碌时间百分比
 	UtilizationRate float64
+	//  GfxActivity 由活动累加器计算的平均图形活动百分比，比 UtilizationRate 的瞬时值稳定
+	GfxActivity float64
+	//  MemActivity 由活动累加器计算的平均内存活动百分比
+	MemActivity float64
 	//  PcieBwMb pcie流量信息
 	PcieBwMb float64
 	// Clk 备系统时钟速度列表
 	Clk float64
+	// ThrottleReasons 当前生效的节流原因，如 power_cap、thermal_hotspot，无节流时为空
+	ThrottleReasons []string
 }
 
 // DeviceInfo 设备信息结构体
@@ -695,42 +693,42 @@ type DMIVDeviceInfo struct {
 	PciBusNumber string
 }
 
-type DMIStatus C.dmiStatus
+type DMIStatus uint32
 
 const (
-	DMI_STATUS_SUCCESS                DMIStatus = C.DMI_STATUS_SUCCESS
-	DMI_STATUS_ERROR                  DMIStatus = C.DMI_STATUS_ERROR
-	DMI_STATUS_NO_MEMORY              DMIStatus = C.DMI_STATUS_NO_MEMORY
-	DMI_STATUS_OPEN_MKFD_FAILED       DMIStatus = C.DMI_STATUS_OPEN_MKFD_FAILED
-	DMI_STATUS_MKFD_ALREADY_OPENED    DMIStatus = C.DMI_STATUS_MKFD_ALREADY_OPENED
-	DMI_STATUS_SYS_NODE_NOT_EXIST     DMIStatus = C.DMI_STATUS_SYS_NODE_NOT_EXIST
-	DMI_STATUS_NOT_SUPPORTED          DMIStatus = C.DMI_STATUS_NOT_SUPPORTED
-	DMI_STATUS_MKFD_NOT_OPENED        DMIStatus = C.DMI_STATUS_MKFD_NOT_OPENED
-	DMI_STATUS_CREATE_VDEV_FAILED     DMIStatus = C.DMI_STATUS_CREATE_VDEV_FAILED
-	DMI_STATUS_DESTROY_VDEV_FAILED    DMIStatus = C.DMI_STATUS_DESTROY_VDEV_FAILED
-	DMI_STATUS_INVALID_ARGUMENTS      DMIStatus = C.DMI_STATUS_INVALID_ARGUMENTS
-	DMI_STATUS_OUT_OF_RESOURCES       DMIStatus = C.DMI_STATUS_OUT_OF_RESOURCES
-	DMI_STATUS_QUERY_VDEV_INFO_FAILED DMIStatus = C.DMI_STATUS_QUERY_VDEV_INFO_FAILED
-	DMI_STATUS_ERROR_NOT_INITIALIZED  DMIStatus = C.DMI_STATUS_ERROR_NOT_INITIALIZED
-	DMI_STATUS_DEVICE_NOT_SUPPORT     DMIStatus = C.DMI_STATUS_DEVICE_NOT_SUPPORT
-	DMI_STATUS_VDEV_NOT_EXIST         DMIStatus = C.DMI_STATUS_VDEV_NOT_EXIST
-	DMI_STATUS_INIT_DEVICE_FAILED     DMIStatus = C.DMI_STATUS_INIT_DEVICE_FAILED
-	DMI_STATUS_DEVICE_BUSY            DMIStatus = C.DMI_STATUS_DEVICE_BUSY
-	DMI_STATUS_FILE_ERROR             DMIStatus = C.DMI_STATUS_FILE_ERROR
-	DMI_STATUS_PERMISSION             DMIStatus = C.DMI_STATUS_PERMISSION
-	DMI_STATUS_INTERNAL_EXCEPTION     DMIStatus = C.DMI_STATUS_INTERNAL_EXCEPTION
-	DMI_STATUS_INPUT_OUT_OF_BOUNDS    DMIStatus = C.DMI_STATUS_INPUT_OUT_OF_BOUNDS
-	DMI_STATUS_SMI_INIT_ERROR         DMIStatus = C.DMI_STATUS_SMI_INIT_ERROR
-	DMI_STATUS_NOT_FOUND              DMIStatus = C.DMI_STATUS_NOT_FOUND
-	DMI_STATUS_INSUFFICIENT_SIZE      DMIStatus = C.DMI_STATUS_INSUFFICIENT_SIZE
-	DMI_STATUS_INTERRUPT              DMIStatus = C.DMI_STATUS_INTERRUPT
-	DMI_STATUS_UNEXPECTED_SIZE        DMIStatus = C.DMI_STATUS_UNEXPECTED_SIZE
-	DMI_STATUS_NO_DATA                DMIStatus = C.DMI_STATUS_NO_DATA
-	DMI_STATUS_UNEXPECTED_DATA        DMIStatus = C.DMI_STATUS_UNEXPECTED_DATA
-	DMI_STATUS_SMI_BUSY               DMIStatus = C.DMI_STATUS_SMI_BUSY
-	DMI_STATUS_REFCOUNT_OVERFLOW      DMIStatus = C.DMI_STATUS_REFCOUNT_OVERFLOW
-	DMI_STATUS_NOT_YET_IMPLEMENTED    DMIStatus = C.DMI_STATUS_NOT_YET_IMPLEMENTED
-	DMI_STATUS_UNKNOWN_ERROR          DMIStatus = C.DMI_STATUS_UNKNOWN_ERROR
+	DMI_STATUS_SUCCESS                DMIStatus = 0
+	DMI_STATUS_ERROR                  DMIStatus = 1
+	DMI_STATUS_NO_MEMORY              DMIStatus = 2
+	DMI_STATUS_OPEN_MKFD_FAILED       DMIStatus = 3
+	DMI_STATUS_MKFD_ALREADY_OPENED    DMIStatus = 4
+	DMI_STATUS_SYS_NODE_NOT_EXIST     DMIStatus = 5
+	DMI_STATUS_NOT_SUPPORTED          DMIStatus = 6
+	DMI_STATUS_MKFD_NOT_OPENED        DMIStatus = 7
+	DMI_STATUS_CREATE_VDEV_FAILED     DMIStatus = 8
+	DMI_STATUS_DESTROY_VDEV_FAILED    DMIStatus = 9
+	DMI_STATUS_INVALID_ARGUMENTS      DMIStatus = 10
+	DMI_STATUS_OUT_OF_RESOURCES       DMIStatus = 11
+	DMI_STATUS_QUERY_VDEV_INFO_FAILED DMIStatus = 12
+	DMI_STATUS_ERROR_NOT_INITIALIZED  DMIStatus = 13
+	DMI_STATUS_DEVICE_NOT_SUPPORT     DMIStatus = 14
+	DMI_STATUS_VDEV_NOT_EXIST         DMIStatus = 15
+	DMI_STATUS_INIT_DEVICE_FAILED     DMIStatus = 16
+	DMI_STATUS_DEVICE_BUSY            DMIStatus = 17
+	DMI_STATUS_FILE_ERROR             DMIStatus = 18
+	DMI_STATUS_PERMISSION             DMIStatus = 19
+	DMI_STATUS_INTERNAL_EXCEPTION     DMIStatus = 20
+	DMI_STATUS_INPUT_OUT_OF_BOUNDS    DMIStatus = 21
+	DMI_STATUS_SMI_INIT_ERROR         DMIStatus = 22
+	DMI_STATUS_NOT_FOUND              DMIStatus = 23
+	DMI_STATUS_INSUFFICIENT_SIZE      DMIStatus = 24
+	DMI_STATUS_INTERRUPT              DMIStatus = 25
+	DMI_STATUS_UNEXPECTED_SIZE        DMIStatus = 26
+	DMI_STATUS_NO_DATA                DMIStatus = 27
+	DMI_STATUS_UNEXPECTED_DATA        DMIStatus = 28
+	DMI_STATUS_SMI_BUSY               DMIStatus = 29
+	DMI_STATUS_REFCOUNT_OVERFLOW      DMIStatus = 30
+	DMI_STATUS_NOT_YET_IMPLEMENTED    DMIStatus = 31
+	DMI_STATUS_UNKNOWN_ERROR          DMIStatus = 32
 )
 
 // Device 物理设备的详细信息
@@ -797,8 +795,14 @@ type PhysicalDeviceInfo struct {
 	VirtualDevices []DMIVDeviceInfo
 }
 
-// 定义事件通知类型名称
-var notificationTypeNames = []string{"VM_FAULT", "THERMAL_THROTTLE", "GPU_RESET"}
+// 定义事件通知类型名称，与 dcgm.AllEventTypes 保持一致
+var notificationTypeNames = func() []string {
+	names := make([]string, len(dcgm.AllEventTypes))
+	for i, t := range dcgm.AllEventTypes {
+		names[i] = string(t)
+	}
+	return names
+}()
 
 // 设备结构体
 type DeviceId struct {
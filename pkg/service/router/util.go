@@ -3,7 +3,7 @@ package router
 import (
 	"fmt"
 
-	"g.sugon.com/das/dcgm-dcu/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
 )
 
 // 将字符串转换为 RSMIDevPerfLevel 类型
@@ -2,10 +2,12 @@ package main
 
 import (
 	"flag"
+	"os"
 
 	"github.com/golang/glog"
 
 	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm/printer"
 )
 
 // 添加注释以描述 server 信息
@@ -75,11 +77,14 @@ func main() {
 	dcgm.XGMIHiveIdGet(1)
 
 	//批量展示显示设备硬件信息
-	dcgm.ShowAllConciseHw([]int{0, 1, 2})
+	conciseHwInfos, _ := dcgm.ShowAllConciseHw([]int{0, 1, 2})
+	printer.ConciseHw(os.Stdout, conciseHwInfos)
 	//批量展示显示时钟信息
-	dcgm.ShowClocks([]int{0, 1, 2})
+	deviceClockInfos, _ := dcgm.ShowClocks([]int{0, 1, 2})
+	printer.Clocks(os.Stdout, deviceClockInfos)
 	//展示风扇转速和风扇级别
-	dcgm.ShowCurrentFans([]int{0, 1, 2}, false)
+	fanInfos, _ := dcgm.ShowCurrentFans([]int{0, 1, 2})
+	printer.Fans(os.Stdout, fanInfos)
 	//显示设备的所有可用温度传感器的温度
 	dcgm.ShowCurrentTemps([]int{0, 1, 2})
 	//显示设备中指定固件类型的固件版本信息
@@ -90,7 +95,8 @@ func main() {
 	//批量获取DCU的使用率
 	dcgm.ShowGpuUse([]int{0, 1, 2})
 	//批量获取设备消耗的能量
-	dcgm.ShowEnergy([]int{0, 1, 2})
+	deviceEnergyInfos, _ := dcgm.ShowEnergy([]int{0, 1, 2})
+	printer.Energy(os.Stdout, deviceEnergyInfos)
 	//DCU设备的ID（十六进制表示）
 	dcgm.DevID(0)
 	//设备的最大功率值
@@ -98,9 +104,11 @@ func main() {
 	//设备的不同类型的内存使用情况 memType:[vram|vis_vram|gtt]
 	dcgm.MemInfo(0, "vram")
 	//批量获取设备内存的信息
-	dcgm.ShowMemInfo([]int{0, 1, 2}, []string{"VRAM", "VIS_VRAM"})
+	deviceMemInfos, _ := dcgm.ShowMemInfo([]int{0, 1, 2}, []string{"VRAM", "VIS_VRAM"})
+	printer.MemInfo(os.Stdout, deviceMemInfos)
 	//批量获取设备内存使用情况
-	dcgm.ShowMemUse([]int{0, 1, 2})
+	deviceMemUseInfos, _ := dcgm.ShowMemUse([]int{0, 1, 2})
+	printer.MemUse(os.Stdout, deviceMemUseInfos)
 	//批量获取备供应商信息
 	dcgm.ShowMemVendor([]int{0, 1, 2})
 	//批量获取设备的PCIe带宽使用情况
@@ -118,12 +126,14 @@ func main() {
 
 	//电流或电压范围（K100_AI卡不支持该操作）
 	devices := []int{0, 1, 2}
-	dcgm.ShowRange(devices, "sclk")
-	dcgm.ShowRange(devices, "mclk")
-	dcgm.ShowRange(devices, "voltage")
+	for _, rangeType := range []string{"sclk", "mclk", "voltage"} {
+		deviceRangeInfos, _ := dcgm.ShowRange(devices, rangeType)
+		printer.Range(os.Stdout, deviceRangeInfos)
+	}
 
 	//显示设备中指定类型的退役页
-	dcgm.ShowRetiredPages([]int{0, 1, 2}, "all")
+	deviceRetiredPagesInfos, _ := dcgm.ShowRetiredPages([]int{0, 1, 2}, "all")
+	printer.RetiredPages(os.Stdout, deviceRetiredPagesInfos)
 	//设备序列号
 	dcgm.ShowSerialNumber([]int{0, 1, 2})
 	//设备的唯一设备ID
@@ -139,8 +149,10 @@ func main() {
 	dcgm.ShowVoltage([]int{0, 1, 2})
 
 	//获取指定设备的电压曲线点（K100_AI卡不支持该操作）
-	dcgm.ShowVoltageCurve([]int{0, 1, 2})
+	deviceVoltageCurveInfos, _ := dcgm.ShowVoltageCurve([]int{0, 1, 2})
+	printer.VoltageCurve(os.Stdout, deviceVoltageCurveInfos)
 	//指定设备的XGMI错误状态（K100_AI卡不支持该操作）
-	dcgm.ShowXgmiErr([]int{0, 1, 2}, true)
+	xgmiErrorInfos, _ := dcgm.ShowXgmiErr([]int{0, 1, 2})
+	printer.XgmiErr(os.Stdout, xgmiErrorInfos)
 
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/glog"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/service/router"
+)
+
+var scenarioFlag = flag.String("scenario", "samples/fake/scenario.yaml", "fake backend scenario file")
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+	// 使用模拟后端初始化，不需要 DCU 硬件和 librocm_smi64/libhydmi
+	backend, err := dcgm.NewFakeBackendFromFile(*scenarioFlag)
+	if err != nil {
+		glog.Fatalf("加载场景失败: %v", err)
+	}
+	if err = dcgm.InitWithBackend(backend); err != nil {
+		glog.Fatalf("DCGM 初始化失败: %v", err)
+	}
+	defer dcgm.ShutDown()
+
+	monitorInfos, err := dcgm.CollectDeviceMetrics()
+	if err != nil {
+		glog.Fatalf("CollectDeviceMetrics: %v", err)
+	}
+	printJSON("CollectDeviceMetrics", monitorInfos)
+
+	allDevices, err := dcgm.AllDeviceInfos()
+	if err != nil {
+		glog.Fatalf("AllDeviceInfos: %v", err)
+	}
+	printJSON("AllDeviceInfos", allDevices)
+
+	blocksInfos, err := dcgm.EccBlocksInfo(0)
+	if err != nil {
+		glog.Fatalf("EccBlocksInfo: %v", err)
+	}
+	printJSON("EccBlocksInfo", blocksInfos)
+
+	// 通过 gin 路由访问同一个模拟后端
+	r := router.InitRouter()
+	for _, path := range []string{"/CollectDeviceMetrics", "/Temperature/0?sensorType=1"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		r.ServeHTTP(w, req)
+		fmt.Printf("========== GET %s -> %d ==========\n%s\n", path, w.Code, w.Body.String())
+	}
+}
+
+func printJSON(title string, v any) {
+	data, _ := json.MarshalIndent(v, "", "  ")
+	fmt.Printf("========== %s ==========\n%s\n", title, data)
+}
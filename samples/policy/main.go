@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"time"
 
 	"github.com/golang/glog"
 
@@ -16,9 +18,17 @@ func main() {
 	dcgm.Init()
 	defer dcgm.ShutDown()
 
-	// 打印事件列表
-	eventList := []string{"VMFAULT", "FIRST", "THERMAL_THROTTLE", "GPU_PRE_RESET", "GPU_POST_RESET", "LAST"} // 示例事件列表
-	dcgm.PrintEventList(1, 100, eventList)
+	// 订阅设备 1 的事件通知，10 秒后取消订阅，通道随之关闭
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	events, err := dcgm.Subscribe(ctx, []int{1}, []dcgm.EventType{dcgm.EventVMFault, dcgm.EventThermalThrottle, dcgm.EventGPUPreReset, dcgm.EventGPUPostReset})
+	if err != nil {
+		glog.Errorf("subscribe events: %v", err)
+	} else {
+		for event := range events {
+			glog.Infof("GPU[%d] %s %s: %s", event.DeviceID, event.Time.Format(time.DateTime), event.Type, event.Message)
+		}
+	}
+	cancel()
 
 	//批量复位风扇驱动控制
 	dcgm.ResetFans([]int{0, 1})
@@ -47,4 +57,22 @@ func main() {
 	dcgm.SetProfile([]int{0}, "BOOTUP DEFAULT")
 	//设置设备功率配置文件（K100_AI卡不支持该操作）
 	dcgm.DevPowerProfileSet(0, 0, dcgm.RSMI_PWR_PROF_PRST_BOOTUP_DEFAULT)
+
+	//注册策略：设备 0 边缘温度超过 85℃ 持续 10 秒时降低性能等级，违规时调用回调
+	unregister := dcgm.RegisterPolicyCallback("hot", func(v dcgm.PolicyViolation) {
+		glog.Warningf("device %d: %s, action %s", v.DeviceID, v.Message, v.Action)
+	})
+	defer unregister()
+	if _, err := dcgm.SetPolicy(dcgm.Policy{
+		Name:      "hot",
+		Devices:   []int{0},
+		Condition: dcgm.PolicyMaxTemperature,
+		Threshold: 85,
+		Seconds:   10,
+		Action:    dcgm.PolicyActionPerfLevelLow,
+	}); err != nil {
+		glog.Errorf("SetPolicy: %v", err)
+	}
+	time.Sleep(time.Minute)
+	glog.Infof("violations: %v", dcgm.PolicyViolations(time.Time{}))
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/glog"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/service/router"
+)
+
+var (
+	backendFlag = flag.String("backend", dcgm.BackendFake, "backend to record, spec name[:config]")
+	outputFlag  = flag.String("output", filepath.Join(os.TempDir(), "dcgm-calls.jsonl"), "recording file")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	// 录制：在真实（或模拟）后端上采集一次指标，所有原语调用写入录制文件
+	recorder, err := dcgm.InitWithRecording(*backendFlag, *outputFlag)
+	if err != nil {
+		glog.Fatalf("DCGM 初始化失败: %v", err)
+	}
+	recorded := collect()
+	dcgm.ShutDown()
+	recorder.Close()
+	fmt.Printf("recorded %s calls to %s\n", *backendFlag, *outputFlag)
+
+	// 重放：使用录制文件初始化，走同样的 pkg/dcgm 与路由代码
+	if err = dcgm.InitWithBackendName(dcgm.BackendReplay + ":" + *outputFlag); err != nil {
+		glog.Fatalf("DCGM 重放初始化失败: %v", err)
+	}
+	defer dcgm.ShutDown()
+	replayed := collect()
+
+	for name, data := range recorded {
+		fmt.Printf("%-30s match=%v\n", name, data == replayed[name])
+	}
+	fmt.Printf("========== replayed /CollectDeviceMetrics ==========\n%s\n", replayed["GET /CollectDeviceMetrics"])
+}
+
+// collect 调用常用的接口，返回各接口结果的 JSON
+func collect() map[string]string {
+	results := map[string]string{}
+	monitorInfos, err := dcgm.CollectDeviceMetrics()
+	results["CollectDeviceMetrics"] = toJSON(monitorInfos, err)
+	deviceInfos, err := dcgm.DeviceInfos()
+	results["DeviceInfos"] = toJSON(deviceInfos, err)
+
+	r := router.InitRouter()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/CollectDeviceMetrics", nil)
+	r.ServeHTTP(w, req)
+	results["GET /CollectDeviceMetrics"] = w.Body.String()
+	return results
+}
+
+func toJSON(v any, err error) string {
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
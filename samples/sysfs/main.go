@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/golang/glog"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
+
+var rootFlag = flag.String("root", "samples/sysfs/fixture", "sysfs root directory")
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+	// 使用 sysfs 后端初始化，只读取 sysfs 文件，不调用 librocm_smi64/libhydmi
+	if err := dcgm.InitWithBackend(dcgm.NewSysfsBackend(*rootFlag)); err != nil {
+		glog.Fatalf("DCGM 初始化失败: %v", err)
+	}
+	defer dcgm.ShutDown()
+
+	deviceInfos, err := dcgm.DeviceInfos()
+	if err != nil {
+		glog.Fatalf("DeviceInfos: %v", err)
+	}
+	printJSON("DeviceInfos", deviceInfos)
+
+	monitorInfos, err := dcgm.CollectDeviceMetrics()
+	if err != nil {
+		glog.Fatalf("CollectDeviceMetrics: %v", err)
+	}
+	printJSON("CollectDeviceMetrics", monitorInfos)
+
+	for i := range deviceInfos {
+		for _, sensor := range []int{dcgm.SENSOR_EDGE, dcgm.SENSOR_JUNCTION, dcgm.SENSOR_MEMORY} {
+			temp, err := dcgm.Temperature(i, sensor)
+			if err != nil {
+				glog.Errorf("Temperature(%d, %d): %v", i, sensor, err)
+				continue
+			}
+			fmt.Printf("device %d sensor %d temperature: %.1f\n", i, sensor, temp)
+		}
+	}
+
+	// fixture 中 card0 为 v1.3、card1 为 v1.1 的 gpu_metrics 度量表
+	for i := range deviceInfos {
+		gpuMetrics, err := dcgm.DevGpuMetrics(i)
+		if err != nil {
+			glog.Errorf("DevGpuMetrics(%d): %v", i, err)
+			continue
+		}
+		printJSON(fmt.Sprintf("DevGpuMetrics %d", i), gpuMetrics)
+	}
+
+	pidList, err := dcgm.PidList()
+	if err != nil {
+		glog.Errorf("PidList: %v", err)
+	} else {
+		printJSON("PidList", pidList)
+	}
+}
+
+func printJSON(title string, v any) {
+	data, _ := json.MarshalIndent(v, "", "  ")
+	fmt.Printf("========== %s ==========\n%s\n", title, data)
+}
@@ -2,10 +2,12 @@ package main
 
 import (
 	"flag"
+	"os"
 
 	"github.com/golang/glog"
 
 	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm/printer"
 )
 
 func main() {
@@ -16,17 +18,19 @@ func main() {
 	dcgm.Init()
 	defer dcgm.ShutDown()
 
-	//硬件拓扑信息(支持json打印信息)
-	dcgm.ShowWeightTopology([]int{0, 1, 2}, true)
-	dcgm.ShowWeightTopology([]int{0, 1, 2}, false)
-	//基于跳数显示硬件拓扑信息(支持json打印信息)
-	dcgm.ShowHopsTopology([]int{0, 1, 2}, false)
-	dcgm.ShowHopsTopology([]int{0, 1, 2}, true)
-	//基于链接类型的硬件拓扑信息(支持json打印信息)
-	dcgm.ShowTypeTopology([]int{0, 1, 2}, true)
-	dcgm.ShowTypeTopology([]int{0, 1, 2}, false)
+	devices := []int{0, 1, 2}
+	//硬件拓扑信息
+	weights, _ := dcgm.ShowWeightTopology(devices)
+	printer.Topology(os.Stdout, "Weight between two GPUs", weights)
+	//基于跳数显示硬件拓扑信息
+	hops, _ := dcgm.ShowHopsTopology(devices)
+	printer.Topology(os.Stdout, "Hops between two GPUs", hops)
+	//基于链接类型的硬件拓扑信息
+	linkTypes, _ := dcgm.ShowTypeTopology(devices)
+	printer.LinkTypes(os.Stdout, linkTypes)
 	//numa节点HW拓扑信息
-	dcgm.ShowNumaTopology([]int{0, 1, 2})
+	dcgm.ShowNumaTopology(devices)
 	//显示硬件拓扑信息,包括权重、跳数、链接类型以及NUMA节点信息
-	dcgm.ShowHwTopology([]int{0, 1, 2})
+	hwTopology, _ := dcgm.ShowHwTopology(devices)
+	printer.HwTopology(os.Stdout, hwTopology)
 }
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/Project-HAMi/dcu-dcgm/pkg/dcgm"
+)
+
+var (
+	backendFlag  = flag.String("backend", dcgm.BackendFake, "backend spec name[:config]")
+	intervalFlag = flag.Duration("interval", 200*time.Millisecond, "update interval")
+	keepFlag     = flag.Duration("keep", 5*time.Second, "max keep age")
+	durationFlag = flag.Duration("duration", time.Second, "how long to sample before reading")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+	if err := dcgm.InitWithBackendName(*backendFlag); err != nil {
+		glog.Fatalf("DCGM 初始化失败: %v", err)
+	}
+	defer dcgm.ShutDown()
+
+	// 动态字段按间隔采样，静态字段只采样一次
+	fields := append(append(append([]dcgm.FieldID{}, dcgm.FieldGroupTemperature...), dcgm.FieldGroupPower...), dcgm.FieldGroupClocks...)
+	watch, err := dcgm.WatchFields(nil, fields, *intervalFlag, *keepFlag)
+	if err != nil {
+		glog.Fatalf("WatchFields: %v", err)
+	}
+	defer watch.Unwatch()
+	identity, err := dcgm.WatchFields(nil, dcgm.FieldGroupIdentity, time.Minute, time.Minute)
+	if err != nil {
+		glog.Fatalf("WatchFields: %v", err)
+	}
+	defer identity.Unwatch()
+
+	start := time.Now()
+	time.Sleep(*durationFlag)
+
+	// 读取时只访问缓冲区，不访问硬件
+	for _, dvInd := range watch.Devices {
+		fmt.Printf("========== device %d ==========\n", dvInd)
+		for _, field := range dcgm.FieldGroupIdentity {
+			value, err := dcgm.LatestValue(dvInd, field)
+			if err != nil {
+				fmt.Printf("%-24s %v\n", field, err)
+				continue
+			}
+			fmt.Printf("%-24s %s\n", field, value.Text)
+		}
+		for _, field := range fields {
+			values, err := dcgm.FieldValues(dvInd, field, start, time.Time{})
+			if err != nil || len(values) == 0 {
+				fmt.Printf("%-24s %v\n", field, err)
+				continue
+			}
+			latest := values[len(values)-1]
+			fmt.Printf("%-24s latest=%-10.2f %s samples=%d\n", field, latest.Value, latest.Text, len(values))
+		}
+	}
+}